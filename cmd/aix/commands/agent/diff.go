@@ -0,0 +1,76 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func init() {
+	Cmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Show local edits to an installed agent",
+	Long: `Show how an installed agent differs from its source in the repository it
+was installed from, as a unified diff from the repository version to the copy
+on each platform.
+
+The description and instructions are compared; edits to other fields are
+reported but not shown, and a merge replaces them. The repository version is
+the one in the local cache, so if the repository changed since the agent was
+installed, upstream changes show up in the diff too.`,
+	Example: `  # Show edits to 'code-reviewer'
+  aix agent diff code-reviewer
+
+  # Only compare the Claude Code copy
+  aix agent diff code-reviewer --platform claude
+
+  See Also:
+    aix agent status   - List modified agents
+    aix agent install  - Reinstall with --merge to keep edits`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func runDiff(_ *cobra.Command, args []string) error {
+	return runDiffWithWriter(os.Stdout, args[0])
+}
+
+// runDiffWithWriter allows injecting a writer for testing.
+func runDiffWithWriter(w io.Writer, name string) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	diffs, err := registry.Diff(resource.TypeAgent, name, platforms)
+	if errors.Is(err, registry.ErrNotRecorded) {
+		return errors.NewUserError(err, "Only agents installed from a configured repository can be compared")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, d := range diffs {
+		switch {
+		case d.Diff == "" && d.Modified:
+			fmt.Fprintf(w, "%s: fields other than the description and instructions were edited\n", d.Platform.DisplayName())
+			continue
+		case d.Diff == "":
+			fmt.Fprintf(w, "%s: no local changes\n", d.Platform.DisplayName())
+			continue
+		}
+		fmt.Fprint(w, d.Diff)
+	}
+	return nil
+}
@@ -55,7 +55,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	// 2. Lookup as installed agent name
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
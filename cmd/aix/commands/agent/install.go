@@ -3,8 +3,10 @@ package agent
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,17 +17,21 @@ import (
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/install"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
+	"github.com/thoreinstein/aix/internal/registry"
 	"github.com/thoreinstein/aix/internal/resource"
 	"github.com/thoreinstein/aix/pkg/frontmatter"
 )
 
 // Sentinel errors for agent install operations.
 var (
-	errAgentInstallFailed  = errors.New("failed to install agent to any platform")
-	errAgentNameRequired   = errors.New("agent name is required")
-	errAgentCollision      = errors.New("agent collision detected")
-	errAgentInstallPartial = errors.New("partial installation failure")
+	errAgentInstallFailed = errors.New("failed to install agent to any platform")
+	errAgentNameRequired  = errors.New("agent name is required")
+	errAgentCollision     = errors.New("agent collision detected")
 )
 
 // installForce enables overwriting existing agents without confirmation.
@@ -37,6 +43,12 @@ var installFile bool
 // installAllFromRepo installs all agents from a specific repository.
 var installAllFromRepo string
 
+// installSet holds key=value assignments for the agent's parameters.
+var installSet []string
+
+// installMerge reinstalls from the agent's repository, merging local edits.
+var installMerge bool
+
 var installer *install.Installer
 
 func init() {
@@ -46,9 +58,24 @@ func init() {
 		"treat argument as a file path instead of searching repos")
 	installCmd.Flags().StringVar(&installAllFromRepo, "all-from-repo", "",
 		"install all agents from a specific repository")
+	installCmd.Flags().StringArrayVar(&installSet, "set", nil,
+		"set a parameter of the agent (key=value, repeatable)")
+	installCmd.Flags().BoolVar(&installMerge, "merge", false,
+		"reinstall from the agent's repository, keeping local edits")
 	Cmd.AddCommand(installCmd)
 
-	installer = install.NewInstaller(resource.TypeAgent, "agent", installFromLocal)
+	installer = install.NewInstaller(resource.TypeAgent, "agent", installFromLocal,
+		install.WithPlatforms(flags.ResolvePlatforms), install.WithRecorder(registry.Record),
+		install.WithForgetter(registry.Forget), install.WithParams(func() []string { return installSet }),
+		install.WithInstallTo(func(w io.Writer, path string, platforms []cli.Platform) (string, error) {
+			return Install(w, path, platforms, true)
+		}))
+}
+
+// Installer returns the installer behind 'aix agent install', for linking
+// into an install.Set.
+func Installer() *install.Installer {
+	return installer
 }
 
 var installCmd = &cobra.Command{
@@ -65,6 +92,15 @@ When given a name (not a path), aix searches configured repositories first.
 If the agent exists in multiple repositories, you will be prompted to select one.
 Use --file to skip repo search and treat the argument as a file path.
 
+An agent may declare parameters that are filled into its text when it is
+installed. Give values with --set key=value; the rest are prompted for, or
+take their defaults when input is not a terminal. The values are recorded for
+installs from a repository, and 'aix upgrade' reuses them.
+
+Use --merge to reinstall an agent from the repository it was installed from
+while keeping edits made to it since; see 'aix agent diff'. If the edits
+conflict with changes in the repository, nothing is installed.
+
 The AGENT.md file should contain YAML frontmatter with at least a 'name' field,
 followed by the agent's instructions in markdown format.
 
@@ -91,6 +127,12 @@ Example AGENT.md:
   # Force overwrite existing agent
   aix agent install code-reviewer --force
 
+  # Fill in parameters
+  aix agent install code-reviewer --set org=acme
+
+  # Reinstall, keeping local edits
+  aix agent install code-reviewer --merge
+
   # Install all agents from a specific repo
   aix agent install --all-from-repo official`,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -98,11 +140,17 @@ Example AGENT.md:
 			if len(args) > 0 {
 				return errors.New("cannot specify both --all-from-repo and a source argument")
 			}
+			if len(installSet) > 0 {
+				return errors.New("cannot specify both --all-from-repo and --set")
+			}
 			return nil
 		}
 		if len(args) != 1 {
 			return errors.New("requires exactly one argument (source)")
 		}
+		if installMerge && (installForce || installFile) {
+			return errors.New("cannot combine --merge with --force or --file")
+		}
 		return nil
 	},
 	RunE: runInstall,
@@ -118,14 +166,18 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	source := args[0]
 
+	if installMerge {
+		return reinstallMerged(source)
+	}
+
 	// If --file flag is set, treat argument as file path (old behavior)
 	if installFile {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// If source is clearly a path, use direct install
 	if install.LooksLikePath(source) {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// Try repo lookup first
@@ -153,37 +205,91 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	// Check if it's a local path that exists
 	if _, err := os.Stat(source); err == nil {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	return errors.Newf("agent %q not found in any configured repository", source)
 }
 
-// installFromLocal installs an agent from a local file or directory.
-func installFromLocal(source string) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+// Load parses the AGENT.md file, or directory containing one, at path into
+// its canonical form without installing it.
+func Load(path string) (*claude.Agent, error) {
+	agentPath, err := resolveAgentPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(agentPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading agent file")
+	}
+
+	agent, err := parseAgentForPlatform("claude", content, defaultAgentName(agentPath))
+	if err != nil {
+		return nil, err
+	}
+	return agent.(*claude.Agent), nil
+}
+
+// reinstallMerged reinstalls the agent called name from the repository it
+// was installed from, merging local edits into the repository version.
+func reinstallMerged(name string) error {
+	platforms, err := flags.ResolvePlatforms()
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
 
+	err = registry.ReinstallRecorded(resource.TypeAgent, name, platforms, func(path string, p cli.Platform) error {
+		_, err := Install(os.Stdout, path, []cli.Platform{p}, true)
+		return err
+	})
+	switch {
+	case errors.Is(err, registry.ErrNotRecorded):
+		return errors.NewUserError(err, "Only agents installed from a configured repository can be merged")
+	case errors.Is(err, registry.ErrMergeConflict):
+		return errors.NewUserError(errors.Wrapf(err, "merging agent %q", name),
+			fmt.Sprintf("Review the edits with 'aix agent diff %s', or use --force to discard them", name))
+	case err != nil:
+		return errors.Wrap(err, "reinstalling agent")
+	}
+	fmt.Printf("[OK] Agent '%s' reinstalled with local edits merged\n", name)
+	return nil
+}
+
+// Install installs the agent at path to platforms, writing progress to w,
+// and returns its name. An existing copy that differs on any platform makes
+// the install fail unless force is set.
+func Install(w io.Writer, path string, platforms []cli.Platform, force bool) (string, error) {
 	// Resolve AGENT.md path
-	agentPath, err := resolveAgentPath(source)
+	agentPath, err := resolveAgentPath(path)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Calculate default name from filename/directory
-	defaultName := strings.TrimSuffix(filepath.Base(agentPath), filepath.Ext(agentPath))
-	if strings.ToUpper(defaultName) == "AGENT" {
-		defaultName = filepath.Base(filepath.Dir(agentPath))
-	}
+	defaultName := defaultAgentName(agentPath)
 
 	// Read and parse the AGENT.md file
 	content, err := os.ReadFile(agentPath)
 	if err != nil {
-		return errors.Wrap(err, "reading agent file")
+		return "", errors.Wrap(err, "reading agent file")
 	}
+	return installTo(w, content, defaultName, platforms, force)
+}
 
+// installFromLocal installs an agent from a local file or directory to the
+// platforms selected by the flags, and returns its name.
+func installFromLocal(source string) (string, error) {
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return "", errors.Wrap(err, "resolving platforms")
+	}
+	return Install(os.Stdout, source, platforms, installForce)
+}
+
+// installTo installs the agent read from content to platforms, writing
+// progress to w, and returns its name.
+func installTo(w io.Writer, content []byte, defaultName string, platforms []cli.Platform, force bool) (string, error) {
 	// Track the agent name once successfully parsed (same for all platforms)
 	var agentName string
 
@@ -197,16 +303,21 @@ func installFromLocal(source string) error {
 	}
 	results := make([]installResult, 0, len(platforms))
 
-	// Install to each platform
+	// Install to each platform; if any fails, every platform is rolled back
+	tx := backup.NewTransaction()
 	for _, p := range platforms {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(p.Name(), p.BackupPaths()); err != nil {
-			return errors.Wrapf(err, "backing up %s before install", p.DisplayName())
+		if err := tx.Begin(p.Name(), p.BackupPaths()); err != nil {
+			return "", cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before install", p.DisplayName()))
 		}
 
 		result := installResult{platform: p.Name()}
 
 		agent, parseErr := parseAgentForPlatform(p.Name(), content, defaultName)
+		if errors.Is(parseErr, errors.ErrNotSupported) {
+			fmt.Fprintf(os.Stderr, "Skipping %s: agents are not supported\n", p.DisplayName())
+			continue
+		}
 		if parseErr != nil {
 			result.errMsg = fmt.Sprintf("could not parse agent: %v", parseErr)
 			results = append(results, result)
@@ -239,7 +350,7 @@ func installFromLocal(source string) error {
 				continue
 			}
 
-			if !installForce {
+			if !force {
 				// Collision detected and no --force
 				result.collision = true
 				results = append(results, result)
@@ -251,7 +362,14 @@ func installFromLocal(source string) error {
 		}
 
 		// Perform installation
+		if err := cli.TrackResource(tx, p, resource.TypeAgent, parsedName); err != nil {
+			return "", cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before install", p.DisplayName()))
+		}
 		if installErr := p.InstallAgent(agent); installErr != nil {
+			if errors.Is(installErr, errors.ErrNotSupported) {
+				fmt.Fprintf(os.Stderr, "Skipping %s: agents are not supported\n", p.DisplayName())
+				continue
+			}
 			result.errMsg = fmt.Sprintf("could not install agent: %v", installErr)
 			results = append(results, result)
 			continue
@@ -276,14 +394,9 @@ func installFromLocal(source string) error {
 		}
 	}
 
-	// Report successful installations
-	if len(installed) > 0 {
-		fmt.Printf("Installed %s to %s\n", agentName, strings.Join(installed, ", "))
-	}
-
-	// Report other errors as warnings (they don't block collision errors)
+	// Report errors; any failure undoes the platforms that succeeded
 	for _, e := range otherErrors {
-		fmt.Fprintf(os.Stderr, "Warning: %s\n", e)
+		fmt.Fprintf(os.Stderr, "Error: %s\n", e)
 	}
 
 	// Handle collision errors
@@ -293,20 +406,23 @@ func installFromLocal(source string) error {
 			fmt.Fprintf(os.Stderr, "  - %s\n", c)
 		}
 		fmt.Fprintf(os.Stderr, "\nUse --force to overwrite existing agents.\n")
+		return "", cli.AbortTransaction(tx, errAgentCollision)
+	}
+	if len(otherErrors) > 0 {
+		return "", cli.AbortTransaction(tx, errAgentInstallFailed)
+	}
 
-		// If some platforms succeeded but others had collisions, return partial error
-		if len(installed) > 0 {
-			return errAgentInstallPartial
-		}
-		return errAgentCollision
+	// Report successful installations
+	if len(installed) > 0 {
+		fmt.Fprintf(w, "Installed %s to %s\n", agentName, strings.Join(installed, ", "))
 	}
 
 	// If nothing was installed
 	if len(installed) == 0 {
-		return errAgentInstallFailed
+		return "", errAgentInstallFailed
 	}
 
-	return nil
+	return agentName, nil
 }
 
 // resolveAgentPath finds the AGENT.md file from the given source path.
@@ -329,6 +445,16 @@ func resolveAgentPath(source string) (string, error) {
 	return source, nil
 }
 
+// defaultAgentName derives an agent name from its file path: the file name
+// without extension, or the parent directory name for AGENT.md files.
+func defaultAgentName(agentPath string) string {
+	name := strings.TrimSuffix(filepath.Base(agentPath), filepath.Ext(agentPath))
+	if strings.ToUpper(name) == "AGENT" {
+		name = filepath.Base(filepath.Dir(agentPath))
+	}
+	return name
+}
+
 // parseAgentForPlatform parses AGENT.md content into platform-specific agent struct.
 func parseAgentForPlatform(platform string, content []byte, defaultName string) (any, error) {
 	switch platform {
@@ -378,8 +504,114 @@ func parseAgentForPlatform(platform string, content []byte, defaultName string)
 			Instructions: string(body),
 		}, nil
 
+	case "codex":
+		var meta struct {
+			Name        string `yaml:"name"`
+			Description string `yaml:"description"`
+		}
+		body, err := frontmatter.Parse(bytes.NewReader(content), &meta)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing frontmatter")
+		}
+		if meta.Name == "" {
+			meta.Name = defaultName
+		}
+		if meta.Name == "" {
+			return nil, errAgentNameRequired
+		}
+		return &codex.Agent{
+			Name:         meta.Name,
+			Description:  meta.Description,
+			Instructions: string(body),
+		}, nil
+
+	case "cursor":
+		var meta struct {
+			Name        string `yaml:"name"`
+			Description string `yaml:"description"`
+		}
+		body, err := frontmatter.Parse(bytes.NewReader(content), &meta)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing frontmatter")
+		}
+		if meta.Name == "" {
+			meta.Name = defaultName
+		}
+		if meta.Name == "" {
+			return nil, errAgentNameRequired
+		}
+		return &cursor.Rule{
+			Name:         meta.Name,
+			Description:  meta.Description,
+			Instructions: string(body),
+		}, nil
+
+	case "copilot":
+		var meta struct {
+			Name        string   `yaml:"name"`
+			Description string   `yaml:"description"`
+			Model       string   `yaml:"model"`
+			Tools       []string `yaml:"tools"`
+		}
+		body, err := frontmatter.Parse(bytes.NewReader(content), &meta)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing frontmatter")
+		}
+		if meta.Name == "" {
+			meta.Name = defaultName
+		}
+		if meta.Name == "" {
+			return nil, errAgentNameRequired
+		}
+		return &copilot.ChatMode{
+			Name:         meta.Name,
+			Description:  meta.Description,
+			Model:        meta.Model,
+			Tools:        meta.Tools,
+			Instructions: string(body),
+		}, nil
+
 	default:
-		return nil, errors.Newf("unsupported platform: %s", platform)
+		// Plugins take agents in the canonical Claude form
+		if _, ok := plugin.Lookup(platform); ok {
+			return parseAgentForPlatform("claude", content, defaultName)
+		}
+		return nil, errors.Wrapf(errors.ErrNotSupported, "unsupported platform: %s", platform)
+	}
+}
+
+// ConvertForPlatform converts a canonical claude.Agent to the appropriate
+// platform-specific agent type.
+func ConvertForPlatform(a *claude.Agent, platformName string) any {
+	switch platformName {
+	case "opencode":
+		return &opencode.Agent{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
+	case "codex":
+		return &codex.Agent{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
+	case "cursor":
+		return &cursor.Rule{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
+	case "copilot":
+		return &copilot.ChatMode{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
+	default:
+		// Claude uses the canonical format; unknown platforms are left to
+		// the adapter
+		return a
 	}
 }
 
@@ -390,6 +622,12 @@ func getAgentName(agent any) string {
 		return a.Name
 	case *opencode.Agent:
 		return a.Name
+	case *codex.Agent:
+		return a.Name
+	case *cursor.Rule:
+		return a.Name
+	case *copilot.ChatMode:
+		return a.Name
 	default:
 		return ""
 	}
@@ -419,6 +657,35 @@ func agentsAreIdentical(newAgent, existingAgent any) bool {
 			new.Temperature == existing.Temperature &&
 			normalizeInstructions(new.Instructions) == normalizeInstructions(existing.Instructions)
 
+	case *codex.Agent:
+		existing, ok := existingAgent.(*codex.Agent)
+		if !ok {
+			return false
+		}
+		return new.Name == existing.Name &&
+			new.Description == existing.Description &&
+			normalizeInstructions(new.Instructions) == normalizeInstructions(existing.Instructions)
+
+	case *cursor.Rule:
+		existing, ok := existingAgent.(*cursor.Rule)
+		if !ok {
+			return false
+		}
+		return new.Name == existing.Name &&
+			new.Description == existing.Description &&
+			normalizeInstructions(new.Instructions) == normalizeInstructions(existing.Instructions)
+
+	case *copilot.ChatMode:
+		existing, ok := existingAgent.(*copilot.ChatMode)
+		if !ok {
+			return false
+		}
+		return new.Name == existing.Name &&
+			new.Description == existing.Description &&
+			new.Model == existing.Model &&
+			slices.Equal(new.Tools, existing.Tools) &&
+			normalizeInstructions(new.Instructions) == normalizeInstructions(existing.Instructions)
+
 	default:
 		return false
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 var listJSON bool
@@ -48,6 +50,7 @@ type listOutput map[string][]infoJSON
 type infoJSON struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Source      string `json:"source"`
 }
 
 func runList(_ *cobra.Command, _ []string) error {
@@ -56,7 +59,7 @@ func runList(_ *cobra.Command, _ []string) error {
 
 // runListWithWriter allows injecting a writer for testing.
 func runListWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -69,6 +72,11 @@ func runListWithWriter(w io.Writer) error {
 
 // outputListJSON outputs agents in JSON format.
 func outputListJSON(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	output := make(listOutput)
 
 	for _, p := range platforms {
@@ -82,6 +90,7 @@ func outputListJSON(w io.Writer, platforms []cli.Platform) error {
 			infos[i] = infoJSON{
 				Name:        a.Name,
 				Description: a.Description,
+				Source:      reg.Source(resource.TypeAgent, a.Name),
 			}
 		}
 		output[p.Name()] = infos
@@ -103,6 +112,11 @@ const (
 
 // outputListTabular outputs agents in tabular format grouped by platform.
 func outputListTabular(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	hasAgents := false
 
 	for i, p := range platforms {
@@ -130,11 +144,13 @@ func outputListTabular(w io.Writer, platforms []cli.Platform) error {
 
 		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 		// Table headers
-		fmt.Fprintf(tw, "  %sNAME%s\t%sDESCRIPTION%s\n", colorBold, colorReset, colorBold, colorReset)
+		fmt.Fprintf(tw, "  %sNAME%s\t%sSOURCE%s\t%sDESCRIPTION%s\n",
+			colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
 
 		for _, a := range agents {
 			desc := truncate(a.Description, 80)
-			fmt.Fprintf(tw, "  %s%s%s\t%s\n", colorGreen, a.Name, colorReset, desc)
+			fmt.Fprintf(tw, "  %s%s%s\t%s\t%s\n", colorGreen, a.Name, colorReset,
+				reg.Source(resource.TypeAgent, a.Name), desc)
 		}
 		tw.Flush()
 	}
@@ -13,7 +13,11 @@ import (
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
 )
 
 const defaultInstructionsPreviewLength = 200
@@ -70,7 +74,7 @@ func runShow(_ *cobra.Command, args []string) error {
 
 // runShowWithWriter allows injecting a writer for testing.
 func runShowWithWriter(name string, w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -83,7 +87,9 @@ func runShowWithWriter(name string, w io.Writer) error {
 		agentAny, err := p.GetAgent(name)
 		if err != nil {
 			// Agent not found on this platform is expected - try next platform
-			if errors.Is(err, claude.ErrAgentNotFound) || errors.Is(err, opencode.ErrAgentNotFound) {
+			if errors.Is(err, claude.ErrAgentNotFound) || errors.Is(err, opencode.ErrAgentNotFound) ||
+				errors.Is(err, cursor.ErrRuleNotFound) || errors.Is(err, copilot.ErrAgentNotFound) ||
+				errors.Is(err, plugin.ErrNotFound) || errors.Is(err, errors.ErrNotSupported) {
 				continue
 			}
 			// Other errors (permission, parse) should be reported
@@ -128,6 +134,20 @@ func extractDetail(agent any) *showDetail {
 		return extractClaudeAgent(a)
 	case *opencode.Agent:
 		return extractOpenCodeAgent(a)
+	case *codex.Agent:
+		return extractCodexAgent(a)
+	case *cursor.Rule:
+		return &showDetail{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
+	case *copilot.ChatMode:
+		return &showDetail{
+			Name:         a.Name,
+			Description:  a.Description,
+			Instructions: a.Instructions,
+		}
 	default:
 		return nil
 	}
@@ -153,6 +173,15 @@ func extractOpenCodeAgent(a *opencode.Agent) *showDetail {
 	}
 }
 
+// extractCodexAgent extracts details from a Codex agent.
+func extractCodexAgent(a *codex.Agent) *showDetail {
+	return &showDetail{
+		Name:         a.Name,
+		Description:  a.Description,
+		Instructions: a.Instructions,
+	}
+}
+
 func outputShowJSON(w io.Writer, detail *showDetail) error {
 	data, err := json.MarshalIndent(detail, "", "  ")
 	if err != nil {
@@ -0,0 +1,689 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/agent"
+	"github.com/thoreinstein/aix/cmd/aix/commands/command"
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/cmd/aix/commands/mcp"
+	"github.com/thoreinstein/aix/cmd/aix/commands/skill"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/params"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+var (
+	applyFile   string
+	applyDryRun bool
+	applyPrune  bool
+	applyFrozen bool
+)
+
+// installers links the install commands of every resource type, so that
+// each installs the resources the others require. Apply installs the
+// requirements of manifest entries with it too.
+var installers = install.NewSet(skill.Installer(), command.Installer(), agent.Installer(), mcp.Installer())
+
+func init() {
+	applyCmd.Flags().StringVarP(&applyFile, "file", "f", "",
+		"path to the manifest (default: aix.yaml in the project root)")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false,
+		"show the plan without changing anything")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false,
+		"remove installed resources that are not in the manifest")
+	applyCmd.Flags().BoolVar(&applyFrozen, "frozen", false,
+		"install exactly the versions recorded in aix.lock")
+	rootCmd.AddCommand(applyCmd)
+}
+
+var applyCmd = &cobra.Command{
+	Use:     "apply",
+	Aliases: []string{"install"},
+	Short:   "Reconcile platforms with the project manifest (aix.yaml)",
+	Long: `Install, update, and optionally remove resources so that each platform
+matches the project manifest.
+
+The manifest (aix.yaml) lists the skills, commands, agents, and MCP servers a
+project needs. Each entry names its source: a configured repository, a git
+URL, or a path relative to the manifest. When the source is omitted, the
+resource is looked up by name in all configured repositories. A resource
+that declares parameters is rendered with the values under params, and with
+defaults for the rest; a required parameter without a value is an error.
+
+  version: 1
+  platforms: [claude, opencode]
+  skills:
+    - name: code-review
+      source: official
+      params:
+        org: acme
+  commands:
+    - name: deploy
+      source: ./aix/commands/deploy.md
+  mcp:
+    - name: github
+      platforms: [claude]
+
+aix apply compares every entry against what each platform has installed and
+plans an install for missing resources and an update for resources whose
+content differs. Resources that an entry from a repository requires are
+installed with it, as 'aix <type> install' does. With --prune, resources
+that are neither declared nor required by an entry are removed.
+Use --dry-run to review the plan first.
+
+Every successful apply records the repository URL, commit SHA, and SHA-256
+of each resource in aix.lock next to the manifest. Commit it with aix.yaml:
+--frozen (or aix install --frozen) then installs exactly those versions and
+fails if the manifest and lockfile disagree or any content hash differs.
+
+aix.lock pins the manifest's sources; it is written only by apply without
+--dry-run or --frozen. Installs and upgrades made with other commands are
+recorded in the install registry, not the lock, so a frozen apply returns
+such resources to their locked versions.
+
+The manifest is read from the project root when --scope project is set, and
+otherwise from the current directory or, failing that, the enclosing git
+repository.`,
+	Example: `  # Show what would change
+  aix apply --dry-run
+
+  # Install the project's resources into its .claude/ and .gemini/ dirs
+  aix apply --scope project
+
+  # Make Claude match the manifest exactly
+  aix apply --platform claude --prune
+
+  # Reproduce the locked versions on another machine
+  aix install --frozen
+
+See Also: aix skill install, aix mcp install`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func runApply(cmd *cobra.Command, _ []string) error {
+	return runApplyWithWriter(cmd.OutOrStdout())
+}
+
+// runApplyWithWriter allows injecting a writer for testing.
+func runApplyWithWriter(w io.Writer) error {
+	path, err := manifestPath()
+	if err != nil {
+		return err
+	}
+
+	m, err := manifest.Load(path)
+	if err != nil {
+		if errors.Is(err, manifest.ErrNotFound) {
+			return errors.NewUserError(err, "Create an aix.yaml or pass --file")
+		}
+		return errors.NewUserError(err, "Fix the manifest and try again")
+	}
+
+	platformNames := flags.GetPlatformFlag()
+	if len(platformNames) == 0 {
+		platformNames = m.Platforms
+	}
+	platforms, err := cli.ResolvePlatforms(platformNames, flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	var resolverOpts []manifest.ResolverOption
+	if applyFrozen {
+		lock, err := manifest.LoadLock(m.LockPath())
+		if err != nil {
+			if errors.Is(err, manifest.ErrLockNotFound) {
+				return errors.NewUserError(err, "Run 'aix apply' without --frozen to create aix.lock")
+			}
+			return err
+		}
+		resolverOpts = append(resolverOpts, manifest.WithLock(lock))
+	}
+
+	resolver := manifest.NewResolver(m, resolverOpts...)
+	defer func() {
+		if cerr := resolver.Close(); cerr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", cerr)
+		}
+	}()
+
+	state, err := collectApplyState(w, m, platforms, resolver)
+	if err != nil {
+		switch {
+		case errors.Is(err, manifest.ErrLockMismatch):
+			return errors.NewUserError(err, "Run 'aix apply' without --frozen to update aix.lock")
+		case errors.Is(err, params.ErrMissingValue), errors.Is(err, params.ErrUnknownParameter):
+			return errors.NewUserError(err, "Set the entry's parameters under params: in "+path)
+		}
+		return err
+	}
+	defer state.close()
+
+	plan := manifest.Compute(state.desired, state.installed, applyPrune)
+	printPlan(w, "Plan for "+path, plan)
+
+	switch {
+	case !plan.HasChanges():
+		fmt.Fprintln(w, "Everything is up to date.")
+	case applyDryRun:
+		fmt.Fprintln(w, "Dry run: no changes made.")
+		return nil
+	default:
+		if err := executePlan(w, plan, state); err != nil {
+			return err
+		}
+	}
+
+	if applyDryRun || applyFrozen {
+		return nil
+	}
+	return writeLock(w, m, state.resolved)
+}
+
+// writeLock records the resolved entries in the manifest's lockfile,
+// reporting when its content changes.
+func writeLock(w io.Writer, m *manifest.Manifest, resolved []*manifest.Resolved) error {
+	path := m.LockPath()
+	lock, err := manifest.LoadLock(path)
+	if err != nil {
+		if !errors.Is(err, manifest.ErrLockNotFound) {
+			return err
+		}
+		lock = &manifest.Lock{}
+	}
+
+	before := append([]manifest.LockedResource(nil), lock.Resources...)
+	lock.Update(m, resolved)
+	if err := lock.Write(path); err != nil {
+		return errors.Wrap(err, "writing lockfile")
+	}
+	if !reflect.DeepEqual(before, lock.Resources) {
+		fmt.Fprintf(w, "Updated %s\n", manifest.LockFileName)
+	}
+	return nil
+}
+
+// manifestPath returns the manifest to read: --file if set, otherwise
+// aix.yaml in the project root (project scope), the current directory, or
+// the enclosing git repository.
+func manifestPath() (string, error) {
+	if applyFile != "" {
+		return applyFile, nil
+	}
+	if root := flags.GetProjectRootFlag(); root != "" {
+		return filepath.Join(root, manifest.FileName), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "getting working directory")
+	}
+	candidate := filepath.Join(cwd, manifest.FileName)
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate, nil
+	}
+	if top, err := git.TopLevel(cwd); err == nil {
+		return filepath.Join(top, manifest.FileName), nil
+	}
+	return candidate, nil
+}
+
+// applyState is the desired and installed state gathered for planning,
+// plus the resolved source of every entry.
+type applyState struct {
+	desired   []manifest.Desired
+	installed []manifest.Installed
+	resolved  []*manifest.Resolved
+	sources   map[string]*applySource
+	platforms map[string]cli.Platform
+	cleanups  []func()
+}
+
+// applySource is the resolved source of an entry and the copy of it that is
+// installed, rendered with the entry's parameters.
+type applySource struct {
+	*manifest.Resolved
+
+	// path is the rendered copy; see params.Render. Resources that declare
+	// no parameters are installed from their source.
+	path   string
+	values map[string]string
+
+	// targets names the platforms the entry is installed to, without those
+	// that cannot hold its type.
+	targets []string
+}
+
+// close removes the rendered copies of the sources.
+func (s *applyState) close() {
+	for _, cleanup := range s.cleanups {
+		cleanup()
+	}
+	s.cleanups = nil
+}
+
+// entryKey identifies an entry in applyState.sources.
+func entryKey(t resource.ResourceType, name string) string {
+	return string(t) + "/" + name
+}
+
+// collectApplyState resolves every manifest entry and inspects every
+// platform. Resource types a platform does not support are skipped with a
+// warning rather than failing the whole run.
+func collectApplyState(w io.Writer, m *manifest.Manifest, platforms []cli.Platform, resolver *manifest.Resolver) (_ *applyState, err error) {
+	state := &applyState{
+		sources:   make(map[string]*applySource),
+		platforms: make(map[string]cli.Platform, len(platforms)),
+	}
+	defer func() {
+		if err != nil {
+			state.close()
+		}
+	}()
+
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+		state.platforms[p.Name()] = p
+	}
+
+	types := m.Types()
+	if applyPrune {
+		types = resourceTypes
+	}
+
+	// Inspect installed resources.
+	declared := make(map[string]bool)
+	for _, e := range m.Entries() {
+		declared[entryKey(e.Type, e.Name)] = true
+	}
+	unsupported := make(map[string]bool)
+	for _, p := range platforms {
+		for _, t := range types {
+			// Some platforms list nothing for a type they cannot hold,
+			// which would plan an install on every run
+			if !cli.Supports(p, t) {
+				fmt.Fprintf(w, "Skipping %ss on %s: not supported\n", t, p.DisplayName())
+				unsupported[p.Name()+"/"+string(t)] = true
+				continue
+			}
+			installed, err := listInstalled(p, t)
+			if err != nil {
+				fmt.Fprintf(w, "Skipping %ss on %s: %v\n", t, p.DisplayName(), err)
+				unsupported[p.Name()+"/"+string(t)] = true
+				continue
+			}
+			for _, name := range installed {
+				in := manifest.Installed{Type: t, Name: name, Platform: p.Name()}
+				if declared[entryKey(t, name)] {
+					in.Digest, err = registry.InstalledDigest(p, t, name)
+					if err != nil {
+						return nil, errors.Wrapf(err, "reading %s %q from %s", t, name, p.DisplayName())
+					}
+				}
+				state.installed = append(state.installed, in)
+			}
+		}
+	}
+
+	// Resolve desired resources.
+	for _, e := range m.Entries() {
+		targets := e.Targets(names)
+		if len(targets) == 0 {
+			continue
+		}
+
+		res, err := resolver.Resolve(e)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving manifest entry")
+		}
+		src, cleanup, err := renderSource(res)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rendering %s %q", e.Type, e.Name)
+		}
+		state.cleanups = append(state.cleanups, cleanup)
+		v, err := loadSource(e.Type, src.path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading %s %q from %s", e.Type, e.Name, res.Path)
+		}
+		state.resolved = append(state.resolved, res)
+		state.sources[entryKey(e.Type, e.Name)] = src
+
+		for _, target := range targets {
+			if unsupported[target+"/"+string(e.Type)] {
+				continue
+			}
+			digest, err := installedDigest(v, e.Type, target)
+			if err != nil {
+				return nil, errors.Wrapf(err, "converting %s %q for %s", e.Type, e.Name, target)
+			}
+			state.desired = append(state.desired, manifest.Desired{Entry: e, Platform: target, Digest: digest})
+			src.targets = append(src.targets, target)
+		}
+	}
+
+	if applyPrune {
+		if err := keepRequired(state, declared); err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// keepRequired drops from the installed state the resources that entries
+// from repositories require on their targets, so that --prune does not
+// remove what apply installed alongside them. Declared resources stay,
+// since they are planned like any other entry.
+func keepRequired(state *applyState, declared map[string]bool) error {
+	var all []resource.Resource
+	required := make(map[string]bool)
+	for _, src := range state.sources {
+		if src.Resource == nil || len(src.Resource.Requires) == 0 {
+			continue
+		}
+		if all == nil {
+			var err error
+			if all, err = resource.ScanConfigured(); err != nil {
+				return err
+			}
+		}
+		plan, err := resource.ResolveRequirements(*src.Resource, all)
+		if err != nil {
+			return errors.Wrapf(err, "resolving requirements of %s", src.Resource.Name)
+		}
+		for _, dep := range plan[:len(plan)-1] {
+			for _, target := range src.targets {
+				required[entryKey(dep.Type, dep.Name)+"/"+target] = true
+			}
+		}
+	}
+	state.installed = slices.DeleteFunc(state.installed, func(in manifest.Installed) bool {
+		key := entryKey(in.Type, in.Name)
+		return !declared[key] && required[key+"/"+in.Platform]
+	})
+	return nil
+}
+
+// renderSource renders res with the parameters its entry sets, as
+// `aix <type> install --set` does, except that nothing is prompted for.
+// It returns the rendered source and a function that removes the copy.
+func renderSource(res *manifest.Resolved) (*applySource, func(), error) {
+	e := res.Entry
+	decl, err := params.Declared(e.Type, res.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := params.CheckNames(decl, e.Params); err != nil {
+		return nil, nil, err
+	}
+	if len(decl) == 0 {
+		return &applySource{Resolved: res, path: res.Path}, func() {}, nil
+	}
+
+	values, err := params.Resolve(decl, e.Params, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, cleanup, err := params.Render(e.Type, res.Path, values)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &applySource{Resolved: res, path: path, values: values}, cleanup, nil
+}
+
+// listInstalled returns the names of installed resources of type t.
+func listInstalled(p cli.Platform, t resource.ResourceType) ([]string, error) {
+	var names []string
+	switch t {
+	case resource.TypeSkill:
+		infos, err := p.ListSkills()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range infos {
+			names = append(names, i.Name)
+		}
+	case resource.TypeCommand:
+		infos, err := p.ListCommands()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range infos {
+			names = append(names, i.Name)
+		}
+	case resource.TypeAgent:
+		infos, err := p.ListAgents()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range infos {
+			names = append(names, i.Name)
+		}
+	case resource.TypeMCP:
+		infos, err := p.ListMCP()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range infos {
+			names = append(names, i.Name)
+		}
+	}
+	return names, nil
+}
+
+// loadSource loads the resource of type t at path in its canonical form.
+func loadSource(t resource.ResourceType, path string) (any, error) {
+	switch t {
+	case resource.TypeSkill:
+		return skill.Load(path)
+	case resource.TypeCommand:
+		return command.Load(path)
+	case resource.TypeAgent:
+		return agent.Load(path)
+	case resource.TypeMCP:
+		return mcp.Load(path)
+	default:
+		return nil, errors.Newf("unknown resource type %q", t)
+	}
+}
+
+// installedDigest returns the content digest canonical resource v has once
+// installed on the named platform: that of v converted for the platform and
+// back, so that fields the platform cannot hold do not cause an update on
+// every run.
+func installedDigest(v any, t resource.ResourceType, platformName string) (string, error) {
+	converted, err := convertResource(v, platformName)
+	if err != nil {
+		return "", err
+	}
+	back, err := canonicalize(t, converted)
+	if err != nil {
+		return "", err
+	}
+	return manifest.Digest(back)
+}
+
+// printPlan writes a heading and the plan as a table followed by a one-line
+// summary.
+func printPlan(w io.Writer, heading string, plan *manifest.Plan) {
+	fmt.Fprintf(w, "%s:\n", heading)
+
+	counts := make(map[manifest.Action]int)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range plan.Changes {
+		counts[c.Action]++
+		if c.Action == manifest.ActionNone {
+			continue
+		}
+		fmt.Fprintf(tw, "  %s %s\t%s\t%s\t%s\n", actionSymbol(c.Action), c.Action, c.Type, c.Name, c.Platform)
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintf(w, "%d to install, %d to update, %d to remove, %d unchanged.\n",
+		counts[manifest.ActionInstall], counts[manifest.ActionUpdate],
+		counts[manifest.ActionRemove], counts[manifest.ActionNone])
+}
+
+func actionSymbol(a manifest.Action) string {
+	switch a {
+	case manifest.ActionInstall:
+		return "+"
+	case manifest.ActionUpdate:
+		return "~"
+	case manifest.ActionRemove:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// executePlan carries out the pending changes. Installs and updates of the
+// same resource are grouped so each resource is installed once, to all of
+// the platforms that need it, by the same code path as `aix <type> install`.
+// Resources an entry requires are installed to the same platforms before
+// it, as `aix <type> install` installs them. If any change fails, every
+// platform is rolled back, except for the required resources, which were
+// installed on their own.
+func executePlan(w io.Writer, plan *manifest.Plan, state *applyState) error {
+	type group struct {
+		entry     *manifest.Entry
+		platforms []string
+	}
+	var order []string
+	groups := make(map[string]*group)
+	var removals []manifest.Change
+
+	for _, c := range plan.Pending() {
+		if c.Action == manifest.ActionRemove {
+			removals = append(removals, c)
+			continue
+		}
+		key := entryKey(c.Type, c.Name)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{entry: c.Entry}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.platforms = append(g.platforms, c.Platform)
+	}
+
+	// Every platform with pending changes joins the transaction up front,
+	// since the install commands run their own transactions per resource.
+	tx := backup.NewTransaction()
+	for _, c := range plan.Pending() {
+		p := state.platforms[c.Platform]
+		if err := cli.BeginChange(tx, p, c.Type, c.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before apply", p.DisplayName()))
+		}
+	}
+
+	applied := 0
+	for _, key := range order {
+		g := groups[key]
+		targets := make([]cli.Platform, len(g.platforms))
+		for i, name := range g.platforms {
+			targets[i] = state.platforms[name]
+		}
+		if res := state.sources[key].Resource; res != nil && len(res.Requires) > 0 {
+			if err := installers.InstallDependencies(w, *res, targets); err != nil {
+				return cli.AbortTransaction(tx, errors.Wrapf(err, "applying %s %q", g.entry.Type, g.entry.Name))
+			}
+		}
+		if err := installFromSource(w, g.entry.Type, state.sources[key].path, targets); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "applying %s %q", g.entry.Type, g.entry.Name))
+		}
+		applied += len(g.platforms)
+	}
+
+	for _, c := range removals {
+		p := state.platforms[c.Platform]
+		fmt.Fprintf(w, "Removing %s '%s' from %s... ", c.Type, c.Name, p.DisplayName())
+		if err := removeResource(p, c.Type, c.Name); err != nil {
+			fmt.Fprintln(w, "failed")
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "removing %s %q from %s", c.Type, c.Name, p.DisplayName()))
+		}
+		fmt.Fprintln(w, "done")
+		applied++
+	}
+
+	for _, key := range order {
+		recordSource(state.sources[key], state.platforms)
+	}
+
+	fmt.Fprintf(w, "[OK] Applied %d change(s)\n", applied)
+	return nil
+}
+
+// recordSource updates the install registry for a resource apply installed,
+// as `aix <type> install` does: resources from configured repositories are
+// recorded against the platforms their entry targets, with the commit and
+// content hash written to the lockfile, and any record of a resource that
+// now comes from elsewhere is dropped. Failures are reported but do not fail
+// the apply, which has already succeeded.
+func recordSource(res *applySource, platforms map[string]cli.Platform) {
+	var err error
+	if res.Resource != nil {
+		targets := make([]cli.Platform, 0, len(res.targets))
+		for _, name := range slices.Sorted(slices.Values(res.targets)) {
+			targets = append(targets, platforms[name])
+		}
+		err = registry.RecordFrom(*res.Resource, res.Path, res.Locked(), targets, res.values)
+	} else {
+		err = registry.Forget(res.Entry.Type, res.Entry.Name)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record install of %s %q: %v\n", res.Entry.Type, res.Entry.Name, err)
+	}
+}
+
+// installFromSource installs the resource at path to platforms with the
+// install function for its type, overwriting existing copies.
+func installFromSource(w io.Writer, t resource.ResourceType, path string, platforms []cli.Platform) error {
+	var err error
+	switch t {
+	case resource.TypeSkill:
+		_, err = skill.Install(w, path, platforms, true)
+	case resource.TypeCommand:
+		_, err = command.Install(w, path, platforms, true)
+	case resource.TypeAgent:
+		_, err = agent.Install(w, path, platforms, true)
+	case resource.TypeMCP:
+		_, err = mcp.Install(w, path, platforms, true, flags.GetAllowCmdSecretsFlag())
+	default:
+		err = errors.Newf("unknown resource type %q", t)
+	}
+	return err
+}
+
+// removeResource uninstalls a resource from a single platform.
+func removeResource(p cli.Platform, t resource.ResourceType, name string) error {
+	switch t {
+	case resource.TypeSkill:
+		return p.UninstallSkill(name)
+	case resource.TypeCommand:
+		return p.UninstallCommand(name)
+	case resource.TypeAgent:
+		return p.UninstallAgent(name)
+	case resource.TypeMCP:
+		return p.RemoveMCP(name)
+	default:
+		return errors.Newf("unknown resource type %q", t)
+	}
+}
@@ -0,0 +1,492 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/cmd/aix/commands/skill"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/config"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/params"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/repo"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestRunApply_LocalSkill(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	skillDir := filepath.Join(tmp, "aix", "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: review\ndescription: Reviews code\n---\n\nReview the diff.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	manifestYAML := "version: 1\nskills:\n  - name: review\n    source: ./aix/skills/review\n"
+	if err := os.WriteFile(manifestFile, []byte(manifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	applyFile = manifestFile
+	applyPrune = false
+	applyFrozen = false
+	backup.ResetBackupState()
+
+	applyDryRun = true
+	var buf bytes.Buffer
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 to install") {
+		t.Errorf("dry run output missing install count:\n%s", buf.String())
+	}
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	if _, err := os.Stat(installed); !os.IsNotExist(err) {
+		t.Fatalf("dry run installed the skill")
+	}
+
+	applyDryRun = false
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply error = %v\n%s", err, buf.String())
+	}
+	if _, err := os.Stat(installed); err != nil {
+		t.Fatalf("skill not installed: %v\n%s", err, buf.String())
+	}
+	// The install writes to apply's writer and leaves the flags alone
+	if !strings.Contains(buf.String(), "Installing 'review' to Claude Code... done") {
+		t.Errorf("apply output missing install progress:\n%s", buf.String())
+	}
+	if got := flags.GetPlatformFlag(); len(got) != 1 || got[0] != "claude" {
+		t.Errorf("--platform after apply = %v, want [claude]", got)
+	}
+
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("second apply error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Everything is up to date.") {
+		t.Errorf("second apply should be a no-op, got:\n%s", buf.String())
+	}
+}
+
+func TestRunApply_UnsupportedPlatformConverges(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	writeSkill(t, filepath.Join(tmp, "aix", "skills", "review", "SKILL.md"), "Review the diff.")
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	manifestYAML := "version: 1\nskills:\n  - name: review\n    source: ./aix/skills/review\n"
+	if err := os.WriteFile(manifestFile, []byte(manifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+		backup.ResetBackupState()
+	})
+	// Zed is described by a definition and has no skills
+	flags.SetPlatformFlag([]string{"claude", "zed"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	applyFile = manifestFile
+	applyDryRun, applyPrune, applyFrozen = false, false, false
+	backup.ResetBackupState()
+
+	var buf bytes.Buffer
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply error = %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "1 to install") || !strings.Contains(buf.String(), "Skipping skills on Zed") {
+		t.Errorf("apply should install on Claude Code only:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("second apply error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Everything is up to date.") {
+		t.Errorf("second apply should plan nothing, got:\n%s", buf.String())
+	}
+}
+
+func TestRunApply_LossyPlatform(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	// Cursor rules hold neither a license nor allowed tools
+	skillDir := filepath.Join(tmp, "aix", "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: review\ndescription: Reviews code\nlicense: MIT\nallowed-tools: Read Grep\n---\n\nReview the diff.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	manifestYAML := "version: 1\nskills:\n  - name: review\n    source: ./aix/skills/review\n"
+	if err := os.WriteFile(manifestFile, []byte(manifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude", "cursor"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	applyFile = manifestFile
+	applyDryRun, applyPrune, applyFrozen = false, false, false
+	backup.ResetBackupState()
+
+	var buf bytes.Buffer
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply error = %v\n%s", err, buf.String())
+	}
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("second apply error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Everything is up to date.") {
+		t.Errorf("fields Cursor cannot hold caused an update:\n%s", buf.String())
+	}
+
+	// Edits to fields the platform holds are drift
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	edited := strings.Replace(skill, "Read Grep", "Read", 1)
+	if err := os.WriteFile(installed, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	applyDryRun = true
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 to update") {
+		t.Errorf("edited allowed-tools not planned for update:\n%s", buf.String())
+	}
+}
+
+func TestRunApply_Frozen(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	cmdFile := filepath.Join(tmp, "deploy.md")
+	if err := os.WriteFile(cmdFile, []byte("---\ndescription: Deploy\n---\nDeploy it.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	if err := os.WriteFile(manifestFile, []byte("version: 1\ncommands:\n  - name: deploy\n    source: ./deploy.md\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	applyFile = manifestFile
+	applyDryRun, applyPrune = false, false
+	backup.ResetBackupState()
+
+	var buf bytes.Buffer
+	applyFrozen = true
+	if err := runApplyWithWriter(&buf); err == nil {
+		t.Fatal("frozen apply without aix.lock should fail")
+	}
+
+	applyFrozen = false
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply error = %v\n%s", err, buf.String())
+	}
+	lock, err := manifest.LoadLock(filepath.Join(tmp, manifest.LockFileName))
+	if err != nil {
+		t.Fatalf("aix.lock not written: %v", err)
+	}
+	if got := lock.Find(resource.TypeCommand, "deploy"); got == nil || got.SHA256 == "" {
+		t.Fatalf("aix.lock missing deploy: %+v", lock.Resources)
+	}
+
+	applyFrozen = true
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("frozen apply error = %v\n%s", err, buf.String())
+	}
+
+	if err := os.WriteFile(cmdFile, []byte("---\ndescription: Deploy\n---\nDeploy it differently.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err = runApplyWithWriter(&buf)
+	if !errors.Is(err, manifest.ErrLockMismatch) {
+		t.Errorf("frozen apply after edit error = %v, want ErrLockMismatch", err)
+	}
+}
+
+func TestRunApply_RecordsRepoSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	tmp := setupUpgradeTest(t)
+
+	src := filepath.Join(tmp, "src")
+	writeSkill(t, filepath.Join(src, "skills", "review", "SKILL.md"), "Review the diff.")
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"add", "."},
+		{"commit", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = src
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if _, err := repo.NewManager(config.DefaultConfigPath()).Add("file://"+src, repo.WithName("official")); err != nil {
+		t.Fatalf("adding repository: %v", err)
+	}
+
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	manifestYAML := "version: 1\nskills:\n  - name: review\n    source: official\n    platforms: [claude]\n"
+	if err := os.WriteFile(manifestFile, []byte(manifestYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The entry targets Claude Code only, so a copy on OpenCode, which
+	// apply did not install, is not recorded
+	flags.SetPlatformFlag([]string{"opencode", "claude"})
+	opencode, err := cli.NewPlatform("opencode", flags.PlatformOptions()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := skill.Install(io.Discard, filepath.Join(src, "skills", "review"), []cli.Platform{opencode}, true); err != nil {
+		t.Fatalf("installing on OpenCode: %v", err)
+	}
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+	})
+	applyFile = manifestFile
+	applyDryRun, applyPrune, applyFrozen = false, false, false
+
+	// Apply installs the skill, and installs it again over a local edit;
+	// both times it keeps the repository record
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	for i := range 2 {
+		var buf bytes.Buffer
+		if err := runApplyWithWriter(&buf); err != nil {
+			t.Fatalf("apply %d error = %v\n%s", i, err, buf.String())
+		}
+		reg, err := registry.Load(registry.DefaultPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := reg.Find(resource.TypeSkill, "review")
+		if e == nil || e.Repo != "official" || len(e.Commit) != 40 || len(e.Platforms) != 1 || e.Platforms["claude"] == "" {
+			t.Fatalf("apply %d registry entry = %+v, want official at a commit, installed on claude only", i, e)
+		}
+		lock, err := manifest.LoadLock(filepath.Join(tmp, manifest.LockFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pin := lock.Find(resource.TypeSkill, "review"); pin == nil || pin.Commit != e.Commit || pin.SHA256 != e.SHA256 {
+			t.Errorf("apply %d lock entry = %+v, want the registry's commit %s and hash %s", i, pin, e.Commit, e.SHA256)
+		}
+		writeSkill(t, installed, "Review the diff, my way.")
+	}
+}
+
+func TestRunApply_InstallsRequirements(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	tmp := setupUpgradeTest(t)
+
+	src := filepath.Join(tmp, "src")
+	files := map[string]string{
+		"skills/review/SKILL.md": "---\nname: review\ndescription: Reviews code\nrequires: [skill:style]\n---\n\nReview the diff.\n",
+		"skills/style/SKILL.md":  "---\nname: style\ndescription: House style\n---\n\nUse tabs.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"add", "."},
+		{"commit", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = src
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if _, err := repo.NewManager(config.DefaultConfigPath()).Add("file://"+src, repo.WithName("official")); err != nil {
+		t.Fatalf("adding repository: %v", err)
+	}
+
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	if err := os.WriteFile(manifestFile, []byte("version: 1\nskills:\n  - name: review\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+	})
+	applyFile = manifestFile
+	applyDryRun, applyPrune, applyFrozen = false, true, false
+
+	// The first apply installs the skill review requires; the second, with
+	// --prune, leaves it in place
+	style := filepath.Join(tmp, ".claude", "skills", "style", "SKILL.md")
+	for i := range 2 {
+		var buf bytes.Buffer
+		if err := runApplyWithWriter(&buf); err != nil {
+			t.Fatalf("apply %d error = %v\n%s", i, err, buf.String())
+		}
+		if _, err := os.Stat(style); err != nil {
+			t.Fatalf("apply %d: required skill not installed: %v\n%s", i, err, buf.String())
+		}
+	}
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := reg.Find(resource.TypeSkill, "style"); e == nil || e.Repo != "official" {
+		t.Errorf("registry entry for style = %+v, want one from official", e)
+	}
+}
+
+func TestRunApply_Params(t *testing.T) {
+	tmp := setupUpgradeTest(t)
+
+	skillDir := filepath.Join(tmp, "review")
+	content := "---\nname: review\ndescription: Reviews {{ .Params.org }} code\nparameters:\n" +
+		"  - name: org\n    required: true\n  - name: team\n    required: true\n  - name: branch\n    default: main\n" +
+		"---\n\nFollow the {{ .Params.org }} guide; merge to {{ .Params.branch }}.\n"
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifestFile := filepath.Join(tmp, "aix.yaml")
+	writeManifest := func(params string) {
+		t.Helper()
+		data := "version: 1\nskills:\n  - name: review\n    source: ./review\n" + params
+		if err := os.WriteFile(manifestFile, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldFile, oldDryRun, oldPrune, oldFrozen := applyFile, applyDryRun, applyPrune, applyFrozen
+	t.Cleanup(func() {
+		applyFile, applyDryRun, applyPrune, applyFrozen = oldFile, oldDryRun, oldPrune, oldFrozen
+	})
+	applyFile = manifestFile
+	applyDryRun, applyPrune, applyFrozen = false, false, false
+
+	// Every missing required parameter is named, and nothing is installed
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	writeManifest("")
+	var buf bytes.Buffer
+	err := runApplyWithWriter(&buf)
+	if !errors.Is(err, params.ErrMissingValue) || !strings.Contains(err.Error(), `"org", "team"`) {
+		t.Fatalf("apply without params error = %v, want org and team missing", err)
+	}
+	if _, err := os.Stat(installed); !os.IsNotExist(err) {
+		t.Fatal("apply without params installed the skill")
+	}
+
+	writeManifest("    params:\n      org: acme\n      team: core\n")
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply error = %v\n%s", err, buf.String())
+	}
+	data, err := os.ReadFile(installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Follow the acme guide; merge to main.") || strings.Contains(string(data), "{{") {
+		t.Errorf("installed skill not rendered:\n%s", data)
+	}
+
+	// The plan compares the rendered content, so a second apply has nothing
+	// to do and a changed value is an update
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("second apply error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Everything is up to date.") {
+		t.Errorf("second apply should be a no-op, got:\n%s", buf.String())
+	}
+	writeManifest("    params:\n      org: initech\n      team: core\n")
+	buf.Reset()
+	if err := runApplyWithWriter(&buf); err != nil {
+		t.Fatalf("apply with new params error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1 to update") {
+		t.Errorf("changed params should update the skill, got:\n%s", buf.String())
+	}
+}
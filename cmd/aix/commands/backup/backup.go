@@ -20,7 +20,8 @@ var Cmd = &cobra.Command{
 	Long: `Manage configuration backups for AI coding assistant platforms.
 
 Before aix modifies platform configurations, it automatically creates backups.
-This command group allows you to list, restore, create, and prune backups.
+This command group allows you to list, compare, restore, create, and prune
+backups, and to export them to another machine.
 
 Backups are stored in ~/.config/aix/backups/ organized by platform.`,
 	Example: `  # List all backups
@@ -35,17 +36,27 @@ Backups are stored in ~/.config/aix/backups/ organized by platform.`,
   # Restore from a specific backup
   aix backup restore 20260123T100712 --platform claude
 
+  # Show what changed since the most recent backup
+  aix backup diff claude
+
   # Create a manual backup
   aix backup create --platform claude
 
   # Remove old backups, keeping the 3 most recent
   aix backup prune --keep 3
 
+  # Move your configuration to a new machine
+  aix backup export snapshot.tar.gz
+  aix backup import snapshot.tar.gz
+
   See Also:
     aix backup list    - List available backups
+    aix backup diff    - Show what changed since a backup
     aix backup restore - Restore from a backup
     aix backup create  - Manually create a backup
-    aix backup prune   - Remove old backups`,
+    aix backup prune   - Remove old backups
+    aix backup export  - Export every platform to one file
+    aix backup import  - Restore an exported bundle`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		return cmd.Help()
 	},
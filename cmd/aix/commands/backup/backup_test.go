@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -236,3 +237,65 @@ func TestBackupPrune_NegativeKeep(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestBackupDiff_ShowsTextDiff(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	testFile := filepath.Join(home, "config.json")
+	if err := os.WriteFile(testFile, []byte("{\n  \"a\": 1\n}\n"), 0o600); err != nil {
+		t.Fatalf("creating test file: %v", err)
+	}
+	manifest, err := backup.NewManager().Backup("claude", []string{testFile})
+	if err != nil {
+		t.Fatalf("creating backup: %v", err)
+	}
+	if err := os.WriteFile(testFile, []byte("{\n  \"a\": 2\n}\n"), 0o600); err != nil {
+		t.Fatalf("modifying test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runDiffWithWriter(&buf, []string{"claude", manifest.ID}); err != nil {
+		t.Fatalf("runDiffWithWriter() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"modified", testFile, "-  \"a\": 1\n", "+  \"a\": 2\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestBackupExportImport_MovesToNewHome(t *testing.T) {
+	origPlatformFlag := flags.GetPlatformFlag()
+	t.Cleanup(func() { flags.SetPlatformFlag(origPlatformFlag) })
+	flags.SetPlatformFlag([]string{"claude"})
+
+	oldHome := t.TempDir()
+	t.Setenv("HOME", oldHome)
+	if err := os.WriteFile(filepath.Join(oldHome, ".claude.json"), []byte(`{"mcpServers":{}}`), 0o600); err != nil {
+		t.Fatalf("creating config: %v", err)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	var buf bytes.Buffer
+	if err := runExportWithWriter(&buf, []string{bundle}); err != nil {
+		t.Fatalf("runExportWithWriter() error = %v", err)
+	}
+
+	newHome := t.TempDir()
+	t.Setenv("HOME", newHome)
+	buf.Reset()
+	if err := runImportWithWriter(&buf, bundle); err != nil {
+		t.Fatalf("runImportWithWriter() error = %v\n%s", err, buf.String())
+	}
+
+	got, err := os.ReadFile(filepath.Join(newHome, ".claude.json"))
+	if err != nil {
+		t.Fatalf("reading restored config: %v\n%s", err, buf.String())
+	}
+	if string(got) != `{"mcpServers":{}}` {
+		t.Errorf("restored config = %q", got)
+	}
+}
@@ -44,7 +44,7 @@ func runCreate(_ *cobra.Command, _ []string) error {
 }
 
 func runCreateWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -0,0 +1,177 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/textdiff"
+)
+
+// maxTextDiffSize is the largest file shown as a text diff.
+const maxTextDiffSize = 1 << 20
+
+// textExtensions are the file types shown as text diffs.
+var textExtensions = map[string]bool{
+	".json":     true,
+	".jsonc":    true,
+	".toml":     true,
+	".md":       true,
+	".markdown": true,
+	".yaml":     true,
+	".yml":      true,
+}
+
+var diffJSON bool
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Output in JSON format")
+	Cmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <platform> [backup-id]",
+	Short: "Show what changed since a backup",
+	Long: `Show how a platform's current configuration differs from a backup.
+
+Files are reported as added (created since the backup), removed (deleted
+since the backup), or modified. Modified JSON, TOML, YAML, and Markdown files
+are shown as unified diffs from the backup to the current contents.
+
+If no backup ID is provided, the most recent backup is used. Backups made
+by older versions of aix do not record their directories, so files added
+since them are not reported.`,
+	Example: `  # Compare Claude Code with its most recent backup
+  aix backup diff claude
+
+  # Compare with a specific backup
+  aix backup diff claude 20260123T100712
+
+  # Output as JSON
+  aix backup diff claude --json
+
+  See Also:
+    aix backup restore - Restore from a backup (--exact also removes added files)
+    aix backup list    - List available backups`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+func runDiff(_ *cobra.Command, args []string) error {
+	return runDiffWithWriter(os.Stdout, args)
+}
+
+func runDiffWithWriter(w io.Writer, args []string) error {
+	platforms, err := cli.ResolvePlatforms(args[:1], flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+	platform := platforms[0]
+	mgr := backup.NewManager()
+
+	backupID, err := resolveBackupID(mgr, platform, args[1:])
+	if err != nil {
+		return err
+	}
+
+	changes, err := mgr.Diff(platform.Name(), backupID)
+	if err != nil {
+		return errors.Wrapf(err, "comparing with backup %s", backupID)
+	}
+
+	if diffJSON {
+		if changes == nil {
+			changes = []backup.Change{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(changes), "encoding output")
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "%s matches backup %s\n", platform.DisplayName(), backupID)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s%s differs from backup %s:%s\n\n", colorBold, platform.DisplayName(), backupID, colorReset)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %s%-8s%s  %s\n", changeColor(c.Kind), c.Kind, colorReset, c.Path)
+	}
+
+	for _, c := range changes {
+		if !textExtensions[strings.ToLower(filepath.Ext(c.Path))] {
+			continue
+		}
+		oldText, ok := readText(c.StoredPath)
+		if !ok {
+			continue
+		}
+		newText, ok := readText(c.Path)
+		if !ok {
+			continue
+		}
+		if diff := textdiff.Unified("backup:"+c.Path, "current:"+c.Path, oldText, newText); diff != "" {
+			fmt.Fprintln(w)
+			fmt.Fprint(w, diff)
+		}
+	}
+	return nil
+}
+
+// resolveBackupID returns the backup ID in args, or the platform's most
+// recent backup if args is empty.
+func resolveBackupID(mgr *backup.Manager, platform cli.Platform, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	manifests, err := mgr.List(platform.Name())
+	if err != nil {
+		if errors.Is(err, backup.ErrNoBackupsFound) {
+			return "", errors.Newf("no backups found for %s", platform.DisplayName())
+		}
+		return "", errors.Wrap(err, "listing backups")
+	}
+	return manifests[0].ID, nil
+}
+
+// readText reads a file for diffing. Missing files read as empty; large and
+// binary files are not diffed.
+func readText(path string) (string, bool) {
+	if path == "" {
+		return "", true
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", true
+	}
+	if err != nil || info.Size() > maxTextDiffSize {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || bytes.IndexByte(data, 0) >= 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// changeColor returns the color used for a change kind.
+func changeColor(kind backup.ChangeKind) string {
+	switch kind {
+	case backup.ChangeAdded:
+		return colorGreen
+	case backup.ChangeModified:
+		return colorYellow
+	default:
+		return colorGray
+	}
+}
@@ -0,0 +1,115 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+func init() {
+	Cmd.AddCommand(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export a snapshot of every platform to one file",
+	Long: `Back up every platform and pack the backups into a single bundle.
+
+The bundle is a gzip-compressed tar archive containing each platform's backup
+and manifest, with a SHA256 checksum for every file. Paths under your home
+directory are stored relative to it, so the bundle can be imported on a
+machine where your home directory is different.
+
+By default, all detected platforms are exported and the bundle is written to
+aix-backup-<timestamp>.tar.gz in the current directory. Use the --platform
+flag to limit the platforms. The bundle may contain credentials from your
+configuration, so it is created readable only by you.`,
+	Example: `  # Export all platforms
+  aix backup export
+
+  # Export to a specific file
+  aix backup export ~/aix-snapshot.tar.gz
+
+  # Export only Claude Code
+  aix backup export --platform claude
+
+  See Also:
+    aix backup import - Restore an exported bundle
+    aix backup create - Create a backup without exporting it`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func runExport(_ *cobra.Command, args []string) error {
+	return runExportWithWriter(os.Stdout, args)
+}
+
+func runExportWithWriter(w io.Writer, args []string) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	file := "aix-backup-" + time.Now().Format("20060102T150405") + ".tar.gz"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	mgr := backup.NewManager()
+	var manifests []backup.BackupManifest
+	for _, p := range platforms {
+		manifest, err := mgr.Backup(p.Name(), p.BackupPaths())
+		if err != nil {
+			if errors.Is(err, backup.ErrNothingToBackUp) {
+				fmt.Fprintf(w, "%s%s: no files found to back up%s\n",
+					colorYellow, p.DisplayName(), colorReset)
+				continue
+			}
+			return errors.Wrapf(err, "backing up %s", p.Name())
+		}
+		manifests = append(manifests, *manifest)
+	}
+	if len(manifests) == 0 {
+		return errors.New("nothing to export: no platform has configuration files")
+	}
+
+	if err := writeBundle(mgr, file, manifests); err != nil {
+		return err
+	}
+
+	files := 0
+	for _, m := range manifests {
+		files += len(m.Files)
+	}
+	fmt.Fprintf(w, "%s[OK] Exported %d platform(s), %d files, to %s%s\n",
+		colorGreen, len(manifests), files, file, colorReset)
+	return nil
+}
+
+// writeBundle exports manifests to file through a temporary file, so a
+// failed export never leaves a partial bundle behind.
+func writeBundle(mgr *backup.Manager, file string, manifests []backup.BackupManifest) error {
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".aix-backup-*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating bundle")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := mgr.Export(tmp, manifests); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "exporting backups")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "writing bundle")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), file), "writing bundle")
+}
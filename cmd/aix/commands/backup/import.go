@@ -0,0 +1,111 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+var importNoRestore bool
+
+func init() {
+	importCmd.Flags().BoolVar(&importNoRestore, "no-restore", false,
+		"add the backups without restoring them")
+	Cmd.AddCommand(importCmd)
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore a bundle exported on another machine",
+	Long: `Import a bundle created by 'aix backup export' and restore it.
+
+Every file in the bundle is checked against its recorded SHA256 checksum
+before anything is changed. Paths that were under the home directory on the
+exporting machine are rewritten to the same place under your home directory.
+A backup may only restore files inside its platform's configuration paths;
+bundles that name any other path, or a platform unknown here, are rejected.
+
+The bundle's backups are added to the backup list, and each platform is
+then restored from its backup. Before a platform is restored, its current
+configuration is backed up. Use --no-restore to only add the backups and
+restore them later with 'aix backup restore'.`,
+	Example: `  # Restore a bundle from another machine
+  aix backup import aix-backup-20260123T100712.tar.gz
+
+  # Add the backups without restoring them
+  aix backup import aix-backup-20260123T100712.tar.gz --no-restore
+
+  See Also:
+    aix backup export  - Export a snapshot of every platform
+    aix backup restore - Restore from a backup`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func runImport(_ *cobra.Command, args []string) error {
+	return runImportWithWriter(os.Stdout, args[0])
+}
+
+// platformBackupPaths returns the paths a backup of platform may restore:
+// the platform's backup paths in the selected scope. Backups of platforms
+// unknown here cannot be checked, so they are rejected.
+func platformBackupPaths(platform string) ([]string, error) {
+	p, err := cli.NewPlatform(platform, flags.PlatformOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	return p.BackupPaths(), nil
+}
+
+func runImportWithWriter(w io.Writer, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrap(err, "opening bundle")
+	}
+	defer f.Close()
+
+	mgr := backup.NewManager()
+	_, manifests, err := mgr.Import(f, platformBackupPaths)
+	if err != nil {
+		if errors.Is(err, backup.ErrInvalidBundle) {
+			return errors.NewUserError(err, "Check that the file was created by 'aix backup export' and was copied completely")
+		}
+		return errors.Wrap(err, "importing bundle")
+	}
+
+	for _, m := range manifests {
+		fmt.Fprintf(w, "Imported %s backup %s (%d files)\n", m.Platform, m.ID, len(m.Files))
+	}
+	if importNoRestore {
+		return nil
+	}
+
+	for _, m := range manifests {
+		p, err := cli.NewPlatform(m.Platform, flags.PlatformOptions()...)
+		if err != nil {
+			return errors.Wrapf(err, "restoring %s", m.Platform)
+		}
+
+		safety, err := mgr.Backup(p.Name(), p.BackupPaths())
+		if err != nil && !errors.Is(err, backup.ErrNothingToBackUp) {
+			return errors.Wrapf(err, "backing up %s before restoring", p.DisplayName())
+		}
+		if safety != nil {
+			fmt.Fprintf(w, "Saved current %s configuration as backup %s\n", p.DisplayName(), safety.ID)
+		}
+
+		if err := mgr.Restore(p.Name(), m.ID); err != nil {
+			return errors.Wrapf(err, "restoring %s", p.DisplayName())
+		}
+		fmt.Fprintf(w, "%s[OK] Restored %s configuration from backup %s%s\n",
+			colorGreen, p.DisplayName(), m.ID, colorReset)
+	}
+	return nil
+}
@@ -65,7 +65,7 @@ func runList(_ *cobra.Command, _ []string) error {
 }
 
 func runListWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -58,7 +58,7 @@ func runPruneWithWriter(w io.Writer) error {
 		return errors.New("--keep must be non-negative")
 	}
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
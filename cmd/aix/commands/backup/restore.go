@@ -13,7 +13,11 @@ import (
 	"github.com/thoreinstein/aix/internal/errors"
 )
 
+var restoreExact bool
+
 func init() {
+	restoreCmd.Flags().BoolVar(&restoreExact, "exact", false,
+		"also remove files added since the backup, after saving a safety backup")
 	Cmd.AddCommand(restoreCmd)
 }
 
@@ -27,18 +31,28 @@ specified platform. The --platform flag is required to avoid accidental
 restoration to the wrong platform.
 
 All files in the backup are restored to their original locations, preserving
-permissions. Existing files are overwritten.`,
+permissions. Existing files are overwritten.
+
+By default, files created since the backup are left in place. With --exact,
+the backed-up directories are made to match the backup exactly: files added
+since are deleted. A safety backup of the current state is created first, so
+an exact restore can itself be undone. Use 'aix backup diff' to preview the
+changes.`,
 	Example: `  # Restore from the most recent Claude backup
   aix backup restore --platform claude
 
   # Restore from a specific backup
   aix backup restore 20260123T100712 --platform claude
 
+  # Restore exactly, removing files created since the backup
+  aix backup restore --platform claude --exact
+
   # List available backups first
   aix backup list --platform claude
 
   See Also:
     aix backup list   - List available backups
+    aix backup diff   - Show what changed since a backup
     aix backup create - Create a new backup`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runRestore,
@@ -54,7 +68,7 @@ func runRestoreWithWriter(_ *cobra.Command, args []string, w io.Writer) error {
 		return errors.New("--platform is required for restore")
 	}
 
-	platforms, err := cli.ResolvePlatforms(platformFlag)
+	platforms, err := cli.ResolvePlatforms(platformFlag, flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -66,19 +80,11 @@ func runRestoreWithWriter(_ *cobra.Command, args []string, w io.Writer) error {
 	mgr := backup.NewManager()
 
 	// Determine backup ID
-	var backupID string
-	if len(args) > 0 {
-		backupID = args[0]
-	} else {
-		// Get most recent backup
-		manifests, err := mgr.List(platform.Name())
-		if err != nil {
-			if errors.Is(err, backup.ErrNoBackupsFound) {
-				return errors.Newf("no backups found for %s", platform.DisplayName())
-			}
-			return errors.Wrap(err, "listing backups")
-		}
-		backupID = manifests[0].ID
+	backupID, err := resolveBackupID(mgr, platform, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
 		fmt.Fprintf(w, "Using most recent backup: %s\n", backupID)
 	}
 
@@ -91,7 +97,19 @@ func runRestoreWithWriter(_ *cobra.Command, args []string, w io.Writer) error {
 	fmt.Fprintf(w, "Restoring %d files from backup %s...\n", len(manifest.Files), backupID)
 
 	// Perform restore
-	if err := mgr.Restore(platform.Name(), backupID); err != nil {
+	if restoreExact {
+		safety, err := mgr.RestoreExact(platform.Name(), backupID)
+		if safety != nil {
+			fmt.Fprintf(w, "Saved current state as backup %s\n", safety.ID)
+		}
+		if errors.Is(err, backup.ErrExactUnsupported) {
+			return errors.NewUserError(err,
+				"This backup predates exact restores; restore it without --exact")
+		}
+		if err != nil {
+			return errors.Wrap(err, "restoring backup")
+		}
+	} else if err := mgr.Restore(platform.Name(), backupID); err != nil {
 		return errors.Wrap(err, "restoring backup")
 	}
 
@@ -36,7 +36,7 @@ Uses the $EDITOR environment variable, falling back to $VISUAL, then nano, then
 func runEdit(_ *cobra.Command, args []string) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,8 +18,12 @@ import (
 	"github.com/thoreinstein/aix/internal/git"
 	"github.com/thoreinstein/aix/internal/install"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/gemini"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/registry"
 	"github.com/thoreinstein/aix/internal/resource"
 )
 
@@ -31,6 +36,12 @@ var installFile bool
 // installAllFromRepo installs all commands from a specific repository.
 var installAllFromRepo string
 
+// installSet holds key=value assignments for the command's parameters.
+var installSet []string
+
+// installMerge reinstalls from the command's repository, merging local edits.
+var installMerge bool
+
 var installer *install.Installer
 
 // Sentinel errors for command install operations.
@@ -43,9 +54,24 @@ func init() {
 		"treat argument as a file path instead of searching repos")
 	installCmd.Flags().StringVar(&installAllFromRepo, "all-from-repo", "",
 		"install all commands from a specific repository")
+	installCmd.Flags().StringArrayVar(&installSet, "set", nil,
+		"set a parameter of the command (key=value, repeatable)")
+	installCmd.Flags().BoolVar(&installMerge, "merge", false,
+		"reinstall from the command's repository, keeping local edits")
 	Cmd.AddCommand(installCmd)
 
-	installer = install.NewInstaller(resource.TypeCommand, "command", installFromLocal)
+	installer = install.NewInstaller(resource.TypeCommand, "command", installFromLocal,
+		install.WithPlatforms(flags.ResolvePlatforms), install.WithRecorder(registry.Record),
+		install.WithForgetter(registry.Forget), install.WithParams(func() []string { return installSet }),
+		install.WithInstallTo(func(w io.Writer, path string, platforms []cli.Platform) (string, error) {
+			return Install(w, path, platforms, true)
+		}))
+}
+
+// Installer returns the installer behind 'aix command install', for linking
+// into an install.Set.
+func Installer() *install.Installer {
+	return installer
 }
 
 var installCmd = &cobra.Command{
@@ -64,7 +90,16 @@ If the command exists in multiple repositories, you will be prompted to select o
 Use --file to skip repo search and treat the argument as a file path.
 
 For git URLs, the repository is cloned to a temporary directory, the command
-is installed, and the temporary directory is cleaned up.`,
+is installed, and the temporary directory is cleaned up.
+
+A command may declare parameters that are filled into its text when it is
+installed. Give values with --set key=value; the rest are prompted for, or
+take their defaults when input is not a terminal. The values are recorded for
+installs from a repository, and 'aix upgrade' reuses them.
+
+Use --merge to reinstall a command from the repository it was installed from
+while keeping edits made to it since; see 'aix command diff'. If the edits
+conflict with changes in the repository, nothing is installed.`,
 	Example: `  # Install by name from configured repos
   aix command install review
 
@@ -82,6 +117,12 @@ is installed, and the temporary directory is cleaned up.`,
   # Force overwrite existing command
   aix command install review --force
 
+  # Fill in parameters
+  aix command install review --set org=acme
+
+  # Reinstall, keeping local edits
+  aix command install review --merge
+
   # Install to a specific platform
   aix command install review --platform claude
 
@@ -97,11 +138,17 @@ is installed, and the temporary directory is cleaned up.`,
 			if len(args) > 0 {
 				return errors.New("cannot specify both --all-from-repo and a source argument")
 			}
+			if len(installSet) > 0 {
+				return errors.New("cannot specify both --all-from-repo and --set")
+			}
 			return nil
 		}
 		if len(args) != 1 {
 			return errors.New("requires exactly one argument (source)")
 		}
+		if installMerge && (installForce || installFile) {
+			return errors.New("cannot combine --merge with --force or --file")
+		}
 		return nil
 	},
 	RunE: runInstall,
@@ -117,6 +164,10 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	source := args[0]
 
+	if installMerge {
+		return reinstallMerged(source)
+	}
+
 	// If --file flag is set, treat argument as file path or URL (old behavior)
 	if installFile {
 		if git.IsURL(source) {
@@ -125,7 +176,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 			}
 			return nil
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// If source is clearly a path or URL, use direct install
@@ -136,7 +187,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 			}
 			return nil
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// Try repo lookup first
@@ -163,120 +214,162 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	// Check if it's a local path that exists
 	if _, err := os.Stat(source); err == nil {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	return errors.Newf("command %q not found in any configured repository", source)
 }
 
-// installFromLocal installs a command from a local file or directory.
-func installFromLocal(source string) error {
-	// Resolve to absolute path for consistent error messages
-	absPath, err := filepath.Abs(source)
+// Load parses the command file or directory at path into its canonical form
+// without validating or installing it.
+func Load(path string) (*claude.Command, error) {
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return errors.Wrap(err, "resolving path")
+		return nil, errors.Wrap(err, "resolving path")
 	}
 
-	// Determine command file path
-	commandPath := absPath
-	info, err := os.Stat(absPath)
+	commandPath, err := resolveCommandPath(absPath)
 	if err != nil {
-		return errors.Wrap(err, "accessing source")
+		return nil, err
 	}
 
-	if info.IsDir() {
-		// Look for command.md in directory first
-		candidatePath := filepath.Join(absPath, "command.md")
-		if _, err := os.Stat(candidatePath); err == nil {
-			commandPath = candidatePath
-		} else {
-			// Fall back to finding any .md file that doesn't start with _
-			commandPath = ""
-			entries, readErr := os.ReadDir(absPath)
-			if readErr != nil {
-				return errors.Wrap(readErr, "reading directory")
-			}
-			for _, e := range entries {
-				if e.IsDir() {
-					continue
-				}
-				name := e.Name()
-				if strings.HasSuffix(name, ".md") && !strings.HasPrefix(name, "_") {
-					commandPath = filepath.Join(absPath, name)
-					break
-				}
-			}
-			if commandPath == "" {
-				return errors.Newf("no command file found in %s (expected command.md or any .md file)", absPath)
-			}
-		}
+	p := parser.New[*claude.Command]()
+	cmd, err := p.ParseFile(commandPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing command")
 	}
+	return *cmd, nil
+}
 
-	// Verify file exists
-	if _, err := os.Stat(commandPath); err != nil {
-		return errors.Newf("command file not found: %s", commandPath)
+// reinstallMerged reinstalls the command called name from the repository it
+// was installed from, merging local edits into the repository version.
+func reinstallMerged(name string) error {
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	err = registry.ReinstallRecorded(resource.TypeCommand, name, platforms, func(path string, p cli.Platform) error {
+		_, err := Install(os.Stdout, path, []cli.Platform{p}, true)
+		return err
+	})
+	switch {
+	case errors.Is(err, registry.ErrNotRecorded):
+		return errors.NewUserError(err, "Only commands installed from a configured repository can be merged")
+	case errors.Is(err, registry.ErrMergeConflict):
+		return errors.NewUserError(errors.Wrapf(err, "merging command %q", name),
+			fmt.Sprintf("Review the edits with 'aix command diff %s', or use --force to discard them", name))
+	case err != nil:
+		return errors.Wrap(err, "reinstalling command")
+	}
+	fmt.Printf("[OK] Command '%s' reinstalled with local edits merged\n", name)
+	return nil
+}
+
+// Install installs the command at path to platforms, writing progress to
+// w, and returns its name. An existing copy on any platform makes the
+// install fail unless force is set.
+func Install(w io.Writer, path string, platforms []cli.Platform, force bool) (string, error) {
+	cmd, err := loadValid(w, path)
+	if err != nil {
+		return "", err
 	}
+	return cmd.Name, installTo(w, cmd, platforms, force)
+}
 
-	fmt.Println("Validating command...")
+// installFromLocal installs a command from a local file or directory to the
+// platforms selected by the flags, and returns its name.
+func installFromLocal(source string) (string, error) {
+	cmd, err := loadValid(os.Stdout, source)
+	if err != nil {
+		return "", err
+	}
+
+	// Get target platforms
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return "", errors.Wrap(err, "resolving platforms")
+	}
+	return cmd.Name, installTo(os.Stdout, cmd, platforms, installForce)
+}
+
+// loadValid parses and validates the command at source, reporting
+// validation errors and warnings to w.
+func loadValid(w io.Writer, source string) (*claude.Command, error) {
+	// Resolve to absolute path for consistent error messages
+	absPath, err := filepath.Abs(source)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving path")
+	}
+
+	commandPath, err := resolveCommandPath(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(w, "Validating command...")
 
 	// Parse command using claude.Command as the canonical type
 	p := parser.New[*claude.Command]()
 	cmd, err := p.ParseFile(commandPath)
 	if err != nil {
-		return errors.Wrap(err, "parsing command")
+		return nil, errors.Wrap(err, "parsing command")
 	}
 
 	// Validate command
 	v := validator.New()
 	result := v.Validate(*cmd, commandPath)
 	if result.HasErrors() {
-		fmt.Println("Command validation failed:")
+		fmt.Fprintln(w, "Command validation failed:")
 		for _, e := range result.Errors {
-			fmt.Printf("  - %v\n", e)
+			fmt.Fprintf(w, "  - %v\n", e)
 		}
-		return errInstallFailed
+		return nil, errInstallFailed
 	}
 
 	// Print warnings (but don't fail)
-	for _, w := range result.Warnings {
-		fmt.Printf("  [WARN] %s\n", w.Message)
-	}
-
-	// Get target platforms
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
-	if err != nil {
-		return errors.Wrap(err, "resolving platforms")
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(w, "  [WARN] %s\n", warning.Message)
 	}
+	return *cmd, nil
+}
 
+// installTo installs cmd to platforms, writing progress to w.
+func installTo(w io.Writer, cmd *claude.Command, platforms []cli.Platform, force bool) error {
 	// Check for existing commands (unless --force)
-	if !installForce {
+	if !force {
 		for _, plat := range platforms {
-			if _, err := plat.GetCommand((*cmd).Name); err == nil {
+			if _, err := plat.GetCommand(cmd.Name); err == nil {
 				return errors.Newf("command %q already exists on %s (use --force to overwrite)",
-					(*cmd).Name, plat.DisplayName())
+					cmd.Name, plat.DisplayName())
 			}
 		}
 	}
 
-	// Install to each platform
+	// Install to each platform; if any fails, every platform is rolled back
 	var installedCount int
+	tx := backup.NewTransaction()
 	for _, plat := range platforms {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(plat.Name(), plat.BackupPaths()); err != nil {
-			return errors.Wrapf(err, "backing up %s before install", plat.DisplayName())
+		if err := cli.BeginChange(tx, plat, resource.TypeCommand, cmd.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before install", plat.DisplayName()))
 		}
 
-		fmt.Printf("Installing '%s' to %s... ", (*cmd).Name, plat.DisplayName())
+		fmt.Fprintf(w, "Installing '%s' to %s... ", cmd.Name, plat.DisplayName())
 
 		// Convert command to platform-specific type
-		platformCmd := convertForPlatform(*cmd, plat.Name())
+		platformCmd := ConvertForPlatform(cmd, plat.Name())
 
 		if err := plat.InstallCommand(platformCmd); err != nil {
-			fmt.Println("failed")
-			return errors.Wrapf(err, "failed to install to %s", plat.DisplayName())
+			if errors.Is(err, errors.ErrNotSupported) {
+				fmt.Fprintln(w, "skipped (not supported)")
+				continue
+			}
+			fmt.Fprintln(w, "failed")
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to install to %s", plat.DisplayName()))
 		}
 
-		fmt.Println("done")
+		fmt.Fprintln(w, "done")
 		installedCount++
 	}
 
@@ -285,14 +378,58 @@ func installFromLocal(source string) error {
 	if installedCount != 1 {
 		platformWord = "platforms"
 	}
-	fmt.Printf("[OK] Command '%s' installed to %d %s\n", (*cmd).Name, installedCount, platformWord)
-
+	fmt.Fprintf(w, "[OK] Command '%s' installed to %d %s\n", cmd.Name, installedCount, platformWord)
 	return nil
 }
 
-// convertForPlatform converts a canonical claude.Command to the appropriate
+// resolveCommandPath returns the command markdown file for absPath, which may
+// be the file itself or a directory containing command.md or another .md file.
+func resolveCommandPath(absPath string) (string, error) {
+	commandPath := absPath
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", errors.Wrap(err, "accessing source")
+	}
+
+	if info.IsDir() {
+		// Look for command.md in directory first
+		candidatePath := filepath.Join(absPath, "command.md")
+		if _, err := os.Stat(candidatePath); err == nil {
+			commandPath = candidatePath
+		} else {
+			// Fall back to finding any .md file that doesn't start with _
+			commandPath = ""
+			entries, readErr := os.ReadDir(absPath)
+			if readErr != nil {
+				return "", errors.Wrap(readErr, "reading directory")
+			}
+			for _, e := range entries {
+				if e.IsDir() {
+					continue
+				}
+				name := e.Name()
+				if strings.HasSuffix(name, ".md") && !strings.HasPrefix(name, "_") {
+					commandPath = filepath.Join(absPath, name)
+					break
+				}
+			}
+			if commandPath == "" {
+				return "", errors.Newf("no command file found in %s (expected command.md or any .md file)", absPath)
+			}
+		}
+	}
+
+	// Verify file exists
+	if _, err := os.Stat(commandPath); err != nil {
+		return "", errors.Newf("command file not found: %s", commandPath)
+	}
+
+	return commandPath, nil
+}
+
+// ConvertForPlatform converts a canonical claude.Command to the appropriate
 // platform-specific command type.
-func convertForPlatform(cmd *claude.Command, platformName string) any {
+func ConvertForPlatform(cmd *claude.Command, platformName string) any {
 	switch platformName {
 	case "claude":
 		// Claude uses the canonical format, return as-is
@@ -300,15 +437,58 @@ func convertForPlatform(cmd *claude.Command, platformName string) any {
 	case "opencode":
 		// Convert to OpenCode command format
 		return convertToOpenCode(cmd)
+	case "codex":
+		// Convert to Codex prompt format
+		return convertToCodex(cmd)
 	case "gemini":
 		// Convert to Gemini command format
 		return convertToGemini(cmd)
+	case "cursor":
+		// Convert to Cursor command format
+		return convertToCursor(cmd)
+	case "copilot":
+		// Convert to a Copilot prompt file
+		return convertToCopilot(cmd)
 	default:
 		// Unknown platform, return as-is and let the adapter handle it
 		return cmd
 	}
 }
 
+// convertToCodex converts a Claude command to a Codex custom prompt.
+// Codex prompts only carry a description and argument hint, so the
+// remaining Claude frontmatter fields are dropped.
+func convertToCodex(c *claude.Command) *codex.Command {
+	return &codex.Command{
+		Name:         c.Name,
+		Description:  c.Description,
+		ArgumentHint: c.ArgumentHint,
+		Instructions: c.Instructions,
+	}
+}
+
+// convertToCursor converts a Claude command to a Cursor command.
+// Cursor commands are plain markdown, so only the instructions are written.
+func convertToCursor(c *claude.Command) *cursor.Command {
+	return &cursor.Command{
+		Name:         c.Name,
+		Description:  c.Description,
+		Instructions: c.Instructions,
+	}
+}
+
+// convertToCopilot converts a Claude command to a Copilot prompt file.
+// Claude model and tool names mean nothing to Copilot, so only the
+// description, argument hint, and instructions are kept.
+func convertToCopilot(c *claude.Command) *copilot.Prompt {
+	return &copilot.Prompt{
+		Name:         c.Name,
+		Description:  c.Description,
+		ArgumentHint: c.ArgumentHint,
+		Instructions: c.Instructions,
+	}
+}
+
 // convertToGemini converts a Claude command to a Gemini command.
 func convertToGemini(c *claude.Command) *gemini.Command {
 	return &gemini.Command{
@@ -88,7 +88,7 @@ func TestInstallFromLocal_MissingFile(t *testing.T) {
 	// Create a temp directory without any command file
 	tempDir := t.TempDir()
 
-	err := installFromLocal(tempDir)
+	_, err := installFromLocal(tempDir)
 	if err == nil {
 		t.Error("expected error for missing command file, got nil")
 	}
@@ -116,7 +116,7 @@ Some instructions.
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(tempDir)
+	_, err := installFromLocal(tempDir)
 	if err == nil {
 		t.Error("expected error for invalid command, got nil")
 	}
@@ -145,7 +145,7 @@ Review the code carefully.
 
 	// Try to install from the file path (not directory)
 	// This should fail because there are no available platforms
-	err := installFromLocal(cmdPath)
+	_, err := installFromLocal(cmdPath)
 	// We expect an error because no platforms are available in test
 	if err == nil {
 		t.Log("expected error (no platforms available), got nil - this may be expected in test env")
@@ -292,7 +292,7 @@ func TestConvertForPlatform(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := convertForPlatform(tt.cmd, tt.platformName)
+			result := ConvertForPlatform(tt.cmd, tt.platformName)
 			tt.checkType(t, result)
 		})
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 // ANSI color codes for terminal output.
@@ -60,6 +62,7 @@ type listOutput map[string][]infoJSON
 type infoJSON struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Source      string `json:"source"`
 }
 
 func runList(_ *cobra.Command, _ []string) error {
@@ -68,7 +71,7 @@ func runList(_ *cobra.Command, _ []string) error {
 
 // runListWithWriter allows injecting a writer for testing.
 func runListWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -81,6 +84,11 @@ func runListWithWriter(w io.Writer) error {
 
 // outputJSON outputs commands in JSON format.
 func outputJSON(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	output := make(listOutput)
 
 	for _, p := range platforms {
@@ -94,6 +102,7 @@ func outputJSON(w io.Writer, platforms []cli.Platform) error {
 			infos[i] = infoJSON{
 				Name:        c.Name,
 				Description: c.Description,
+				Source:      reg.Source(resource.TypeCommand, c.Name),
 			}
 		}
 		output[p.Name()] = infos
@@ -106,6 +115,11 @@ func outputJSON(w io.Writer, platforms []cli.Platform) error {
 
 // outputTabular outputs commands in tabular format grouped by platform.
 func outputTabular(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	hasCommands := false
 
 	for i, p := range platforms {
@@ -133,11 +147,13 @@ func outputTabular(w io.Writer, platforms []cli.Platform) error {
 
 		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 		// Table headers
-		fmt.Fprintf(tw, "  %sNAME%s\t%sDESCRIPTION%s\n", colorBold, colorReset, colorBold, colorReset)
+		fmt.Fprintf(tw, "  %sNAME%s\t%sSOURCE%s\t%sDESCRIPTION%s\n",
+			colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
 
 		for _, c := range commands {
 			desc := truncate(c.Description, 80)
-			fmt.Fprintf(tw, "  %s/%s%s\t%s\n", colorGreen, c.Name, colorReset, desc)
+			fmt.Fprintf(tw, "  %s/%s%s\t%s\t%s\n", colorGreen, c.Name, colorReset,
+				reg.Source(resource.TypeCommand, c.Name), desc)
 		}
 		tw.Flush()
 	}
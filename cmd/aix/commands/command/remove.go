@@ -13,6 +13,8 @@ import (
 	"github.com/thoreinstein/aix/internal/backup"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 var removeForce bool
@@ -56,7 +58,7 @@ func runRemove(_ *cobra.Command, args []string) error {
 func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -67,6 +69,8 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 		return errors.Newf("command %q not found on any platform", name)
 	}
 
+	install.WarnDependents(w, installedOn, resource.TypeCommand, name)
+
 	// Confirm removal unless --force is specified
 	if !removeForce {
 		if !confirmRemoval(w, r, name, installedOn) {
@@ -75,28 +79,22 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 		}
 	}
 
-	// Remove from each platform
-	var failed []string
+	// Remove from each platform; if any fails, every platform is rolled back
+	tx := backup.NewTransaction()
 	for _, p := range installedOn {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(p.Name(), p.BackupPaths()); err != nil {
-			failed = append(failed, fmt.Sprintf("%s: backup failed: %v", p.DisplayName(), err))
-			continue
+		if err := cli.BeginChange(tx, p, resource.TypeCommand, name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before remove", p.DisplayName()))
 		}
 
 		fmt.Fprintf(w, "Removing from %s... ", p.DisplayName())
 		if err := p.UninstallCommand(name); err != nil {
 			fmt.Fprintln(w, "failed")
-			failed = append(failed, fmt.Sprintf("%s: %v", p.DisplayName(), err))
-			continue
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to remove from %s", p.DisplayName()))
 		}
 		fmt.Fprintln(w, "done")
 	}
 
-	if len(failed) > 0 {
-		return errors.New("failed to remove from some platforms:\n  " + strings.Join(failed, "\n  "))
-	}
-
 	fmt.Fprintf(w, "\u2713 Command %q removed from %d platform(s)\n", name, len(installedOn))
 	return nil
 }
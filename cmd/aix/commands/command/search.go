@@ -92,11 +92,9 @@ func runSearchWithWriter(w io.Writer, args []string) error {
 		}
 	}
 
-	// Scan all repos
-	scanner := resource.NewScanner()
-	resources, err := scanner.ScanAll(repos)
+	idx, err := resource.OpenIndex(resource.DefaultIndexPath(), repos, resource.NewScanner())
 	if err != nil {
-		return errors.Wrap(err, "scanning repositories")
+		return errors.Wrap(err, "indexing repositories")
 	}
 
 	// Build search options - filter to commands only
@@ -108,7 +106,14 @@ func runSearchWithWriter(w io.Writer, args []string) error {
 	}
 
 	// Search
-	results := resource.Search(resources, query, opts)
+	hits, err := idx.Search(query, opts)
+	if err != nil {
+		return err
+	}
+	results := make([]resource.Resource, len(hits))
+	for i, h := range hits {
+		results[i] = h.Resource
+	}
 
 	if len(results) == 0 {
 		fmt.Fprintf(w, "No commands found matching %q\n", query)
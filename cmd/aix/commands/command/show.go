@@ -14,6 +14,9 @@ import (
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
 )
 
@@ -91,7 +94,7 @@ func runShow(_ *cobra.Command, args []string) error {
 
 // runShowWithWriter allows injecting a writer for testing.
 func runShowWithWriter(name string, w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -145,6 +148,22 @@ func extractDetail(cmd any) *showDetail {
 		return extractClaudeDetail(c)
 	case *opencode.Command:
 		return extractOpenCodeDetail(c)
+	case *codex.Command:
+		return extractCodexDetail(c)
+	case *cursor.Command:
+		return &showDetail{
+			Name:         c.Name,
+			Description:  c.Description,
+			Instructions: c.Instructions,
+		}
+	case *copilot.Prompt:
+		return &showDetail{
+			Name:         c.Name,
+			Description:  c.Description,
+			Model:        c.Model,
+			ArgumentHint: c.ArgumentHint,
+			Instructions: c.Instructions,
+		}
 	default:
 		return nil
 	}
@@ -185,6 +204,16 @@ func extractOpenCodeDetail(c *opencode.Command) *showDetail {
 	}
 }
 
+// extractCodexDetail extracts details from a Codex custom prompt.
+func extractCodexDetail(c *codex.Command) *showDetail {
+	return &showDetail{
+		Name:         c.Name,
+		Description:  c.Description,
+		ArgumentHint: c.ArgumentHint,
+		Instructions: c.Instructions,
+	}
+}
+
 func outputShowJSON(w io.Writer, detail *showDetail) error {
 	data, err := json.MarshalIndent(detail, "", "  ")
 	if err != nil {
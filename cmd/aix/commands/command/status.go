@@ -0,0 +1,96 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+var statusJSON bool
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output in JSON format")
+	Cmd.AddCommand(statusCmd)
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which installed commands were modified locally",
+	Long: `Show whether each installed command was edited since aix installed it, and
+whether its repository has a newer version.
+
+Only commands installed from a configured repository can be checked; commands
+installed from a local path or git URL are listed as untracked. Run
+'aix repo update' first to compare against the latest upstream changes.`,
+	Example: `  # Check all installed commands
+  aix command status
+
+  # Output as JSON
+  aix command status --json
+
+  See Also:
+    aix command diff     - Show local edits to a command
+    aix upgrade        - Reinstall commands that changed upstream`,
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+}
+
+func runStatus(_ *cobra.Command, _ []string) error {
+	return runStatusWithWriter(os.Stdout)
+}
+
+// runStatusWithWriter allows injecting a writer for testing.
+func runStatusWithWriter(w io.Writer) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	installed := make(map[string][]cli.Platform)
+	for _, p := range platforms {
+		commands, err := p.ListCommands()
+		if err != nil {
+			return errors.Wrapf(err, "listing commands for %s", p.Name())
+		}
+		for _, c := range commands {
+			installed[c.Name] = append(installed[c.Name], p)
+		}
+	}
+
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+	reports, err := reg.Reports(resource.TypeCommand, installed)
+	if err != nil {
+		return err
+	}
+
+	if statusJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(reports), "encoding output")
+	}
+
+	if len(reports) == 0 {
+		fmt.Fprintln(w, "No commands installed")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%sNAME%s\t%sSOURCE%s\t%sSTATUS%s\n",
+		colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s%s%s\t%s\t%s\n", colorGreen, r.Name, colorReset, r.Source, r.Status)
+	}
+	return tw.Flush()
+}
@@ -109,6 +109,7 @@ See Also: aix config list, aix init`,
 
 func runConfigGet(_ *cobra.Command, args []string) error {
 	key := args[0]
+	setPlatformDefault()
 
 	// Check if value exists
 	if !viper.IsSet(key) {
@@ -188,6 +189,8 @@ func runConfigSet(_ *cobra.Command, args []string) error {
 }
 
 func runConfigList(_ *cobra.Command, _ []string) error {
+	setPlatformDefault()
+
 	// Build config structure from viper
 	cfg := map[string]any{
 		"version":           viper.GetInt("version"),
@@ -219,6 +222,14 @@ func runConfigEdit(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// setPlatformDefault shows default_platforms as every platform when the
+// config file does not set it, which is what it means. Only the commands
+// that show the configuration set it, since listing every platform loads
+// the plugins.
+func setPlatformDefault() {
+	viper.SetDefault("default_platforms", paths.Platforms())
+}
+
 // parsePlatforms splits a comma-separated string into a slice of platform names.
 func parsePlatforms(s string) []string {
 	var platforms []string
@@ -0,0 +1,332 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+var (
+	diffJSON     bool
+	diffTypes    []string
+	diffExitCode bool
+)
+
+// errDiffFound is returned with --exit-code when platforms differ.
+var errDiffFound = errors.New("platforms differ")
+
+// Drift statuses reported by aix diff.
+const (
+	driftInSync  = "in-sync"
+	driftMissing = "missing"
+	driftDiffers = "differs"
+)
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "output as JSON")
+	diffCmd.Flags().StringSliceVar(&diffTypes, "type", nil,
+		"resource types to compare: skill, command, agent, mcp (default: all)")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false,
+		"exit with status 1 if any resource is missing or differs")
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Report resources that differ between platforms",
+	Long: `Compare the skills, commands, agents, and MCP servers installed on each
+platform.
+
+For every resource name, diff shows which platforms have it. Resources that
+are installed on several platforms are compared in their canonical form, and
+those whose content differs are reported together with the fields that
+differ, such as MCP server args or env, or a skill's instructions.
+
+Fields that some platforms cannot represent, such as an MCP server's OS
+restrictions, and local preferences such as whether a server is enabled are
+not compared. Platforms that do not support a resource type are not counted
+as missing it.
+
+Use --json for machine-readable output and --exit-code to fail a CI job when
+platforms have drifted apart.`,
+	Example: `  # Compare all detected platforms
+  aix diff
+
+  # Compare MCP servers on Claude Code and OpenCode
+  aix diff --platform claude,opencode --type mcp
+
+  # Fail a CI job on drift
+  aix diff --json --exit-code
+
+See Also: aix sync`,
+	Args: cobra.NoArgs,
+	RunE: runDiff,
+}
+
+// driftEntry is the state of a single resource across platforms.
+type driftEntry struct {
+	Type   resource.ResourceType `json:"type"`
+	Name   string                `json:"name"`
+	Status string                `json:"status"`
+
+	// Present and Missing list the platforms that do and do not have the
+	// resource, among those that support its type.
+	Present []string `json:"present"`
+	Missing []string `json:"missing,omitempty"`
+
+	// Fields lists the compared fields that differ, and Variants groups
+	// the platforms whose content is the same. Both are empty unless the
+	// status is "differs".
+	Fields   []string   `json:"fields,omitempty"`
+	Variants [][]string `json:"variants,omitempty"`
+}
+
+// diffJSONOutput is the --json document.
+type diffJSONOutput struct {
+	Platforms []string     `json:"platforms"`
+	Resources []driftEntry `json:"resources"`
+}
+
+func runDiff(cmd *cobra.Command, _ []string) error {
+	return runDiffWithWriter(cmd.OutOrStdout())
+}
+
+// runDiffWithWriter allows injecting a writer for testing.
+func runDiffWithWriter(w io.Writer) error {
+	types, err := parseResourceTypes(diffTypes)
+	if err != nil {
+		return errors.NewUserError(err, "Valid types are: skill, command, agent, mcp")
+	}
+
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+	if len(platforms) < 2 {
+		return errors.NewUserError(
+			errors.New("need at least two platforms to compare"),
+			"Pass --platform with two or more platforms")
+	}
+
+	entries, unsupported, err := collectDrift(platforms, types)
+	if err != nil {
+		return err
+	}
+
+	if diffJSON {
+		out := diffJSONOutput{Resources: entries}
+		for _, p := range platforms {
+			out.Platforms = append(out.Platforms, p.Name())
+		}
+		if out.Resources == nil {
+			out.Resources = []driftEntry{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return errors.Wrap(err, "encoding JSON")
+		}
+	} else {
+		printDrift(w, platforms, entries, unsupported)
+	}
+
+	if diffExitCode {
+		for _, e := range entries {
+			if e.Status != driftInSync {
+				return errDiffFound
+			}
+		}
+	}
+	return nil
+}
+
+// collectDrift compares every resource of the selected types across
+// platforms. It also returns the set of "platform/type" pairs that were
+// skipped because the platform does not support the type.
+func collectDrift(platforms []cli.Platform, types []resource.ResourceType) ([]driftEntry, map[string]bool, error) {
+	unsupported := make(map[string]bool)
+	var entries []driftEntry
+
+	for _, t := range types {
+		var supported []cli.Platform
+		installed := make(map[string][]cli.Platform)
+		var names []string
+		for _, p := range platforms {
+			list, err := listInstalled(p, t)
+			if err != nil {
+				unsupported[p.Name()+"/"+string(t)] = true
+				continue
+			}
+			supported = append(supported, p)
+			for _, name := range list {
+				if _, ok := installed[name]; !ok {
+					names = append(names, name)
+				}
+				installed[name] = append(installed[name], p)
+			}
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			entry, err := compareResource(t, name, installed[name], supported)
+			if err != nil {
+				return nil, nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, unsupported, nil
+}
+
+// compareResource builds the drift entry for a resource installed on
+// present, out of the platforms that support its type.
+func compareResource(t resource.ResourceType, name string, present, supported []cli.Platform) (driftEntry, error) {
+	entry := driftEntry{Type: t, Name: name, Status: driftInSync}
+	for _, p := range supported {
+		if slices.Contains(present, p) {
+			entry.Present = append(entry.Present, p.Name())
+		} else {
+			entry.Missing = append(entry.Missing, p.Name())
+		}
+	}
+	if len(entry.Missing) > 0 {
+		entry.Status = driftMissing
+	}
+	if len(present) < 2 {
+		return entry, nil
+	}
+
+	// Group platforms by content digest, keeping one value per group.
+	var (
+		digests []string
+		values  []any
+	)
+	for _, p := range present {
+		v, err := cli.CanonicalResource(p, t, name)
+		if err != nil {
+			return entry, errors.Wrapf(err, "reading %s %q from %s", t, name, p.DisplayName())
+		}
+		v = commonFields(v, t, present)
+		d, err := manifest.Digest(v)
+		if err != nil {
+			return entry, err
+		}
+		i := slices.Index(digests, d)
+		if i < 0 {
+			digests = append(digests, d)
+			values = append(values, v)
+			entry.Variants = append(entry.Variants, nil)
+			i = len(digests) - 1
+		}
+		entry.Variants[i] = append(entry.Variants[i], p.Name())
+	}
+
+	if len(digests) == 1 {
+		entry.Variants = nil
+		return entry, nil
+	}
+
+	entry.Status = driftDiffers
+	for _, v := range values[1:] {
+		fields, err := manifest.DiffFields(values[0], v)
+		if err != nil {
+			return entry, err
+		}
+		for _, f := range fields {
+			if !slices.Contains(entry.Fields, f) {
+				entry.Fields = append(entry.Fields, f)
+			}
+		}
+	}
+	return entry, nil
+}
+
+// commonFields returns canonical resource v with only the fields that every
+// one of platforms can hold, by converting it for each platform in turn and
+// back, as installing it there would. Platforms it cannot be converted for
+// are left out.
+func commonFields(v any, t resource.ResourceType, platforms []cli.Platform) any {
+	for _, p := range platforms {
+		converted, err := convertResource(v, p.Name())
+		if err != nil {
+			continue
+		}
+		back, err := canonicalize(t, converted)
+		if err != nil {
+			continue
+		}
+		v = back
+	}
+	return v
+}
+
+// printDrift writes one row per resource with a column per platform,
+// followed by a summary.
+func printDrift(w io.Writer, platforms []cli.Platform, entries []driftEntry, unsupported map[string]bool) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No resources installed.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"TYPE", "NAME"}
+	for _, p := range platforms {
+		header = append(header, strings.ToUpper(p.Name()))
+	}
+	header = append(header, "STATUS")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	counts := make(map[string]int)
+	for _, e := range entries {
+		counts[e.Status]++
+		row := []string{string(e.Type), e.Name}
+		for _, p := range platforms {
+			switch {
+			case unsupported[p.Name()+"/"+string(e.Type)]:
+				row = append(row, "n/a")
+			case slices.Contains(e.Present, p.Name()):
+				row = append(row, "yes")
+			default:
+				row = append(row, "-")
+			}
+		}
+		row = append(row, driftStatusText(e))
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintf(w, "\n%d in sync, %d missing on some platforms, %d with differing content.\n",
+		counts[driftInSync], counts[driftMissing], counts[driftDiffers])
+}
+
+// driftStatusText describes an entry's status for the STATUS column.
+func driftStatusText(e driftEntry) string {
+	switch e.Status {
+	case driftDiffers:
+		groups := make([]string, len(e.Variants))
+		for i, v := range e.Variants {
+			groups[i] = strings.Join(v, ",")
+		}
+		text := fmt.Sprintf("differs: %s (%s)", strings.Join(e.Fields, ", "), strings.Join(groups, " vs "))
+		if len(e.Missing) > 0 {
+			text += "; missing on " + strings.Join(e.Missing, ", ")
+		}
+		return text
+	case driftMissing:
+		return "missing on " + strings.Join(e.Missing, ", ")
+	default:
+		return "ok"
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+)
+
+func TestRunDiff_ReportsMissingAndDiffering(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	if err := os.MkdirAll(filepath.Join(tmp, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	claudeMCP := `{"mcpServers": {
+		"github": {"command": "npx", "args": ["-y", "gh"]},
+		"fetch": {"command": "uvx", "args": ["mcp-fetch"], "platforms": ["darwin"]},
+		"local": {"command": "./server"}
+	}}`
+	if err := os.WriteFile(filepath.Join(tmp, ".claude", ".mcp.json"), []byte(claudeMCP), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	openCode := `{"mcp": {
+		"github": {"type": "local", "command": ["npx", "-y", "gh", "--verbose"]},
+		"fetch": {"type": "local", "command": ["uvx", "mcp-fetch"]}
+	}}`
+	if err := os.WriteFile(filepath.Join(tmp, "opencode.json"), []byte(openCode), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldScope, oldRoot, oldPlatforms := flags.GetScopeFlag(), flags.GetProjectRootFlag(), flags.GetPlatformFlag()
+	oldJSON, oldTypes, oldExitCode := diffJSON, diffTypes, diffExitCode
+	t.Cleanup(func() {
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		flags.SetPlatformFlag(oldPlatforms)
+		diffJSON, diffTypes, diffExitCode = oldJSON, oldTypes, oldExitCode
+	})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	flags.SetPlatformFlag([]string{"claude", "opencode"})
+	diffTypes = []string{"mcp"}
+	diffExitCode = true
+
+	var buf bytes.Buffer
+	err := runDiffWithWriter(&buf)
+	if err != errDiffFound {
+		t.Fatalf("runDiffWithWriter() error = %v, want errDiffFound", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"differs: args (claude vs opencode)",
+		"missing on opencode",
+		"1 in sync, 1 missing on some platforms, 1 with differing content.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	diffJSON = true
+	diffExitCode = false
+	buf.Reset()
+	if err := runDiffWithWriter(&buf); err != nil {
+		t.Fatalf("runDiffWithWriter() --json error = %v", err)
+	}
+	var doc diffJSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	got := make(map[string]driftEntry)
+	for _, e := range doc.Resources {
+		got[e.Name] = e
+	}
+	if e := got["github"]; e.Status != driftDiffers || !slices.Equal(e.Fields, []string{"args"}) {
+		t.Errorf("github = %+v, want differs on args", e)
+	}
+	if e := got["local"]; e.Status != driftMissing || !slices.Equal(e.Missing, []string{"opencode"}) {
+		t.Errorf("local = %+v, want missing on opencode", e)
+	}
+	if e := got["fetch"]; e.Status != driftInSync {
+		t.Errorf("fetch = %+v, want in sync", e)
+	}
+}
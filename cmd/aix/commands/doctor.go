@@ -4,18 +4,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/doctor"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp/probe"
 )
 
 var (
-	doctorJSON    bool
-	doctorQuiet   bool
-	doctorVerbose bool
-	doctorFix     bool
+	doctorJSON         bool
+	doctorQuiet        bool
+	doctorVerbose      bool
+	doctorFix          bool
+	doctorProbe        bool
+	doctorProbeTimeout time.Duration
 )
 
 func init() {
@@ -27,6 +33,10 @@ func init() {
 		"show detailed check-by-check output")
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false,
 		"automatically fix issues where possible")
+	doctorCmd.Flags().BoolVar(&doctorProbe, "probe", false,
+		"start every MCP server and check that it answers the MCP handshake")
+	doctorCmd.Flags().DurationVar(&doctorProbeTimeout, "probe-timeout", 10*time.Second,
+		"how long to wait for each MCP server with --probe")
 	rootCmd.AddCommand(doctorCmd)
 }
 
@@ -47,6 +57,11 @@ Output modes (mutually exclusive):
 Auto-fix mode:
   --fix       Automatically fix issues where possible (e.g., file permissions)
 
+Live checks:
+  --probe     Start or connect to every configured MCP server, perform the
+              MCP initialize handshake, and list its tools. Servers that
+              fail are reported as errors.
+
 Exit codes:
   0 - All checks passed (no errors or warnings)
   1 - Warnings present, no errors
@@ -60,6 +75,9 @@ Exit codes:
   # Automatically fix issues
   aix doctor --fix
 
+  # Also check that every MCP server actually starts
+  aix doctor --probe
+
   # Output as JSON for scripts
   aix doctor --json
 
@@ -94,16 +112,11 @@ type checkRegistry struct {
 	pathPermissions *doctor.PathPermissionCheck
 }
 
-func runDoctor(_ *cobra.Command, _ []string) error {
-	runner := doctor.NewRunner()
-	registry := &checkRegistry{}
-
-	// Create and register checks, keeping references for fixing
-	registry.pathPermissions = doctor.NewPathPermissionCheck()
-	runner.AddCheck(registry.pathPermissions)
-	runner.AddCheck(doctor.NewPlatformCheck())
-	runner.AddCheck(doctor.NewConfigSyntaxCheck())
-	runner.AddCheck(doctor.NewConfigSemanticCheck())
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	runner, registry, err := newDoctorRunner(cmd.Root().Version)
+	if err != nil {
+		return err
+	}
 
 	report := runner.Run()
 
@@ -120,14 +133,11 @@ func runDoctor(_ *cobra.Command, _ []string) error {
 				fmt.Println("\nRe-running checks...")
 			}
 
-			// Create a fresh runner and registry for re-check
-			runner = doctor.NewRunner()
-			registry = &checkRegistry{}
-			registry.pathPermissions = doctor.NewPathPermissionCheck()
-			runner.AddCheck(registry.pathPermissions)
-			runner.AddCheck(doctor.NewPlatformCheck())
-			runner.AddCheck(doctor.NewConfigSyntaxCheck())
-			runner.AddCheck(doctor.NewConfigSemanticCheck())
+			// Create a fresh runner for re-check
+			runner, _, err = newDoctorRunner(cmd.Root().Version)
+			if err != nil {
+				return err
+			}
 
 			report = runner.Run()
 		}
@@ -147,6 +157,61 @@ func runDoctor(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// newDoctorRunner creates a runner with every check registered, keeping
+// references to the checks that can fix issues. version is reported to MCP
+// servers probed with --probe.
+func newDoctorRunner(version string) (*doctor.Runner, *checkRegistry, error) {
+	runner := doctor.NewRunner()
+	registry := &checkRegistry{}
+
+	registry.pathPermissions = doctor.NewPathPermissionCheck()
+	runner.AddCheck(registry.pathPermissions)
+	runner.AddCheck(doctor.NewPlatformCheck())
+	runner.AddCheck(doctor.NewConfigSyntaxCheck())
+	runner.AddCheck(doctor.NewConfigSemanticCheck())
+
+	if doctorProbe {
+		targets, err := mcpProbeTargets()
+		if err != nil {
+			return nil, nil, err
+		}
+		prober := probe.New(probe.WithClientInfo("aix", version),
+			probe.WithCommands(flags.GetAllowCmdSecretsFlag()))
+		runner.AddCheck(doctor.NewMCPProbeCheck(targets, prober, doctorProbeTimeout))
+	}
+
+	return runner, registry, nil
+}
+
+// mcpProbeTargets returns every MCP server configured on the targeted
+// platforms.
+func mcpProbeTargets() ([]doctor.MCPProbeTarget, error) {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving platforms")
+	}
+
+	var targets []doctor.MCPProbeTarget
+	for _, p := range platforms {
+		infos, err := p.ListMCP()
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing MCP servers on %s", p.DisplayName())
+		}
+		for _, info := range infos {
+			v, err := p.GetMCP(info.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading MCP server %q on %s", info.Name, p.DisplayName())
+			}
+			server, err := cli.CanonicalMCP(v)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, doctor.MCPProbeTarget{Platform: p.Name(), Server: server})
+		}
+	}
+	return targets, nil
+}
+
 // runFixes attempts to fix all fixable issues and returns the results.
 func runFixes(registry *checkRegistry, report *doctor.DoctorReport) []doctor.FixResult {
 	var allResults []doctor.FixResult
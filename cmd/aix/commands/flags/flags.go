@@ -3,6 +3,8 @@
 // and noun subpackages (skill, mcp, agent, etc.).
 package flags
 
+import "github.com/thoreinstein/aix/internal/cli"
+
 // platformFlag holds the value of the --platform flag.
 var platformFlag []string
 
@@ -18,3 +20,70 @@ func GetPlatformFlag() []string {
 func SetPlatformFlag(platforms []string) {
 	platformFlag = platforms
 }
+
+// scopeFlag holds the parsed value of the --scope flag.
+var scopeFlag = cli.ScopeUser
+
+// projectRootFlag holds the resolved project root for project scope.
+var projectRootFlag string
+
+// GetScopeFlag returns the current value of the --scope flag.
+func GetScopeFlag() cli.Scope {
+	return scopeFlag
+}
+
+// SetScopeFlag sets the scope flag value.
+func SetScopeFlag(scope cli.Scope) {
+	scopeFlag = scope
+}
+
+// GetProjectRootFlag returns the resolved project root.
+// It is empty unless the scope is project.
+func GetProjectRootFlag() string {
+	return projectRootFlag
+}
+
+// SetProjectRootFlag sets the project root value.
+func SetProjectRootFlag(root string) {
+	projectRootFlag = root
+}
+
+// allowCmdSecretsFlag holds the value of the --allow-cmd-secrets flag.
+var allowCmdSecretsFlag bool
+
+// GetAllowCmdSecretsFlag reports whether --allow-cmd-secrets was given, so
+// that ${cmd:...} secret references read from files may be run.
+func GetAllowCmdSecretsFlag() bool {
+	return allowCmdSecretsFlag
+}
+
+// SetAllowCmdSecretsFlag sets the allow-cmd-secrets flag value.
+func SetAllowCmdSecretsFlag(allow bool) {
+	allowCmdSecretsFlag = allow
+}
+
+// defaultPlatforms holds default_platforms from the aix config file.
+var defaultPlatforms []string
+
+// SetDefaultPlatforms sets the platforms targeted when --platform is not
+// given. The root command calls it once the config is loaded.
+func SetDefaultPlatforms(names []string) {
+	defaultPlatforms = names
+}
+
+// PlatformOptions returns the cli.Option values derived from the --scope and
+// --project-root flags and the configured default platforms, for passing to
+// cli.ResolvePlatforms and cli.NewPlatform.
+func PlatformOptions() []cli.Option {
+	return []cli.Option{
+		cli.WithScope(scopeFlag),
+		cli.WithProjectRoot(projectRootFlag),
+		cli.WithDefaultPlatforms(defaultPlatforms),
+	}
+}
+
+// ResolvePlatforms resolves the platforms selected by the --platform,
+// --scope, and --project-root flags.
+func ResolvePlatforms() ([]cli.Platform, error) {
+	return cli.ResolvePlatforms(platformFlag, PlatformOptions()...)
+}
@@ -12,9 +12,14 @@ import (
 	"github.com/thoreinstein/aix/internal/backup"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/gemini"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 // Sentinel errors for MCP add operations.
@@ -23,7 +28,7 @@ var (
 	errMCPAddBothCommandAndURL   = errors.New("cannot specify both command and --url")
 	errMCPAddMissingName         = errors.New("server name is required")
 	errMCPAddMissingCommand      = errors.New("command is required for stdio transport")
-	errMCPAddMissingURL          = errors.New("URL is required for sse transport")
+	errMCPAddMissingURL          = errors.New("URL is required for http and sse transports")
 )
 
 // Package-level flag variables for mcp add command.
@@ -38,13 +43,13 @@ var (
 
 func init() {
 	addCmd.Flags().StringVar(&mcpAddURL, "url", "",
-		"remote server endpoint for SSE transport")
+		"remote server endpoint for http or sse transport")
 	addCmd.Flags().StringSliceVar(&mcpAddEnv, "env", nil,
 		"environment variables in KEY=VALUE format (repeatable)")
 	addCmd.Flags().StringVar(&mcpAddTransport, "transport", "",
-		"explicit transport type: stdio, sse")
+		"explicit transport type: stdio, http, sse (default: http with --url)")
 	addCmd.Flags().StringSliceVar(&mcpAddHeaders, "headers", nil,
-		"HTTP headers for SSE auth in KEY=VALUE format (repeatable)")
+		"HTTP headers for remote server auth in KEY=VALUE format (repeatable)")
 	addCmd.Flags().StringSliceVar(&mcpAddPlatforms, "platform", nil,
 		"restrict server to specific platform(s): darwin, linux, windows (repeatable)")
 	addCmd.Flags().BoolVarP(&mcpAddForce, "force", "f", false,
@@ -60,9 +65,18 @@ var addCmd = &cobra.Command{
 When called without arguments, runs in interactive mode.
 
 For local stdio servers, provide a command and optional arguments.
-For remote SSE servers, use the --url flag.
+For remote servers, use the --url flag. Remote servers use the Streamable
+HTTP transport by default; pass --transport sse for legacy SSE servers.
 Environment variables can be set with --env (repeatable).
-HTTP headers for SSE authentication can be set with --headers (repeatable).
+HTTP headers for remote authentication can be set with --headers (repeatable).
+
+Env and header values may reference secrets instead of holding them in
+plaintext: ${env:NAME} reads an environment variable, ${file:PATH} reads a
+file, and ${cmd:COMMAND} runs a shell command. Platforms that interpolate
+environment variables or files themselves receive the reference in their own
+syntax; otherwise the reference is resolved when the server is added. Quote
+references so your shell does not expand them.
+
 Platform restrictions (for Claude Code only) can be set with --platform.`,
 	Example: `  # Interactive mode
   aix mcp add
@@ -70,12 +84,23 @@ Platform restrictions (for Claude Code only) can be set with --platform.`,
   # Add a local stdio server
   aix mcp add github npx -y @modelcontextprotocol/server-github
 
-  # Add a remote SSE server with headers
+  # Add a remote Streamable HTTP server with headers
   aix mcp add api --url=https://api.example.com/mcp --headers "Auth=Bearer token"
 
+  # Add a legacy SSE server
+  aix mcp add events --url=https://api.example.com/sse --transport sse
+
   # Add a local server with environment variables
   aix mcp add db-tools ./db-mcp --env DB_HOST=localhost --env DB_PORT=5432
 
+  # Reference a token instead of storing it
+  aix mcp add github npx -y @modelcontextprotocol/server-github \
+    --env 'GITHUB_TOKEN=${env:GITHUB_TOKEN}'
+
+  # Read a header value from a password manager
+  aix mcp add api --url=https://api.example.com/mcp \
+    --headers 'Authorization=Bearer ${cmd:pass show api-token}'
+
   # Overwrite existing server
   aix mcp add github npx @modelcontextprotocol/server-github --force
 
@@ -138,28 +163,28 @@ func runMCPAddCore(args []string) error {
 	transport := mcpAddTransport
 	if transport == "" {
 		if mcpAddURL != "" {
-			transport = "sse"
+			transport = mcp.TransportHTTP
 		} else {
-			transport = "stdio"
+			transport = mcp.TransportStdio
 		}
 	}
 
 	// Validate transport value and required fields
 	switch transport {
-	case "stdio":
+	case mcp.TransportStdio:
 		if command == "" {
 			return errMCPAddMissingCommand
 		}
-	case "sse":
+	case mcp.TransportHTTP, mcp.TransportSSE:
 		if mcpAddURL == "" {
 			return errMCPAddMissingURL
 		}
 	default:
-		return errors.Newf("invalid --transport %q: must be 'stdio' or 'sse'", transport)
+		return errors.Newf("invalid --transport %q: must be 'stdio', 'http', or 'sse'", transport)
 	}
 
 	// Get target platforms
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -174,12 +199,13 @@ func runMCPAddCore(args []string) error {
 		}
 	}
 
-	// Add to each platform
+	// Add to each platform; if any fails, every platform is rolled back
 	var addedCount int
+	tx := backup.NewTransaction()
 	for _, plat := range platforms {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(plat.Name(), plat.BackupPaths()); err != nil {
-			return errors.Wrapf(err, "backing up %s before add", plat.DisplayName())
+		if err := cli.BeginChange(tx, plat, resource.TypeMCP, name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before add", plat.DisplayName()))
 		}
 
 		fmt.Printf("Adding '%s' to %s... ", name, plat.DisplayName())
@@ -187,7 +213,7 @@ func runMCPAddCore(args []string) error {
 		// Create platform-specific server and add it
 		if err := addMCPToPlatform(plat, name, command, cmdArgs, transport, envMap, headersMap); err != nil {
 			fmt.Println("failed")
-			return errors.Wrapf(err, "failed to add to %s", plat.DisplayName())
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to add to %s", plat.DisplayName()))
 		}
 
 		fmt.Println("done")
@@ -222,7 +248,8 @@ func runMCPAddInteractive(_ *cobra.Command) error {
 	// 2. Transport type
 	fmt.Println("Select transport type:")
 	fmt.Println("  [1] stdio (local command)")
-	fmt.Println("  [2] sse (remote URL)")
+	fmt.Println("  [2] http (remote URL, Streamable HTTP)")
+	fmt.Println("  [3] sse (remote URL, legacy SSE)")
 	fmt.Print("Choice [1]: ")
 	choice, err := reader.ReadString('\n')
 	if err != nil {
@@ -237,8 +264,10 @@ func runMCPAddInteractive(_ *cobra.Command) error {
 	var commandArgs []string
 	var url string
 	var headers map[string]string
+	var transport string
 
 	if choice == "1" || choice == "stdio" {
+		transport = mcp.TransportStdio
 		// 3a. Stdio: command and args
 		fmt.Print("Enter command: ")
 		command, err = reader.ReadString('\n')
@@ -260,7 +289,12 @@ func runMCPAddInteractive(_ *cobra.Command) error {
 			commandArgs = strings.Fields(argsLine)
 		}
 	} else {
-		// 3b. SSE: URL and headers
+		// 3b. Remote: URL and headers
+		transport = mcp.TransportHTTP
+		if choice == "3" || choice == "sse" {
+			transport = mcp.TransportSSE
+		}
+
 		fmt.Print("Enter URL: ")
 		url, err = reader.ReadString('\n')
 		if err != nil {
@@ -321,6 +355,7 @@ func runMCPAddInteractive(_ *cobra.Command) error {
 
 	// Set the package-level flag variables with collected values
 	mcpAddURL = url
+	mcpAddTransport = transport
 	mcpAddEnv = formatKeyValueSlice(env)
 	mcpAddHeaders = formatKeyValueSlice(headers)
 
@@ -344,17 +379,12 @@ func addMCPToPlatform(
 ) error {
 	switch plat.Name() {
 	case "claude":
-		// Map canonical transport to Claude type: stdio -> stdio, sse -> http
-		claudeType := transport
-		if transport == "sse" {
-			claudeType = "http"
-		}
-
+		// Claude types match canonical transports
 		server := &claude.MCPServer{
 			Name:      name,
 			Command:   command,
 			Args:      args,
-			Type:      claudeType,
+			Type:      transport,
 			URL:       mcpAddURL,
 			Env:       env,
 			Headers:   headers,
@@ -375,10 +405,10 @@ func addMCPToPlatform(
 			cmdSlice = append([]string{command}, args...)
 		}
 
-		// Map transport types: stdio -> local, sse -> remote
-		typ := "local"
-		if transport == "sse" {
-			typ = "remote"
+		// Map transport types: stdio -> local, http/sse -> remote
+		typ := opencode.TypeLocal
+		if transport != mcp.TransportStdio {
+			typ = opencode.TypeRemote
 		}
 
 		server := &opencode.MCPServer{
@@ -391,6 +421,29 @@ func addMCPToPlatform(
 		}
 		return errors.Wrap(plat.AddMCP(server), "adding MCP server to OpenCode")
 
+	case "codex":
+		// Codex does not support platform restrictions
+		if len(mcpAddPlatforms) > 0 {
+			fmt.Printf("\n  Warning: Codex CLI does not support platform restrictions; "+
+				"--platform %s will be ignored\n", strings.Join(mcpAddPlatforms, ", "))
+		}
+
+		// Codex infers transport from the presence of url and only
+		// speaks Streamable HTTP
+		if transport == mcp.TransportSSE {
+			fmt.Printf("\n  Warning: Codex CLI does not support SSE servers; "+
+				"'%s' will be configured as Streamable HTTP\n", name)
+		}
+		server := &codex.MCPServer{
+			Name:        name,
+			Command:     command,
+			Args:        args,
+			URL:         mcpAddURL,
+			Env:         env,
+			HTTPHeaders: headers,
+		}
+		return errors.Wrap(plat.AddMCP(server), "adding MCP server to Codex CLI")
+
 	case "gemini":
 		// Gemini does not support platform restrictions
 		if len(mcpAddPlatforms) > 0 {
@@ -398,19 +451,84 @@ func addMCPToPlatform(
 				"--platform %s will be ignored\n", strings.Join(mcpAddPlatforms, ", "))
 		}
 
+		// Gemini selects the remote transport by field: httpUrl or url
 		server := &gemini.MCPServer{
 			Name:    name,
 			Command: command,
 			Args:    args,
-			URL:     mcpAddURL,
 			Env:     env,
 			Headers: headers,
 			Enabled: true,
 		}
+		if transport == mcp.TransportSSE {
+			server.URL = mcpAddURL
+		} else {
+			server.HTTPURL = mcpAddURL
+		}
 		return errors.Wrap(plat.AddMCP(server), "adding MCP server to Gemini CLI")
 
+	case "cursor":
+		// Cursor does not support platform restrictions
+		if len(mcpAddPlatforms) > 0 {
+			fmt.Printf("\n  Warning: Cursor does not support platform restrictions; "+
+				"--platform %s will be ignored\n", strings.Join(mcpAddPlatforms, ", "))
+		}
+
+		// Cursor infers transport from the presence of url and negotiates
+		// Streamable HTTP or SSE itself
+		server := &cursor.MCPServer{
+			Name:    name,
+			Command: command,
+			Args:    args,
+			URL:     mcpAddURL,
+			Env:     env,
+			Headers: headers,
+		}
+		return errors.Wrap(plat.AddMCP(server), "adding MCP server to Cursor")
+
+	case "copilot":
+		// VS Code does not support platform restrictions
+		if len(mcpAddPlatforms) > 0 {
+			fmt.Printf("\n  Warning: GitHub Copilot does not support platform restrictions; "+
+				"--platform %s will be ignored\n", strings.Join(mcpAddPlatforms, ", "))
+		}
+
+		// VS Code types match canonical transports
+		server := &copilot.MCPServer{
+			Name:    name,
+			Type:    transport,
+			Command: command,
+			Args:    args,
+			URL:     mcpAddURL,
+			Env:     env,
+			Headers: headers,
+		}
+		return errors.Wrap(plat.AddMCP(server), "adding MCP server to GitHub Copilot")
+
 	default:
-		return errors.Newf("unsupported platform: %s", plat.Name())
+		// Platforms described by a definition and plugins take canonical
+		// servers. Plugins receive the platform restrictions as well.
+		server := &mcp.Server{
+			Name:      name,
+			Command:   command,
+			Args:      args,
+			URL:       mcpAddURL,
+			Transport: transport,
+			Env:       env,
+			Headers:   headers,
+		}
+		switch {
+		case isPlugin(plat.Name()):
+			server.Platforms = mcpAddPlatforms
+		case isDefined(plat.Name()):
+			if len(mcpAddPlatforms) > 0 {
+				fmt.Printf("\n  Warning: %s does not support platform restrictions; "+
+					"--platform %s will be ignored\n", plat.DisplayName(), strings.Join(mcpAddPlatforms, ", "))
+			}
+		default:
+			return errors.Newf("unsupported platform: %s", plat.Name())
+		}
+		return errors.Wrapf(plat.AddMCP(server), "adding MCP server to %s", plat.DisplayName())
 	}
 }
 
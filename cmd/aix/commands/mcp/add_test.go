@@ -717,7 +717,7 @@ func TestAddNewSentinelErrors(t *testing.T) {
 	if errMCPAddMissingCommand.Error() != "command is required for stdio transport" {
 		t.Errorf("unexpected error message: %s", errMCPAddMissingCommand.Error())
 	}
-	if errMCPAddMissingURL.Error() != "URL is required for sse transport" {
+	if errMCPAddMissingURL.Error() != "URL is required for http and sse transports" {
 		t.Errorf("unexpected error message: %s", errMCPAddMissingURL.Error())
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/thoreinstein/aix/internal/backup"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 func init() {
@@ -64,7 +65,7 @@ Use 'aix mcp enable' to re-enable it later.`,
 // runSetEnabledWithIO enables or disables an MCP server across platforms.
 // The enabled parameter controls whether to enable (true) or disable (false).
 func runSetEnabledWithIO(name string, enabled bool, w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -78,7 +79,9 @@ func runSetEnabledWithIO(name string, enabled bool, w io.Writer) error {
 
 	fmt.Fprintf(w, "%s MCP server %q...\n", action, name)
 
+	// If any platform fails, every platform is rolled back
 	var foundAny bool
+	tx := backup.NewTransaction()
 	for _, plat := range platforms {
 		if !plat.IsAvailable() {
 			continue
@@ -94,9 +97,8 @@ func runSetEnabledWithIO(name string, enabled bool, w io.Writer) error {
 		foundAny = true
 
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(plat.Name(), plat.BackupPaths()); err != nil {
-			fmt.Fprintf(w, "  %s: backup failed: %v\n", plat.Name(), err)
-			continue
+		if err := cli.BeginChange(tx, plat, resource.TypeMCP, name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s", plat.DisplayName()))
 		}
 
 		if enabled {
@@ -105,10 +107,14 @@ func runSetEnabledWithIO(name string, enabled bool, w io.Writer) error {
 			err = plat.DisableMCP(name)
 		}
 
-		if err != nil {
-			fmt.Fprintf(w, "  %s: error: %v\n", plat.Name(), err)
+		if errors.Is(err, errors.ErrNotSupported) {
+			fmt.Fprintf(w, "  %s: skipped (not supported)\n", plat.Name())
 			continue
 		}
+		if err != nil {
+			fmt.Fprintf(w, "  %s: failed\n", plat.Name())
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed on %s", plat.DisplayName()))
+		}
 
 		fmt.Fprintf(w, "  %s: %s\n", plat.Name(), pastTense)
 	}
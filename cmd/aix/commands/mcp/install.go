@@ -3,10 +3,12 @@ package mcp
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
@@ -17,6 +19,15 @@ import (
 	"github.com/thoreinstein/aix/internal/install"
 	"github.com/thoreinstein/aix/internal/mcp"
 	mcpvalidator "github.com/thoreinstein/aix/internal/mcp/validator"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
+	"github.com/thoreinstein/aix/internal/platform/gemini"
+	"github.com/thoreinstein/aix/internal/platform/generic"
+	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
+	"github.com/thoreinstein/aix/internal/registry"
 	"github.com/thoreinstein/aix/internal/resource"
 	"github.com/thoreinstein/aix/internal/validator"
 )
@@ -25,6 +36,7 @@ var (
 	installForce       bool
 	installFile        bool
 	installAllFromRepo string
+	installSet         []string
 	installer          *install.Installer
 )
 
@@ -35,9 +47,22 @@ func init() {
 		"treat argument as a file path instead of searching repos")
 	installCmd.Flags().StringVar(&installAllFromRepo, "all-from-repo", "",
 		"install all MCP servers from a specific repository")
+	installCmd.Flags().StringArrayVar(&installSet, "set", nil,
+		"set a parameter of the server (key=value, repeatable)")
 	Cmd.AddCommand(installCmd)
 
-	installer = install.NewInstaller(resource.TypeMCP, "MCP server", installFromLocal)
+	installer = install.NewInstaller(resource.TypeMCP, "MCP server", installFromLocal,
+		install.WithPlatforms(flags.ResolvePlatforms), install.WithRecorder(registry.Record),
+		install.WithForgetter(registry.Forget), install.WithParams(func() []string { return installSet }),
+		install.WithInstallTo(func(w io.Writer, path string, platforms []cli.Platform) (string, error) {
+			return Install(w, path, platforms, true, flags.GetAllowCmdSecretsFlag())
+		}))
+}
+
+// Installer returns the installer behind 'aix mcp install', for linking
+// into an install.Set.
+func Installer() *install.Installer {
+	return installer
 }
 
 var installCmd = &cobra.Command{
@@ -55,7 +80,12 @@ If the server exists in multiple repositories, you will be prompted to select on
 Use --file to skip repo search and treat the argument as a file path.
 
 For git URLs, the repository is cloned to a temporary directory, MCP servers
-are discovered in the mcp/ directory, and you select which to install.`,
+are discovered in the mcp/ directory, and you select which to install.
+
+An MCP server may declare parameters that are filled into its text when it is
+installed. Give values with --set key=value; the rest are prompted for, or
+take their defaults when input is not a terminal. The values are recorded for
+installs from a repository, and 'aix upgrade' reuses them.`,
 	Example: `  # Install by name from configured repos
   aix mcp install github-mcp
 
@@ -72,6 +102,9 @@ are discovered in the mcp/ directory, and you select which to install.`,
   # Force overwrite existing server
   aix mcp install github-mcp --force
 
+  # Fill in parameters
+  aix mcp install github-mcp --set org=acme
+
   # Install all MCP servers from a specific repo
   aix mcp install --all-from-repo official`,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -79,6 +112,9 @@ are discovered in the mcp/ directory, and you select which to install.`,
 			if len(args) > 0 {
 				return errors.New("cannot specify both --all-from-repo and a source argument")
 			}
+			if len(installSet) > 0 {
+				return errors.New("cannot specify both --all-from-repo and --set")
+			}
 			return nil
 		}
 		if len(args) != 1 {
@@ -107,7 +143,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 		if git.IsURL(source) {
 			return installFromGit(source)
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// If source is clearly a path or URL, use direct install
@@ -115,7 +151,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 		if git.IsURL(source) {
 			return installFromGit(source)
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// Try repo lookup first
@@ -143,7 +179,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	// Check if it's a local path that exists
 	if _, err := os.Stat(source); err == nil {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	return errors.Newf("MCP server %q not found in any configured repository", source)
@@ -197,7 +233,7 @@ func installFromGit(url string) error {
 
 	// If single file, install it directly
 	if len(jsonFiles) == 1 {
-		return installFromLocal(jsonFiles[0])
+		return installer.InstallFromPath(jsonFiles[0])
 	}
 
 	// Multiple files - prompt user to select
@@ -212,11 +248,197 @@ func installFromGit(url string) error {
 		return errors.New("invalid selection")
 	}
 
-	return installFromLocal(jsonFiles[choice-1])
+	return installer.InstallFromPath(jsonFiles[choice-1])
+}
+
+// Load parses the MCP server JSON file at path into its canonical form
+// without validating or installing it.
+func Load(path string) (*mcp.Server, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving path")
+	}
+	return readServerFile(absPath)
+}
+
+// Install installs the MCP server file at path to platforms, writing
+// progress to w, and returns the server's name. An existing server of the
+// same name on any platform makes the install fail unless force is set. A
+// command reference in the server's env or headers makes it fail unless
+// allowCommands is set.
+func Install(w io.Writer, path string, platforms []cli.Platform, force, allowCommands bool) (string, error) {
+	server, err := loadValid(w, path, allowCommands)
+	if err != nil {
+		return "", err
+	}
+	return server.Name, installTo(w, server, platforms, force)
+}
+
+// ConvertForPlatform converts a canonical server to the platform-specific
+// server type for platformName by passing it through that platform's
+// mcp.Translator.
+func ConvertForPlatform(server *mcp.Server, platformName string) (any, error) {
+	cfg := mcp.NewConfig()
+	cfg.Servers[server.Name] = server
+
+	var (
+		out any
+		ok  bool
+	)
+	switch platformName {
+	case "claude":
+		data, err := claude.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc claude.MCPConfig
+		if err := json.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated Claude server")
+		}
+		var s *claude.MCPServer
+		if s, ok = pc.MCPServers[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	case "opencode":
+		data, err := opencode.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc opencode.MCPConfig
+		if err := json.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated OpenCode server")
+		}
+		var s *opencode.MCPServer
+		if s, ok = pc.MCP[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	case "codex":
+		data, err := codex.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc codex.MCPConfig
+		if err := toml.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated Codex server")
+		}
+		var s *codex.MCPServer
+		if s, ok = pc.Servers[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	case "gemini":
+		data, err := gemini.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc gemini.MCPConfig
+		if err := toml.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated Gemini server")
+		}
+		var s *gemini.MCPServer
+		if s, ok = pc.Servers[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	case "cursor":
+		data, err := cursor.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc cursor.MCPConfig
+		if err := json.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated Cursor server")
+		}
+		var s *cursor.MCPServer
+		if s, ok = pc.MCPServers[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	case "copilot":
+		data, err := copilot.NewMCPTranslator().FromCanonical(cfg)
+		if err != nil {
+			return nil, err
+		}
+		var pc copilot.MCPConfig
+		if err := json.Unmarshal(data, &pc); err != nil {
+			return nil, errors.Wrap(err, "parsing translated GitHub Copilot server")
+		}
+		var s *copilot.MCPServer
+		if s, ok = pc.Servers[server.Name]; ok {
+			s.Name = server.Name
+			out = s
+		}
+	default:
+		// Platforms described by a definition and plugins take canonical
+		// servers and translate them on write
+		if !isDefined(platformName) && !isPlugin(platformName) {
+			return nil, errors.Newf("unsupported platform: %s", platformName)
+		}
+		s := *server
+		return &s, nil
+	}
+
+	if !ok {
+		return nil, errors.Newf("translating MCP server %q for %s", server.Name, platformName)
+	}
+	return out, nil
+}
+
+// isDefined reports whether name is a platform described by a definition.
+func isDefined(name string) bool {
+	_, ok := generic.Lookup(name)
+	return ok
+}
+
+// isPlugin reports whether name is a platform implemented by a plugin.
+func isPlugin(name string) bool {
+	_, ok := plugin.Lookup(name)
+	return ok
+}
+
+// readServerFile reads an MCP server definition, deriving its name from the
+// file name when the JSON does not set one.
+func readServerFile(absPath string) (*mcp.Server, error) {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading server file")
+	}
+
+	var server mcp.Server
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, errors.Wrap(err, "parsing server JSON")
+	}
+
+	// Derive name from filename if not set in JSON
+	if server.Name == "" {
+		server.Name = strings.TrimSuffix(filepath.Base(absPath), ".json")
+	}
+	return &server, nil
+}
+
+// installFromLocal installs an MCP server from a local JSON file to the
+// platforms selected by the flags, and returns its name.
+func installFromLocal(serverPath string) (string, error) {
+	server, err := loadValid(os.Stdout, serverPath, flags.GetAllowCmdSecretsFlag())
+	if err != nil {
+		return "", err
+	}
+
+	// Get target platforms
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return "", errors.Wrap(err, "resolving platforms")
+	}
+	return server.Name, installTo(os.Stdout, server, platforms, installForce)
 }
 
-// installFromLocal installs an MCP server from a local JSON file.
-func installFromLocal(serverPath string) error {
+// loadValid reads and validates the MCP server file at serverPath,
+// reporting validation errors and warnings to w. The file may come from a
+// repository anyone can publish to, so command references in it are
+// refused unless allowCommands is set.
+func loadValid(w io.Writer, serverPath string, allowCommands bool) (*mcp.Server, error) {
 	// Resolve to absolute path for consistent error messages
 	absPath, err := filepath.Abs(serverPath)
 	if err != nil {
@@ -227,70 +449,63 @@ func installFromLocal(serverPath string) error {
 	info, err := os.Lstat(absPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return errors.Newf("MCP server file not found: %s", absPath)
+			return nil, errors.Newf("MCP server file not found: %s", absPath)
 		}
-		return errors.Wrapf(err, "checking file: %s", absPath)
+		return nil, errors.Wrapf(err, "checking file: %s", absPath)
 	}
 
 	// Reject symlinks for security (prevent traversal out of repo)
 	if info.Mode()&os.ModeSymlink != 0 {
-		return errors.Newf("MCP server file is a symlink (security restriction): %s", absPath)
+		return nil, errors.Newf("MCP server file is a symlink (security restriction): %s", absPath)
 	}
 
 	// Check if it's a JSON file
 	if !strings.HasSuffix(strings.ToLower(absPath), ".json") {
-		return errors.Newf("expected .json file, got: %s", absPath)
+		return nil, errors.Newf("expected .json file, got: %s", absPath)
 	}
 
-	fmt.Println("Validating MCP server configuration...")
+	fmt.Fprintln(w, "Validating MCP server configuration...")
 
-	// Read and parse the JSON file
-	data, err := os.ReadFile(absPath)
+	server, err := readServerFile(absPath)
 	if err != nil {
-		return errors.Wrap(err, "reading server file")
-	}
-
-	var server mcp.Server
-	if err := json.Unmarshal(data, &server); err != nil {
-		return errors.Wrap(err, "parsing server JSON")
+		return nil, err
 	}
-
-	// Derive name from filename if not set in JSON
-	if server.Name == "" {
-		server.Name = strings.TrimSuffix(filepath.Base(absPath), ".json")
+	if !allowCommands {
+		if err := server.CheckUntrusted(); err != nil {
+			return nil, errors.Wrapf(err, "reading %s", absPath)
+		}
 	}
 
 	// Validate the server configuration by wrapping in a Config
 	cfg := &mcp.Config{
 		Servers: map[string]*mcp.Server{
-			server.Name: &server,
+			server.Name: server,
 		},
 	}
 
 	v := mcpvalidator.New()
 	result := v.Validate(cfg)
 	if result.HasErrors() {
-		fmt.Println("MCP server validation failed:")
-		reporter := validator.NewReporter(os.Stdout, validator.FormatText)
+		fmt.Fprintln(w, "MCP server validation failed:")
+		reporter := validator.NewReporter(w, validator.FormatText)
 		_ = reporter.Report(result)
-		return errInstallFailed
+		return nil, errInstallFailed
 	}
 
 	// Print warnings if any
 	if result.HasWarnings() {
-		for _, w := range result.Warnings() {
-			fmt.Printf("  Warning: %s\n", w.Message)
+		for _, warning := range result.Warnings() {
+			fmt.Fprintf(w, "  Warning: %s\n", warning.Message)
 		}
 	}
+	return server, nil
+}
 
-	// Get target platforms
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
-	if err != nil {
-		return errors.Wrap(err, "resolving platforms")
-	}
-
+// installTo adds server to platforms, writing progress to w. Each platform
+// receives the server converted for it, as sync does.
+func installTo(w io.Writer, server *mcp.Server, platforms []cli.Platform, force bool) error {
 	// Check for existing servers (unless --force)
-	if !installForce {
+	if !force {
 		for _, plat := range platforms {
 			if _, err := plat.GetMCP(server.Name); err == nil {
 				return errors.Newf("MCP server %q already exists on %s (use --force to overwrite)",
@@ -299,37 +514,28 @@ func installFromLocal(serverPath string) error {
 		}
 	}
 
-	// Determine transport type
-	transport := server.Transport
-	if transport == "" {
-		if server.URL != "" {
-			transport = "sse"
-		} else {
-			transport = "stdio"
-		}
-	}
-
-	// Install to each platform
+	// Install to each platform; if any fails, every platform is rolled back
 	var installedCount int
+	tx := backup.NewTransaction()
 	for _, plat := range platforms {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(plat.Name(), plat.BackupPaths()); err != nil {
-			return errors.Wrapf(err, "backing up %s before install", plat.DisplayName())
+		if err := cli.BeginChange(tx, plat, resource.TypeMCP, server.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before install", plat.DisplayName()))
 		}
 
-		fmt.Printf("Installing '%s' to %s... ", server.Name, plat.DisplayName())
-
-		// Set package-level variables that addMCPToPlatform expects
-		mcpAddURL = server.URL
-		mcpAddPlatforms = server.Platforms
+		fmt.Fprintf(w, "Installing '%s' to %s... ", server.Name, plat.DisplayName())
 
-		// Use the existing addMCPToPlatform function
-		if err := addMCPToPlatform(plat, server.Name, server.Command, server.Args, transport, server.Env, server.Headers); err != nil {
-			fmt.Println("failed")
-			return errors.Wrapf(err, "failed to install to %s", plat.DisplayName())
+		converted, err := ConvertForPlatform(server, plat.Name())
+		if err == nil {
+			warnDroppedPlatforms(w, server, converted, plat)
+			err = plat.AddMCP(converted)
+		}
+		if err != nil {
+			fmt.Fprintln(w, "failed")
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to install to %s", plat.DisplayName()))
 		}
 
-		fmt.Println("done")
+		fmt.Fprintln(w, "done")
 		installedCount++
 	}
 
@@ -338,7 +544,20 @@ func installFromLocal(serverPath string) error {
 	if installedCount != 1 {
 		platformWord = "platforms"
 	}
-	fmt.Printf("[OK] MCP server '%s' installed to %d %s\n", server.Name, installedCount, platformWord)
-
+	fmt.Fprintf(w, "[OK] MCP server '%s' installed to %d %s\n", server.Name, installedCount, platformWord)
 	return nil
 }
+
+// warnDroppedPlatforms warns when the OS restriction of server is lost in
+// converted, the form it takes on plat.
+func warnDroppedPlatforms(w io.Writer, server *mcp.Server, converted any, plat cli.Platform) {
+	if len(server.Platforms) == 0 {
+		return
+	}
+	back, err := cli.CanonicalMCP(converted)
+	if err != nil || len(back.Platforms) > 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n  Warning: %s does not support platform restrictions; --platform %s will be ignored\n",
+		plat.DisplayName(), strings.Join(server.Platforms, ", "))
+}
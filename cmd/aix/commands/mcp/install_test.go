@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/thoreinstein/aix/internal/git"
 	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/secret"
 )
 
 func Test_isGitURL(t *testing.T) {
@@ -110,7 +112,7 @@ func Test_isGitURL(t *testing.T) {
 }
 
 func Test_installFromLocal_FileNotFound(t *testing.T) {
-	err := installFromLocal("/nonexistent/path/to/server.json")
+	_, err := installFromLocal("/nonexistent/path/to/server.json")
 	if err == nil {
 		t.Error("expected error for nonexistent file, got nil")
 	}
@@ -133,7 +135,7 @@ func Test_installFromLocal_NotJSONFile(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(txtPath)
+	_, err := installFromLocal(txtPath)
 	if err == nil {
 		t.Error("expected error for non-JSON file extension, got nil")
 	}
@@ -154,7 +156,7 @@ func Test_installFromLocal_InvalidJSON(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	if err == nil {
 		t.Error("expected error for invalid JSON, got nil")
 	}
@@ -169,7 +171,7 @@ func Test_installFromLocal_EmptyJSON(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	if err == nil {
 		t.Error("expected error for empty JSON (missing required fields), got nil")
 	}
@@ -185,7 +187,7 @@ func Test_installFromLocal_MissingCommand(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	if err == nil {
 		t.Error("expected error for server missing command/URL, got nil")
 	}
@@ -434,7 +436,7 @@ func Test_installFromLocal_VariousJSONFormats(t *testing.T) {
 				t.Fatalf("failed to write test file: %v", err)
 			}
 
-			err := installFromLocal(jsonPath)
+			_, err := installFromLocal(jsonPath)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("installFromLocal() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -458,7 +460,7 @@ func Test_installFromLocal_FilePermissions(t *testing.T) {
 		_ = os.Chmod(jsonPath, 0o644)
 	})
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	if err == nil {
 		// On some systems (e.g., running as root), permission checks may pass
 		t.Skip("permission test requires non-root user")
@@ -474,7 +476,7 @@ func Test_installFromLocal_DirectoryInsteadOfFile(t *testing.T) {
 		t.Fatalf("failed to create test directory: %v", err)
 	}
 
-	err := installFromLocal(dirPath)
+	_, err := installFromLocal(dirPath)
 	if err == nil {
 		t.Error("expected error when path is a directory, got nil")
 	}
@@ -497,7 +499,7 @@ func Test_installFromLocal_SymlinkRejected(t *testing.T) {
 	}
 
 	// The function should reject symlinks for security
-	err := installFromLocal(symlink)
+	_, err := installFromLocal(symlink)
 	if err == nil {
 		t.Fatal("expected error due to symlink, but succeeded")
 	}
@@ -521,7 +523,7 @@ func Test_installFromLocal_ValidServerWithWarnings(t *testing.T) {
 	}
 
 	// This should pass validation (with warnings) and proceed to platform install
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Expect success or platform-related error (not validation error)
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass (possibly with warnings), got errInstallFailed")
@@ -540,7 +542,7 @@ func Test_installFromLocal_URLServer(t *testing.T) {
 	}
 
 	// This should pass validation and proceed to platform install
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for valid remote server")
@@ -562,13 +564,31 @@ func Test_installFromLocal_ServerWithEnvAndHeaders(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for server with headers and env")
 	}
 }
 
+func Test_loadValid_CommandReferences(t *testing.T) {
+	// A server file may come from anyone's repository, so its command
+	// references are refused unless the user opts in
+	jsonPath := filepath.Join(t.TempDir(), "cmd-ref.json")
+	content := `{"name": "cmd-ref", "command": "npx", "env": {"TOKEN": "${cmd:pass show token}"}}`
+	if err := os.WriteFile(jsonPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	_, err := loadValid(io.Discard, jsonPath, false)
+	if !errors.Is(err, secret.ErrUntrustedCommand) {
+		t.Errorf("loadValid() error = %v, want ErrUntrustedCommand", err)
+	}
+	if _, err := loadValid(io.Discard, jsonPath, true); err != nil {
+		t.Errorf("loadValid() with commands allowed error = %v", err)
+	}
+}
+
 func Test_installFromLocal_ServerWithPlatforms(t *testing.T) {
 	// Test a server config with platform restrictions
 	tempDir := t.TempDir()
@@ -583,7 +603,7 @@ func Test_installFromLocal_ServerWithPlatforms(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for server with platform restriction")
@@ -604,7 +624,7 @@ func Test_installFromLocal_ServerWithInvalidPlatform(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for invalid platform, got: %v", err)
@@ -625,7 +645,7 @@ func Test_installFromLocal_ServerWithInvalidTransport(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for invalid transport, got: %v", err)
@@ -646,7 +666,7 @@ func Test_installFromLocal_ServerWithEmptyEnvKey(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for empty env key, got: %v", err)
@@ -667,7 +687,7 @@ func Test_installFromLocal_ServerWithEmptyHeaderKey(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for empty header key, got: %v", err)
@@ -688,7 +708,7 @@ func Test_installFromLocal_StdioTransportWithURL(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation (stdio requires command)
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for stdio without command, got: %v", err)
@@ -709,7 +729,7 @@ func Test_installFromLocal_SSETransportWithCommand(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should fail validation (sse requires url)
 	if !errors.Is(err, errInstallFailed) {
 		t.Errorf("expected errInstallFailed for sse without url, got: %v", err)
@@ -731,7 +751,7 @@ func Test_installFromLocal_ExplicitTransportStdio(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for valid stdio server")
@@ -752,7 +772,7 @@ func Test_installFromLocal_ExplicitTransportSSE(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for valid sse server")
@@ -773,7 +793,7 @@ func Test_installFromLocal_DisabledServer(t *testing.T) {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(jsonPath)
+	_, err := installFromLocal(jsonPath)
 	// Should not fail at validation - disabled is just a flag
 	if errors.Is(err, errInstallFailed) {
 		t.Error("expected validation to pass for disabled server")
@@ -794,7 +814,7 @@ func Test_installFromLocal_RelativePath(t *testing.T) {
 	t.Chdir(tempDir)
 
 	// Use relative path
-	err := installFromLocal("./relative.json")
+	_, err := installFromLocal("./relative.json")
 	// Should not fail at file reading
 	if err != nil && strings.Contains(err.Error(), "not found") {
 		t.Errorf("failed to read file with relative path: %v", err)
@@ -13,6 +13,8 @@ import (
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/doctor"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 // ANSI color codes for terminal output.
@@ -77,6 +79,7 @@ type serverInfoJSON struct {
 	URL       string            `json:"url,omitempty"`
 	Disabled  bool              `json:"disabled"`
 	Env       map[string]string `json:"env,omitempty"`
+	Source    string            `json:"source"`
 }
 
 func runList(_ *cobra.Command, _ []string) error {
@@ -85,7 +88,7 @@ func runList(_ *cobra.Command, _ []string) error {
 
 // runListWithWriter allows injecting a writer for testing.
 func runListWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -98,6 +101,11 @@ func runListWithWriter(w io.Writer) error {
 
 // outputJSON outputs MCP servers in JSON format.
 func outputJSON(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	output := make([]listPlatformOutput, 0, len(platforms))
 
 	for _, p := range platforms {
@@ -115,6 +123,7 @@ func outputJSON(w io.Writer, platforms []cli.Platform) error {
 				URL:       s.URL,
 				Disabled:  s.Disabled,
 				Env:       maskSecretsIfNeeded(s.Env),
+				Source:    reg.Source(resource.TypeMCP, s.Name),
 			}
 		}
 		output = append(output, listPlatformOutput{
@@ -130,6 +139,11 @@ func outputJSON(w io.Writer, platforms []cli.Platform) error {
 
 // outputTabular outputs MCP servers in tabular format grouped by platform.
 func outputTabular(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	hasServers := false
 
 	for i, p := range platforms {
@@ -157,7 +171,8 @@ func outputTabular(w io.Writer, platforms []cli.Platform) error {
 
 		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 		// Table headers
-		fmt.Fprintf(tw, "  %sNAME%s\t%sTRANSPORT%s\t%sCOMMAND/URL%s\t%sSTATUS%s\n",
+		fmt.Fprintf(tw, "  %sNAME%s\t%sTRANSPORT%s\t%sCOMMAND/URL%s\t%sSTATUS%s\t%sSOURCE%s\n",
+			colorBold, colorReset,
 			colorBold, colorReset,
 			colorBold, colorReset,
 			colorBold, colorReset,
@@ -179,11 +194,12 @@ func outputTabular(w io.Writer, platforms []cli.Platform) error {
 				statusColor = colorGray
 			}
 
-			fmt.Fprintf(tw, "  %s%s%s\t%s\t%s\t%s%s%s\n",
+			fmt.Fprintf(tw, "  %s%s%s\t%s\t%s\t%s%s%s\t%s\n",
 				colorGreen, s.Name, colorReset,
 				s.Transport,
 				endpoint,
-				statusColor, status, colorReset)
+				statusColor, status, colorReset,
+				reg.Source(resource.TypeMCP, s.Name))
 		}
 		if err := tw.Flush(); err != nil {
 			return errors.Wrap(err, "flushing tabwriter")
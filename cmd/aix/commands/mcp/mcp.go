@@ -28,6 +28,7 @@ configurations in Claude Code, OpenCode, and other supported platforms.`,
     aix mcp add      - Add a new MCP server
     aix mcp list     - List configured servers
     aix mcp show     - Show server details
+    aix mcp test     - Check that a server starts and answers
     aix mcp remove   - Remove a server
     aix mcp enable   - Enable a server
     aix mcp disable  - Disable a server`,
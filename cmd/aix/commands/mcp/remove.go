@@ -13,6 +13,8 @@ import (
 	"github.com/thoreinstein/aix/internal/backup"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 var removeForce bool
@@ -55,7 +57,7 @@ func runRemove(_ *cobra.Command, args []string) error {
 func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -66,6 +68,8 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 		return errors.Newf("server %q not found on any platform", name)
 	}
 
+	install.WarnDependents(w, configuredOn, resource.TypeMCP, name)
+
 	// Confirm removal unless --force is specified
 	if !removeForce {
 		if !confirmRemoval(w, r, name, configuredOn) {
@@ -77,14 +81,9 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 	// Remove from each platform
 	fmt.Fprintf(w, "Removing MCP server %q...\n", name)
 
-	var failed []string
+	// If removal fails on any platform, every platform is rolled back
+	tx := backup.NewTransaction()
 	for _, p := range platforms {
-		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(p.Name(), p.BackupPaths()); err != nil {
-			failed = append(failed, fmt.Sprintf("%s: backup failed: %v", p.DisplayName(), err))
-			continue
-		}
-
 		// Check if server exists on this platform
 		_, err := p.GetMCP(name)
 		if err != nil {
@@ -92,18 +91,18 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 			continue
 		}
 
+		// Ensure backup exists before modifying
+		if err := cli.BeginChange(tx, p, resource.TypeMCP, name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before remove", p.DisplayName()))
+		}
+
 		if err := p.RemoveMCP(name); err != nil {
 			fmt.Fprintf(w, "  %s: failed\n", p.Name())
-			failed = append(failed, fmt.Sprintf("%s: %v", p.DisplayName(), err))
-			continue
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to remove from %s", p.DisplayName()))
 		}
 		fmt.Fprintf(w, "  %s: removed\n", p.Name())
 	}
 
-	if len(failed) > 0 {
-		return errors.New("failed to remove from some platforms:\n  " + strings.Join(failed, "\n  "))
-	}
-
 	return nil
 }
 
@@ -59,6 +59,7 @@ func TestSearchCommand_NoReposConfigured(t *testing.T) {
 func TestSearchCommand_InvalidRepoFilter(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("AIX_CONFIG_DIR", tmpDir)
+	t.Setenv("XDG_CACHE_HOME", tmpDir)
 
 	// Create a fake repo directory (needed for scanner)
 	repoDir := filepath.Join(tmpDir, "repos", "test-repo")
@@ -105,6 +106,7 @@ func TestSearchCommand_InvalidRepoFilter(t *testing.T) {
 func TestSearchCommand_NoResults(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("AIX_CONFIG_DIR", tmpDir)
+	t.Setenv("XDG_CACHE_HOME", tmpDir)
 
 	// Create a repo directory with mcp dir (but no MCP servers)
 	repoDir := filepath.Join(tmpDir, "repos", "empty-repo")
@@ -174,6 +176,7 @@ func TestSearchCommand_Metadata(t *testing.T) {
 func TestSearchCommand_ValidRepoFilter(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("AIX_CONFIG_DIR", tmpDir)
+	t.Setenv("XDG_CACHE_HOME", tmpDir)
 
 	// Create a repo directory with an MCP server
 	repoDir := filepath.Join(tmpDir, "repos", "my-repo")
@@ -232,6 +235,7 @@ func TestSearchCommand_ValidRepoFilter(t *testing.T) {
 func TestSearchCommand_JSONOutput(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("AIX_CONFIG_DIR", tmpDir)
+	t.Setenv("XDG_CACHE_HOME", tmpDir)
 
 	// Create a repo directory with an MCP server
 	repoDir := filepath.Join(tmpDir, "repos", "json-repo")
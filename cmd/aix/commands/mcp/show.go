@@ -13,7 +13,11 @@ import (
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/doctor"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
 )
 
@@ -81,7 +85,7 @@ type showOutput struct {
 func runShow(_ *cobra.Command, args []string) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -128,6 +132,14 @@ func extractServerDetail(server any, platformName string) *serverDetail {
 		return extractClaudeMCPServer(s, platformName)
 	case *opencode.MCPServer:
 		return extractOpenCodeMCPServer(s, platformName)
+	case *codex.MCPServer:
+		return extractCodexMCPServer(s, platformName)
+	case *cursor.MCPServer:
+		return extractCursorMCPServer(s, platformName)
+	case *copilot.MCPServer:
+		return extractCopilotMCPServer(s, platformName)
+	case *mcp.Server:
+		return extractCanonicalMCPServer(s, platformName)
 	default:
 		return nil
 	}
@@ -135,15 +147,11 @@ func extractServerDetail(server any, platformName string) *serverDetail {
 
 // extractClaudeMCPServer extracts details from a Claude MCP server.
 func extractClaudeMCPServer(s *claude.MCPServer, platformName string) *serverDetail {
-	// Map Claude type to canonical transport: stdio -> stdio, http -> sse
+	// Claude types match canonical transports; infer one when unset
 	transport := s.Type
-	switch transport {
-	case "http":
-		transport = "sse"
-	case "":
-		// Infer transport from URL presence
+	if transport == "" {
 		if s.URL != "" {
-			transport = "sse"
+			transport = "http"
 		} else {
 			transport = "stdio"
 		}
@@ -166,7 +174,7 @@ func extractClaudeMCPServer(s *claude.MCPServer, platformName string) *serverDet
 func extractOpenCodeMCPServer(s *opencode.MCPServer, platformName string) *serverDetail {
 	transport := "stdio"
 	if s.Type == "remote" || s.URL != "" {
-		transport = "sse"
+		transport = "http"
 	}
 
 	var command string
@@ -193,6 +201,66 @@ func extractOpenCodeMCPServer(s *opencode.MCPServer, platformName string) *serve
 	}
 }
 
+// extractCodexMCPServer extracts details from a Codex MCP server.
+func extractCodexMCPServer(s *codex.MCPServer, platformName string) *serverDetail {
+	transport := "stdio"
+	if s.URL != "" {
+		transport = "http"
+	}
+
+	return &serverDetail{
+		Platform:  platformName,
+		Transport: transport,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Disabled:  !s.IsEnabled(),
+		Env:       s.Env,
+		Headers:   s.HTTPHeaders,
+	}
+}
+
+// extractCursorMCPServer extracts details from a Cursor MCP server.
+func extractCursorMCPServer(s *cursor.MCPServer, platformName string) *serverDetail {
+	transport := "stdio"
+	if s.URL != "" {
+		transport = "http"
+	}
+
+	return &serverDetail{
+		Platform:  platformName,
+		Transport: transport,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Disabled:  s.Disabled,
+		Env:       s.Env,
+		Headers:   s.Headers,
+	}
+}
+
+// extractCopilotMCPServer extracts details from a GitHub Copilot MCP server.
+func extractCopilotMCPServer(s *copilot.MCPServer, platformName string) *serverDetail {
+	transport := s.Type
+	if transport == "" {
+		if s.URL != "" {
+			transport = "http"
+		} else {
+			transport = "stdio"
+		}
+	}
+
+	return &serverDetail{
+		Platform:  platformName,
+		Transport: transport,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Env:       s.Env,
+		Headers:   s.Headers,
+	}
+}
+
 // findDifferences compares server configurations across platforms and returns differences.
 func findDifferences(details map[string]*serverDetail) []string {
 	if len(details) < 2 {
@@ -366,3 +434,24 @@ func printSortedMap(m map[string]string, indent string) {
 		fmt.Printf("%s%s: %s\n", indent, k, m[k])
 	}
 }
+
+// extractCanonicalMCPServer extracts details from a canonical MCP server, as
+// returned by platforms described by a definition.
+func extractCanonicalMCPServer(s *mcp.Server, platformName string) *serverDetail {
+	transport := s.EffectiveTransport()
+	if transport == "" {
+		transport = "stdio"
+	}
+
+	return &serverDetail{
+		Platform:  platformName,
+		Transport: transport,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Disabled:  s.Disabled,
+		Env:       s.Env,
+		Headers:   s.Headers,
+		Platforms: s.Platforms,
+	}
+}
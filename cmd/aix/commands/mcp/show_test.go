@@ -309,7 +309,7 @@ func TestExtractServerDetail_Claude(t *testing.T) {
 			platform: "Claude Code",
 			want: &serverDetail{
 				Platform:  "Claude Code",
-				Transport: "sse",
+				Transport: "http",
 				URL:       "https://api.example.com/mcp",
 				Headers:   map[string]string{"Auth": "Bearer token"},
 			},
@@ -323,7 +323,7 @@ func TestExtractServerDetail_Claude(t *testing.T) {
 			platform: "Claude Code",
 			want: &serverDetail{
 				Platform:  "Claude Code",
-				Transport: "sse",
+				Transport: "http",
 				URL:       "https://api.example.com/mcp",
 			},
 		},
@@ -429,7 +429,7 @@ func TestExtractServerDetail_OpenCode(t *testing.T) {
 			platform: "OpenCode",
 			want: &serverDetail{
 				Platform:  "OpenCode",
-				Transport: "sse",
+				Transport: "http",
 				URL:       "https://api.example.com/mcp",
 				Headers:   map[string]string{"Auth": "Bearer token"},
 			},
@@ -444,7 +444,7 @@ func TestExtractServerDetail_OpenCode(t *testing.T) {
 			platform: "OpenCode",
 			want: &serverDetail{
 				Platform:  "OpenCode",
-				Transport: "sse",
+				Transport: "http",
 				URL:       "https://api.example.com/mcp",
 			},
 		},
@@ -457,7 +457,7 @@ func TestExtractServerDetail_OpenCode(t *testing.T) {
 			platform: "OpenCode",
 			want: &serverDetail{
 				Platform:  "OpenCode",
-				Transport: "sse",
+				Transport: "http",
 				URL:       "https://api.example.com/mcp",
 			},
 		},
@@ -485,7 +485,7 @@ func TestExtractServerDetail_OpenCode(t *testing.T) {
 			platform: "OpenCode",
 			want: &serverDetail{
 				Platform:  "OpenCode",
-				Transport: "sse",
+				Transport: "http",
 				Command:   "",
 				Args:      nil,
 				URL:       "http://example.com",
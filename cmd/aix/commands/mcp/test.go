@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp/probe"
+)
+
+// errMCPTestFailed is returned when any probed server fails.
+var errMCPTestFailed = errors.New("MCP server test failed")
+
+var (
+	testTimeout time.Duration
+	testJSON    bool
+)
+
+func init() {
+	testCmd.Flags().DurationVar(&testTimeout, "timeout", 10*time.Second,
+		"how long to wait for each server to answer")
+	testCmd.Flags().BoolVar(&testJSON, "json", false, "Output as JSON")
+	Cmd.AddCommand(testCmd)
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Check that an MCP server starts and answers",
+	Long: `Connect to an MCP server the way an assistant would and check that it works.
+
+Local servers are started with their configured command, arguments, and
+environment; remote servers are reached at their URL over Streamable HTTP or
+SSE. aix then performs the MCP initialize handshake and lists the server's
+tools, reporting the negotiated protocol version, the server's name and
+version, and the number of tools.
+
+The server is tested once for each platform it is configured on, since the
+configurations may differ. Secret references in env and header values are
+resolved before connecting.`,
+	Example: `  # Test a server on every platform
+  aix mcp test github
+
+  # Test only the Claude Code configuration, allowing a slow start
+  aix mcp test github --platform claude --timeout 30s
+
+  See Also:
+    aix mcp show     - Show server details
+    aix doctor       - Run diagnostics (--probe tests every server)`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTest,
+}
+
+// testOutcome is the result of probing one platform's configuration.
+type testOutcome struct {
+	Platform string        `json:"platform"`
+	Result   *probe.Result `json:"result,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	return runTestWithWriter(cmd.OutOrStdout(), args[0], cmd.Root().Version)
+}
+
+// runTestWithWriter allows injecting a writer for testing.
+func runTestWithWriter(w io.Writer, name, version string) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	prober := probe.New(probe.WithClientInfo("aix", version),
+		probe.WithCommands(flags.GetAllowCmdSecretsFlag()))
+	var outcomes []testOutcome
+	failed := false
+	for _, p := range platforms {
+		v, err := p.GetMCP(name)
+		if err != nil {
+			continue
+		}
+		server, err := cli.CanonicalMCP(v)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s from %s", name, p.DisplayName())
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		result, err := prober.Probe(ctx, server)
+		cancel()
+
+		outcome := testOutcome{Platform: p.Name(), Result: result}
+		if err != nil {
+			outcome.Error = err.Error()
+			failed = true
+		}
+		outcomes = append(outcomes, outcome)
+
+		if !testJSON {
+			fmt.Fprintf(w, "%s (%s): %s\n", name, p.DisplayName(), describeProbe(result, err))
+		}
+	}
+
+	if len(outcomes) == 0 {
+		return errors.Newf("MCP server %q not found on any platform", name)
+	}
+
+	if testJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(outcomes); err != nil {
+			return errors.Wrap(err, "encoding JSON")
+		}
+	}
+
+	if failed {
+		return errMCPTestFailed
+	}
+	return nil
+}
+
+// describeProbe summarizes a probe for a single line of output.
+func describeProbe(result *probe.Result, err error) string {
+	if err != nil {
+		return "FAILED: " + err.Error()
+	}
+	server := result.ServerName
+	if result.ServerVersion != "" {
+		server += " " + result.ServerVersion
+	}
+	tools := "tools"
+	if result.Tools == 1 {
+		tools = "tool"
+	}
+	return fmt.Sprintf("OK (%s, protocol %s, %d %s, %s)",
+		server, result.ProtocolVersion, result.Tools, tools, result.Duration.Round(time.Millisecond))
+}
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+)
+
+var outdatedJSON bool
+
+func init() {
+	outdatedCmd.Flags().BoolVar(&outdatedJSON, "json", false, "output as JSON")
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List installed resources that changed in their repository",
+	Long: `List skills, commands, agents, and MCP servers whose source changed in
+their repository since they were installed.
+
+aix records the repository, commit, and content hash of everything it
+installs from a configured repository. outdated compares those records with
+the repository cache, so run 'aix repo update' first to fetch the latest
+changes. Resources installed from a local path or git URL are not tracked.
+
+Resources that were edited in place since they were installed are marked as
+modified; 'aix upgrade' will not overwrite them without --force.`,
+	Example: `  # Fetch the latest changes, then list what has updates
+  aix repo update
+  aix outdated
+
+  # Only check Claude Code
+  aix outdated --platform claude
+
+  # Machine-readable output
+  aix outdated --json
+
+See Also: aix upgrade, aix repo update`,
+	Args: cobra.NoArgs,
+	RunE: runOutdated,
+}
+
+// outdatedEntry is a row of aix outdated output.
+type outdatedEntry struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Repo      string   `json:"repo"`
+	Installed string   `json:"installed_commit,omitempty"`
+	Latest    string   `json:"latest_commit,omitempty"`
+	Removed   bool     `json:"removed,omitempty"`
+	Platforms []string `json:"platforms"`
+	Modified  []string `json:"modified,omitempty"`
+}
+
+func runOutdated(cmd *cobra.Command, _ []string) error {
+	return runOutdatedWithWriter(cmd.OutOrStdout())
+}
+
+// runOutdatedWithWriter allows injecting a writer for testing.
+func runOutdatedWithWriter(w io.Writer) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+	statuses, err := installStatuses(platforms)
+	if err != nil {
+		return err
+	}
+
+	entries := []outdatedEntry{}
+	for _, st := range statuses {
+		if st.Source != nil && !st.Outdated {
+			continue
+		}
+		e := outdatedEntry{
+			Type:      string(st.Entry.Type),
+			Name:      st.Entry.Name,
+			Repo:      st.Entry.Repo,
+			Installed: st.Entry.Commit,
+			Latest:    st.Commit,
+			Removed:   st.Source == nil,
+			Platforms: platformNames(st.Installed),
+			Modified:  platformNames(st.Modified),
+		}
+		entries = append(entries, e)
+	}
+
+	if outdatedJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return errors.Wrap(enc.Encode(entries), "encoding JSON")
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "All resources installed from repositories are up to date.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tNAME\tREPO\tINSTALLED\tLATEST\tSTATUS")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Type, e.Name, e.Repo, shortCommit(e.Installed), shortCommit(e.Latest), outdatedStatusText(e))
+	}
+	_ = tw.Flush()
+	fmt.Fprintf(w, "\n%d resource(s) can be upgraded with 'aix upgrade'.\n", upgradable(entries))
+	return nil
+}
+
+// installStatuses checks every recorded install against the repository
+// cache. Records of resources that are not installed on any of platforms
+// are skipped.
+func installStatuses(platforms []cli.Platform) ([]*registry.Status, error) {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*registry.Status
+	for _, e := range reg.Entries {
+		st, err := registry.Check(e, platforms)
+		if err != nil {
+			return nil, err
+		}
+		if len(st.Installed) > 0 {
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
+
+// outdatedStatusText describes an entry for the STATUS column.
+func outdatedStatusText(e outdatedEntry) string {
+	text := "update available"
+	if e.Removed {
+		text = "removed from repository"
+	}
+	if len(e.Modified) > 0 {
+		text += "; modified on " + strings.Join(e.Modified, ", ")
+	}
+	return text
+}
+
+// upgradable counts the entries that aix upgrade would install without
+// --force.
+func upgradable(entries []outdatedEntry) int {
+	n := 0
+	for _, e := range entries {
+		if !e.Removed && len(e.Modified) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// platformNames returns the names of platforms.
+func platformNames(platforms []cli.Platform) []string {
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+	}
+	return names
+}
+
+// shortCommit abbreviates a commit SHA for display.
+func shortCommit(sha string) string {
+	if sha == "" {
+		return "-"
+	}
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -13,10 +13,14 @@ import (
 )
 
 // Package-level flag variables for repo add command.
-var nameFlag string
+var (
+	nameFlag string
+	refFlag  string
+)
 
 func init() {
 	addCmd.Flags().StringVar(&nameFlag, "name", "", "custom name for the repository")
+	addCmd.Flags().StringVar(&refFlag, "ref", "", "pin to a branch, tag, or commit SHA")
 	Cmd.AddCommand(addCmd)
 }
 
@@ -26,13 +30,19 @@ var addCmd = &cobra.Command{
 	Long: `Add a Git repository as a source for skills, commands, and agents.
 
 The repository is shallow cloned to the local cache. The repository name
-is derived from the URL unless overridden with --name.`,
+is derived from the URL unless overridden with --name.
+
+With --ref, the repository is pinned to a branch, tag, or commit SHA and
+'aix repo update' keeps it there. Change the pin later with 'aix repo pin'.`,
 	Example: `  # Add from GitHub
   aix repo add https://github.com/example/community-skills.git
 
   # Add with custom name
   aix repo add https://github.com/example/skills.git --name my-skills
 
+  # Pin to a release tag
+  aix repo add https://github.com/example/skills.git --ref v1.2.0
+
   # Add from private repo (SSH)
   aix repo add git@github.com:org/private-skills.git`,
 	Args: cobra.ExactArgs(1),
@@ -55,6 +65,9 @@ func runAddWithIO(args []string, configPath string, w io.Writer) error {
 	if nameFlag != "" {
 		opts = append(opts, repo.WithName(nameFlag))
 	}
+	if refFlag != "" {
+		opts = append(opts, repo.WithRef(refFlag))
+	}
 
 	// Add the repository with progress indicator
 	fmt.Fprintf(w, "Cloning %s... ", url)
@@ -68,6 +81,9 @@ func runAddWithIO(args []string, configPath string, w io.Writer) error {
 	// Print success message
 	fmt.Fprintf(w, "[OK] Repository '%s' added from %s\n", repoConfig.Name, url)
 	fmt.Fprintf(w, "  Cached at: %s\n", repoConfig.Path)
+	if repoConfig.Ref != "" {
+		fmt.Fprintf(w, "  Pinned to: %s\n", repoConfig.Ref)
+	}
 
 	// Validate repository content and show warnings
 	warnings := repo.ValidateRepoContent(repoConfig.Path)
@@ -89,6 +105,11 @@ func handleAddError(err error) error {
 			err,
 			"Run: aix repo list to see existing repositories\n       Use: --name <alternate-name> to specify a different name",
 		)
+	case errors.Is(err, repo.ErrRefNotFound):
+		return errors.NewUserError(
+			errors.Newf("ref %q not found in repository", refFlag),
+			"Use an existing branch, tag, or commit SHA",
+		)
 	case errors.Is(err, repo.ErrInvalidName):
 		return errors.NewUserError(
 			errors.New("invalid repository name"),
@@ -940,3 +940,103 @@ func TestIntegration_RepoAddWithValidationWarnings(t *testing.T) {
 	manager := repo.NewManager(configPath)
 	_ = manager.Remove("warning-test")
 }
+
+// TestIntegration_RepoPin tests pinning a repository with add --ref and
+// changing the pin with the pin and unpin commands.
+func TestIntegration_RepoPin(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	repoURL := createLocalGitRepo(t,
+		map[string]string{
+			"first-skill": validSkillFrontmatter("first-skill", "First skill"),
+		},
+		nil, nil, nil,
+	)
+	srcDir := strings.TrimPrefix(repoURL, "file://")
+	if err := runGit(srcDir, "tag", "v1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Publish a second skill after the tag.
+	skillDir := filepath.Join(srcDir, "skills", "second-skill")
+	if err := os.MkdirAll(skillDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"),
+		[]byte(validSkillFrontmatter("second-skill", "Second skill")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGit(srcDir, "add", "-A"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runGit(srcDir, "commit", "-m", "Add second skill"); err != nil {
+		t.Fatal(err)
+	}
+
+	oldNameFlag, oldRefFlag := nameFlag, refFlag
+	nameFlag, refFlag = "pin-test", "v1.0.0"
+	defer func() { nameFlag, refFlag = oldNameFlag, oldRefFlag }()
+
+	configPath := setupTestConfig(t)
+	manager := repo.NewManager(configPath)
+	scanner := resource.NewScanner()
+	countSkills := func() int {
+		t.Helper()
+		repos, err := manager.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resources, err := scanner.ScanAll(repos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(resources)
+	}
+
+	var buf bytes.Buffer
+	if err := runAddWithIO([]string{repoURL}, configPath, &buf); err != nil {
+		t.Fatalf("runAddWithIO() failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Pinned to: v1.0.0") {
+		t.Errorf("add output missing pin:\n%s", buf.String())
+	}
+	if got := countSkills(); got != 1 {
+		t.Errorf("pinned to v1.0.0: got %d skills, want 1", got)
+	}
+
+	if err := manager.Update("pin-test"); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	if got := countSkills(); got != 1 {
+		t.Errorf("update moved pinned repo: got %d skills, want 1", got)
+	}
+
+	buf.Reset()
+	if err := runListWithWriter(&buf, configPath); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "REF") || !strings.Contains(buf.String(), "v1.0.0") {
+		t.Errorf("list output missing ref:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runUnpinWithIO([]string{"pin-test"}, configPath, &buf); err != nil {
+		t.Fatalf("runUnpinWithIO() failed: %v", err)
+	}
+	if got := countSkills(); got != 2 {
+		t.Errorf("unpinned: got %d skills, want 2", got)
+	}
+
+	buf.Reset()
+	if err := runPinWithIO([]string{"pin-test", "v1.0.0"}, configPath, &buf); err != nil {
+		t.Fatalf("runPinWithIO() failed: %v", err)
+	}
+	if got := countSkills(); got != 1 {
+		t.Errorf("re-pinned: got %d skills, want 1", got)
+	}
+
+	err := runPinWithIO([]string{"pin-test", "v9.9.9"}, configPath, &buf)
+	if err == nil || !strings.Contains(err.Error(), "v9.9.9") {
+		t.Errorf("pin to missing ref error = %v, want mention of v9.9.9", err)
+	}
+}
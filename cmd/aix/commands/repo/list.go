@@ -44,6 +44,7 @@ var listCmd = &cobra.Command{
 type repoJSON struct {
 	Name    string    `json:"name"`
 	URL     string    `json:"url"`
+	Ref     string    `json:"ref,omitempty"`
 	Path    string    `json:"path"`
 	AddedAt time.Time `json:"added_at"`
 }
@@ -74,6 +75,7 @@ func outputListJSON(w io.Writer, repos []config.RepoConfig) error {
 		output[i] = repoJSON{
 			Name:    r.Name,
 			URL:     r.URL,
+			Ref:     r.Ref,
 			Path:    r.Path,
 			AddedAt: r.AddedAt,
 		}
@@ -113,15 +115,21 @@ func outputListTabular(w io.Writer, repos []config.RepoConfig) error {
 	})
 
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(tw, "%sNAME%s\t%sURL%s\t%sADDED%s\n",
+	fmt.Fprintf(tw, "%sNAME%s\t%sURL%s\t%sREF%s\t%sADDED%s\n",
+		colorBold, colorReset,
 		colorBold, colorReset,
 		colorBold, colorReset,
 		colorBold, colorReset)
 
 	for _, r := range repos {
-		fmt.Fprintf(tw, "%s%s%s\t%s\t%s%s%s\n",
+		ref := r.Ref
+		if ref == "" {
+			ref = "-"
+		}
+		fmt.Fprintf(tw, "%s%s%s\t%s\t%s\t%s%s%s\n",
 			colorGreen, r.Name, colorReset,
 			r.URL,
+			ref,
 			colorGray, formatRelativeTime(r.AddedAt), colorReset)
 	}
 
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/internal/config"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/repo"
+)
+
+func init() {
+	Cmd.AddCommand(pinCmd)
+	Cmd.AddCommand(unpinCmd)
+}
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <name> <ref>",
+	Short: "Pin a repository to a branch, tag, or commit",
+	Long: `Pin a repository to a branch, tag, or commit SHA.
+
+The cached clone is checked out at the ref and 'aix repo update' keeps it
+there: a tag or commit does not move, and a pinned branch follows only that
+branch. Use 'aix repo unpin' to track the default branch again.`,
+	Example: `  # Pin to a release tag
+  aix repo pin community-skills v1.2.0
+
+  # Pin to a commit
+  aix repo pin community-skills 3f2c1a9
+
+  See Also:
+    aix repo unpin - Track the default branch again`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPin,
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <name>",
+	Short: "Remove a repository's pin",
+	Long: `Remove a repository's pin so that it tracks the remote's default branch.
+
+The cached clone is checked out at the latest commit of the default branch.`,
+	Example: `  aix repo unpin community-skills`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUnpin,
+}
+
+func runPin(_ *cobra.Command, args []string) error {
+	return runPinWithIO(args, config.ActiveConfigPath(), os.Stdout)
+}
+
+// runPinWithIO allows injecting a writer and config path for testing.
+func runPinWithIO(args []string, configPath string, w io.Writer) error {
+	name, ref := args[0], args[1]
+	manager := repo.NewManager(configPath)
+
+	fmt.Fprintf(w, "Pinning %s to %s... ", name, ref)
+	if _, err := manager.Pin(name, ref); err != nil {
+		fmt.Fprintln(w, "failed")
+		return handlePinError(name, ref, err)
+	}
+	fmt.Fprintln(w, "done")
+	fmt.Fprintf(w, "[OK] Repository '%s' pinned to %s\n", name, ref)
+	return nil
+}
+
+func runUnpin(_ *cobra.Command, args []string) error {
+	return runUnpinWithIO(args, config.ActiveConfigPath(), os.Stdout)
+}
+
+// runUnpinWithIO allows injecting a writer and config path for testing.
+func runUnpinWithIO(args []string, configPath string, w io.Writer) error {
+	name := args[0]
+	manager := repo.NewManager(configPath)
+
+	fmt.Fprintf(w, "Unpinning %s... ", name)
+	if _, err := manager.Unpin(name); err != nil {
+		fmt.Fprintln(w, "failed")
+		return handlePinError(name, "", err)
+	}
+	fmt.Fprintln(w, "done")
+	fmt.Fprintf(w, "[OK] Repository '%s' now tracks its default branch\n", name)
+	return nil
+}
+
+// handlePinError returns a user-friendly error message for known error types.
+func handlePinError(name, ref string, err error) error {
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+		return errors.NewUserError(
+			errors.Newf("repository '%s' not found", name),
+			"Run: aix repo list to see available repositories",
+		)
+	case errors.Is(err, repo.ErrRefNotFound):
+		return errors.NewUserError(
+			errors.Newf("ref %q not found in repository '%s'", ref, name),
+			"Use an existing branch, tag, or commit SHA",
+		)
+	default:
+		return errors.NewSystemError(
+			errors.Wrapf(err, "updating pin for '%s'", name),
+			"Check your network connection and repository access",
+		)
+	}
+}
@@ -29,6 +29,9 @@ Repositories are shallow cloned to a local cache for efficient discovery and ins
   # Update all repositories
   aix repo update
 
+  # Pin a repository to a release tag
+  aix repo pin community-skills v1.2.0
+
   # Remove a repository
   aix repo remove community-skills
 
@@ -36,6 +39,8 @@ Repositories are shallow cloned to a local cache for efficient discovery and ins
     aix repo add    - Add a repository source
     aix repo list   - List configured repositories
     aix repo update - Update repository caches
+    aix repo pin    - Pin a repository to a branch, tag, or commit
+    aix repo unpin  - Track the default branch again
     aix repo remove - Remove a repository`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		return cmd.Help()
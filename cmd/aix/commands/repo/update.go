@@ -11,6 +11,7 @@ import (
 	"github.com/thoreinstein/aix/internal/config"
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/repo"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 func init() {
@@ -23,7 +24,10 @@ var updateCmd = &cobra.Command{
 	Long: `Update repository sources by pulling latest changes.
 
 If a name is provided, only that repository is updated.
-If no name is provided, all repositories are updated.`,
+If no name is provided, all repositories are updated.
+
+Pinned repositories stay on their ref: a tag or commit does not move, and a
+pinned branch is updated to the latest commit on that branch.`,
 	Example: `  # Update all repositories
   aix repo update
 
@@ -58,6 +62,7 @@ func runUpdateWithIO(args []string, w io.Writer) error {
 			return handleUpdateError(name, err)
 		}
 		fmt.Fprintln(w, "\u2713 done")
+		invalidateSearchIndex(w)
 
 		// Validate repository content and show warnings
 		repoConfig, err := manager.Get(name)
@@ -82,9 +87,9 @@ func runUpdateWithIO(args []string, w io.Writer) error {
 	var failed []string
 	var allWarnings []repo.ValidationWarning
 	for _, r := range repos {
-		fmt.Fprintf(w, "Updating %s... ", r.Name)
-		// Use UpdateByPath to avoid redundant config reload
-		if err := manager.UpdateByPath(r.Path); err != nil {
+		fmt.Fprintf(w, "Updating %s%s... ", r.Name, pinSuffix(r.Ref))
+		// Use UpdateRepo to avoid redundant config reload
+		if err := manager.UpdateRepo(&r); err != nil {
 			fmt.Fprintln(w, "\u2717 failed")
 			failed = append(failed, fmt.Sprintf("%s: %v", r.Name, err))
 			continue
@@ -96,6 +101,8 @@ func runUpdateWithIO(args []string, w io.Writer) error {
 		allWarnings = append(allWarnings, warnings...)
 	}
 
+	invalidateSearchIndex(w)
+
 	// Print all validation warnings at the end
 	printValidationWarnings(w, allWarnings)
 
@@ -120,7 +127,23 @@ func handleUpdateError(name string, err error) error {
 	)
 }
 
+// pinSuffix describes a pin for progress output.
+func pinSuffix(ref string) string {
+	if ref == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (pinned to %s)", ref)
+}
+
 // joinErrors joins error strings with newline and indentation.
 func joinErrors(errs []string) string {
 	return strings.Join(errs, "\n  ")
 }
+
+// invalidateSearchIndex discards the search index so that the next search
+// sees the updated content. Failing to do so is reported but not fatal.
+func invalidateSearchIndex(w io.Writer) {
+	if err := resource.InvalidateIndex(resource.DefaultIndexPath()); err != nil {
+		fmt.Fprintf(w, "Warning: %v\n", err)
+	}
+}
@@ -3,16 +3,24 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/config"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
 	"github.com/thoreinstein/aix/internal/logging"
 	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform/generic"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
 )
 
 // version is set at build time via ldflags.
@@ -22,6 +30,15 @@ const version = "0.8.1"
 // platformFlag holds the value of the --platform flag.
 var platformFlag []string
 
+// scopeFlag holds the value of the --scope flag.
+var scopeFlag string
+
+// projectRootFlag holds the value of the --project-root flag.
+var projectRootFlag string
+
+// allowCmdSecrets holds the value of the --allow-cmd-secrets flag.
+var allowCmdSecrets bool
+
 // verbosity holds the count of -v flags.
 var verbosity int
 
@@ -45,7 +62,13 @@ func init() {
 
 	// Add persistent flags
 	rootCmd.PersistentFlags().StringSliceVarP(&platformFlag, "platform", "p", nil,
-		`target platform(s): claude, opencode, codex, gemini (default: all detected)`)
+		`target platform(s): claude, opencode, codex, gemini, cursor, copilot, zed, goose, windsurf (default: detected default_platforms)`)
+	rootCmd.PersistentFlags().StringVar(&scopeFlag, "scope", string(cli.ScopeUser),
+		"configuration scope: user, project")
+	rootCmd.PersistentFlags().StringVar(&projectRootFlag, "project-root", "",
+		"project root for --scope project (default: git repository root)")
+	rootCmd.PersistentFlags().BoolVar(&allowCmdSecrets, "allow-cmd-secrets", false,
+		"run ${cmd:...} secret references read from repositories, files, and platform configuration")
 	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v",
 		"increase verbosity level (e.g., -v, -vv)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false,
@@ -64,10 +87,37 @@ func init() {
 	rootCmd.SilenceUsage = true
 }
 
+// loadPlatformDefinitions registers the user's platform definitions. It runs
+// once, as initConfig runs on every Execute.
+var loadPlatformDefinitions = sync.OnceValue(func() error {
+	return errors.Wrap(generic.LoadDir(config.PlatformsDir()), "loading platform definitions")
+})
+
+// loadPlugins arranges for the platform plugins found in the plugins
+// directory and on PATH to be registered when a command first needs them.
+// A plugin that fails to load is reported and left out rather than failing
+// the command, since it may not be needed.
+var loadPlugins = sync.OnceFunc(func() {
+	plugin.LoadOnDemand(plugin.SearchPath(config.PluginsDir()), func(err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	})
+})
+
 func initConfig() {
+	loadPlugins()
+	if configLoadErr = loadPlatformDefinitions(); configLoadErr != nil {
+		config.Init()
+		return
+	}
 	config.Init()
 	// Capture load errors for later reporting
-	_, configLoadErr = config.Load("")
+	var cfg *config.Config
+	cfg, configLoadErr = config.Load("")
+	if configLoadErr != nil {
+		return
+	}
+	paths.SetConfigDirOverrides(cfg.ConfigDirs())
+	flags.SetDefaultPlatforms(cfg.DefaultPlatforms)
 }
 
 var rootCmd = &cobra.Command{
@@ -77,13 +127,30 @@ var rootCmd = &cobra.Command{
 across multiple platforms including Claude Code, OpenCode, Codex CLI,
 and Gemini CLI.
 
+Zed, Goose, Windsurf, and any platform described by a YAML definition in
+the platforms directory next to the config file are supported for MCP
+servers.
+
+Other platforms can be added with plugins: executables named
+aix-platform-<name> in the plugins directory next to the config file or
+on PATH.
+
 It manages skills, slash commands, agents, and MCP server configurations.
 Write once, deploy everywhere. Define your configurations in a
 platform-agnostic format and let aix handle the translation to each
 platform's native format.
 
 Use the --platform flag to target specific platforms, or omit it to
-target all detected/installed platforms.`,
+target the detected/installed platforms listed in default_platforms
+(all of them unless configured otherwise). Set platforms.<name>.config_dir
+or AIX_<NAME>_CONFIG_DIR to use an assistant whose config directory has
+been relocated; CLAUDE_CONFIG_DIR, CODEX_HOME, and OPENCODE_CONFIG_DIR are
+honored too.
+
+Use --scope project to read and write project-level configuration
+(.claude/, .gemini/, .codex/, .cursor/, opencode.json, and Copilot's
+.vscode/ and .github/ files) instead of your user configuration. The
+project root defaults to the enclosing git repository.`,
 	Example: `  # Initialize configuration
   aix init
 
@@ -96,13 +163,21 @@ target all detected/installed platforms.`,
   # Target specific platform
   aix skill list --platform claude
 
+  # Install a skill into the current project
+  aix skill install ./my-skill --scope project
+
   See Also: aix init, aix doctor, aix config`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Initialize logging first
 		if err := setupLogging(cmd); err != nil {
 			return err
 		}
-		return validatePlatformFlag(cmd, args)
+		if err := validatePlatformFlag(cmd, args); err != nil {
+			return err
+		}
+		flags.SetPlatformFlag(platformFlag)
+		flags.SetAllowCmdSecretsFlag(allowCmdSecrets)
+		return resolveScope(cmd)
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
 		// Close log file if opened
@@ -225,6 +300,66 @@ func validatePlatformFlag(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// resolveScope validates the --scope flag and, for project scope, resolves
+// the project root. The results are published through the flags package.
+func resolveScope(cmd *cobra.Command) error {
+	if cmd.Name() == "help" || cmd.Name() == "version" {
+		return nil
+	}
+
+	scope, err := cli.ParseScope(scopeFlag)
+	if err != nil {
+		return errors.NewUserError(err, "Use --scope user or --scope project")
+	}
+
+	root := ""
+	if scope == cli.ScopeProject {
+		root, err = resolveProjectRoot(projectRootFlag)
+		if err != nil {
+			return err
+		}
+	} else if projectRootFlag != "" {
+		return errors.NewUserError(
+			errors.New("--project-root requires --scope project"),
+			"Add --scope project or remove --project-root",
+		)
+	}
+
+	flags.SetScopeFlag(scope)
+	flags.SetProjectRootFlag(root)
+	return nil
+}
+
+// resolveProjectRoot returns the absolute project root. An explicit value
+// must be an existing directory; otherwise the git toplevel of the current
+// directory is used.
+func resolveProjectRoot(explicit string) (string, error) {
+	if explicit != "" {
+		abs, err := filepath.Abs(explicit)
+		if err != nil {
+			return "", errors.Wrap(err, "resolving project root")
+		}
+		info, err := os.Stat(abs)
+		if err != nil || !info.IsDir() {
+			return "", errors.NewUserError(
+				errors.Newf("project root %s is not a directory", abs),
+				"Pass an existing directory to --project-root",
+			)
+		}
+		return abs, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "getting working directory")
+	}
+	root, err := git.TopLevel(cwd)
+	if err != nil {
+		return "", errors.NewUserError(err, "Run inside a git repository or pass --project-root")
+	}
+	return root, nil
+}
+
 // GetPlatformFlag returns the current value of the --platform flag.
 // This is used by subcommands to access the flag value.
 func GetPlatformFlag() []string {
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/logging"
 )
 
@@ -244,3 +246,59 @@ func TestSetupLogging_ContextInjection(t *testing.T) {
 	// but we can check if it works.
 	logger.Info("context logging works")
 }
+
+func TestResolveScope(t *testing.T) {
+	origScope, origRoot := scopeFlag, projectRootFlag
+	defer func() {
+		scopeFlag, projectRootFlag = origScope, origRoot
+		flags.SetScopeFlag(cli.ScopeUser)
+		flags.SetProjectRootFlag("")
+	}()
+
+	root := t.TempDir()
+
+	t.Run("user scope", func(t *testing.T) {
+		scopeFlag, projectRootFlag = "user", ""
+		if err := resolveScope(rootCmd); err != nil {
+			t.Fatalf("resolveScope() error = %v", err)
+		}
+		if flags.GetScopeFlag() != cli.ScopeUser || flags.GetProjectRootFlag() != "" {
+			t.Errorf("got scope %q root %q, want user scope without root",
+				flags.GetScopeFlag(), flags.GetProjectRootFlag())
+		}
+	})
+
+	t.Run("project scope with explicit root", func(t *testing.T) {
+		scopeFlag, projectRootFlag = "project", root
+		if err := resolveScope(rootCmd); err != nil {
+			t.Fatalf("resolveScope() error = %v", err)
+		}
+		if flags.GetScopeFlag() != cli.ScopeProject {
+			t.Errorf("scope = %q, want %q", flags.GetScopeFlag(), cli.ScopeProject)
+		}
+		if flags.GetProjectRootFlag() != root {
+			t.Errorf("project root = %q, want %q", flags.GetProjectRootFlag(), root)
+		}
+	})
+
+	t.Run("project root must exist", func(t *testing.T) {
+		scopeFlag, projectRootFlag = "project", filepath.Join(root, "missing")
+		if err := resolveScope(rootCmd); err == nil {
+			t.Error("resolveScope() expected error for missing project root")
+		}
+	})
+
+	t.Run("project root without project scope", func(t *testing.T) {
+		scopeFlag, projectRootFlag = "user", root
+		if err := resolveScope(rootCmd); err == nil {
+			t.Error("resolveScope() expected error for --project-root with user scope")
+		}
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		scopeFlag, projectRootFlag = "global", ""
+		if err := resolveScope(rootCmd); err == nil {
+			t.Error("resolveScope() expected error for invalid scope")
+		}
+	})
+}
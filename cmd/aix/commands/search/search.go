@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -18,10 +19,11 @@ import (
 
 // ANSI color codes for terminal output.
 const (
-	colorReset = "\033[0m"
-	colorBold  = "\033[1m"
-	colorGreen = "\033[32m"
-	colorGray  = "\033[90m"
+	colorReset  = "\033[0m"
+	colorBold   = "\033[1m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorGray   = "\033[90m"
 )
 
 var (
@@ -42,25 +44,44 @@ var Cmd = &cobra.Command{
 	Short: "Search for resources across cached repositories",
 	Long: `Search for skills, commands, agents, and MCP servers across all cached repositories.
 
-The search is case-insensitive and matches against resource names and descriptions.
-Results are sorted by match quality: exact name matches first, then prefix matches,
-then substring matches, then description-only matches.
+The query is matched word by word against resource names, descriptions,
+metadata such as allowed tools, and the text of each skill, command, and
+agent. Results are ranked by relevance, with name matches ranked highest.
+Words also match longer words they begin, and a word with a small typo
+still finds results when nothing matches it exactly. Each result shows the
+text that matched, highlighted.
+
+The query may include filters, which can be combined with each other and
+with search words:
+
+  type:<skill|command|agent|mcp>   only resources of this type
+  repo:<name>                      only resources from this repository
+  tool:<name>                      only resources that use this tool
+
+The index is stored in the cache directory and rebuilt for a repository
+when it is added, updated, or pinned.
 
 If no query is provided, all resources are listed (subject to filters).`,
-	Example: `  # Search for resources containing "deploy"
+	Example: `  # Search for resources about deploying
   aix search deploy
 
+  # Typos still match
+  aix search kubernets
+
+  # Skills from the official repository that use Bash
+  aix search "type:skill repo:official tool:Bash"
+
   # Search for skills only
   aix search --type=skill
 
-  # Search in a specific repository
-  aix search --repo=official deploy
-
   # Output as JSON
   aix search deploy --json
 
   # List all resources
-  aix search`,
+  aix search
+
+See Also:
+  aix repo update   Update repositories and refresh the index`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runSearch,
 }
@@ -94,11 +115,9 @@ func runSearchWithWriter(w io.Writer, args []string) error {
 		return nil
 	}
 
-	// Scan all repos
-	scanner := resource.NewScanner()
-	resources, err := scanner.ScanAll(repos)
+	idx, err := resource.OpenIndex(resource.DefaultIndexPath(), repos, resource.NewScanner())
 	if err != nil {
-		return errors.Wrap(err, "scanning repositories")
+		return errors.Wrap(err, "indexing repositories")
 	}
 
 	// Build search options
@@ -107,33 +126,49 @@ func runSearchWithWriter(w io.Writer, args []string) error {
 		RepoName: repoFilter,
 	}
 
+	hits, err := idx.Search(query, opts)
+	if err != nil {
+		return errors.NewUserError(err, "Filters are type:<skill|command|agent|mcp>, repo:<name>, and tool:<name>")
+	}
+
 	// Interactive mode: no query and not JSON
 	if query == "" && !jsonOutput {
-		// Apply filters even in interactive mode
-		filtered := resource.Search(resources, "", opts)
-		return runInteractiveSearch(w, filtered)
+		resources := make([]resource.Resource, len(hits))
+		for i, h := range hits {
+			resources[i] = h.Resource
+		}
+		return runInteractiveSearch(w, resources)
 	}
 
-	// Search
-	results := resource.Search(resources, query, opts)
-
 	// Output
 	if jsonOutput {
-		return outputJSON(w, results)
+		return outputJSON(w, hits)
 	}
-	return outputTabular(w, results)
+	return outputTabular(w, hits)
+}
+
+// hitJSON is a search result in JSON output.
+type hitJSON struct {
+	resource.Resource
+	Score   float64 `json:"score,omitempty"`
+	Snippet string  `json:"snippet,omitempty"`
 }
 
-// outputJSON outputs resources in JSON format.
-func outputJSON(w io.Writer, resources []resource.Resource) error {
+// outputJSON outputs search results in JSON format.
+func outputJSON(w io.Writer, hits []resource.Hit) error {
+	out := make([]hitJSON, len(hits))
+	for i, h := range hits {
+		out[i] = hitJSON{Resource: h.Resource, Score: h.Score, Snippet: h.Snippet}
+	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
-	return errors.Wrap(enc.Encode(resources), "encoding output")
+	return errors.Wrap(enc.Encode(out), "encoding output")
 }
 
-// outputTabular outputs resources in a human-readable table format.
-func outputTabular(w io.Writer, resources []resource.Resource) error {
-	if len(resources) == 0 {
+// outputTabular outputs search results in a human-readable table format,
+// showing each result's snippet with the matched words highlighted.
+func outputTabular(w io.Writer, hits []resource.Hit) error {
+	if len(hits) == 0 {
 		fmt.Fprintln(w, "No resources found.")
 		return nil
 	}
@@ -145,17 +180,44 @@ func outputTabular(w io.Writer, resources []resource.Resource) error {
 		colorBold, colorReset,
 		colorBold, colorReset)
 
-	for _, r := range resources {
+	for _, h := range hits {
+		r := h.Resource
 		fmt.Fprintf(tw, "%s\t%s\t%s%s%s\t%s%s%s\n",
 			r.Type,
 			r.RepoName,
 			colorGreen, r.Name, colorReset,
-			colorGray, truncate(r.Description, 50), colorReset)
+			colorGray, highlight(h.Snippet, h.Highlights, 50), colorReset)
 	}
 
 	return errors.Wrap(tw.Flush(), "flushing tabwriter")
 }
 
+// highlight truncates s like truncate and colors the highlighted ranges
+// that remain. It is used for the last column only, where the varying
+// length of the color codes does not disturb alignment.
+func highlight(s string, spans []resource.Span, maxLen int) string {
+	text := truncate(s, maxLen)
+	limit := len(text)
+	if limit < len(s) {
+		limit = max(maxLen-3, 0)
+	}
+
+	var sb strings.Builder
+	pos := 0
+	for _, sp := range spans {
+		if sp.Start < pos || sp.End > limit {
+			continue
+		}
+		sb.WriteString(text[pos:sp.Start])
+		sb.WriteString(colorReset + colorYellow + colorBold)
+		sb.WriteString(text[sp.Start:sp.End])
+		sb.WriteString(colorReset + colorGray)
+		pos = sp.End
+	}
+	sb.WriteString(text[pos:])
+	return sb.String()
+}
+
 // truncate shortens a string to maxLen characters, adding "..." if truncated.
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -96,7 +96,7 @@ func TestOutputJSON_ValidFormat(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := outputJSON(&buf, resources)
+	err := outputJSON(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -135,7 +135,7 @@ func TestOutputJSON_EmptyResources(t *testing.T) {
 	resources := []resource.Resource{}
 
 	var buf bytes.Buffer
-	err := outputJSON(&buf, resources)
+	err := outputJSON(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -163,7 +163,7 @@ func TestOutputJSON_FormattedOutput(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := outputJSON(&buf, resources)
+	err := outputJSON(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputJSON() error = %v", err)
 	}
@@ -197,7 +197,7 @@ func TestOutputTabular_WithResources(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := outputTabular(&buf, resources)
+	err := outputTabular(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputTabular() error = %v", err)
 	}
@@ -243,7 +243,7 @@ func TestOutputTabular_NoResults(t *testing.T) {
 	resources := []resource.Resource{}
 
 	var buf bytes.Buffer
-	err := outputTabular(&buf, resources)
+	err := outputTabular(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputTabular() error = %v", err)
 	}
@@ -267,7 +267,7 @@ func TestOutputTabular_TruncatesLongDescriptions(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := outputTabular(&buf, resources)
+	err := outputTabular(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputTabular() error = %v", err)
 	}
@@ -316,7 +316,7 @@ func TestOutputTabular_AllResourceTypes(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := outputTabular(&buf, resources)
+	err := outputTabular(&buf, toHits(resources))
 	if err != nil {
 		t.Fatalf("outputTabular() error = %v", err)
 	}
@@ -392,3 +392,31 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+// toHits wraps resources as search hits whose snippet is the description.
+func toHits(resources []resource.Resource) []resource.Hit {
+	hits := make([]resource.Hit, len(resources))
+	for i, r := range resources {
+		hits[i] = resource.Hit{Resource: r, Snippet: r.Description}
+	}
+	return hits
+}
+
+func TestHighlight(t *testing.T) {
+	s := "Deploy applications to production"
+	spans := []resource.Span{{Start: 0, End: 6}, {Start: 23, End: 33}}
+
+	got := highlight(s, spans, 50)
+	want := colorReset + colorYellow + colorBold + "Deploy" + colorReset + colorGray + " applications to " +
+		colorReset + colorYellow + colorBold + "production" + colorReset + colorGray
+	if got != want {
+		t.Errorf("highlight() = %q, want %q", got, want)
+	}
+
+	// A span cut off by truncation is not colored.
+	got = highlight(s, spans, 20)
+	want = colorReset + colorYellow + colorBold + "Deploy" + colorReset + colorGray + " applicatio..."
+	if got != want {
+		t.Errorf("highlight() truncated = %q, want %q", got, want)
+	}
+}
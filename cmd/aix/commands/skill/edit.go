@@ -69,7 +69,7 @@ func runEdit(_ *cobra.Command, args []string) error {
 		return errors.Wrap(editor.Open(absPath), "opening editor")
 	}
 	// 2. Lookup as installed skill name
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -2,6 +2,7 @@ package skill
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,8 +16,12 @@ import (
 	"github.com/thoreinstein/aix/internal/git"
 	"github.com/thoreinstein/aix/internal/install"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/gemini"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/registry"
 	"github.com/thoreinstein/aix/internal/resource"
 	"github.com/thoreinstein/aix/internal/skill/parser"
 	skillvalidator "github.com/thoreinstein/aix/internal/skill/validator"
@@ -27,6 +32,8 @@ var (
 	installForce       bool
 	installFile        bool
 	installAllFromRepo string
+	installSet         []string
+	installMerge       bool
 	installer          *install.Installer
 )
 
@@ -37,9 +44,24 @@ func init() {
 		"treat argument as a file path instead of searching repos")
 	installCmd.Flags().StringVar(&installAllFromRepo, "all-from-repo", "",
 		"install all skills from a specific repository")
+	installCmd.Flags().StringArrayVar(&installSet, "set", nil,
+		"set a parameter of the skill (key=value, repeatable)")
+	installCmd.Flags().BoolVar(&installMerge, "merge", false,
+		"reinstall from the skill's repository, keeping local edits")
 	Cmd.AddCommand(installCmd)
 
-	installer = install.NewInstaller(resource.TypeSkill, "skill", installFromLocal)
+	installer = install.NewInstaller(resource.TypeSkill, "skill", installFromLocal,
+		install.WithPlatforms(flags.ResolvePlatforms), install.WithRecorder(registry.Record),
+		install.WithForgetter(registry.Forget), install.WithParams(func() []string { return installSet }),
+		install.WithInstallTo(func(w io.Writer, path string, platforms []cli.Platform) (string, error) {
+			return Install(w, path, platforms, true)
+		}))
+}
+
+// Installer returns the installer behind 'aix skill install', for linking
+// into an install.Set.
+func Installer() *install.Installer {
+	return installer
 }
 
 var installCmd = &cobra.Command{
@@ -57,7 +79,16 @@ If the skill exists in multiple repositories, you will be prompted to select one
 Use --file to skip repo search and treat the argument as a file path.
 
 For git URLs, the repository is cloned to a temporary directory, the skill
-is installed, and the temporary directory is cleaned up.`,
+is installed, and the temporary directory is cleaned up.
+
+A skill may declare parameters that are filled into its text when it is
+installed. Give values with --set key=value; the rest are prompted for, or
+take their defaults when input is not a terminal. The values are recorded for
+installs from a repository, and 'aix upgrade' reuses them.
+
+Use --merge to reinstall a skill from the repository it was installed from
+while keeping edits made to it since; see 'aix skill diff'. If the edits
+conflict with changes in the repository, nothing is installed.`,
 	Example: `  # Install by name from configured repos
   aix skill install code-review
 
@@ -77,6 +108,12 @@ is installed, and the temporary directory is cleaned up.`,
   # Force overwrite existing skill
   aix skill install code-review --force
 
+  # Fill in parameters
+  aix skill install code-review --set org=acme
+
+  # Reinstall, keeping local edits
+  aix skill install code-review --merge
+
   # Install all skills from a specific repo
   aix skill install --all-from-repo official`,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -84,11 +121,17 @@ is installed, and the temporary directory is cleaned up.`,
 			if len(args) > 0 {
 				return errors.New("cannot specify both --all-from-repo and a source argument")
 			}
+			if len(installSet) > 0 {
+				return errors.New("cannot specify both --all-from-repo and --set")
+			}
 			return nil
 		}
 		if len(args) != 1 {
 			return errors.New("requires exactly one argument (source)")
 		}
+		if installMerge && (installForce || installFile) {
+			return errors.New("cannot combine --merge with --force or --file")
+		}
 		return nil
 	},
 	RunE: runInstall,
@@ -109,6 +152,10 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	source := args[0]
 
+	if installMerge {
+		return reinstallMerged(source)
+	}
+
 	// If --file flag is set, treat argument as file path or URL (old behavior)
 	if installFile {
 		if git.IsURL(source) {
@@ -117,7 +164,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 			}
 			return nil
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// If source is clearly a path or URL, use direct install
@@ -128,7 +175,7 @@ func runInstall(_ *cobra.Command, args []string) error {
 			}
 			return nil
 		}
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	// Try repo lookup first
@@ -156,14 +203,84 @@ func runInstall(_ *cobra.Command, args []string) error {
 
 	// Check if it's a local path that exists
 	if _, err := os.Stat(source); err == nil {
-		return installFromLocal(source)
+		return installer.InstallFromPath(source)
 	}
 
 	return errors.Newf("skill %q not found in any configured repository", source)
 }
 
-// installFromLocal installs a skill from a local directory.
-func installFromLocal(skillPath string) error {
+// reinstallMerged reinstalls the skill called name from the repository it
+// was installed from, merging local edits into the repository version.
+func reinstallMerged(name string) error {
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+
+	err = registry.ReinstallRecorded(resource.TypeSkill, name, platforms, func(path string, p cli.Platform) error {
+		_, err := Install(os.Stdout, path, []cli.Platform{p}, true)
+		return err
+	})
+	switch {
+	case errors.Is(err, registry.ErrNotRecorded):
+		return errors.NewUserError(err, "Only skills installed from a configured repository can be merged")
+	case errors.Is(err, registry.ErrMergeConflict):
+		return errors.NewUserError(errors.Wrapf(err, "merging skill %q", name),
+			fmt.Sprintf("Review the edits with 'aix skill diff %s', or use --force to discard them", name))
+	case err != nil:
+		return errors.Wrap(err, "reinstalling skill")
+	}
+	fmt.Printf("[OK] Skill '%s' reinstalled with local edits merged\n", name)
+	return nil
+}
+
+// Load parses the skill directory at path into its canonical form without
+// validating or installing it.
+func Load(path string) (*claude.Skill, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving path")
+	}
+
+	p := parser.New()
+	skill, err := p.ParseFile(filepath.Join(absPath, "SKILL.md"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing skill")
+	}
+	skill.SourceDir = absPath
+	return skill, nil
+}
+
+// Install installs the skill directory at path to platforms, writing
+// progress to w, and returns its name. An existing copy on any platform
+// makes the install fail unless force is set.
+func Install(w io.Writer, path string, platforms []cli.Platform, force bool) (string, error) {
+	skill, err := loadValid(w, path)
+	if err != nil {
+		return "", err
+	}
+	return skill.Name, installTo(w, skill, platforms, force)
+}
+
+// installFromLocal installs a skill from a local directory to the platforms
+// selected by the flags, and returns its name.
+func installFromLocal(skillPath string) (string, error) {
+	skill, err := loadValid(os.Stdout, skillPath)
+	if err != nil {
+		return "", err
+	}
+
+	// Get target platforms
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		return "", errors.Wrap(err, "resolving platforms")
+	}
+	return skill.Name, installTo(os.Stdout, skill, platforms, installForce)
+}
+
+// loadValid parses and validates the skill directory at skillPath,
+// reporting validation errors to w.
+func loadValid(w io.Writer, skillPath string) (*claude.Skill, error) {
 	// Resolve to absolute path for consistent error messages
 	absPath, err := filepath.Abs(skillPath)
 	if err != nil {
@@ -175,39 +292,37 @@ func installFromLocal(skillPath string) error {
 
 	// Check if SKILL.md exists
 	if _, err := os.Stat(skillFile); os.IsNotExist(err) {
-		return errors.Newf("SKILL.md not found at %s", absPath)
+		return nil, errors.Newf("SKILL.md not found at %s", absPath)
 	}
 
-	fmt.Println("Validating skill...")
+	fmt.Fprintln(w, "Validating skill...")
 
 	// Parse the skill
 	p := parser.New()
 	skill, err := p.ParseFile(skillFile)
 	if err != nil {
-		return errors.Wrap(err, "parsing skill")
+		return nil, errors.Wrap(err, "parsing skill")
 	}
 
 	// Validate the skill
 	v := skillvalidator.New()
 	result := v.ValidateWithPath(skill, skillFile)
 	if result.HasErrors() {
-		fmt.Println("Skill validation failed:")
-		reporter := validator.NewReporter(os.Stdout, validator.FormatText)
+		fmt.Fprintln(w, "Skill validation failed:")
+		reporter := validator.NewReporter(w, validator.FormatText)
 		_ = reporter.Report(result)
-		return errInstallFailed
+		return nil, errInstallFailed
 	}
 
 	// Record source directory so supporting files can be copied during install
 	skill.SourceDir = absPath
+	return skill, nil
+}
 
-	// Get target platforms
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
-	if err != nil {
-		return errors.Wrap(err, "resolving platforms")
-	}
-
+// installTo installs skill to platforms, writing progress to w.
+func installTo(w io.Writer, skill *claude.Skill, platforms []cli.Platform, force bool) error {
 	// Check for existing skills (unless --force)
-	if !installForce {
+	if !force {
 		for _, plat := range platforms {
 			if _, err := plat.GetSkill(skill.Name); err == nil {
 				return errors.Newf("skill %q already exists on %s (use --force to overwrite)",
@@ -216,25 +331,30 @@ func installFromLocal(skillPath string) error {
 		}
 	}
 
-	// Install to each platform
+	// Install to each platform; if any fails, every platform is rolled back
 	var installedCount int
+	tx := backup.NewTransaction()
 	for _, plat := range platforms {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(plat.Name(), plat.BackupPaths()); err != nil {
-			return errors.Wrapf(err, "backing up %s before install", plat.DisplayName())
+		if err := cli.BeginChange(tx, plat, resource.TypeSkill, skill.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before install", plat.DisplayName()))
 		}
 
-		fmt.Printf("Installing '%s' to %s... ", skill.Name, plat.DisplayName())
+		fmt.Fprintf(w, "Installing '%s' to %s... ", skill.Name, plat.DisplayName())
 
 		// Convert skill to platform-specific type
-		platformSkill := convertSkillForPlatform(skill, plat.Name())
+		platformSkill := ConvertForPlatform(skill, plat.Name())
 
 		if err := plat.InstallSkill(platformSkill); err != nil {
-			fmt.Println("failed")
-			return errors.Wrapf(err, "failed to install to %s", plat.DisplayName())
+			if errors.Is(err, errors.ErrNotSupported) {
+				fmt.Fprintln(w, "skipped (not supported)")
+				continue
+			}
+			fmt.Fprintln(w, "failed")
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to install to %s", plat.DisplayName()))
 		}
 
-		fmt.Println("done")
+		fmt.Fprintln(w, "done")
 		installedCount++
 	}
 
@@ -243,14 +363,13 @@ func installFromLocal(skillPath string) error {
 	if installedCount != 1 {
 		platformWord = "platforms"
 	}
-	fmt.Printf("[OK] Skill '%s' installed to %d %s\n", skill.Name, installedCount, platformWord)
-
+	fmt.Fprintf(w, "[OK] Skill '%s' installed to %d %s\n", skill.Name, installedCount, platformWord)
 	return nil
 }
 
-// convertSkillForPlatform converts a canonical claude.Skill to the appropriate
+// ConvertForPlatform converts a canonical claude.Skill to the appropriate
 // platform-specific skill type.
-func convertSkillForPlatform(skill *claude.Skill, platformName string) any {
+func ConvertForPlatform(skill *claude.Skill, platformName string) any {
 	switch platformName {
 	case "claude":
 		// Claude uses the canonical format, return as-is
@@ -258,15 +377,61 @@ func convertSkillForPlatform(skill *claude.Skill, platformName string) any {
 	case "opencode":
 		// Convert to OpenCode skill format
 		return convertToOpenCodeSkill(skill)
+	case "codex":
+		// Convert to Codex skill format
+		return convertToCodexSkill(skill)
 	case "gemini":
 		// Convert to Gemini skill format
 		return convertToGeminiSkill(skill)
+	case "cursor":
+		// Convert to a Cursor rule
+		return convertToCursorRule(skill)
+	case "copilot":
+		// Convert to a Copilot instructions file
+		return convertToCopilotInstruction(skill)
 	default:
 		// Unknown platform, return as-is and let the adapter handle it
 		return skill
 	}
 }
 
+// convertToCodexSkill converts a Claude skill to a Codex skill.
+func convertToCodexSkill(s *claude.Skill) *codex.Skill {
+	return &codex.Skill{
+		Name:          s.Name,
+		Description:   s.Description,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		Metadata:      s.Metadata,
+		AllowedTools:  codex.ToolList(s.AllowedTools),
+		Instructions:  s.Instructions,
+		SourceDir:     s.SourceDir,
+	}
+}
+
+// convertToCursorRule converts a Claude skill to a Cursor rule. The rule
+// is attached when the model asks for it by description, which is the
+// closest match to how skills are loaded. Supporting files are not copied.
+func convertToCursorRule(s *claude.Skill) *cursor.Rule {
+	return &cursor.Rule{
+		Name:         s.Name,
+		Description:  s.Description,
+		Instructions: s.Instructions,
+	}
+}
+
+// convertToCopilotInstruction converts a Claude skill to a Copilot
+// instructions file. Without an applyTo glob the file is attached by hand
+// or by description rather than to every request. Supporting files are not
+// copied.
+func convertToCopilotInstruction(s *claude.Skill) *copilot.Instruction {
+	return &copilot.Instruction{
+		Name:         s.Name,
+		Description:  s.Description,
+		Instructions: s.Instructions,
+	}
+}
+
 // convertToGeminiSkill converts a Claude skill to a Gemini skill.
 func convertToGeminiSkill(s *claude.Skill) *gemini.Skill {
 	return &gemini.Skill{
@@ -4,9 +4,14 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/params"
 )
 
 func TestIsGitURL(t *testing.T) {
@@ -76,7 +81,7 @@ func TestInstallFromLocal_MissingSKILLMD(t *testing.T) {
 	// Create a temp directory without SKILL.md
 	tempDir := t.TempDir()
 
-	err := installFromLocal(tempDir)
+	_, err := installFromLocal(tempDir)
 	if err == nil {
 		t.Error("expected error for missing SKILL.md, got nil")
 	}
@@ -103,7 +108,7 @@ Some instructions.
 		t.Fatalf("failed to write test file: %v", err)
 	}
 
-	err := installFromLocal(tempDir)
+	_, err := installFromLocal(tempDir)
 	if err == nil {
 		t.Error("expected error for invalid skill, got nil")
 	}
@@ -232,3 +237,56 @@ type opencodeSkillForTest struct {
 	Compatibility map[string]string
 	Instructions  string
 }
+
+func TestInstallFromPath_Parameters(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldSet, oldForce := installSet, installForce
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		installSet, installForce = oldSet, oldForce
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	installForce = true
+	backup.ResetBackupState()
+
+	src := filepath.Join(tmp, "src", "triage")
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: triage\ndescription: Triage {{ .Params.org }} issues\nparameters:\n  - name: org\n    required: true\n  - name: label\n    default: bug\n---\n\nLabel {{ .Params.org }} issues as {{ .Params.label }}.\n"
+	if err := os.WriteFile(filepath.Join(src, "SKILL.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	installSet = nil
+	if err := installer.InstallFromPath(src); !errors.Is(err, params.ErrMissingValue) {
+		t.Errorf("InstallFromPath() without a required value error = %v, want ErrMissingValue", err)
+	}
+	installSet = []string{"org=acme", "typo=x"}
+	if err := installer.InstallFromPath(src); !errors.Is(err, params.ErrUnknownParameter) {
+		t.Errorf("InstallFromPath() with an unknown parameter error = %v, want ErrUnknownParameter", err)
+	}
+
+	installSet = []string{"org=acme"}
+	if err := installer.InstallFromPath(src); err != nil {
+		t.Fatalf("InstallFromPath() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmp, ".claude", "skills", "triage", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Triage acme issues", "Label acme issues as bug."} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("installed skill missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 var listJSON bool
@@ -50,6 +52,7 @@ type listOutput map[string][]infoJSON
 type infoJSON struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Source      string `json:"source"`
 }
 
 func runList(_ *cobra.Command, _ []string) error {
@@ -58,7 +61,7 @@ func runList(_ *cobra.Command, _ []string) error {
 
 // runListWithWriter allows injecting a writer for testing.
 func runListWithWriter(w io.Writer) error {
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -71,6 +74,11 @@ func runListWithWriter(w io.Writer) error {
 
 // outputListJSON outputs skills in JSON format.
 func outputListJSON(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	output := make(listOutput)
 
 	for _, p := range platforms {
@@ -84,6 +92,7 @@ func outputListJSON(w io.Writer, platforms []cli.Platform) error {
 			infos[i] = infoJSON{
 				Name:        s.Name,
 				Description: s.Description,
+				Source:      reg.Source(resource.TypeSkill, s.Name),
 			}
 		}
 		output[p.Name()] = infos
@@ -105,6 +114,11 @@ const (
 
 // outputListTabular outputs skills in tabular format grouped by platform.
 func outputListTabular(w io.Writer, platforms []cli.Platform) error {
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		return err
+	}
+
 	hasSkills := false
 
 	for i, p := range platforms {
@@ -132,11 +146,13 @@ func outputListTabular(w io.Writer, platforms []cli.Platform) error {
 
 		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 		// Table headers
-		fmt.Fprintf(tw, "  %sNAME%s\t%sDESCRIPTION%s\n", colorBold, colorReset, colorBold, colorReset)
+		fmt.Fprintf(tw, "  %sNAME%s\t%sSOURCE%s\t%sDESCRIPTION%s\n",
+			colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
 
 		for _, s := range skills {
 			desc := truncate(s.Description, 80)
-			fmt.Fprintf(tw, "  %s%s%s\t%s\n", colorGreen, s.Name, colorReset, desc)
+			fmt.Fprintf(tw, "  %s%s%s\t%s\t%s\n", colorGreen, s.Name, colorReset,
+				reg.Source(resource.TypeSkill, s.Name), desc)
 		}
 		tw.Flush()
 	}
@@ -13,6 +13,8 @@ import (
 	"github.com/thoreinstein/aix/internal/backup"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 var removeForce bool
@@ -55,7 +57,7 @@ func runRemove(_ *cobra.Command, args []string) error {
 func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -66,6 +68,8 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 		return errors.Newf("skill %q not found on any platform", name)
 	}
 
+	install.WarnDependents(w, installedOn, resource.TypeSkill, name)
+
 	// Confirm removal unless --force is specified
 	if !removeForce {
 		if !confirmRemoval(w, r, name, installedOn) {
@@ -74,28 +78,22 @@ func runRemoveWithIO(args []string, w io.Writer, r io.Reader) error {
 		}
 	}
 
-	// Remove from each platform
-	var failed []string
+	// Remove from each platform; if any fails, every platform is rolled back
+	tx := backup.NewTransaction()
 	for _, p := range installedOn {
 		// Ensure backup exists before modifying
-		if err := backup.EnsureBackedUp(p.Name(), p.BackupPaths()); err != nil {
-			failed = append(failed, fmt.Sprintf("%s: backup failed: %v", p.DisplayName(), err))
-			continue
+		if err := cli.BeginChange(tx, p, resource.TypeSkill, name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before remove", p.DisplayName()))
 		}
 
 		fmt.Fprintf(w, "Removing from %s... ", p.DisplayName())
 		if err := p.UninstallSkill(name); err != nil {
 			fmt.Fprintln(w, "failed")
-			failed = append(failed, fmt.Sprintf("%s: %v", p.DisplayName(), err))
-			continue
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "failed to remove from %s", p.DisplayName()))
 		}
 		fmt.Fprintln(w, "done")
 	}
 
-	if len(failed) > 0 {
-		return errors.New("failed to remove from some platforms:\n  " + strings.Join(failed, "\n  "))
-	}
-
 	fmt.Fprintf(w, "\u2713 Skill %q removed from %d platform(s)\n", name, len(installedOn))
 	return nil
 }
@@ -12,6 +12,9 @@ import (
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
 )
 
@@ -78,7 +81,7 @@ type installLocation struct {
 func runShow(_ *cobra.Command, args []string) error {
 	name := args[0]
 
-	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag())
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
 	if err != nil {
 		return errors.Wrap(err, "resolving platforms")
 	}
@@ -132,6 +135,20 @@ func extractDetail(skill any) *showDetail {
 		return extractClaudeDetail(s)
 	case *opencode.Skill:
 		return extractOpenCodeDetail(s)
+	case *codex.Skill:
+		return extractCodexDetail(s)
+	case *cursor.Rule:
+		return &showDetail{
+			Name:         s.Name,
+			Description:  s.Description,
+			Instructions: s.Instructions,
+		}
+	case *copilot.Instruction:
+		return &showDetail{
+			Name:         s.Name,
+			Description:  s.Description,
+			Instructions: s.Instructions,
+		}
 	default:
 		return nil
 	}
@@ -152,6 +169,19 @@ func extractClaudeDetail(s *claude.Skill) *showDetail {
 	}
 }
 
+// extractCodexDetail extracts details from a Codex skill.
+func extractCodexDetail(s *codex.Skill) *showDetail {
+	return &showDetail{
+		Name:          s.Name,
+		Description:   s.Description,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		AllowedTools:  []string(s.AllowedTools),
+		Metadata:      s.Metadata,
+		Instructions:  s.Instructions,
+	}
+}
+
 // extractOpenCodeDetail extracts details from an OpenCode skill.
 func extractOpenCodeDetail(s *opencode.Skill) *showDetail {
 	// Convert OpenCode's map[string]any metadata to map[string]string
@@ -0,0 +1,103 @@
+package skill
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestStatusDiffAndMerge(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tmp, "cache"))
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	backup.ResetBackupState()
+
+	writeSkill := func(path, body string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\nname: review\ndescription: Reviews code\n---\n\n" + body + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res := resource.Resource{Name: "review", Type: resource.TypeSkill, RepoName: "official", Path: "skills/review"}
+	source := filepath.Join(res.SourcePath(), "SKILL.md")
+	writeSkill(source, "Read the diff.\n\nApprove.")
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(io.Discard, res.SourcePath(), platforms, true); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if err := registry.Record(res, platforms, nil); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := runStatusWithWriter(&buf); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "unmodified") {
+		t.Errorf("status of fresh install:\n%s", buf.String())
+	}
+
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	writeSkill(installed, "Read the whole diff.\n\nApprove.")
+
+	buf.Reset()
+	if err := runStatusWithWriter(&buf); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "modified on claude") {
+		t.Errorf("status of edited skill:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runDiffWithWriter(&buf, "review"); err != nil {
+		t.Fatalf("diff error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "-Read the diff.\n+Read the whole diff.\n") {
+		t.Errorf("diff output:\n%s", buf.String())
+	}
+
+	writeSkill(source, "Read the diff.\n\nApprove or request changes.")
+	if err := reinstallMerged("review"); err != nil {
+		t.Fatalf("reinstallMerged() error = %v", err)
+	}
+	data, err := os.ReadFile(installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Read the whole diff.") || !strings.Contains(string(data), "request changes") {
+		t.Errorf("merged skill:\n%s", data)
+	}
+
+	if err := reinstallMerged("missing"); err == nil {
+		t.Error("reinstallMerged() of an unrecorded skill succeeded")
+	}
+}
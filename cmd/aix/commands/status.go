@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
 	"github.com/thoreinstein/aix/internal/cli"
 	"github.com/thoreinstein/aix/internal/doctor"
 	"github.com/thoreinstein/aix/internal/errors"
@@ -92,7 +93,7 @@ func runStatusWithWriter(w io.Writer) error {
 	platforms := make([]cli.Platform, 0, len(allPlatformNames))
 
 	for _, name := range allPlatformNames {
-		p, err := cli.NewPlatform(name)
+		p, err := cli.NewPlatform(name, flags.PlatformOptions()...)
 		if err != nil {
 			continue // Skip platforms without adapters
 		}
@@ -0,0 +1,429 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/agent"
+	"github.com/thoreinstein/aix/cmd/aix/commands/command"
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/cmd/aix/commands/mcp"
+	"github.com/thoreinstein/aix/cmd/aix/commands/skill"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/manifest"
+	mcpconfig "github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+var (
+	syncFrom   string
+	syncTo     []string
+	syncTypes  []string
+	syncDryRun bool
+)
+
+// resourceTypes lists every resource type, in the order commands that span
+// types report them.
+var resourceTypes = []resource.ResourceType{
+	resource.TypeSkill, resource.TypeCommand, resource.TypeAgent, resource.TypeMCP,
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncFrom, "from", "",
+		"platform to copy resources from (required)")
+	syncCmd.Flags().StringSliceVar(&syncTo, "to", nil,
+		"platforms to copy resources to (default: all other detected platforms)")
+	syncCmd.Flags().StringSliceVar(&syncTypes, "type", nil,
+		"resource types to sync: skill, command, agent, mcp (default: all)")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false,
+		"show the plan without changing anything")
+	_ = syncCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(syncCmd)
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Mirror resources from one platform to the others",
+	Long: `Copy skills, commands, agents, and MCP servers from one platform to others.
+
+Every resource installed on the --from platform is read, converted to the
+canonical form, and installed on each --to platform. Resources that are
+missing on a target are installed; resources whose content differs are
+overwritten. Resources that exist only on a target are left alone.
+
+Not every platform can represent every field. When a conversion drops or
+changes a field, for example the OS restriction of an MCP server synced to
+OpenCode, sync lists it under "Lossy conversions" before making changes.
+Use --dry-run to review the plan first.
+
+Resource types a platform does not support, such as agents on Gemini CLI,
+are skipped.
+
+An MCP server whose env or headers on the --from platform reference a
+command, as in ${cmd:...}, stops the sync: aix only runs commands from its
+own manifest, repositories, and command line.`,
+	Example: `  # Copy everything from Claude Code to Gemini CLI and OpenCode
+  aix sync --from claude --to gemini,opencode
+
+  # Copy only MCP servers and skills, after reviewing the plan
+  aix sync --from claude --to opencode --type mcp,skill --dry-run
+
+  # Sync a project's configuration
+  aix sync --from claude --to gemini --scope project
+
+See Also: aix apply, aix mcp show`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+func runSync(cmd *cobra.Command, _ []string) error {
+	return runSyncWithWriter(cmd.OutOrStdout())
+}
+
+// runSyncWithWriter allows injecting a writer for testing.
+func runSyncWithWriter(w io.Writer) error {
+	types, err := parseResourceTypes(syncTypes)
+	if err != nil {
+		return errors.NewUserError(err, "Valid types are: skill, command, agent, mcp")
+	}
+
+	opts := flags.PlatformOptions()
+	source, err := cli.NewPlatform(syncFrom, opts...)
+	if err != nil {
+		return errors.NewUserError(err, "Valid platforms are: "+strings.Join(paths.Platforms(), ", "))
+	}
+	targets, err := syncTargets(source, opts)
+	if err != nil {
+		return err
+	}
+
+	state, err := collectSyncState(w, source, targets, types)
+	if err != nil {
+		return err
+	}
+
+	plan := manifest.Compute(state.desired, state.installed, false)
+	printPlan(w, "Sync from "+source.DisplayName(), plan)
+	printLossy(w, plan, state)
+
+	switch {
+	case !plan.HasChanges():
+		fmt.Fprintln(w, "Everything is up to date.")
+		return nil
+	case syncDryRun:
+		fmt.Fprintln(w, "Dry run: no changes made.")
+		return nil
+	default:
+		return executeSync(w, plan, state)
+	}
+}
+
+// parseResourceTypes converts --type values to resource types, accepting
+// plurals. No values selects every type.
+func parseResourceTypes(values []string) ([]resource.ResourceType, error) {
+	if len(values) == 0 {
+		return resourceTypes, nil
+	}
+
+	want := make(map[resource.ResourceType]bool)
+	for _, v := range values {
+		t := resource.ResourceType(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), "s"))
+		if !slices.Contains(resourceTypes, t) {
+			return nil, errors.Newf("unknown resource type %q", v)
+		}
+		want[t] = true
+	}
+
+	var types []resource.ResourceType
+	for _, t := range resourceTypes {
+		if want[t] {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// syncTargets resolves --to, defaulting to every detected platform other
+// than the source.
+func syncTargets(source cli.Platform, opts []cli.Option) ([]cli.Platform, error) {
+	platforms, err := cli.ResolvePlatforms(syncTo, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving platforms")
+	}
+
+	var targets []cli.Platform
+	for _, p := range platforms {
+		if p.Name() == source.Name() {
+			if len(syncTo) > 0 {
+				return nil, errors.NewUserError(
+					errors.Newf("cannot sync %s to itself", source.DisplayName()),
+					"Remove "+source.Name()+" from --to")
+			}
+			continue
+		}
+		targets = append(targets, p)
+	}
+	if len(targets) == 0 {
+		return nil, errors.NewUserError(
+			errors.Wrap(cli.ErrNoPlatformsAvailable, "no platforms to sync to"),
+			"Pass --to with the platforms to copy resources to")
+	}
+	return targets, nil
+}
+
+// syncState is the desired and installed state gathered for planning, plus
+// the converted value of every resource for every target.
+type syncState struct {
+	desired   []manifest.Desired
+	installed []manifest.Installed
+	targets   map[string]cli.Platform
+
+	// converted and lossy are keyed by target platform and entryKey.
+	converted map[string]any
+	lossy     map[string][]string
+}
+
+// syncKey identifies a resource on a target in syncState.
+func syncKey(platform string, t resource.ResourceType, name string) string {
+	return platform + ":" + entryKey(t, name)
+}
+
+// collectSyncState reads every resource of the selected types from source
+// and inspects every target. Types the source or a target does not support
+// are skipped with a message.
+func collectSyncState(w io.Writer, source cli.Platform, targets []cli.Platform, types []resource.ResourceType) (*syncState, error) {
+	state := &syncState{
+		targets:   make(map[string]cli.Platform, len(targets)),
+		converted: make(map[string]any),
+		lossy:     make(map[string][]string),
+	}
+	for _, p := range targets {
+		state.targets[p.Name()] = p
+	}
+
+	for _, t := range types {
+		names, err := listInstalled(source, t)
+		if err != nil {
+			fmt.Fprintf(w, "Skipping %ss on %s: %v\n", t, source.DisplayName(), err)
+			continue
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		var supported []cli.Platform
+		for _, p := range targets {
+			if !cli.Supports(p, t) {
+				fmt.Fprintf(w, "Skipping %ss on %s: not supported\n", t, p.DisplayName())
+				continue
+			}
+			installed, err := listInstalled(p, t)
+			if err != nil {
+				fmt.Fprintf(w, "Skipping %ss on %s: %v\n", t, p.DisplayName(), err)
+				continue
+			}
+			supported = append(supported, p)
+			for _, name := range installed {
+				if !slices.Contains(names, name) {
+					continue
+				}
+				digest, err := registry.InstalledDigest(p, t, name)
+				if err != nil {
+					return nil, errors.Wrapf(err, "reading %s %q from %s", t, name, p.DisplayName())
+				}
+				state.installed = append(state.installed,
+					manifest.Installed{Type: t, Name: name, Platform: p.Name(), Digest: digest})
+			}
+		}
+
+		for _, name := range names {
+			v, err := cli.CanonicalResource(source, t, name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading %s %q from %s", t, name, source.DisplayName())
+			}
+			// The source's configuration is not aix's own, so command
+			// references in it are only run when the user opts in
+			if server, ok := v.(*mcpconfig.Server); ok && !flags.GetAllowCmdSecretsFlag() {
+				if err := server.CheckUntrusted(); err != nil {
+					return nil, errors.Wrapf(err, "reading MCP servers from %s", source.DisplayName())
+				}
+			}
+
+			for _, p := range supported {
+				converted, err := convertResource(v, p.Name())
+				if err != nil {
+					return nil, errors.Wrapf(err, "converting %s %q for %s", t, name, p.DisplayName())
+				}
+				back, err := canonicalize(t, converted)
+				if err != nil {
+					return nil, errors.Wrapf(err, "converting %s %q for %s", t, name, p.DisplayName())
+				}
+				// Compare against what the target can hold, so that lossy
+				// fields do not cause an update on every run.
+				digest, err := manifest.Digest(back)
+				if err != nil {
+					return nil, err
+				}
+
+				key := syncKey(p.Name(), t, name)
+				state.converted[key] = converted
+				state.lossy[key] = droppedFields(v, back)
+				state.desired = append(state.desired, manifest.Desired{
+					Entry:    manifest.Entry{Type: t, Name: name},
+					Platform: p.Name(),
+					Digest:   digest,
+				})
+			}
+		}
+	}
+
+	return state, nil
+}
+
+// convertResource converts a canonical resource to the platform-specific
+// type the named platform's install method expects.
+func convertResource(v any, platformName string) (any, error) {
+	switch r := v.(type) {
+	case *claude.Skill:
+		return skill.ConvertForPlatform(r, platformName), nil
+	case *claude.Command:
+		return command.ConvertForPlatform(r, platformName), nil
+	case *claude.Agent:
+		return agent.ConvertForPlatform(r, platformName), nil
+	case *mcpconfig.Server:
+		return mcp.ConvertForPlatform(r, platformName)
+	default:
+		return nil, errors.Newf("unsupported resource type %T", v)
+	}
+}
+
+// canonicalize converts a platform-specific resource back to its canonical
+// form, so that it can be compared with the value it was converted from.
+func canonicalize(t resource.ResourceType, v any) (any, error) {
+	switch t {
+	case resource.TypeSkill:
+		return cli.CanonicalSkill(v)
+	case resource.TypeCommand:
+		return cli.CanonicalCommand(v)
+	case resource.TypeAgent:
+		return cli.CanonicalAgent(v)
+	case resource.TypeMCP:
+		return cli.CanonicalMCP(v)
+	default:
+		return nil, errors.Newf("unknown resource type %q", t)
+	}
+}
+
+// droppedFields returns the names of the fields that are set in want but
+// differ in got, which must be a pointer to the same struct type. Fields
+// are named by their yaml or json key.
+func droppedFields(want, got any) []string {
+	wv, gv := reflect.ValueOf(want).Elem(), reflect.ValueOf(got).Elem()
+
+	var fields []string
+	for i := range wv.NumField() {
+		f := wv.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		w := wv.Field(i)
+		if isEmptyValue(w) || reflect.DeepEqual(w.Interface(), gv.Field(i).Interface()) {
+			continue
+		}
+		fields = append(fields, fieldKey(f))
+	}
+	return fields
+}
+
+// isEmptyValue reports whether v is the zero value or an empty collection.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// fieldKey returns the name a user writes for a struct field: its yaml key,
+// else its json key, else its lowercased Go name.
+func fieldKey(f reflect.StructField) string {
+	for _, tag := range []string{"yaml", "json"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(f.Name)
+}
+
+// printLossy lists the fields each pending change cannot carry over.
+func printLossy(w io.Writer, plan *manifest.Plan, state *syncState) {
+	header := false
+	for _, c := range plan.Pending() {
+		fields := state.lossy[syncKey(c.Platform, c.Type, c.Name)]
+		if len(fields) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintln(w, "Lossy conversions:")
+			header = true
+		}
+		fmt.Fprintf(w, "  %s %s -> %s: %s\n", c.Type, c.Name, c.Platform, strings.Join(fields, ", "))
+	}
+}
+
+// executeSync installs the converted resource for every pending change. If
+// any change fails, every platform is rolled back.
+func executeSync(w io.Writer, plan *manifest.Plan, state *syncState) error {
+	applied := 0
+	tx := backup.NewTransaction()
+	for _, c := range plan.Pending() {
+		p := state.targets[c.Platform]
+		if err := cli.BeginChange(tx, p, c.Type, c.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before sync", p.DisplayName()))
+		}
+
+		fmt.Fprintf(w, "Syncing %s '%s' to %s... ", c.Type, c.Name, p.DisplayName())
+		if err := installConverted(p, c.Type, state.converted[syncKey(c.Platform, c.Type, c.Name)]); err != nil {
+			if errors.Is(err, errors.ErrNotSupported) {
+				fmt.Fprintln(w, "skipped (not supported)")
+				continue
+			}
+			fmt.Fprintln(w, "failed")
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "syncing %s %q to %s", c.Type, c.Name, p.DisplayName()))
+		}
+		fmt.Fprintln(w, "done")
+		applied++
+	}
+
+	fmt.Fprintf(w, "[OK] Synced %d change(s)\n", applied)
+	return nil
+}
+
+// installConverted installs a platform-specific resource, replacing any
+// existing resource of the same name.
+func installConverted(p cli.Platform, t resource.ResourceType, v any) error {
+	switch t {
+	case resource.TypeSkill:
+		return p.InstallSkill(v)
+	case resource.TypeCommand:
+		return p.InstallCommand(v)
+	case resource.TypeAgent:
+		return p.InstallAgent(v)
+	case resource.TypeMCP:
+		return p.AddMCP(v)
+	default:
+		return errors.Newf("unknown resource type %q", t)
+	}
+}
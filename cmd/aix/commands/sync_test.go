@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/internal/secret"
+)
+
+func TestRunSync_ClaudeToOpenCode(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	skillDir := filepath.Join(tmp, ".claude", "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: review\ndescription: Reviews code\n---\n\nReview the diff.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mcpJSON := `{"mcpServers": {"github": {"command": "npx", "args": ["-y", "gh"], "platforms": ["darwin"]}}}`
+	if err := os.WriteFile(filepath.Join(tmp, ".claude", ".mcp.json"), []byte(mcpJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldScope, oldRoot := flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFrom, oldTo, oldTypes, oldDryRun := syncFrom, syncTo, syncTypes, syncDryRun
+	t.Cleanup(func() {
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		syncFrom, syncTo, syncTypes, syncDryRun = oldFrom, oldTo, oldTypes, oldDryRun
+		backup.ResetBackupState()
+	})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	syncFrom = "claude"
+	syncTo = []string{"opencode"}
+	syncTypes = []string{"mcp", "skills"}
+	backup.ResetBackupState()
+
+	syncDryRun = true
+	var buf bytes.Buffer
+	if err := runSyncWithWriter(&buf); err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "2 to install") {
+		t.Errorf("dry run output missing install count:\n%s", out)
+	}
+	if !strings.Contains(out, "mcp github -> opencode: platforms") {
+		t.Errorf("dry run output missing lossy platforms field:\n%s", out)
+	}
+	openCodeConfig := filepath.Join(tmp, "opencode.json")
+	if _, err := os.Stat(openCodeConfig); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote %s", openCodeConfig)
+	}
+
+	syncDryRun = false
+	buf.Reset()
+	if err := runSyncWithWriter(&buf); err != nil {
+		t.Fatalf("sync error = %v\n%s", err, buf.String())
+	}
+
+	data, err := os.ReadFile(openCodeConfig)
+	if err != nil {
+		t.Fatalf("reading OpenCode config: %v\n%s", err, buf.String())
+	}
+	var cfg struct {
+		MCP map[string]struct {
+			Command []string `json:"command"`
+			Type    string   `json:"type"`
+		} `json:"mcp"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	github := cfg.MCP["github"]
+	if github.Type != "local" || !slices.Equal(github.Command, []string{"npx", "-y", "gh"}) {
+		t.Errorf("github = %+v, want local [npx -y gh]", github)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "skills", "review", "SKILL.md")); err != nil {
+		t.Errorf("skill not synced: %v", err)
+	}
+
+	buf.Reset()
+	if err := runSyncWithWriter(&buf); err != nil {
+		t.Fatalf("second sync error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Everything is up to date.") {
+		t.Errorf("second sync should be a no-op:\n%s", buf.String())
+	}
+}
+
+func TestRunSync_RefusesCommandReferences(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	// A project's .mcp.json is not aix's own configuration, so the command
+	// must not run when the server is written to OpenCode
+	marker := filepath.Join(tmp, "ran")
+	mcpJSON := `{"mcpServers": {"github": {"command": "npx", "env": {"TOKEN": "${cmd:touch ` + marker + `}"}}}}`
+	if err := os.MkdirAll(filepath.Join(tmp, ".claude"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, ".claude", ".mcp.json"), []byte(mcpJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldScope, oldRoot := flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFrom, oldTo, oldTypes, oldDryRun := syncFrom, syncTo, syncTypes, syncDryRun
+	t.Cleanup(func() {
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		syncFrom, syncTo, syncTypes, syncDryRun = oldFrom, oldTo, oldTypes, oldDryRun
+		backup.ResetBackupState()
+	})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	syncFrom = "claude"
+	syncTo = []string{"opencode"}
+	syncTypes = []string{"mcp"}
+	syncDryRun = false
+	backup.ResetBackupState()
+
+	var buf bytes.Buffer
+	err := runSyncWithWriter(&buf)
+	if !errors.Is(err, secret.ErrUntrustedCommand) {
+		t.Fatalf("runSyncWithWriter() error = %v, want ErrUntrustedCommand", err)
+	}
+	if !strings.Contains(err.Error(), `server "github" env: TOKEN references`) {
+		t.Errorf("error = %v, want the server and key named", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("the referenced command was run, stat error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "opencode.json")); !os.IsNotExist(err) {
+		t.Errorf("sync wrote the OpenCode config, stat error = %v", err)
+	}
+
+	// With --allow-cmd-secrets the user vouches for the project
+	t.Cleanup(func() { flags.SetAllowCmdSecretsFlag(false) })
+	flags.SetAllowCmdSecretsFlag(true)
+	if err := runSyncWithWriter(&buf); err != nil {
+		t.Fatalf("runSyncWithWriter() with --allow-cmd-secrets error = %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("the referenced command was not run: %v", err)
+	}
+}
+
+func TestParseResourceTypes(t *testing.T) {
+	got, err := parseResourceTypes([]string{"MCP", "skills"})
+	if err != nil {
+		t.Fatalf("parseResourceTypes() error = %v", err)
+	}
+	want := []resource.ResourceType{resource.TypeSkill, resource.TypeMCP}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseResourceTypes() = %v, want %v", got, want)
+	}
+
+	if got, _ := parseResourceTypes(nil); len(got) != 4 {
+		t.Errorf("parseResourceTypes(nil) = %v, want all types", got)
+	}
+	if _, err := parseResourceTypes([]string{"plugin"}); err == nil {
+		t.Error("parseResourceTypes() with unknown type should fail")
+	}
+}
+
+func TestRunSync_RollsBackOnFailure(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+
+	skillDir := filepath.Join(tmp, ".claude", "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	skill := "---\nname: review\ndescription: Reviews code\n---\n\nReview the diff.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skill), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A file where Gemini CLI's skill directory belongs makes the install fail.
+	if err := os.MkdirAll(filepath.Join(tmp, ".gemini", "skills"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, ".gemini", "skills", "review"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldScope, oldRoot := flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldFrom, oldTo, oldTypes, oldDryRun := syncFrom, syncTo, syncTypes, syncDryRun
+	t.Cleanup(func() {
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		syncFrom, syncTo, syncTypes, syncDryRun = oldFrom, oldTo, oldTypes, oldDryRun
+		backup.ResetBackupState()
+	})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	syncFrom = "claude"
+	syncTo = []string{"opencode", "gemini"}
+	syncTypes = []string{"skills"}
+	syncDryRun = false
+	backup.ResetBackupState()
+
+	var buf bytes.Buffer
+	err := runSyncWithWriter(&buf)
+	if err == nil {
+		t.Fatalf("sync should fail when Gemini CLI cannot be written\n%s", buf.String())
+	}
+	if !strings.Contains(err.Error(), "rolled back opencode, gemini") {
+		t.Errorf("error = %v, want it to report the rollback", err)
+	}
+	if !strings.Contains(buf.String(), "to OpenCode... done") {
+		t.Fatalf("OpenCode should be synced before Gemini CLI fails:\n%s", buf.String())
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "skills")); !os.IsNotExist(err) {
+		t.Errorf("OpenCode skill should be rolled back, stat error = %v", err)
+	}
+}
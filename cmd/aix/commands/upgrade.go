@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/registry"
+)
+
+var (
+	upgradeAll   bool
+	upgradeForce bool
+	upgradeMerge bool
+)
+
+// errUpgradeSkipped is returned when some resources were not upgraded
+// because they were modified locally or their local edits could not be
+// merged.
+var errUpgradeSkipped = errors.New("some resources were not upgraded")
+
+func init() {
+	upgradeCmd.Flags().BoolVar(&upgradeAll, "all", false,
+		"upgrade every outdated resource")
+	upgradeCmd.Flags().BoolVar(&upgradeForce, "force", false,
+		"overwrite resources that were modified locally")
+	upgradeCmd.Flags().BoolVar(&upgradeMerge, "merge", false,
+		"merge local edits into the new version")
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [name]",
+	Short: "Reinstall resources that changed in their repository",
+	Long: `Reinstall skills, commands, agents, and MCP servers whose source changed in
+their repository since they were installed. See 'aix outdated' for the list.
+
+Each resource is reinstalled from the repository cache on the platforms that
+have it now. Resources that were edited in place since they were installed
+are not overwritten unless --force is given. With --merge, local edits to
+skills, commands, and agents are merged into the new version instead; a
+resource whose edits conflict with upstream changes is left alone. Resources
+that were removed from their repository are left alone too.
+
+Run 'aix repo update' first to fetch the latest changes.`,
+	Example: `  # Upgrade one resource
+  aix upgrade code-review
+
+  # Upgrade everything that has an update
+  aix upgrade --all
+
+  # Keep local edits
+  aix upgrade --all --merge
+
+  # Discard local edits
+  aix upgrade code-review --force
+
+See Also: aix outdated, aix repo update`,
+	Args: func(_ *cobra.Command, args []string) error {
+		if upgradeForce && upgradeMerge {
+			return errors.New("cannot specify both --force and --merge")
+		}
+		if upgradeAll && len(args) > 0 {
+			return errors.New("cannot specify both --all and a resource name")
+		}
+		if !upgradeAll && len(args) != 1 {
+			return errors.New("requires a resource name or --all")
+		}
+		return nil
+	},
+	RunE: runUpgrade,
+}
+
+func runUpgrade(cmd *cobra.Command, args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	return runUpgradeWithWriter(cmd.OutOrStdout(), name)
+}
+
+// runUpgradeWithWriter upgrades the outdated resource called name, or every
+// outdated resource when name is empty.
+func runUpgradeWithWriter(w io.Writer, name string) error {
+	platforms, err := cli.ResolvePlatforms(flags.GetPlatformFlag(), flags.PlatformOptions()...)
+	if err != nil {
+		return errors.Wrap(err, "resolving platforms")
+	}
+	statuses, err := installStatuses(platforms)
+	if err != nil {
+		return err
+	}
+
+	var selected []*registry.Status
+	for _, st := range statuses {
+		if name == "" || st.Entry.Name == name {
+			selected = append(selected, st)
+		}
+	}
+	if name != "" && len(selected) == 0 {
+		return errors.NewUserError(
+			errors.Newf("%q was not installed from a repository", name),
+			"Run 'aix outdated' to list resources that can be upgraded")
+	}
+
+	upgraded, skipped := 0, 0
+	for _, st := range selected {
+		e := st.Entry
+		switch {
+		case st.Source == nil:
+			fmt.Fprintf(w, "Skipping %s %q: it was removed from repository %s\n", e.Type, e.Name, e.Repo)
+			continue
+		case !st.Outdated:
+			if name != "" {
+				fmt.Fprintf(w, "%s %q is up to date\n", e.Type, e.Name)
+			}
+			continue
+		case len(st.Modified) > 0 && !upgradeForce && !upgradeMerge:
+			fmt.Fprintf(w, "Skipping %s %q: modified locally on %s (use --merge to keep the edits or --force to overwrite)\n",
+				e.Type, e.Name, strings.Join(platformNames(st.Modified), ", "))
+			skipped++
+			continue
+		}
+
+		fmt.Fprintf(w, "Upgrading %s %q from %s (%s -> %s)\n",
+			e.Type, e.Name, e.Repo, shortCommit(e.Commit), shortCommit(st.Commit))
+		err := registry.Reinstall(st, upgradeMerge, func(path string, p cli.Platform) error {
+			return installFromSource(w, e.Type, path, []cli.Platform{p})
+		})
+		if errors.Is(err, registry.ErrMergeConflict) || errors.Is(err, registry.ErrNoBase) {
+			fmt.Fprintf(w, "Skipping %s %q: %v\n", e.Type, e.Name, err)
+			skipped++
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "upgrading %s %q", e.Type, e.Name)
+		}
+		upgraded++
+	}
+
+	fmt.Fprintf(w, "[OK] Upgraded %d resource(s)\n", upgraded)
+	if skipped > 0 {
+		return errors.NewUserError(
+			errors.Wrapf(errUpgradeSkipped, "%d modified locally", skipped),
+			"Review the changes with 'aix <type> diff <name>', then run again with --force to overwrite them")
+	}
+	return nil
+}
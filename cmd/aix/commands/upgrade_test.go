@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/cmd/aix/commands/flags"
+	"github.com/thoreinstein/aix/cmd/aix/commands/skill"
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/params"
+	"github.com/thoreinstein/aix/internal/registry"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestOutdatedAndUpgrade(t *testing.T) {
+	tmp := setupUpgradeTest(t)
+	source := installRecordedSkill(t, "Review the diff.")
+
+	var buf bytes.Buffer
+	if err := runOutdatedWithWriter(&buf); err != nil {
+		t.Fatalf("outdated error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "up to date") {
+		t.Errorf("fresh install reported as outdated:\n%s", buf.String())
+	}
+
+	writeSkill(t, source, "Review the diff carefully.")
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	writeSkill(t, installed, "Review the diff, my way.")
+
+	buf.Reset()
+	if err := runOutdatedWithWriter(&buf); err != nil {
+		t.Fatalf("outdated error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "update available; modified on claude") {
+		t.Errorf("outdated output missing modified update:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := runUpgradeWithWriter(&buf, "review"); err == nil {
+		t.Fatal("upgrade overwrote a locally modified skill without --force")
+	}
+	if data, _ := os.ReadFile(installed); !strings.Contains(string(data), "my way") {
+		t.Fatal("refused upgrade still changed the installed skill")
+	}
+
+	upgradeForce = true
+	buf.Reset()
+	if err := runUpgradeWithWriter(&buf, "review"); err != nil {
+		t.Fatalf("upgrade --force error = %v\n%s", err, buf.String())
+	}
+	if data, _ := os.ReadFile(installed); !strings.Contains(string(data), "carefully") {
+		t.Errorf("upgrade did not install the new version:\n%s", data)
+	}
+
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reg.Source(resource.TypeSkill, "review"); got != "official" {
+		t.Errorf("Source() after upgrade = %q, want official", got)
+	}
+	buf.Reset()
+	if err := runOutdatedWithWriter(&buf); err != nil {
+		t.Fatalf("outdated error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "up to date") {
+		t.Errorf("upgraded skill still reported as outdated:\n%s", buf.String())
+	}
+}
+
+func TestUpgrade_Merge(t *testing.T) {
+	tmp := setupUpgradeTest(t)
+	source := installRecordedSkill(t, "Read the diff.\n\nComment on style.\n\nApprove.")
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+
+	writeSkill(t, source, "Read the diff.\n\nComment on style.\n\nApprove or request changes.")
+	writeSkill(t, installed, "Read the whole diff.\n\nComment on style.\n\nApprove.")
+
+	upgradeMerge = true
+	var buf bytes.Buffer
+	if err := runUpgradeWithWriter(&buf, "review"); err != nil {
+		t.Fatalf("upgrade --merge error = %v\n%s", err, buf.String())
+	}
+	data, err := os.ReadFile(installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Read the whole diff.") || !strings.Contains(string(data), "request changes") {
+		t.Errorf("upgrade --merge did not keep both changes:\n%s", data)
+	}
+
+	// The merged edits are still local modifications.
+	buf.Reset()
+	if err := runOutdatedWithWriter(&buf); err != nil {
+		t.Fatalf("outdated error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "up to date") {
+		t.Errorf("merged skill reported as outdated:\n%s", buf.String())
+	}
+	st := installStatus(t, "review")
+	if len(st.Modified) != 1 {
+		t.Errorf("merged skill Modified = %v, want claude", st.Modified)
+	}
+
+	// Conflicting edits leave the installed copy alone.
+	writeSkill(t, source, "Read the diff.\n\nComment on tests.\n\nApprove or request changes.")
+	writeSkill(t, installed, "Read the whole diff.\n\nComment on naming.\n\nApprove or request changes.")
+	before, _ := os.ReadFile(installed)
+	buf.Reset()
+	if err := runUpgradeWithWriter(&buf, "review"); err == nil {
+		t.Fatal("upgrade --merge with conflicting edits succeeded")
+	}
+	if !strings.Contains(buf.String(), "conflicting change") {
+		t.Errorf("upgrade output does not mention the conflict:\n%s", buf.String())
+	}
+	if after, _ := os.ReadFile(installed); string(after) != string(before) {
+		t.Errorf("conflicting merge changed the installed skill:\n%s", after)
+	}
+}
+
+// setupUpgradeTest points aix at a temporary configuration, cache, and
+// Claude Code project, and returns the project root.
+func setupUpgradeTest(t *testing.T) string {
+	t.Helper()
+	tmp := t.TempDir()
+	t.Setenv("HOME", filepath.Join(tmp, "home"))
+	t.Setenv("AIX_CONFIG_DIR", filepath.Join(tmp, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tmp, "cache"))
+
+	oldPlatforms, oldScope, oldRoot := flags.GetPlatformFlag(), flags.GetScopeFlag(), flags.GetProjectRootFlag()
+	oldForce, oldMerge := upgradeForce, upgradeMerge
+	t.Cleanup(func() {
+		flags.SetPlatformFlag(oldPlatforms)
+		flags.SetScopeFlag(oldScope)
+		flags.SetProjectRootFlag(oldRoot)
+		upgradeForce, upgradeMerge = oldForce, oldMerge
+		backup.ResetBackupState()
+	})
+	flags.SetPlatformFlag([]string{"claude"})
+	flags.SetScopeFlag(cli.ScopeProject)
+	flags.SetProjectRootFlag(tmp)
+	upgradeForce, upgradeMerge = false, false
+	backup.ResetBackupState()
+	return tmp
+}
+
+// installRecordedSkill installs a "review" skill with body from repository
+// "official" as aix skill install would, and returns its source file.
+func installRecordedSkill(t *testing.T, body string) string {
+	t.Helper()
+	res := resource.Resource{Name: "review", Type: resource.TypeSkill, RepoName: "official", Path: "skills/review"}
+	source := filepath.Join(res.SourcePath(), "SKILL.md")
+	writeSkill(t, source, body)
+
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := skill.Install(io.Discard, res.SourcePath(), platforms, true); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if err := registry.Record(res, platforms, nil); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	return source
+}
+
+// installStatus returns the install status of the skill called name.
+func installStatus(t *testing.T, name string) *registry.Status {
+	t.Helper()
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := installStatuses(platforms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range statuses {
+		if st.Entry.Name == name {
+			return st
+		}
+	}
+	t.Fatalf("no install status for %q", name)
+	return nil
+}
+
+// writeSkill writes a "review" skill with body to path.
+func writeSkill(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: review\ndescription: Reviews code\n---\n\n" + body + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpgrade_RerendersParameters(t *testing.T) {
+	tmp := setupUpgradeTest(t)
+	res := resource.Resource{Name: "review", Type: resource.TypeSkill, RepoName: "official", Path: "skills/review"}
+	source := filepath.Join(res.SourcePath(), "SKILL.md")
+	writeTemplated := func(body string) {
+		t.Helper()
+		content := "---\nname: review\ndescription: Reviews {{ .Params.org }} code\nparameters:\n  - name: org\n    required: true\n---\n\n" + body + "\n"
+		if err := os.MkdirAll(filepath.Dir(source), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(source, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTemplated("Follow the {{ .Params.org }} style guide.")
+
+	values := map[string]string{"org": "acme"}
+	rendered, cleanup, err := params.Render(resource.TypeSkill, res.SourcePath(), values)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	defer cleanup()
+	platforms, err := flags.ResolvePlatforms()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := skill.Install(io.Discard, rendered, platforms, true); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if err := registry.Record(res, platforms, values); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if st := installStatus(t, "review"); len(st.Modified) != 0 || st.Outdated {
+		t.Fatalf("fresh parameterized install: Modified = %v, Outdated = %v", st.Modified, st.Outdated)
+	}
+
+	writeTemplated("Follow the {{ .Params.org }} style guide strictly.")
+	var buf bytes.Buffer
+	if err := runUpgradeWithWriter(&buf, "review"); err != nil {
+		t.Fatalf("upgrade error = %v\n%s", err, buf.String())
+	}
+	data, err := os.ReadFile(filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Follow the acme style guide strictly.") || !strings.Contains(string(data), "Reviews acme code") {
+		t.Errorf("upgrade did not re-render with the recorded values:\n%s", data)
+	}
+	if st := installStatus(t, "review"); len(st.Modified) != 0 {
+		t.Errorf("re-rendered skill reported as modified on %v", st.Modified)
+	}
+}
+
+func TestUpgrade_RollsBackEveryPlatform(t *testing.T) {
+	setupUpgradeTest(t)
+	flags.SetPlatformFlag([]string{"claude", "opencode"})
+	source := installRecordedSkill(t, "Review the diff.")
+	writeSkill(t, source, "Review the diff carefully.")
+
+	st := installStatus(t, "review")
+	if len(st.Installed) != 2 {
+		t.Fatalf("Installed = %v, want claude and opencode", st.Installed)
+	}
+	before := st.Entry
+
+	// The first platform is upgraded, then the second fails
+	err := registry.Reinstall(st, false, func(path string, p cli.Platform) error {
+		if p.Name() != st.Installed[0].Name() {
+			return errors.New("disk full")
+		}
+		_, err := skill.Install(io.Discard, path, []cli.Platform{p}, true)
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("Reinstall() error = %v, want the second platform's failure", err)
+	}
+
+	for _, p := range st.Installed {
+		path := filepath.Join(p.SkillDir(), "review", "SKILL.md")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "carefully") {
+			t.Errorf("%s was not rolled back:\n%s", path, data)
+		}
+	}
+	reg, err := registry.Load(registry.DefaultPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := reg.Find(resource.TypeSkill, "review"); e == nil || e.Commit != before.Commit || !maps.Equal(e.Platforms, before.Platforms) {
+		t.Errorf("registry entry changed by a failed reinstall: %+v, was %+v", e, before)
+	}
+}
+
+func TestStatus_DetectsFrontmatterEdits(t *testing.T) {
+	tmp := setupUpgradeTest(t)
+	installRecordedSkill(t, "Review the diff.")
+
+	installed := filepath.Join(tmp, ".claude", "skills", "review", "SKILL.md")
+	content := "---\nname: review\ndescription: Reviews code\nallowed-tools: Read Grep\n---\n\nReview the diff.\n"
+	if err := os.WriteFile(installed, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	st := installStatus(t, "review")
+	if len(st.Modified) != 1 {
+		t.Errorf("Modified = %v after editing allowed-tools, want claude", st.Modified)
+	}
+
+	diffs, err := registry.Diff(resource.TypeSkill, "review", st.Installed)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if len(diffs) != 1 || diffs[0].Diff != "" || !diffs[0].Modified {
+		t.Errorf("Diff() = %+v, want no text diff but modified", diffs)
+	}
+}
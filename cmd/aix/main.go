@@ -10,6 +10,8 @@ import (
 	"github.com/thoreinstein/aix/cmd/aix/commands"
 	"github.com/thoreinstein/aix/internal/config"
 	aixerrors "github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
+	"github.com/thoreinstein/aix/pkg/fileutil"
 )
 
 func main() {
@@ -21,6 +23,15 @@ func main() {
 
 func handleError(err error) {
 	var exitErr *aixerrors.ExitError
+	if errors.Is(err, fileutil.ErrLocked) && !errors.As(err, &exitErr) {
+		err = aixerrors.NewUserError(err, "Wait for the other aix command to finish, then try again.")
+	}
+	if errors.Is(err, fileutil.ErrConflict) && !errors.As(err, &exitErr) {
+		err = aixerrors.NewUserError(err, "Another program is rewriting this file; close it or wait, then try again.")
+	}
+	if errors.Is(err, secret.ErrUntrustedCommand) && !errors.As(err, &exitErr) {
+		err = aixerrors.NewUserError(err, "Pass --allow-cmd-secrets to run it if you trust the file it came from.")
+	}
 	if errors.As(err, &exitErr) {
 		prefix := "Error:"
 		if exitErr.Code == aixerrors.ExitSystem {
@@ -78,8 +78,10 @@ func (m *Manager) Backup(platform string, paths []string) (*BackupManifest, erro
 		return nil, errors.Wrap(err, "creating backup directory")
 	}
 
-	// Track backed up files
+	// Track backed up files, and the directories and missing paths that
+	// exact restores need
 	var files []BackupFile
+	var dirs, absent []string
 
 	// Back up each path
 	for _, p := range paths {
@@ -90,6 +92,7 @@ func (m *Manager) Backup(platform string, paths []string) (*BackupManifest, erro
 		if err != nil {
 			if os.IsNotExist(err) {
 				// Skip non-existent paths
+				absent = append(absent, expanded)
 				continue
 			}
 			return nil, errors.Wrapf(err, "stat %s", p)
@@ -102,6 +105,7 @@ func (m *Manager) Backup(platform string, paths []string) (*BackupManifest, erro
 				return nil, errors.Wrapf(err, "backing up directory %s", p)
 			}
 			files = append(files, dirFiles...)
+			dirs = append(dirs, expanded)
 		} else {
 			// Back up single file
 			bf, err := m.backupFile(expanded, backupPath)
@@ -115,7 +119,7 @@ func (m *Manager) Backup(platform string, paths []string) (*BackupManifest, erro
 	if len(files) == 0 {
 		// Clean up empty backup directory
 		os.RemoveAll(backupPath)
-		return nil, errors.New("no files to back up")
+		return nil, ErrNothingToBackUp
 	}
 
 	// Create manifest
@@ -124,6 +128,8 @@ func (m *Manager) Backup(platform string, paths []string) (*BackupManifest, erro
 		CreatedAt:  time.Now().UTC(),
 		Platform:   platform,
 		Files:      files,
+		Dirs:       dirs,
+		Absent:     absent,
 		AIXVersion: Version,
 		ID:         backupID,
 	}
@@ -203,14 +209,93 @@ func (m *Manager) Restore(platform, backupID string) error {
 		return err
 	}
 
-	backupPath := m.backupPath(platform, backupID)
+	return restoreFiles(m.backupPath(platform, backupID), manifest.Files)
+}
+
+// RestoreExact restores a backup and removes files added since it was
+// taken, so that the backed-up paths match the backup exactly. A safety
+// backup of the current state is created first and returned; it is nil if
+// there was nothing to back up.
+//
+// Returns ErrExactUnsupported for backups whose manifests do not record the
+// backed-up directories.
+func (m *Manager) RestoreExact(platform, backupID string) (*BackupManifest, error) {
+	if platform == "" {
+		return nil, errors.New("platform is required")
+	}
+	if backupID == "" {
+		return nil, errors.New("backup ID is required")
+	}
+
+	manifest, err := m.Get(platform, backupID)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Version < 2 {
+		return nil, errors.Wrapf(ErrExactUnsupported, "backup %s has manifest version %d", backupID, manifest.Version)
+	}
+
+	if err := verifyFiles(m.backupPath(platform, backupID), manifest.Files); err != nil {
+		return nil, err
+	}
+
+	safety, err := m.Backup(platform, manifestPaths(manifest))
+	if err != nil && !errors.Is(err, ErrNothingToBackUp) {
+		return nil, errors.Wrap(err, "creating safety backup")
+	}
+	return safety, m.restoreExact(platform, manifest)
+}
+
+// restoreExact makes the paths manifest covers match it: paths that were
+// absent and files added since are removed, and the backed-up files are
+// restored.
+func (m *Manager) restoreExact(platform string, manifest *BackupManifest) error {
+	changes, err := m.diff(platform, manifest)
+	if err != nil {
+		return err
+	}
+
+	// Paths that did not exist are removed whole, directories included.
+	for _, p := range manifest.Absent {
+		if err := os.RemoveAll(p); err != nil {
+			return errors.Wrapf(err, "removing %s", p)
+		}
+	}
+	for _, c := range changes {
+		if c.Kind != ChangeAdded {
+			continue
+		}
+		if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "removing %s", c.Path)
+		}
+		removeEmptyParents(filepath.Dir(c.Path), manifest.Dirs)
+	}
+
+	return restoreFiles(m.backupPath(platform, manifest.ID), manifest.Files)
+}
+
+// verifyFiles checks every stored file against its recorded hash.
+func verifyFiles(backupPath string, files []BackupFile) error {
+	for _, bf := range files {
+		hash, err := HashFile(filepath.Join(backupPath, bf.RelPath))
+		if err != nil {
+			return errors.Wrapf(err, "reading backup file %s", bf.RelPath)
+		}
+		if hash != bf.SHA256Hash {
+			return errors.Wrapf(ErrBackupCorrupted, "file %s hash mismatch", bf.RelPath)
+		}
+	}
+	return nil
+}
 
-	// Restore each file
-	for _, bf := range manifest.Files {
+// restoreFiles copies files from the backup at backupPath to their
+// original locations.
+func restoreFiles(backupPath string, files []BackupFile) error {
+	for _, bf := range files {
 		srcPath := filepath.Join(backupPath, bf.RelPath)
 
 		// Verify integrity before restoring
-		hash, err := hashFile(srcPath)
+		hash, err := HashFile(srcPath)
 		if err != nil {
 			return errors.Wrapf(err, "reading backup file %s", bf.RelPath)
 		}
@@ -353,8 +438,8 @@ func (m *Manager) platformBackupDir(platform string) string {
 	return filepath.Join(m.rootDir, platform)
 }
 
-// hashFile computes the SHA256 hash of a file.
-func hashFile(path string) (string, error) {
+// HashFile computes the SHA256 hash of a file, hex-encoded.
+func HashFile(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return "", errors.Wrap(err, "opening file")
@@ -209,6 +209,28 @@ func TestManager_Get_Errors(t *testing.T) {
 	}
 }
 
+func TestManager_Backup_NothingToBackUp(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(WithBackupDir(t.TempDir()))
+
+	_, err := m.Backup("platform1", []string{filepath.Join(t.TempDir(), "missing")})
+	if !errors.Is(err, ErrNothingToBackUp) {
+		t.Errorf("expected ErrNothingToBackUp, got %v", err)
+	}
+}
+
+func TestEnsureBackedUp_NothingToBackUp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ResetBackupState()
+	defer ResetBackupState()
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	if err := EnsureBackedUp("fresh-platform", []string{missing}); err != nil {
+		t.Errorf("EnsureBackedUp() with no existing files = %v, want nil", err)
+	}
+}
+
 func TestBackup_Collision(t *testing.T) {
 	// Create a temporary directory for backups
 	backupDir := t.TempDir()
@@ -0,0 +1,377 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// bundleIndexName is the name of the bundle's table of contents.
+const bundleIndexName = "bundle.json"
+
+// Export writes backups to w as a gzip-compressed tar bundle. Each backup
+// is stored under {platform}/{id}/ with its manifest, and paths under the
+// home directory are recorded relative to it so the bundle can be imported
+// on a machine with a different home directory.
+func (m *Manager) Export(w io.Writer, backups []BackupManifest) (*Bundle, error) {
+	if len(backups) == 0 {
+		return nil, errors.New("at least one backup is required")
+	}
+
+	bundle := &Bundle{
+		Version:    BundleVersion,
+		CreatedAt:  time.Now().UTC(),
+		AIXVersion: Version,
+	}
+	manifests := make([][]byte, len(backups))
+	for i, b := range backups {
+		if err := verifyFiles(m.backupPath(b.Platform, b.ID), b.Files); err != nil {
+			return nil, errors.Wrapf(err, "verifying backup %s", b.ID)
+		}
+		data, err := json.MarshalIndent(portableManifest(b), "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "encoding manifest")
+		}
+		sum := sha256.Sum256(data)
+		manifests[i] = data
+		bundle.Backups = append(bundle.Backups, BundleEntry{
+			Platform:     b.Platform,
+			ID:           b.ID,
+			ManifestHash: hex.EncodeToString(sum[:]),
+		})
+	}
+	index, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding bundle index")
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeTarFile(tw, bundleIndexName, index); err != nil {
+		return nil, err
+	}
+	for i, b := range backups {
+		prefix := b.Platform + "/" + b.ID + "/"
+		if err := writeTarFile(tw, prefix+"manifest.json", manifests[i]); err != nil {
+			return nil, err
+		}
+		for _, bf := range b.Files {
+			data, err := os.ReadFile(filepath.Join(m.backupPath(b.Platform, b.ID), bf.RelPath))
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading backup file %s", bf.RelPath)
+			}
+			if err := writeTarFile(tw, prefix+filepath.ToSlash(bf.RelPath), data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "finishing archive")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "finishing compression")
+	}
+	return bundle, nil
+}
+
+// PathsFunc returns the paths a platform's backups may cover, normally the
+// platform's backup paths on this machine.
+type PathsFunc func(platform string) ([]string, error)
+
+// Import reads a bundle written by Export and adds its backups to the
+// backup directory, rewriting home-relative paths for this machine. Every
+// file is checked against its manifest before anything is added. Backups
+// that already exist are left as they are.
+//
+// A bundle is not trusted to say where its files go: every path a backup
+// restores to or removes must be inside the paths allowed returns for its
+// platform.
+//
+// Returns ErrInvalidBundle if the bundle is malformed, a checksum fails or
+// a backup names a path outside its platform.
+func (m *Manager) Import(r io.Reader, allowed PathsFunc) (*Bundle, []BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, errors.Wrapf(ErrInvalidBundle, "decompressing: %v", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	bundle, err := readBundleIndex(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := os.MkdirAll(m.rootDir, 0o700); err != nil {
+		return nil, nil, errors.Wrap(err, "creating backup directory")
+	}
+	staging, err := os.MkdirTemp(m.rootDir, ".import-")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating staging directory")
+	}
+	defer os.RemoveAll(staging)
+
+	if err := extractBundle(tr, bundle, staging); err != nil {
+		return nil, nil, err
+	}
+
+	manifests := make([]BackupManifest, 0, len(bundle.Backups))
+	for _, e := range bundle.Backups {
+		roots, err := allowed(e.Platform)
+		if err != nil {
+			return nil, nil, errors.Wrapf(ErrInvalidBundle, "backup %s: %v", e.ID, err)
+		}
+		manifest, err := prepareBackup(staging, e, roots)
+		if err != nil {
+			return nil, nil, err
+		}
+		manifests = append(manifests, *manifest)
+	}
+	for i, e := range bundle.Backups {
+		dst := m.backupPath(e.Platform, e.ID)
+		if _, err := os.Stat(dst); err == nil {
+			existing, err := m.Get(e.Platform, e.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			manifests[i] = *existing
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+			return nil, nil, errors.Wrap(err, "creating backup directory")
+		}
+		if err := os.Rename(filepath.Join(staging, e.Platform, e.ID), dst); err != nil {
+			return nil, nil, errors.Wrapf(err, "adding backup %s", e.ID)
+		}
+	}
+	return bundle, manifests, nil
+}
+
+// readBundleIndex reads and validates the bundle's first entry.
+func readBundleIndex(tr *tar.Reader) (*Bundle, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "reading archive: %v", err)
+	}
+	if hdr.Name != bundleIndexName {
+		return nil, errors.Wrapf(ErrInvalidBundle, "first entry is %q, not %s", hdr.Name, bundleIndexName)
+	}
+	var bundle Bundle
+	if err := json.NewDecoder(tr).Decode(&bundle); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "parsing %s: %v", bundleIndexName, err)
+	}
+	if bundle.Version < 1 || bundle.Version > BundleVersion {
+		return nil, errors.Wrapf(ErrInvalidBundle, "unsupported bundle version %d", bundle.Version)
+	}
+	for _, e := range bundle.Backups {
+		if !isPlainName(e.Platform) || !isPlainName(e.ID) {
+			return nil, errors.Wrapf(ErrInvalidBundle, "invalid backup %q/%q", e.Platform, e.ID)
+		}
+	}
+	return &bundle, nil
+}
+
+// extractBundle writes the bundle's remaining entries under staging. Only
+// regular files inside a listed backup are accepted.
+func extractBundle(tr *tar.Reader, bundle *Bundle, staging string) error {
+	known := make(map[string]bool, len(bundle.Backups))
+	for _, e := range bundle.Backups {
+		known[e.Platform+"/"+e.ID] = true
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(ErrInvalidBundle, "reading archive: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return errors.Wrapf(ErrInvalidBundle, "entry %q is not a regular file", hdr.Name)
+		}
+		parts := strings.SplitN(hdr.Name, "/", 3)
+		if len(parts) != 3 || !known[parts[0]+"/"+parts[1]] ||
+			path.Clean(parts[2]) != parts[2] || containsPathTraversal(parts[2]) || path.IsAbs(parts[2]) {
+			return errors.Wrapf(ErrInvalidBundle, "unexpected entry %q", hdr.Name)
+		}
+
+		dst := filepath.Join(staging, parts[0], parts[1], filepath.FromSlash(parts[2]))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+			return errors.Wrap(err, "creating staging directory")
+		}
+		f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return errors.Wrapf(err, "extracting %s", hdr.Name)
+		}
+		_, err = io.Copy(f, tr)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return errors.Wrapf(err, "extracting %s", hdr.Name)
+		}
+	}
+}
+
+// prepareBackup verifies a staged backup and rewrites its manifest for this
+// machine. Its paths must be inside roots.
+func prepareBackup(staging string, e BundleEntry, roots []string) (*BackupManifest, error) {
+	src := filepath.Join(staging, e.Platform, e.ID)
+	manifestPath := filepath.Join(src, "manifest.json")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s has no manifest", e.ID)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != e.ManifestHash {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s manifest hash mismatch", e.ID)
+	}
+
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "parsing manifest of backup %s: %v", e.ID, err)
+	}
+	for _, bf := range manifest.Files {
+		if containsPathTraversal(bf.RelPath) || filepath.IsAbs(bf.RelPath) {
+			return nil, errors.Wrapf(ErrInvalidBundle, "backup %s has invalid file %q", e.ID, bf.RelPath)
+		}
+	}
+	if err := verifyFiles(src, manifest.Files); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s: %v", e.ID, err)
+	}
+	if err := localizeManifest(&manifest); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s: %v", e.ID, err)
+	}
+	if manifest.Platform != e.Platform {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s is listed for %s but is a %s backup", e.ID, e.Platform, manifest.Platform)
+	}
+	if err := checkPaths(&manifest, roots); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBundle, "backup %s: %v", e.ID, err)
+	}
+	manifest.ID = e.ID
+
+	if err := fileutil.AtomicWriteJSONWithPerm(manifestPath, &manifest, 0o600); err != nil {
+		return nil, errors.Wrap(err, "writing manifest")
+	}
+	return &manifest, nil
+}
+
+// portableManifest returns a copy of manifest with paths under the home
+// directory written as ~/relative/path.
+func portableManifest(manifest BackupManifest) BackupManifest {
+	home, _ := os.UserHomeDir()
+	portable := func(p string) string {
+		if home == "" || !within(p, home) {
+			return p
+		}
+		rel, _ := filepath.Rel(home, p)
+		if rel == "." {
+			return "~"
+		}
+		return "~/" + filepath.ToSlash(rel)
+	}
+
+	manifest.Files = append([]BackupFile(nil), manifest.Files...)
+	for i := range manifest.Files {
+		manifest.Files[i].OriginalPath = portable(manifest.Files[i].OriginalPath)
+	}
+	manifest.Dirs = mapPaths(manifest.Dirs, portable)
+	manifest.Absent = mapPaths(manifest.Absent, portable)
+	return manifest
+}
+
+// localizeManifest expands home-relative paths written by portableManifest.
+func localizeManifest(manifest *BackupManifest) error {
+	var err error
+	local := func(p string) string {
+		if !strings.HasPrefix(p, "~") {
+			return p
+		}
+		expanded := expandHome(p)
+		if strings.HasPrefix(expanded, "~") && err == nil {
+			err = errors.Newf("cannot resolve path %q", p)
+		}
+		return expanded
+	}
+
+	for i := range manifest.Files {
+		manifest.Files[i].OriginalPath = local(manifest.Files[i].OriginalPath)
+	}
+	manifest.Dirs = mapPaths(manifest.Dirs, local)
+	manifest.Absent = mapPaths(manifest.Absent, local)
+	return err
+}
+
+// checkPaths returns an error if a path manifest restores to or removes is
+// not inside one of roots.
+func checkPaths(manifest *BackupManifest, roots []string) error {
+	roots = mapPaths(roots, expandHome)
+	check := func(p string) error {
+		if filepath.IsAbs(p) && slices.ContainsFunc(roots, func(root string) bool {
+			return filepath.IsAbs(root) && within(p, root)
+		}) {
+			return nil
+		}
+		return errors.Newf("path %q is outside the %s configuration", p, manifest.Platform)
+	}
+
+	for _, bf := range manifest.Files {
+		if err := check(bf.OriginalPath); err != nil {
+			return err
+		}
+	}
+	for _, p := range slices.Concat(manifest.Dirs, manifest.Absent) {
+		if err := check(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapPaths returns fn applied to each of paths.
+func mapPaths(paths []string, fn func(string) string) []string {
+	if paths == nil {
+		return nil
+	}
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = fn(p)
+	}
+	return out
+}
+
+// writeTarFile adds a regular file to tw.
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+		Format:  tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Wrapf(err, "writing %s", name)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrapf(err, "writing %s", name)
+	}
+	return nil
+}
+
+// isPlainName reports whether name is usable as a single path component.
+func isPlainName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\:`)
+}
@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// exportTestBundle backs up a file and a directory under a temporary home
+// and exports them.
+func exportTestBundle(t *testing.T) []byte {
+	t.Helper()
+
+	oldHome := t.TempDir()
+	t.Setenv("HOME", oldHome)
+	writeFile(t, filepath.Join(oldHome, ".claude.json"), `{"mcpServers":{}}`)
+	writeFile(t, filepath.Join(oldHome, ".claude", "skills", "review", "SKILL.md"), "original skill")
+
+	m := NewManager(WithBackupDir(t.TempDir()))
+	manifest, err := m.Backup("claude", []string{
+		filepath.Join(oldHome, ".claude.json"),
+		filepath.Join(oldHome, ".claude"),
+	})
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	bundle, err := m.Export(&buf, []BackupManifest{*manifest})
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(bundle.Backups) != 1 || bundle.Backups[0].Platform != "claude" {
+		t.Fatalf("Export() bundle = %+v", bundle)
+	}
+	return buf.Bytes()
+}
+
+// claudePaths allows the paths exportTestBundle backs up, under the
+// current home directory.
+func claudePaths(platform string) ([]string, error) {
+	if platform != "claude" {
+		return nil, errors.Newf("unknown platform %q", platform)
+	}
+	return []string{"~/.claude.json", "~/.claude"}, nil
+}
+
+func TestManager_ExportImport(t *testing.T) {
+	data := exportTestBundle(t)
+
+	newHome := t.TempDir()
+	t.Setenv("HOME", newHome)
+	m := NewManager(WithBackupDir(t.TempDir()))
+
+	_, manifests, err := m.Import(bytes.NewReader(data), claudePaths)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if len(manifests) != 1 {
+		t.Fatalf("Import() returned %d manifests, want 1", len(manifests))
+	}
+	for _, bf := range manifests[0].Files {
+		if !strings.HasPrefix(bf.OriginalPath, newHome) {
+			t.Errorf("OriginalPath = %q, want it under the new home %q", bf.OriginalPath, newHome)
+		}
+	}
+	if want := []string{filepath.Join(newHome, ".claude")}; len(manifests[0].Dirs) != 1 || manifests[0].Dirs[0] != want[0] {
+		t.Errorf("Dirs = %v, want %v", manifests[0].Dirs, want)
+	}
+
+	if err := m.Restore("claude", manifests[0].ID); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(newHome, ".claude", "skills", "review", "SKILL.md"))
+	if err != nil {
+		t.Fatalf("reading restored skill: %v", err)
+	}
+	if string(got) != "original skill" {
+		t.Errorf("restored skill = %q, want %q", got, "original skill")
+	}
+
+	// Importing the same bundle again leaves the backup in place.
+	if _, _, err := m.Import(bytes.NewReader(data), claudePaths); err != nil {
+		t.Errorf("second Import failed: %v", err)
+	}
+}
+
+func TestManager_Import_Tampered(t *testing.T) {
+	data := exportTestBundle(t)
+
+	// Alter a file's contents without changing its length, so the archive
+	// itself stays readable.
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw = bytes.Replace(raw, []byte("original skill"), []byte("tampered skill"), 1)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backupDir := t.TempDir()
+	m := NewManager(WithBackupDir(backupDir))
+	if _, _, err := m.Import(&buf, claudePaths); !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("Import() error = %v, want ErrInvalidBundle", err)
+	}
+	if _, err := m.List("claude"); !errors.Is(err, ErrNoBackupsFound) {
+		t.Errorf("List() error = %v, want no backups after a failed import", err)
+	}
+}
+
+func TestManager_Import_NotABundle(t *testing.T) {
+	m := NewManager(WithBackupDir(t.TempDir()))
+	if _, _, err := m.Import(strings.NewReader("not a bundle"), claudePaths); !errors.Is(err, ErrInvalidBundle) {
+		t.Errorf("Import() error = %v, want ErrInvalidBundle", err)
+	}
+}
+
+func TestManager_Import_OutsidePlatform(t *testing.T) {
+	data := exportTestBundle(t)
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name    string
+		allowed PathsFunc
+	}{
+		{"file outside the backup paths", func(string) ([]string, error) {
+			return []string{"~/.claude"}, nil
+		}},
+		{"directory outside the backup paths", func(string) ([]string, error) {
+			return []string{"~/.claude.json", "~/.claude/skills"}, nil
+		}},
+		{"unknown platform", func(platform string) ([]string, error) {
+			return nil, errors.Newf("unknown platform %q", platform)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(WithBackupDir(t.TempDir()))
+			if _, _, err := m.Import(bytes.NewReader(data), tt.allowed); !errors.Is(err, ErrInvalidBundle) {
+				t.Fatalf("Import() error = %v, want ErrInvalidBundle", err)
+			}
+			if _, err := m.List("claude"); !errors.Is(err, ErrNoBackupsFound) {
+				t.Errorf("List() error = %v, want no backups after a rejected import", err)
+			}
+		})
+	}
+}
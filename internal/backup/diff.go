@@ -0,0 +1,106 @@
+package backup
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Diff reports how the current state differs from a backup, sorted by
+// path. Files added since the backup are only found for backups whose
+// manifests record the backed-up directories.
+func (m *Manager) Diff(platform, backupID string) ([]Change, error) {
+	if platform == "" {
+		return nil, errors.New("platform is required")
+	}
+	if backupID == "" {
+		return nil, errors.New("backup ID is required")
+	}
+
+	manifest, err := m.Get(platform, backupID)
+	if err != nil {
+		return nil, err
+	}
+	return m.diff(platform, manifest)
+}
+
+// diff compares each backed-up file with its original location, then walks
+// the backed-up directories and absent paths for files that are new.
+func (m *Manager) diff(platform string, manifest *BackupManifest) ([]Change, error) {
+	backupPath := m.backupPath(platform, manifest.ID)
+	seen := make(map[string]bool, len(manifest.Files))
+	var changes []Change
+
+	for _, bf := range manifest.Files {
+		seen[bf.OriginalPath] = true
+		stored := filepath.Join(backupPath, bf.RelPath)
+
+		hash, err := HashFile(bf.OriginalPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: bf.OriginalPath, StoredPath: stored})
+		case err != nil:
+			return nil, errors.Wrapf(err, "reading %s", bf.OriginalPath)
+		case hash != bf.SHA256Hash:
+			changes = append(changes, Change{Kind: ChangeModified, Path: bf.OriginalPath, StoredPath: stored})
+		}
+	}
+
+	for _, root := range slices.Concat(manifest.Dirs, manifest.Absent) {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == root && errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || seen[path] {
+				return nil
+			}
+			seen[path] = true
+			changes = append(changes, Change{Kind: ChangeAdded, Path: path})
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "walking %s", root)
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return changes, nil
+}
+
+// manifestPaths returns the paths a backup covers: its directories, its
+// absent paths, and any files outside those directories.
+func manifestPaths(manifest *BackupManifest) []string {
+	paths := slices.Concat(manifest.Dirs, manifest.Absent)
+	for _, bf := range manifest.Files {
+		if !slices.ContainsFunc(manifest.Dirs, func(dir string) bool { return within(bf.OriginalPath, dir) }) {
+			paths = append(paths, bf.OriginalPath)
+		}
+	}
+	return paths
+}
+
+// removeEmptyParents removes dir and its parents while they are empty,
+// stopping at the backed-up directory that contains them.
+func removeEmptyParents(dir string, roots []string) {
+	for slices.ContainsFunc(roots, func(root string) bool { return dir != root && within(dir, root) }) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// setupDrift backs up a directory and a missing file, then changes the
+// directory and creates the file.
+func setupDrift(t *testing.T) (m *Manager, manifest *BackupManifest, dir, config string) {
+	t.Helper()
+
+	m = NewManager(WithBackupDir(t.TempDir()))
+	srcDir := t.TempDir()
+	dir = filepath.Join(srcDir, "base")
+	config = filepath.Join(srcDir, "config.json")
+
+	writeFile(t, filepath.Join(dir, "keep.md"), "keep")
+	writeFile(t, filepath.Join(dir, "edit.md"), "before\n")
+	writeFile(t, filepath.Join(dir, "gone.md"), "gone")
+
+	manifest, err := m.Backup("test-platform", []string{dir, config})
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	writeFile(t, filepath.Join(dir, "edit.md"), "after\n")
+	if err := os.Remove(filepath.Join(dir, "gone.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "skills", "new", "SKILL.md"), "new")
+	writeFile(t, config, "{}")
+	return m, manifest, dir, config
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_Diff(t *testing.T) {
+	t.Parallel()
+
+	m, manifest, dir, config := setupDrift(t)
+
+	changes, err := m.Diff("test-platform", manifest.ID)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+
+	want := []Change{
+		{Kind: ChangeModified, Path: filepath.Join(dir, "edit.md")},
+		{Kind: ChangeRemoved, Path: filepath.Join(dir, "gone.md")},
+		{Kind: ChangeAdded, Path: filepath.Join(dir, "skills", "new", "SKILL.md")},
+		{Kind: ChangeAdded, Path: config},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %+v, want %d changes", changes, len(want))
+	}
+	for i, c := range changes {
+		if c.Kind != want[i].Kind || c.Path != want[i].Path {
+			t.Errorf("change %d = %s %s, want %s %s", i, c.Kind, c.Path, want[i].Kind, want[i].Path)
+		}
+		if (c.StoredPath == "") != (c.Kind == ChangeAdded) {
+			t.Errorf("change %d StoredPath = %q", i, c.StoredPath)
+		}
+	}
+}
+
+func TestManager_RestoreExact(t *testing.T) {
+	t.Parallel()
+
+	m, manifest, dir, config := setupDrift(t)
+
+	safety, err := m.RestoreExact("test-platform", manifest.ID)
+	if err != nil {
+		t.Fatalf("RestoreExact failed: %v", err)
+	}
+	if safety == nil || safety.ID == manifest.ID {
+		t.Fatalf("RestoreExact() safety backup = %+v, want a new backup", safety)
+	}
+
+	changes, err := m.Diff("test-platform", manifest.ID)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Diff() after exact restore = %+v, want no changes", changes)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skills")); !os.IsNotExist(err) {
+		t.Errorf("added directory should be removed, stat error = %v", err)
+	}
+	if _, err := os.Stat(config); !os.IsNotExist(err) {
+		t.Errorf("file absent from the backup should be removed, stat error = %v", err)
+	}
+
+	// The safety backup captures what the exact restore replaced.
+	undo, err := m.Diff("test-platform", safety.ID)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(undo) != 4 {
+		t.Errorf("Diff() against safety backup = %+v, want 4 changes", undo)
+	}
+}
+
+func TestManager_RestoreExact_OldManifest(t *testing.T) {
+	t.Parallel()
+
+	m, manifest, _, _ := setupDrift(t)
+
+	manifest.Version = 1
+	path := filepath.Join(m.backupPath("test-platform", manifest.ID), "manifest.json")
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.RestoreExact("test-platform", manifest.ID); !errors.Is(err, ErrExactUnsupported) {
+		t.Errorf("RestoreExact() error = %v, want ErrExactUnsupported", err)
+	}
+}
@@ -39,8 +39,29 @@
 //
 //	err := mgr.Restore("claude", "20260123T100712")
 //
-// Before restoring, the current configuration is backed up automatically to prevent
-// data loss. The restore operation verifies file integrity using stored checksums.
+// The restore operation verifies file integrity using stored checksums. It
+// leaves files created since the backup in place; [Manager.RestoreExact] also
+// removes them, after backing up the current configuration to prevent data
+// loss:
+//
+//	safety, err := mgr.RestoreExact("claude", "20260123T100712")
+//
+// # Comparing Backups
+//
+// Use [Manager.Diff] to see which files were added, removed, or modified since
+// a backup:
+//
+//	changes, err := mgr.Diff("claude", "20260123T100712")
+//
+// # Moving Between Machines
+//
+// [Manager.Export] packs backups of several platforms into one gzip-compressed
+// tar bundle, storing paths under the home directory relative to it.
+// [Manager.Import] checks every file in a bundle against its checksum, then
+// adds the backups with paths rewritten for the current home directory:
+//
+//	bundle, err := mgr.Export(w, manifests)
+//	bundle, manifests, err := mgr.Import(r)
 //
 // # Retention Management
 //
@@ -67,6 +88,8 @@
 //   - CreatedAt: Timestamp when the backup was created
 //   - Platform: The AI assistant platform (claude, opencode, etc.)
 //   - Files: List of backed up files with paths, hashes, and permissions
+//   - Dirs and Absent: Backed-up directories and paths that did not exist,
+//     used to find files added since the backup
 //   - AIXVersion: Version of aix that created the backup
 //
 // # Integrity Verification
@@ -82,4 +105,6 @@
 //   - [ErrNoBackupsFound]: No backups exist for the specified platform
 //   - [ErrBackupCorrupted]: Backup file integrity check failed
 //   - [ErrRestoreConflict]: Target file has been modified since backup
+//   - [ErrExactUnsupported]: Backup predates exact restores
+//   - [ErrInvalidBundle]: Exported bundle is malformed or fails its checksums
 package backup
@@ -1,20 +1,27 @@
 package backup
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/thoreinstein/aix/internal/errors"
 )
 
-// backupOnce tracks per-platform backup state within a session.
+// sessionBackup is the backup taken of a platform in this session.
+type sessionBackup struct {
+	paths    []string
+	manifest *BackupManifest // nil if there was nothing to back up
+}
+
+// sessions tracks per-platform backup state within a session.
 // This prevents redundant backups when multiple operations occur.
 var (
-	backupOnce  = make(map[string]*sync.Once)
+	sessions    = make(map[string]*sessionBackup)
 	backupMutex sync.Mutex
 )
 
 // EnsureBackedUp ensures a backup exists for the platform before modification.
-// Uses sync.Once pattern to ensure only one backup is created per platform per session.
+// Only one backup is created per platform per session.
 //
 // The function is safe for concurrent calls and will only create one backup
 // per platform regardless of how many times it's called.
@@ -22,41 +29,39 @@ var (
 // Returns nil if:
 //   - A backup was just created successfully
 //   - A backup was already created in this session (no-op)
-//   - No paths are provided (nothing to back up)
+//   - No paths are provided, or none of them exist yet (nothing to back up)
 //
 // Returns an error if:
 //   - The backup creation fails
 func EnsureBackedUp(platformName string, paths []string) error {
+	_, err := SessionBackup(platformName, paths)
+	return err
+}
+
+// SessionBackup returns the backup of platformName taken in this session,
+// creating it on first use. A new backup is taken if paths differ from the
+// ones the session backup covers.
+//
+// Returns nil and no error if no paths are provided or none of them exist
+// yet. If the backup fails, no state is recorded so the caller can retry.
+func SessionBackup(platformName string, paths []string) (*BackupManifest, error) {
 	if len(paths) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	backupMutex.Lock()
-	once, exists := backupOnce[platformName]
-	if !exists {
-		once = &sync.Once{}
-		backupOnce[platformName] = once
-	}
-	backupMutex.Unlock()
-
-	var backupErr error
-	once.Do(func() {
-		mgr := NewManager()
-		_, backupErr = mgr.Backup(platformName, paths)
-		if backupErr != nil {
-			// If backup fails, we don't want to proceed with modifications
-			// Reset the Once so caller can retry
-			backupMutex.Lock()
-			delete(backupOnce, platformName)
-			backupMutex.Unlock()
-		}
-	})
+	defer backupMutex.Unlock()
 
-	if backupErr != nil {
-		return errors.Wrapf(backupErr, "creating backup for %s", platformName)
+	if s, ok := sessions[platformName]; ok && slices.Equal(s.paths, paths) {
+		return s.manifest, nil
 	}
 
-	return nil
+	manifest, err := NewManager().Backup(platformName, paths)
+	if err != nil && !errors.Is(err, ErrNothingToBackUp) {
+		return nil, errors.Wrapf(err, "creating backup for %s", platformName)
+	}
+	sessions[platformName] = &sessionBackup{paths: slices.Clone(paths), manifest: manifest}
+	return manifest, nil
 }
 
 // ResetBackupState clears the backup state for all platforms.
@@ -64,7 +69,7 @@ func EnsureBackedUp(platformName string, paths []string) error {
 func ResetBackupState() {
 	backupMutex.Lock()
 	defer backupMutex.Unlock()
-	backupOnce = make(map[string]*sync.Once)
+	sessions = make(map[string]*sessionBackup)
 }
 
 // ResetPlatformBackupState clears the backup state for a specific platform.
@@ -73,5 +78,5 @@ func ResetBackupState() {
 func ResetPlatformBackupState(platformName string) {
 	backupMutex.Lock()
 	defer backupMutex.Unlock()
-	delete(backupOnce, platformName)
+	delete(sessions, platformName)
 }
@@ -0,0 +1,235 @@
+package backup
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Transaction makes a change to several platforms all-or-nothing. Call
+// Begin before modifying each platform, and Track or TrackResource before
+// writing each path; if a later platform fails, Rollback returns every
+// tracked path to its state before the transaction.
+//
+// Only tracked paths are rolled back. Other files under a platform's backup
+// paths, such as the history and settings the assistant itself writes, are
+// left alone; the session backup taken by Begin still covers them for
+// manual restores.
+type Transaction struct {
+	platforms []string
+	snapshots []*snapshot
+}
+
+// snapshot is the saved state of the entries of dir selected by match.
+type snapshot struct {
+	platform string
+	dir      string
+	key      string
+	match    func(name string) bool
+
+	// entries holds the matching files, directories and symlinks, parents
+	// first.
+	entries []snapshotEntry
+
+	// missing lists dir and those of its parents that did not exist, deepest
+	// first. Rollback removes them again if they are empty.
+	missing []string
+}
+
+// snapshotEntry is a file, directory or symlink saved by a snapshot.
+type snapshotEntry struct {
+	path   string
+	mode   fs.FileMode
+	data   []byte
+	target string
+}
+
+// NewTransaction creates an empty transaction.
+func NewTransaction() *Transaction {
+	return &Transaction{}
+}
+
+// Begin adds a platform to the transaction, backing up paths if this
+// session has not already done so. Beginning a platform twice has no
+// effect.
+func (tx *Transaction) Begin(platform string, paths []string) error {
+	if slices.Contains(tx.platforms, platform) {
+		return nil
+	}
+	if _, err := SessionBackup(platform, paths); err != nil {
+		return err
+	}
+	tx.platforms = append(tx.platforms, platform)
+	return nil
+}
+
+// Track saves path, a file or directory that the change to platform is
+// about to write, so that Rollback can restore it. If path does not exist,
+// Rollback removes it. Tracking a path twice keeps the first state.
+func (tx *Transaction) Track(platform, path string) error {
+	path = filepath.Clean(expandHome(path))
+	base := filepath.Base(path)
+	return tx.track(platform, filepath.Dir(path), "path:"+base, func(name string) bool {
+		return name == base
+	})
+}
+
+// TrackResource saves the entries of dir that belong to the resource
+// named name, before the change to platform writes it. These are the entry
+// called name, such as a skill directory, and those called name followed by
+// an extension, such as name.md or name.prompt.md. Rollback restores them
+// and removes any created since.
+func (tx *Transaction) TrackResource(platform, dir, name string) error {
+	full := filepath.Join(expandHome(dir), name)
+	base := filepath.Base(full)
+	return tx.track(platform, filepath.Dir(full), "resource:"+base, func(entry string) bool {
+		return entry == base || strings.HasPrefix(entry, base+".")
+	})
+}
+
+func (tx *Transaction) track(platform, dir, key string, match func(string) bool) error {
+	key = dir + "\x00" + key
+	if slices.ContainsFunc(tx.snapshots, func(s *snapshot) bool { return s.key == key }) {
+		return nil
+	}
+
+	s := &snapshot{platform: platform, dir: dir, key: key, match: match}
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Lstat(d); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return errors.Wrapf(err, "stat %s", d)
+		}
+		s.missing = append(s.missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	if err := s.save(); err != nil {
+		return errors.Wrapf(err, "saving %s for rollback", dir)
+	}
+	tx.snapshots = append(tx.snapshots, s)
+	return nil
+}
+
+// save reads the matching entries of the snapshot's directory.
+func (s *snapshot) save() error {
+	names, err := s.matching()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		err := filepath.WalkDir(filepath.Join(s.dir, name), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			e := snapshotEntry{path: path, mode: info.Mode()}
+			switch {
+			case info.Mode()&fs.ModeSymlink != 0:
+				e.target, err = os.Readlink(path)
+			case info.Mode().IsRegular():
+				e.data, err = os.ReadFile(path)
+			}
+			if err != nil {
+				return err
+			}
+			s.entries = append(s.entries, e)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matching returns the names of the entries of the snapshot's directory
+// that it covers.
+func (s *snapshot) matching() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if s.match(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// restore removes the matching entries and writes back the saved ones.
+func (s *snapshot) restore() error {
+	names, err := s.matching()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := os.RemoveAll(filepath.Join(s.dir, name)); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range s.entries {
+		var err error
+		switch {
+		case e.mode.IsDir():
+			err = os.MkdirAll(e.path, e.mode.Perm())
+		case e.mode&fs.ModeSymlink != 0:
+			err = os.Symlink(e.target, e.path)
+		default:
+			if err = os.MkdirAll(filepath.Dir(e.path), 0o700); err == nil {
+				err = os.WriteFile(e.path, e.data, e.mode.Perm())
+			}
+			if err == nil {
+				// WriteFile leaves the mode of an existing file as it is
+				err = os.Chmod(e.path, e.mode.Perm())
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "restoring %s", e.path)
+		}
+	}
+
+	// Directories created for the change go too, unless something else
+	// was put in them since
+	for _, d := range s.missing {
+		if os.Remove(d) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// Platforms returns the platforms begun so far, in order.
+func (tx *Transaction) Platforms() []string {
+	return slices.Clone(tx.platforms)
+}
+
+// Rollback restores every tracked path, most recently tracked first. Every
+// path is attempted even if one fails, and the failures are returned
+// together, per platform. The transaction is empty afterwards.
+func (tx *Transaction) Rollback() error {
+	var errs []error
+	for _, s := range slices.Backward(tx.snapshots) {
+		if err := s.restore(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "rolling back %s", s.platform))
+		}
+	}
+	tx.platforms = nil
+	tx.snapshots = nil
+	return errors.Join(errs...)
+}
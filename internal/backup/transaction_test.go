@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTransaction_Rollback(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ResetBackupState()
+	defer ResetBackupState()
+
+	// One platform has existing configuration; the other has none yet.
+	existing := filepath.Join(t.TempDir(), "existing")
+	writeFile(t, filepath.Join(existing, "config.json"), "original")
+	writeFile(t, filepath.Join(existing, "commands", "review.md"), "original review")
+	fresh := filepath.Join(t.TempDir(), "fresh")
+
+	tx := NewTransaction()
+	if err := tx.Begin("platform1", []string{existing}); err != nil {
+		t.Fatalf("Begin(platform1) failed: %v", err)
+	}
+	if err := tx.Track("platform1", filepath.Join(existing, "config.json")); err != nil {
+		t.Fatalf("Track() failed: %v", err)
+	}
+	if err := tx.TrackResource("platform1", filepath.Join(existing, "skills"), "new"); err != nil {
+		t.Fatalf("TrackResource(skills) failed: %v", err)
+	}
+	if err := tx.TrackResource("platform1", filepath.Join(existing, "commands"), "review"); err != nil {
+		t.Fatalf("TrackResource(commands) failed: %v", err)
+	}
+	writeFile(t, filepath.Join(existing, "config.json"), "changed")
+	writeFile(t, filepath.Join(existing, "skills", "new", "SKILL.md"), "new")
+	if err := os.Remove(filepath.Join(existing, "commands", "review.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(existing, "commands", "review.toml"), "converted")
+
+	// Files the transaction did not track are left as they are, even in
+	// the platform's backup paths
+	writeFile(t, filepath.Join(existing, "history.jsonl"), "session")
+	writeFile(t, filepath.Join(existing, "commands", "other.md"), "other")
+
+	if err := tx.Begin("platform2", []string{fresh}); err != nil {
+		t.Fatalf("Begin(platform2) failed: %v", err)
+	}
+	if err := tx.Begin("platform1", []string{existing}); err != nil {
+		t.Fatalf("second Begin(platform1) failed: %v", err)
+	}
+	if err := tx.Track("platform2", filepath.Join(fresh, "config.json")); err != nil {
+		t.Fatalf("Track(platform2) failed: %v", err)
+	}
+	writeFile(t, filepath.Join(fresh, "config.json"), "created")
+
+	if got := tx.Platforms(); len(got) != 2 || got[0] != "platform1" || got[1] != "platform2" {
+		t.Errorf("Platforms() = %v, want [platform1 platform2]", got)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(existing, "config.json"):           "original",
+		filepath.Join(existing, "commands", "review.md"): "original review",
+		filepath.Join(existing, "history.jsonl"):         "session",
+		filepath.Join(existing, "commands", "other.md"):  "other",
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	for _, path := range []string{
+		filepath.Join(existing, "skills"),
+		filepath.Join(existing, "commands", "review.toml"),
+		fresh,
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s created during the transaction should be removed, stat error = %v", path, err)
+		}
+	}
+	if len(tx.Platforms()) != 0 {
+		t.Errorf("Platforms() after Rollback = %v, want none", tx.Platforms())
+	}
+}
+
+func TestTransaction_TrackKeepsFirstState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mcp.json")
+	writeFile(t, path, "first")
+
+	tx := NewTransaction()
+	if err := tx.Track("p", path); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "second")
+	if err := tx.Track("p", path); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "third")
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "first" {
+		t.Errorf("mcp.json = %q, want the state before the first write", got)
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"github.com/thoreinstein/aix/internal/errors"
 )
 
+// BundleVersion is the exported bundle format version.
+const BundleVersion = 1
+
 // Manifest format version for forward compatibility.
-const ManifestVersion = 1
+// Version 2 added Dirs and Absent, which exact restores depend on.
+const ManifestVersion = 2
 
 // Default configuration values.
 const (
@@ -28,6 +32,18 @@ var (
 	// ErrRestoreConflict indicates the target file has been modified since the backup.
 	// This prevents accidental overwrites of user changes.
 	ErrRestoreConflict = errors.New("restore conflict")
+
+	// ErrNothingToBackUp indicates none of the requested paths exist yet.
+	ErrNothingToBackUp = errors.New("no files to back up")
+
+	// ErrExactUnsupported indicates a backup was created before manifests
+	// recorded the backed-up directories, so an exact restore cannot tell
+	// which files were added since.
+	ErrExactUnsupported = errors.New("backup does not support exact restore")
+
+	// ErrInvalidBundle indicates an exported bundle is malformed or fails its
+	// checksums.
+	ErrInvalidBundle = errors.New("invalid backup bundle")
 )
 
 // BackupManifest contains metadata about a backup.
@@ -45,6 +61,14 @@ type BackupManifest struct {
 	// Files contains metadata for each backed up file.
 	Files []BackupFile `json:"files"`
 
+	// Dirs lists the directories that were backed up. Files found under
+	// them that are not in Files were added after the backup.
+	Dirs []string `json:"dirs,omitempty"`
+
+	// Absent lists requested paths that did not exist when the backup was
+	// taken. Anything found at them now was added after the backup.
+	Absent []string `json:"absent,omitempty"`
+
 	// AIXVersion is the version of aix that created this backup.
 	AIXVersion string `json:"aix_version"`
 
@@ -79,3 +103,63 @@ type BackupConfig struct {
 	// Defaults to ~/.config/aix/backups/
 	BackupDir string
 }
+
+// ChangeKind describes how a file differs between a backup and the current
+// state.
+type ChangeKind string
+
+// Change kinds, from the backup's point of view.
+const (
+	// ChangeAdded means the file exists now but is not in the backup.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeRemoved means the file is in the backup but no longer exists.
+	ChangeRemoved ChangeKind = "removed"
+
+	// ChangeModified means the file's contents differ from the backup.
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a file that differs between a backup and the current state.
+type Change struct {
+	// Kind is how the file differs.
+	Kind ChangeKind `json:"kind"`
+
+	// Path is the file's original location.
+	Path string `json:"path"`
+
+	// StoredPath is the backup's copy of the file. It is empty for added
+	// files.
+	StoredPath string `json:"-"`
+}
+
+// Bundle is the table of contents of an exported backup bundle: backups of
+// several platforms packed into one archive that can be restored on another
+// machine. It is stored as bundle.json, the archive's first entry.
+type Bundle struct {
+	// Version is the bundle format version.
+	Version int `json:"version"`
+
+	// CreatedAt is when the bundle was exported.
+	CreatedAt time.Time `json:"created_at"`
+
+	// AIXVersion is the version of aix that exported the bundle.
+	AIXVersion string `json:"aix_version"`
+
+	// Backups lists the backups in the bundle.
+	Backups []BundleEntry `json:"backups"`
+}
+
+// BundleEntry is one backup in a bundle.
+type BundleEntry struct {
+	// Platform is the platform the backup belongs to.
+	Platform string `json:"platform"`
+
+	// ID is the backup identifier.
+	ID string `json:"id"`
+
+	// ManifestHash is the hex-encoded SHA256 hash of the backup's manifest
+	// as stored in the bundle. The manifest in turn records the hash of
+	// every file.
+	ManifestHash string `json:"manifest_sha256"`
+}
@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
+	"github.com/thoreinstein/aix/internal/platform/gemini"
+	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/internal/secret"
+)
+
+// The canonical forms used across aix are the Claude Code types for skills,
+// commands and agents, and mcp.Server for MCP servers. The functions below
+// convert the platform-specific values returned by the Platform Get* methods
+// back into those forms so resources can be compared across platforms.
+
+// CanonicalResource reads the resource of type t named name from p and
+// converts it to its canonical form: a claude.Skill, claude.Command,
+// claude.Agent or mcp.Server.
+func CanonicalResource(p Platform, t resource.ResourceType, name string) (any, error) {
+	switch t {
+	case resource.TypeSkill:
+		v, err := p.GetSkill(name)
+		if err != nil {
+			return nil, err
+		}
+		return CanonicalSkill(v)
+	case resource.TypeCommand:
+		v, err := p.GetCommand(name)
+		if err != nil {
+			return nil, err
+		}
+		return CanonicalCommand(v)
+	case resource.TypeAgent:
+		v, err := p.GetAgent(name)
+		if err != nil {
+			return nil, err
+		}
+		return CanonicalAgent(v)
+	case resource.TypeMCP:
+		v, err := p.GetMCP(name)
+		if err != nil {
+			return nil, err
+		}
+		return CanonicalMCP(v)
+	default:
+		return nil, errors.Newf("unknown resource type %q", t)
+	}
+}
+
+// CanonicalSkill converts a skill returned by Platform.GetSkill into the
+// canonical claude.Skill representation.
+func CanonicalSkill(v any) (*claude.Skill, error) {
+	switch s := v.(type) {
+	case *claude.Skill:
+		c := *s
+		return &c, nil
+	case *opencode.Skill:
+		c := &claude.Skill{
+			Name:         s.Name,
+			Description:  s.Description,
+			AllowedTools: claude.ToolList(s.AllowedTools),
+			Instructions: s.Instructions,
+			SourceDir:    s.SourceDir,
+		}
+		if len(s.Compatibility) > 0 {
+			keys := make([]string, 0, len(s.Compatibility))
+			for k := range s.Compatibility {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				c.Compatibility = append(c.Compatibility, strings.TrimSpace(k+" "+s.Compatibility[k]))
+			}
+		}
+		if len(s.Metadata) > 0 || s.Version != "" || s.Author != "" {
+			c.Metadata = make(map[string]string, len(s.Metadata)+2)
+			for k, val := range s.Metadata {
+				c.Metadata[k] = fmt.Sprint(val)
+			}
+			if s.Version != "" {
+				c.Metadata["version"] = s.Version
+			}
+			if s.Author != "" {
+				c.Metadata["author"] = s.Author
+			}
+		}
+		return c, nil
+	case *codex.Skill:
+		return &claude.Skill{
+			Name:          s.Name,
+			Description:   s.Description,
+			License:       s.License,
+			Compatibility: s.Compatibility,
+			Metadata:      s.Metadata,
+			AllowedTools:  claude.ToolList(s.AllowedTools),
+			Instructions:  s.Instructions,
+			SourceDir:     s.SourceDir,
+		}, nil
+	case *gemini.Skill:
+		return &claude.Skill{
+			Name:          s.Name,
+			Description:   s.Description,
+			License:       s.License,
+			Compatibility: s.Compatibility,
+			Metadata:      s.Metadata,
+			AllowedTools:  claude.ToolList(s.AllowedTools),
+			Instructions:  s.Instructions,
+			SourceDir:     s.SourceDir,
+		}, nil
+	case *cursor.Rule:
+		return &claude.Skill{Name: s.Name, Description: s.Description, Instructions: s.Instructions}, nil
+	case *copilot.Instruction:
+		return &claude.Skill{Name: s.Name, Description: s.Description, Instructions: s.Instructions}, nil
+	default:
+		return nil, errors.Newf("unsupported skill type %T", v)
+	}
+}
+
+// CanonicalCommand converts a command returned by Platform.GetCommand into
+// the canonical claude.Command representation.
+func CanonicalCommand(v any) (*claude.Command, error) {
+	switch c := v.(type) {
+	case *claude.Command:
+		out := *c
+		return &out, nil
+	case *opencode.Command:
+		return &claude.Command{
+			Name:         c.Name,
+			Description:  c.Description,
+			Agent:        c.Agent,
+			Model:        c.Model,
+			Instructions: c.Instructions,
+		}, nil
+	case *codex.Command:
+		return &claude.Command{
+			Name:         c.Name,
+			Description:  c.Description,
+			ArgumentHint: c.ArgumentHint,
+			Instructions: c.Instructions,
+		}, nil
+	case *gemini.Command:
+		return &claude.Command{
+			Name:         c.Name,
+			Description:  c.Description,
+			Instructions: c.Instructions,
+		}, nil
+	case *cursor.Command:
+		return &claude.Command{
+			Name:         c.Name,
+			Description:  c.Description,
+			Instructions: c.Instructions,
+		}, nil
+	case *copilot.Prompt:
+		return &claude.Command{
+			Name:         c.Name,
+			Description:  c.Description,
+			ArgumentHint: c.ArgumentHint,
+			Instructions: c.Instructions,
+		}, nil
+	default:
+		return nil, errors.Newf("unsupported command type %T", v)
+	}
+}
+
+// CanonicalAgent converts an agent returned by Platform.GetAgent into the
+// canonical claude.Agent representation.
+func CanonicalAgent(v any) (*claude.Agent, error) {
+	switch a := v.(type) {
+	case *claude.Agent:
+		out := *a
+		return &out, nil
+	case *opencode.Agent:
+		return &claude.Agent{Name: a.Name, Description: a.Description, Instructions: a.Instructions}, nil
+	case *codex.Agent:
+		return &claude.Agent{Name: a.Name, Description: a.Description, Instructions: a.Instructions}, nil
+	case *gemini.Agent:
+		return &claude.Agent{Name: a.Name, Description: a.Description, Instructions: a.Instructions}, nil
+	case *cursor.Rule:
+		return &claude.Agent{Name: a.Name, Description: a.Description, Instructions: a.Instructions}, nil
+	case *copilot.ChatMode:
+		return &claude.Agent{Name: a.Name, Description: a.Description, Instructions: a.Instructions}, nil
+	default:
+		return nil, errors.Newf("unsupported agent type %T", v)
+	}
+}
+
+// CanonicalMCP converts an MCP server returned by Platform.GetMCP into the
+// canonical mcp.Server representation. The transport is always populated.
+func CanonicalMCP(v any) (*mcp.Server, error) {
+	switch s := v.(type) {
+	case *mcp.Server:
+		out := *s
+		if out.Transport == "" {
+			out.Transport = inferTransport("", out.URL)
+		}
+		return &out, nil
+	case *claude.MCPServer:
+		return &mcp.Server{
+			Name:      s.Name,
+			Command:   s.Command,
+			Args:      s.Args,
+			URL:       s.URL,
+			Transport: inferTransport(s.Type, s.URL),
+			Env:       secret.CanonicalizeMap(s.Env, claude.SecretSyntax),
+			Headers:   secret.CanonicalizeMap(s.Headers, claude.SecretSyntax),
+			Platforms: s.Platforms,
+			Disabled:  s.Disabled,
+		}, nil
+	case *opencode.MCPServer:
+		out := &mcp.Server{
+			Name:      s.Name,
+			URL:       s.URL,
+			Transport: mcp.TransportStdio,
+			Env:       secret.CanonicalizeMap(s.Environment, opencode.SecretSyntax),
+			Headers:   secret.CanonicalizeMap(s.Headers, opencode.SecretSyntax),
+			Disabled:  s.Enabled != nil && !*s.Enabled,
+		}
+		if s.Type == "remote" || s.URL != "" {
+			out.Transport = mcp.TransportHTTP
+		}
+		if len(s.Command) > 0 {
+			out.Command = s.Command[0]
+			if len(s.Command) > 1 {
+				out.Args = s.Command[1:]
+			}
+		}
+		return out, nil
+	case *codex.MCPServer:
+		out := &mcp.Server{
+			Name:      s.Name,
+			Command:   s.Command,
+			Args:      s.Args,
+			URL:       s.URL,
+			Transport: inferTransport("", s.URL),
+			Env:       s.Env,
+			Headers:   s.HTTPHeaders,
+			Disabled:  !s.IsEnabled(),
+		}
+		if s.BearerTokenEnvVar != "" {
+			out.Headers = make(map[string]string, len(s.HTTPHeaders)+1)
+			maps.Copy(out.Headers, s.HTTPHeaders)
+			out.Headers["Authorization"] = "Bearer " + secret.Ref{Kind: secret.KindEnv, Value: s.BearerTokenEnvVar}.String()
+		}
+		return out, nil
+	case *gemini.MCPServer:
+		return &mcp.Server{
+			Name:      s.Name,
+			Command:   s.Command,
+			Args:      s.Args,
+			URL:       s.Endpoint(),
+			Transport: s.Transport(),
+			Env:       secret.CanonicalizeMap(s.Env, gemini.SecretSyntax),
+			Headers:   secret.CanonicalizeMap(s.Headers, gemini.SecretSyntax),
+			Disabled:  !s.Enabled,
+		}, nil
+	case *cursor.MCPServer:
+		out := cursor.CanonicalServer(s.Name, s)
+		out.Env = secret.CanonicalizeMap(s.Env, cursor.SecretSyntax)
+		out.Headers = secret.CanonicalizeMap(s.Headers, cursor.SecretSyntax)
+		return out, nil
+	case *copilot.MCPServer:
+		out := copilot.CanonicalServer(s.Name, s)
+		out.Env = secret.CanonicalizeMap(s.Env, copilot.SecretSyntax)
+		out.Headers = secret.CanonicalizeMap(s.Headers, copilot.SecretSyntax)
+		return out, nil
+	default:
+		return nil, errors.Newf("unsupported MCP server type %T", v)
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
+	"github.com/thoreinstein/aix/internal/platform/gemini"
+	"github.com/thoreinstein/aix/internal/platform/opencode"
+)
+
+func TestCanonicalSkill(t *testing.T) {
+	s, err := CanonicalSkill(&opencode.Skill{
+		Name:          "review",
+		Description:   "Reviews code",
+		Version:       "1.0",
+		AllowedTools:  []string{"Read"},
+		Compatibility: opencode.CompatibilityMap{"claude": "1.x"},
+		Instructions:  "Review it",
+	})
+	if err != nil {
+		t.Fatalf("CanonicalSkill() error = %v", err)
+	}
+	if s.Metadata["version"] != "1.0" {
+		t.Errorf("version not mapped to metadata: %v", s.Metadata)
+	}
+	if !reflect.DeepEqual(s.Compatibility, []string{"claude 1.x"}) {
+		t.Errorf("Compatibility = %v", s.Compatibility)
+	}
+	if s.Instructions != "Review it" || len(s.AllowedTools) != 1 {
+		t.Errorf("skill not converted: %+v", s)
+	}
+
+	if _, err := CanonicalSkill("not a skill"); err == nil {
+		t.Error("CanonicalSkill() expected error for unsupported type")
+	}
+}
+
+func TestCanonicalCommand(t *testing.T) {
+	c, err := CanonicalCommand(&codex.Command{Name: "deploy", ArgumentHint: "<env>", Instructions: "Deploy $ARGUMENTS"})
+	if err != nil {
+		t.Fatalf("CanonicalCommand() error = %v", err)
+	}
+	if c.ArgumentHint != "<env>" || c.Instructions != "Deploy $ARGUMENTS" {
+		t.Errorf("command not converted: %+v", c)
+	}
+
+	orig := &claude.Command{Name: "x"}
+	c, _ = CanonicalCommand(orig)
+	if c == orig {
+		t.Error("CanonicalCommand() should return a copy of claude commands")
+	}
+}
+
+func TestCanonicalAgent(t *testing.T) {
+	a, err := CanonicalAgent(&opencode.Agent{Name: "reviewer", Description: "d", Mode: "subagent", Instructions: "i"})
+	if err != nil {
+		t.Fatalf("CanonicalAgent() error = %v", err)
+	}
+	if a.Name != "reviewer" || a.Description != "d" || a.Instructions != "i" {
+		t.Errorf("agent not converted: %+v", a)
+	}
+}
+
+func TestCanonicalMCP(t *testing.T) {
+	enabled := false
+	tests := []struct {
+		name string
+		in   any
+		want mcp.Server
+	}{
+		{
+			name: "claude http",
+			in:   &claude.MCPServer{Name: "api", Type: "http", URL: "https://x"},
+			want: mcp.Server{Name: "api", Transport: mcp.TransportHTTP, URL: "https://x"},
+		},
+		{
+			name: "opencode local",
+			in: &opencode.MCPServer{Name: "gh", Type: "local", Command: []string{"npx", "-y", "gh"},
+				Environment: map[string]string{"A": "1"}, Enabled: &enabled},
+			want: mcp.Server{Name: "gh", Transport: mcp.TransportStdio, Command: "npx", Args: []string{"-y", "gh"},
+				Env: map[string]string{"A": "1"}, Disabled: true},
+		},
+		{
+			name: "codex remote",
+			in:   &codex.MCPServer{Name: "api", URL: "https://x", HTTPHeaders: map[string]string{"H": "v"}},
+			want: mcp.Server{Name: "api", Transport: mcp.TransportHTTP, URL: "https://x", Headers: map[string]string{"H": "v"}},
+		},
+		{
+			name: "claude secret reference",
+			in:   &claude.MCPServer{Name: "gh", Command: "gh-mcp", Env: map[string]string{"TOKEN": "${GH_TOKEN}"}},
+			want: mcp.Server{Name: "gh", Transport: mcp.TransportStdio, Command: "gh-mcp",
+				Env: map[string]string{"TOKEN": "${env:GH_TOKEN}"}},
+		},
+		{
+			name: "codex bearer token env var",
+			in:   &codex.MCPServer{Name: "api", URL: "https://x", BearerTokenEnvVar: "API_TOKEN"},
+			want: mcp.Server{Name: "api", Transport: mcp.TransportHTTP, URL: "https://x",
+				Headers: map[string]string{"Authorization": "Bearer ${env:API_TOKEN}"}},
+		},
+		{
+			name: "gemini stdio",
+			in:   &gemini.MCPServer{Name: "fs", Command: "fs-server", Enabled: true},
+			want: mcp.Server{Name: "fs", Transport: mcp.TransportStdio, Command: "fs-server"},
+		},
+		{
+			name: "cursor remote",
+			in:   &cursor.MCPServer{Name: "api", URL: "https://x", Headers: map[string]string{"Authorization": "Bearer ${env:API_TOKEN}"}},
+			want: mcp.Server{Name: "api", Transport: mcp.TransportHTTP, URL: "https://x",
+				Headers: map[string]string{"Authorization": "Bearer ${env:API_TOKEN}"}},
+		},
+		{
+			name: "copilot sse",
+			in:   &copilot.MCPServer{Name: "events", Type: "sse", URL: "https://x", Env: map[string]string{"K": "${input:events-K}"}},
+			want: mcp.Server{Name: "events", Transport: mcp.TransportSSE, URL: "https://x",
+				Env: map[string]string{"K": "${input:events-K}"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalMCP(tt.in)
+			if err != nil {
+				t.Fatalf("CanonicalMCP() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("CanonicalMCP() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
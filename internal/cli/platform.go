@@ -2,14 +2,22 @@
 package cli
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 	"github.com/thoreinstein/aix/internal/paths"
 	"github.com/thoreinstein/aix/internal/platform"
 	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
 	"github.com/thoreinstein/aix/internal/platform/gemini"
+	"github.com/thoreinstein/aix/internal/platform/generic"
 	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 // Sentinel errors for platform operations.
@@ -19,8 +27,90 @@ var (
 
 	// ErrNoPlatformsAvailable is returned when no platforms are detected.
 	ErrNoPlatformsAvailable = errors.New("no platforms available")
+
+	// ErrInvalidScope is returned when an unknown scope name is provided.
+	ErrInvalidScope = errors.New("invalid scope")
+
+	// ErrProjectRootRequired is returned when project scope is requested
+	// without a project root.
+	ErrProjectRootRequired = errors.New("project root required for project scope")
 )
 
+// Scope selects whether platform adapters read and write user-level
+// configuration (e.g. ~/.claude/) or project-level configuration
+// (e.g. <project>/.claude/).
+type Scope string
+
+const (
+	// ScopeUser targets the user's global configuration directories.
+	ScopeUser Scope = "user"
+
+	// ScopeProject targets configuration inside a project root.
+	ScopeProject Scope = "project"
+)
+
+// ParseScope converts a --scope flag value to a Scope.
+// An empty string is treated as ScopeUser.
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case "", ScopeUser:
+		return ScopeUser, nil
+	case ScopeProject:
+		return ScopeProject, nil
+	default:
+		return "", errors.Wrapf(ErrInvalidScope, "%q (valid: %s, %s)", s, ScopeUser, ScopeProject)
+	}
+}
+
+// options holds the configuration applied when constructing platform adapters.
+type options struct {
+	scope       Scope
+	projectRoot string
+	defaults    []string
+}
+
+// Option configures how NewPlatform and ResolvePlatforms build adapters.
+type Option func(*options)
+
+// WithScope sets the configuration scope for created platforms.
+func WithScope(scope Scope) Option {
+	return func(o *options) {
+		o.scope = scope
+	}
+}
+
+// WithProjectRoot sets the project root used when the scope is ScopeProject.
+func WithProjectRoot(root string) Option {
+	return func(o *options) {
+		o.projectRoot = root
+	}
+}
+
+// WithDefaultPlatforms sets the platforms ResolvePlatforms targets when no
+// names are given, usually default_platforms from the aix config file.
+// Without it, or with no names, every detected platform is targeted.
+func WithDefaultPlatforms(names []string) Option {
+	return func(o *options) {
+		o.defaults = slices.Clone(names)
+	}
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := options{scope: ScopeUser}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.scope == ScopeProject && o.projectRoot == "" {
+		return o, ErrProjectRootRequired
+	}
+	return o, nil
+}
+
+// isProject reports whether adapters should use project-scoped paths.
+func (o options) isProject() bool {
+	return o.scope == ScopeProject
+}
+
 // SkillInfo provides a simplified view of a skill for CLI display.
 // This is a platform-agnostic representation used for listing.
 type SkillInfo struct {
@@ -30,7 +120,8 @@ type SkillInfo struct {
 	// Description explains what the skill does.
 	Description string
 
-	// Source indicates where the skill came from: "local" or a git URL.
+	// Source is "local". Platforms do not know where a resource came from;
+	// the list commands look it up in the install registry instead.
 	Source string
 }
 
@@ -43,16 +134,16 @@ type CommandInfo struct {
 	// Description explains what the command does.
 	Description string
 
-	// Source indicates where the command came from: file path or "installed".
+	// Source is "local"; see SkillInfo.Source.
 	Source string
 }
 
 // MCPInfo provides platform-agnostic MCP server information for display.
 type MCPInfo struct {
 	Name      string
-	Transport string // "stdio" or "sse"
+	Transport string // "stdio", "http", or "sse"
 	Command   string // Executable path (stdio)
-	URL       string // Endpoint (sse)
+	URL       string // Endpoint (http, sse)
 	Disabled  bool
 	Env       map[string]string // Environment variables
 }
@@ -61,7 +152,7 @@ type MCPInfo struct {
 type AgentInfo struct {
 	Name        string
 	Description string
-	Source      string // "local" or future: git URL
+	Source      string // "local"; see SkillInfo.Source
 }
 
 // Platform defines the interface that platform adapters must implement
@@ -167,8 +258,12 @@ type claudeAdapter struct {
 	claude *claude.ClaudePlatform
 }
 
-func newClaudeAdapter() *claudeAdapter {
-	p := claude.NewClaudePlatform()
+func newClaudeAdapter(o options) *claudeAdapter {
+	var opts []claude.Option
+	if o.isProject() {
+		opts = append(opts, claude.WithScope(claude.ScopeProject), claude.WithProjectRoot(o.projectRoot))
+	}
+	p := claude.NewClaudePlatform(opts...)
 	return &claudeAdapter{
 		baseAdapter: baseAdapter{p: p},
 		claude:      p,
@@ -226,7 +321,7 @@ func (a *claudeAdapter) ListCommands() ([]CommandInfo, error) {
 	}
 	infos := make([]CommandInfo, len(commands))
 	for i, c := range commands {
-		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "installed"}
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
 	}
 	return infos, nil
 }
@@ -259,9 +354,6 @@ func (a *claudeAdapter) ListMCP() ([]MCPInfo, error) {
 	infos := make([]MCPInfo, len(servers))
 	for i, s := range servers {
 		transport := inferTransport(s.Type, s.URL)
-		if s.Type == "http" {
-			transport = "sse" // Claude uses "http" for remote, we display as "sse"
-		}
 		infos[i] = MCPInfo{
 			Name: s.Name, Transport: transport, Command: s.Command,
 			URL: s.URL, Disabled: s.Disabled, Env: s.Env,
@@ -324,8 +416,12 @@ type opencodeAdapter struct {
 	opencode *opencode.OpenCodePlatform
 }
 
-func newOpenCodeAdapter() *opencodeAdapter {
-	p := opencode.NewOpenCodePlatform()
+func newOpenCodeAdapter(o options) *opencodeAdapter {
+	var opts []opencode.Option
+	if o.isProject() {
+		opts = append(opts, opencode.WithScope(opencode.ScopeProject), opencode.WithProjectRoot(o.projectRoot))
+	}
+	p := opencode.NewOpenCodePlatform(opts...)
 	return &opencodeAdapter{
 		baseAdapter: baseAdapter{p: p},
 		opencode:    p,
@@ -383,7 +479,7 @@ func (a *opencodeAdapter) ListCommands() ([]CommandInfo, error) {
 	}
 	infos := make([]CommandInfo, len(commands))
 	for i, c := range commands {
-		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "installed"}
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
 	}
 	return infos, nil
 }
@@ -415,9 +511,9 @@ func (a *opencodeAdapter) ListMCP() ([]MCPInfo, error) {
 	}
 	infos := make([]MCPInfo, len(servers))
 	for i, s := range servers {
-		transport := "stdio"
+		transport := mcp.TransportStdio
 		if s.Type == "remote" || s.URL != "" {
-			transport = "sse"
+			transport = mcp.TransportHTTP
 		}
 		cmd := ""
 		if len(s.Command) > 0 {
@@ -480,14 +576,176 @@ func (a *opencodeAdapter) GetAgent(name string) (any, error) {
 	return ag, nil
 }
 
+// codexAdapter wraps CodexPlatform to implement the Platform interface.
+type codexAdapter struct {
+	baseAdapter
+	codex *codex.CodexPlatform
+}
+
+func newCodexAdapter(o options) *codexAdapter {
+	var opts []codex.Option
+	if o.isProject() {
+		opts = append(opts, codex.WithScope(codex.ScopeProject), codex.WithProjectRoot(o.projectRoot))
+	}
+	p := codex.NewCodexPlatform(opts...)
+	return &codexAdapter{
+		baseAdapter: baseAdapter{p: p},
+		codex:       p,
+	}
+}
+
+func (a *codexAdapter) InstallSkill(skill any) error {
+	s, ok := skill.(*codex.Skill)
+	if !ok {
+		return errors.Newf("expected *codex.Skill, got %T", skill)
+	}
+	return errors.Wrap(a.codex.InstallSkill(s), "installing skill to Codex")
+}
+
+func (a *codexAdapter) UninstallSkill(name string) error {
+	return errors.Wrap(a.codex.UninstallSkill(name), "uninstalling skill from Codex")
+}
+
+func (a *codexAdapter) ListSkills() ([]SkillInfo, error) {
+	skills, err := a.codex.ListSkills()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Codex skills")
+	}
+	infos := make([]SkillInfo, len(skills))
+	for i, s := range skills {
+		infos[i] = SkillInfo{Name: s.Name, Description: s.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *codexAdapter) GetSkill(name string) (any, error) {
+	s, err := a.codex.GetSkill(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Codex skill")
+	}
+	return s, nil
+}
+
+func (a *codexAdapter) InstallCommand(cmd any) error {
+	c, ok := cmd.(*codex.Command)
+	if !ok {
+		return errors.Newf("expected *codex.Command, got %T", cmd)
+	}
+	return errors.Wrap(a.codex.InstallCommand(c), "installing command to Codex")
+}
+
+func (a *codexAdapter) UninstallCommand(name string) error {
+	return errors.Wrap(a.codex.UninstallCommand(name), "uninstalling command from Codex")
+}
+
+func (a *codexAdapter) ListCommands() ([]CommandInfo, error) {
+	commands, err := a.codex.ListCommands()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Codex commands")
+	}
+	infos := make([]CommandInfo, len(commands))
+	for i, c := range commands {
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *codexAdapter) GetCommand(name string) (any, error) {
+	c, err := a.codex.GetCommand(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Codex command")
+	}
+	return c, nil
+}
+
+func (a *codexAdapter) AddMCP(server any) error {
+	s, ok := server.(*codex.MCPServer)
+	if !ok {
+		return errors.Newf("expected *codex.MCPServer, got %T", server)
+	}
+	return errors.Wrap(a.codex.AddMCP(s), "adding MCP server to Codex")
+}
+
+func (a *codexAdapter) RemoveMCP(name string) error {
+	return errors.Wrap(a.codex.RemoveMCP(name), "removing MCP server from Codex")
+}
+
+func (a *codexAdapter) ListMCP() ([]MCPInfo, error) {
+	servers, err := a.codex.ListMCP()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Codex MCP servers")
+	}
+	infos := make([]MCPInfo, len(servers))
+	for i, s := range servers {
+		transport := inferTransport("", s.URL)
+		infos[i] = MCPInfo{
+			Name: s.Name, Transport: transport, Command: s.Command,
+			URL: s.URL, Disabled: !s.IsEnabled(), Env: s.Env,
+		}
+	}
+	return infos, nil
+}
+
+func (a *codexAdapter) GetMCP(name string) (any, error) {
+	s, err := a.codex.GetMCP(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Codex MCP server")
+	}
+	return s, nil
+}
+
+func (a *codexAdapter) EnableMCP(name string) error {
+	return errors.Wrap(a.codex.EnableMCP(name), "enabling Codex MCP server")
+}
+
+func (a *codexAdapter) DisableMCP(name string) error {
+	return errors.Wrap(a.codex.DisableMCP(name), "disabling Codex MCP server")
+}
+
+func (a *codexAdapter) InstallAgent(agent any) error {
+	ag, ok := agent.(*codex.Agent)
+	if !ok {
+		return errors.Newf("expected *codex.Agent, got %T", agent)
+	}
+	return errors.Wrap(a.codex.InstallAgent(ag), "installing agent to Codex")
+}
+
+func (a *codexAdapter) UninstallAgent(name string) error {
+	return errors.Wrap(a.codex.UninstallAgent(name), "uninstalling agent from Codex")
+}
+
+func (a *codexAdapter) ListAgents() ([]AgentInfo, error) {
+	agents, err := a.codex.ListAgents()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Codex agents")
+	}
+	infos := make([]AgentInfo, len(agents))
+	for i, ag := range agents {
+		infos[i] = AgentInfo{Name: ag.Name, Description: ag.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *codexAdapter) GetAgent(name string) (any, error) {
+	ag, err := a.codex.GetAgent(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Codex agent")
+	}
+	return ag, nil
+}
+
 // geminiAdapter wraps GeminiPlatform to implement the Platform interface.
 type geminiAdapter struct {
 	baseAdapter
 	gemini *gemini.GeminiPlatform
 }
 
-func newGeminiAdapter() *geminiAdapter {
-	p := gemini.NewGeminiPlatform()
+func newGeminiAdapter(o options) *geminiAdapter {
+	var opts []gemini.Option
+	if o.isProject() {
+		opts = append(opts, gemini.WithScope(gemini.ScopeProject), gemini.WithProjectRoot(o.projectRoot))
+	}
+	p := gemini.NewGeminiPlatform(opts...)
 	return &geminiAdapter{
 		baseAdapter: baseAdapter{p: p},
 		gemini:      p,
@@ -545,7 +803,7 @@ func (a *geminiAdapter) ListCommands() ([]CommandInfo, error) {
 	}
 	infos := make([]CommandInfo, len(commands))
 	for i, c := range commands {
-		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "installed"}
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
 	}
 	return infos, nil
 }
@@ -577,10 +835,9 @@ func (a *geminiAdapter) ListMCP() ([]MCPInfo, error) {
 	}
 	infos := make([]MCPInfo, len(servers))
 	for i, s := range servers {
-		transport := inferTransport("", s.URL)
 		infos[i] = MCPInfo{
-			Name: s.Name, Transport: transport, Command: s.Command,
-			URL: s.URL, Disabled: !s.Enabled, Env: s.Env,
+			Name: s.Name, Transport: s.Transport(), Command: s.Command,
+			URL: s.Endpoint(), Disabled: !s.Enabled, Env: s.Env,
 		}
 	}
 	return infos, nil
@@ -618,37 +875,676 @@ func (a *geminiAdapter) GetAgent(name string) (any, error) {
 	return nil, errors.New("agents are not supported by Gemini CLI")
 }
 
+// cursorAdapter wraps CursorPlatform to implement the Platform interface.
+type cursorAdapter struct {
+	baseAdapter
+	cursor *cursor.CursorPlatform
+}
+
+func newCursorAdapter(o options) *cursorAdapter {
+	var opts []cursor.Option
+	if o.isProject() {
+		opts = append(opts, cursor.WithScope(cursor.ScopeProject), cursor.WithProjectRoot(o.projectRoot))
+	}
+	p := cursor.NewCursorPlatform(opts...)
+	return &cursorAdapter{
+		baseAdapter: baseAdapter{p: p},
+		cursor:      p,
+	}
+}
+
+func (a *cursorAdapter) InstallSkill(skill any) error {
+	r, ok := skill.(*cursor.Rule)
+	if !ok {
+		return errors.Newf("expected *cursor.Rule, got %T", skill)
+	}
+	return errors.Wrap(a.cursor.InstallSkill(r), "installing skill to Cursor")
+}
+
+func (a *cursorAdapter) UninstallSkill(name string) error {
+	return errors.Wrap(a.cursor.UninstallSkill(name), "uninstalling skill from Cursor")
+}
+
+func (a *cursorAdapter) ListSkills() ([]SkillInfo, error) {
+	rules, err := a.cursor.ListSkills()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Cursor skills")
+	}
+	infos := make([]SkillInfo, len(rules))
+	for i, r := range rules {
+		infos[i] = SkillInfo{Name: r.Name, Description: r.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *cursorAdapter) GetSkill(name string) (any, error) {
+	r, err := a.cursor.GetSkill(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Cursor skill")
+	}
+	return r, nil
+}
+
+func (a *cursorAdapter) InstallCommand(cmd any) error {
+	c, ok := cmd.(*cursor.Command)
+	if !ok {
+		return errors.Newf("expected *cursor.Command, got %T", cmd)
+	}
+	return errors.Wrap(a.cursor.InstallCommand(c), "installing command to Cursor")
+}
+
+func (a *cursorAdapter) UninstallCommand(name string) error {
+	return errors.Wrap(a.cursor.UninstallCommand(name), "uninstalling command from Cursor")
+}
+
+func (a *cursorAdapter) ListCommands() ([]CommandInfo, error) {
+	commands, err := a.cursor.ListCommands()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Cursor commands")
+	}
+	infos := make([]CommandInfo, len(commands))
+	for i, c := range commands {
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *cursorAdapter) GetCommand(name string) (any, error) {
+	c, err := a.cursor.GetCommand(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Cursor command")
+	}
+	return c, nil
+}
+
+func (a *cursorAdapter) AddMCP(server any) error {
+	s, ok := server.(*cursor.MCPServer)
+	if !ok {
+		return errors.Newf("expected *cursor.MCPServer, got %T", server)
+	}
+	return errors.Wrap(a.cursor.AddMCP(s), "adding MCP server to Cursor")
+}
+
+func (a *cursorAdapter) RemoveMCP(name string) error {
+	return errors.Wrap(a.cursor.RemoveMCP(name), "removing MCP server from Cursor")
+}
+
+func (a *cursorAdapter) ListMCP() ([]MCPInfo, error) {
+	servers, err := a.cursor.ListMCP()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Cursor MCP servers")
+	}
+	infos := make([]MCPInfo, len(servers))
+	for i, s := range servers {
+		infos[i] = MCPInfo{
+			Name: s.Name, Transport: inferTransport("", s.URL), Command: s.Command,
+			URL: s.URL, Disabled: s.Disabled, Env: s.Env,
+		}
+	}
+	return infos, nil
+}
+
+func (a *cursorAdapter) GetMCP(name string) (any, error) {
+	s, err := a.cursor.GetMCP(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Cursor MCP server")
+	}
+	return s, nil
+}
+
+func (a *cursorAdapter) EnableMCP(name string) error {
+	return errors.Wrap(a.cursor.EnableMCP(name), "enabling Cursor MCP server")
+}
+
+func (a *cursorAdapter) DisableMCP(name string) error {
+	return errors.Wrap(a.cursor.DisableMCP(name), "disabling Cursor MCP server")
+}
+
+func (a *cursorAdapter) InstallAgent(agent any) error {
+	r, ok := agent.(*cursor.Rule)
+	if !ok {
+		return errors.Newf("expected *cursor.Rule, got %T", agent)
+	}
+	return errors.Wrap(a.cursor.InstallAgent(r), "installing agent to Cursor")
+}
+
+func (a *cursorAdapter) UninstallAgent(name string) error {
+	return errors.Wrap(a.cursor.UninstallAgent(name), "uninstalling agent from Cursor")
+}
+
+func (a *cursorAdapter) ListAgents() ([]AgentInfo, error) {
+	rules, err := a.cursor.ListAgents()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Cursor agents")
+	}
+	infos := make([]AgentInfo, len(rules))
+	for i, r := range rules {
+		infos[i] = AgentInfo{Name: r.Name, Description: r.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *cursorAdapter) GetAgent(name string) (any, error) {
+	r, err := a.cursor.GetAgent(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Cursor agent")
+	}
+	return r, nil
+}
+
+// copilotAdapter wraps CopilotPlatform to implement the Platform interface.
+type copilotAdapter struct {
+	baseAdapter
+	copilot *copilot.CopilotPlatform
+}
+
+func newCopilotAdapter(o options) *copilotAdapter {
+	var opts []copilot.Option
+	if o.isProject() {
+		opts = append(opts, copilot.WithScope(copilot.ScopeProject), copilot.WithProjectRoot(o.projectRoot))
+	}
+	p := copilot.NewCopilotPlatform(opts...)
+	return &copilotAdapter{
+		baseAdapter: baseAdapter{p: p},
+		copilot:     p,
+	}
+}
+
+func (a *copilotAdapter) InstallSkill(skill any) error {
+	i, ok := skill.(*copilot.Instruction)
+	if !ok {
+		return errors.Newf("expected *copilot.Instruction, got %T", skill)
+	}
+	return errors.Wrap(a.copilot.InstallSkill(i), "installing skill to GitHub Copilot")
+}
+
+func (a *copilotAdapter) UninstallSkill(name string) error {
+	return errors.Wrap(a.copilot.UninstallSkill(name), "uninstalling skill from GitHub Copilot")
+}
+
+func (a *copilotAdapter) ListSkills() ([]SkillInfo, error) {
+	skills, err := a.copilot.ListSkills()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing GitHub Copilot skills")
+	}
+	infos := make([]SkillInfo, len(skills))
+	for i, s := range skills {
+		infos[i] = SkillInfo{Name: s.Name, Description: s.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *copilotAdapter) GetSkill(name string) (any, error) {
+	i, err := a.copilot.GetSkill(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub Copilot skill")
+	}
+	return i, nil
+}
+
+func (a *copilotAdapter) InstallCommand(cmd any) error {
+	p, ok := cmd.(*copilot.Prompt)
+	if !ok {
+		return errors.Newf("expected *copilot.Prompt, got %T", cmd)
+	}
+	return errors.Wrap(a.copilot.InstallCommand(p), "installing command to GitHub Copilot")
+}
+
+func (a *copilotAdapter) UninstallCommand(name string) error {
+	return errors.Wrap(a.copilot.UninstallCommand(name), "uninstalling command from GitHub Copilot")
+}
+
+func (a *copilotAdapter) ListCommands() ([]CommandInfo, error) {
+	commands, err := a.copilot.ListCommands()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing GitHub Copilot commands")
+	}
+	infos := make([]CommandInfo, len(commands))
+	for i, c := range commands {
+		infos[i] = CommandInfo{Name: c.Name, Description: c.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *copilotAdapter) GetCommand(name string) (any, error) {
+	c, err := a.copilot.GetCommand(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub Copilot command")
+	}
+	return c, nil
+}
+
+func (a *copilotAdapter) AddMCP(server any) error {
+	s, ok := server.(*copilot.MCPServer)
+	if !ok {
+		return errors.Newf("expected *copilot.MCPServer, got %T", server)
+	}
+	return errors.Wrap(a.copilot.AddMCP(s), "adding MCP server to GitHub Copilot")
+}
+
+func (a *copilotAdapter) RemoveMCP(name string) error {
+	return errors.Wrap(a.copilot.RemoveMCP(name), "removing MCP server from GitHub Copilot")
+}
+
+func (a *copilotAdapter) ListMCP() ([]MCPInfo, error) {
+	servers, err := a.copilot.ListMCP()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing GitHub Copilot MCP servers")
+	}
+	infos := make([]MCPInfo, len(servers))
+	for i, s := range servers {
+		infos[i] = MCPInfo{
+			Name: s.Name, Transport: inferTransport(s.Type, s.URL), Command: s.Command,
+			URL: s.URL, Env: s.Env,
+		}
+	}
+	return infos, nil
+}
+
+func (a *copilotAdapter) GetMCP(name string) (any, error) {
+	s, err := a.copilot.GetMCP(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub Copilot MCP server")
+	}
+	return s, nil
+}
+
+func (a *copilotAdapter) EnableMCP(name string) error {
+	return errors.Wrap(a.copilot.EnableMCP(name), "enabling GitHub Copilot MCP server")
+}
+
+func (a *copilotAdapter) DisableMCP(name string) error {
+	return errors.Wrap(a.copilot.DisableMCP(name), "disabling GitHub Copilot MCP server")
+}
+
+func (a *copilotAdapter) InstallAgent(agent any) error {
+	c, ok := agent.(*copilot.ChatMode)
+	if !ok {
+		return errors.Newf("expected *copilot.ChatMode, got %T", agent)
+	}
+	return errors.Wrap(a.copilot.InstallAgent(c), "installing agent to GitHub Copilot")
+}
+
+func (a *copilotAdapter) UninstallAgent(name string) error {
+	return errors.Wrap(a.copilot.UninstallAgent(name), "uninstalling agent from GitHub Copilot")
+}
+
+func (a *copilotAdapter) ListAgents() ([]AgentInfo, error) {
+	modes, err := a.copilot.ListAgents()
+	if err != nil {
+		return nil, errors.Wrap(err, "listing GitHub Copilot agents")
+	}
+	infos := make([]AgentInfo, len(modes))
+	for i, m := range modes {
+		infos[i] = AgentInfo{Name: m.Name, Description: m.Description, Source: "local"}
+	}
+	return infos, nil
+}
+
+func (a *copilotAdapter) GetAgent(name string) (any, error) {
+	m, err := a.copilot.GetAgent(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub Copilot agent")
+	}
+	return m, nil
+}
+
+// genericAdapter wraps a GenericPlatform, a platform described by a
+// definition, to implement the Platform interface. Such platforms only have
+// MCP servers; the skill, command and agent methods report
+// errors.ErrNotSupported for installs and find nothing installed.
+type genericAdapter struct {
+	baseAdapter
+	generic *generic.GenericPlatform
+}
+
+func newGenericAdapter(def *generic.Definition, o options) *genericAdapter {
+	var opts []generic.Option
+	if o.isProject() {
+		opts = append(opts, generic.WithScope(generic.ScopeProject), generic.WithProjectRoot(o.projectRoot))
+	}
+	p := generic.NewGenericPlatform(def, opts...)
+	return &genericAdapter{
+		baseAdapter: baseAdapter{p: p},
+		generic:     p,
+	}
+}
+
+// notSupported returns an error reporting that the platform has no
+// resources of the given kind.
+func (a *genericAdapter) notSupported(kind string) error {
+	return errors.Wrapf(errors.ErrNotSupported, "%s does not support %s", a.generic.DisplayName(), kind)
+}
+
+func (a *genericAdapter) InstallSkill(any) error           { return a.notSupported("skills") }
+func (a *genericAdapter) UninstallSkill(string) error      { return nil }
+func (a *genericAdapter) ListSkills() ([]SkillInfo, error) { return nil, nil }
+func (a *genericAdapter) GetSkill(string) (any, error)     { return nil, a.notSupported("skills") }
+
+func (a *genericAdapter) InstallCommand(any) error             { return a.notSupported("commands") }
+func (a *genericAdapter) UninstallCommand(string) error        { return nil }
+func (a *genericAdapter) ListCommands() ([]CommandInfo, error) { return nil, nil }
+func (a *genericAdapter) GetCommand(string) (any, error)       { return nil, a.notSupported("commands") }
+
+func (a *genericAdapter) InstallAgent(any) error           { return a.notSupported("agents") }
+func (a *genericAdapter) UninstallAgent(string) error      { return nil }
+func (a *genericAdapter) ListAgents() ([]AgentInfo, error) { return nil, nil }
+func (a *genericAdapter) GetAgent(string) (any, error)     { return nil, a.notSupported("agents") }
+
+func (a *genericAdapter) AddMCP(server any) error {
+	s, ok := server.(*mcp.Server)
+	if !ok {
+		return errors.Newf("expected *mcp.Server, got %T", server)
+	}
+	return errors.Wrapf(a.generic.AddMCP(s), "adding MCP server to %s", a.generic.DisplayName())
+}
+
+func (a *genericAdapter) RemoveMCP(name string) error {
+	return errors.Wrapf(a.generic.RemoveMCP(name), "removing MCP server from %s", a.generic.DisplayName())
+}
+
+func (a *genericAdapter) ListMCP() ([]MCPInfo, error) {
+	servers, err := a.generic.ListMCP()
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing %s MCP servers", a.generic.DisplayName())
+	}
+	infos := make([]MCPInfo, len(servers))
+	for i, s := range servers {
+		infos[i] = MCPInfo{
+			Name: s.Name, Transport: s.Transport, Command: s.Command,
+			URL: s.URL, Disabled: s.Disabled, Env: s.Env,
+		}
+	}
+	return infos, nil
+}
+
+func (a *genericAdapter) GetMCP(name string) (any, error) {
+	s, err := a.generic.GetMCP(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s MCP server", a.generic.DisplayName())
+	}
+	return s, nil
+}
+
+func (a *genericAdapter) EnableMCP(name string) error {
+	return errors.Wrapf(a.generic.EnableMCP(name), "enabling %s MCP server", a.generic.DisplayName())
+}
+
+func (a *genericAdapter) DisableMCP(name string) error {
+	return errors.Wrapf(a.generic.DisableMCP(name), "disabling %s MCP server", a.generic.DisplayName())
+}
+
+// pluginAdapter wraps a PluginPlatform, a platform implemented by an
+// external executable, to implement the Platform interface. A plugin that
+// reports errors.ErrNotSupported for a list or uninstall is treated as
+// having nothing installed, as for definition-based platforms.
+type pluginAdapter struct {
+	baseAdapter
+	plugin *plugin.PluginPlatform
+}
+
+func newPluginAdapter(pl *plugin.Plugin, o options) *pluginAdapter {
+	var opts []plugin.Option
+	if o.isProject() {
+		opts = append(opts, plugin.WithScope(plugin.ScopeProject), plugin.WithProjectRoot(o.projectRoot))
+	}
+	p := plugin.NewPluginPlatform(pl, opts...)
+	return &pluginAdapter{
+		baseAdapter: baseAdapter{p: p},
+		plugin:      p,
+	}
+}
+
+// ignoreNotSupported returns nil for errors.ErrNotSupported and err
+// otherwise.
+func ignoreNotSupported(err error) error {
+	if errors.Is(err, errors.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// resourceInfos lists resources with list and converts them with info.
+func resourceInfos[T any](list func() ([]plugin.ResourceInfo, error), info func(plugin.ResourceInfo) T) ([]T, error) {
+	resources, err := list()
+	if err != nil {
+		return nil, ignoreNotSupported(err)
+	}
+	infos := make([]T, len(resources))
+	for i, r := range resources {
+		infos[i] = info(r)
+	}
+	return infos, nil
+}
+
+func (a *pluginAdapter) InstallSkill(skill any) error {
+	s, ok := skill.(*claude.Skill)
+	if !ok {
+		return errors.Newf("expected *claude.Skill, got %T", skill)
+	}
+	return errors.Wrapf(a.plugin.InstallSkill(s), "installing skill to %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) UninstallSkill(name string) error {
+	return errors.Wrapf(ignoreNotSupported(a.plugin.UninstallSkill(name)), "uninstalling skill from %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) ListSkills() ([]SkillInfo, error) {
+	infos, err := resourceInfos(a.plugin.ListSkills, func(r plugin.ResourceInfo) SkillInfo {
+		return SkillInfo{Name: r.Name, Description: r.Description, Source: "local"}
+	})
+	return infos, errors.Wrapf(err, "listing %s skills", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) GetSkill(name string) (any, error) {
+	s, err := a.plugin.GetSkill(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s skill", a.plugin.DisplayName())
+	}
+	return s, nil
+}
+
+func (a *pluginAdapter) InstallCommand(cmd any) error {
+	c, ok := cmd.(*claude.Command)
+	if !ok {
+		return errors.Newf("expected *claude.Command, got %T", cmd)
+	}
+	return errors.Wrapf(a.plugin.InstallCommand(c), "installing command to %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) UninstallCommand(name string) error {
+	return errors.Wrapf(ignoreNotSupported(a.plugin.UninstallCommand(name)), "uninstalling command from %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) ListCommands() ([]CommandInfo, error) {
+	infos, err := resourceInfos(a.plugin.ListCommands, func(r plugin.ResourceInfo) CommandInfo {
+		return CommandInfo{Name: r.Name, Description: r.Description, Source: "local"}
+	})
+	return infos, errors.Wrapf(err, "listing %s commands", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) GetCommand(name string) (any, error) {
+	c, err := a.plugin.GetCommand(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s command", a.plugin.DisplayName())
+	}
+	return c, nil
+}
+
+func (a *pluginAdapter) InstallAgent(agent any) error {
+	ag, ok := agent.(*claude.Agent)
+	if !ok {
+		return errors.Newf("expected *claude.Agent, got %T", agent)
+	}
+	return errors.Wrapf(a.plugin.InstallAgent(ag), "installing agent to %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) UninstallAgent(name string) error {
+	return errors.Wrapf(ignoreNotSupported(a.plugin.UninstallAgent(name)), "uninstalling agent from %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) ListAgents() ([]AgentInfo, error) {
+	infos, err := resourceInfos(a.plugin.ListAgents, func(r plugin.ResourceInfo) AgentInfo {
+		return AgentInfo{Name: r.Name, Description: r.Description, Source: "local"}
+	})
+	return infos, errors.Wrapf(err, "listing %s agents", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) GetAgent(name string) (any, error) {
+	ag, err := a.plugin.GetAgent(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s agent", a.plugin.DisplayName())
+	}
+	return ag, nil
+}
+
+func (a *pluginAdapter) AddMCP(server any) error {
+	s, ok := server.(*mcp.Server)
+	if !ok {
+		return errors.Newf("expected *mcp.Server, got %T", server)
+	}
+	return errors.Wrapf(a.plugin.AddMCP(s), "adding MCP server to %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) RemoveMCP(name string) error {
+	return errors.Wrapf(a.plugin.RemoveMCP(name), "removing MCP server from %s", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) ListMCP() ([]MCPInfo, error) {
+	servers, err := a.plugin.ListMCP()
+	if err != nil {
+		return nil, errors.Wrapf(ignoreNotSupported(err), "listing %s MCP servers", a.plugin.DisplayName())
+	}
+	infos := make([]MCPInfo, len(servers))
+	for i, s := range servers {
+		infos[i] = MCPInfo{
+			Name: s.Name, Transport: inferTransport(s.Transport, s.URL), Command: s.Command,
+			URL: s.URL, Disabled: s.Disabled, Env: s.Env,
+		}
+	}
+	return infos, nil
+}
+
+func (a *pluginAdapter) GetMCP(name string) (any, error) {
+	s, err := a.plugin.GetMCP(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s MCP server", a.plugin.DisplayName())
+	}
+	return s, nil
+}
+
+func (a *pluginAdapter) EnableMCP(name string) error {
+	return errors.Wrapf(a.plugin.EnableMCP(name), "enabling %s MCP server", a.plugin.DisplayName())
+}
+
+func (a *pluginAdapter) DisableMCP(name string) error {
+	return errors.Wrapf(a.plugin.DisableMCP(name), "disabling %s MCP server", a.plugin.DisplayName())
+}
+
+// supporter is implemented by adapters whose list methods find nothing,
+// rather than fail, for resource types the platform does not have.
+type supporter interface {
+	supports(t resource.ResourceType) bool
+}
+
+// Supports reports whether p can hold resources of type t. Definition-based
+// platforms only have MCP servers, and a plugin has the types it does not
+// answer not_supported for. Other platforms report a type they lack as an
+// error from its list method.
+func Supports(p Platform, t resource.ResourceType) bool {
+	if s, ok := p.(supporter); ok {
+		return s.supports(t)
+	}
+	return true
+}
+
+func (a *genericAdapter) supports(t resource.ResourceType) bool {
+	return t == resource.TypeMCP
+}
+
+func (a *pluginAdapter) supports(t resource.ResourceType) bool {
+	var err error
+	switch t {
+	case resource.TypeSkill:
+		_, err = a.plugin.ListSkills()
+	case resource.TypeCommand:
+		_, err = a.plugin.ListCommands()
+	case resource.TypeAgent:
+		_, err = a.plugin.ListAgents()
+	case resource.TypeMCP:
+		_, err = a.plugin.ListMCP()
+	}
+	return !errors.Is(err, errors.ErrNotSupported)
+}
+
 // inferTransport determines the transport type based on server type and URL.
+// Remote servers without an explicit type use Streamable HTTP.
 func inferTransport(serverType, url string) string {
 	if serverType != "" {
 		return serverType
 	}
 	if url != "" {
-		return "sse"
+		return mcp.TransportHTTP
 	}
-	return "stdio"
+	return mcp.TransportStdio
 }
 
-func NewPlatform(name string) (Platform, error) {
+// NewPlatform returns the Platform adapter for the named platform.
+// By default adapters use user-scoped paths; pass WithScope(ScopeProject)
+// and WithProjectRoot to target a project's configuration instead.
+func NewPlatform(name string, opts ...Option) (Platform, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	switch name {
 	case paths.PlatformClaude:
-		return newClaudeAdapter(), nil
+		return newClaudeAdapter(o), nil
 	case paths.PlatformOpenCode:
-		return newOpenCodeAdapter(), nil
+		return newOpenCodeAdapter(o), nil
+	case paths.PlatformCodex:
+		return newCodexAdapter(o), nil
 	case paths.PlatformGemini:
-		return newGeminiAdapter(), nil
+		return newGeminiAdapter(o), nil
+	case paths.PlatformCursor:
+		return newCursorAdapter(o), nil
+	case paths.PlatformCopilot:
+		return newCopilotAdapter(o), nil
 	default:
+		if def, ok := generic.Lookup(name); ok {
+			return newGenericAdapter(def, o), nil
+		}
+		if p, ok := plugin.Lookup(name); ok {
+			return newPluginAdapter(p, o), nil
+		}
 		return nil, errors.Wrapf(ErrUnknownPlatform, "platform %q not recognized", name)
 	}
 }
 
 // ResolvePlatforms returns Platform instances for the given platform names.
-// If names is empty, returns all detected/installed platforms.
+// If names is empty, returns the detected/installed platforms among the
+// defaults set with WithDefaultPlatforms.
 // Returns an error if any platform name is invalid or if no platforms are available.
-func ResolvePlatforms(names []string) ([]Platform, error) {
-	// If no names specified, use all detected platforms
+// The options are applied to every returned platform.
+func ResolvePlatforms(names []string, opts ...Option) ([]Platform, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no names specified, use the detected default platforms
 	if len(names) == 0 {
 		detected := platform.DetectInstalled()
+		if len(o.defaults) > 0 {
+			detected = slices.DeleteFunc(detected, func(d *platform.DetectionResult) bool {
+				return !slices.Contains(o.defaults, d.Name)
+			})
+			if len(detected) == 0 {
+				return nil, errors.Wrapf(ErrNoPlatformsAvailable, "none of the default platforms (%s) detected on this system",
+					strings.Join(o.defaults, ", "))
+			}
+		}
 		if len(detected) == 0 {
 			return nil, errors.Wrap(ErrNoPlatformsAvailable, "no AI assistants detected on this system")
 		}
@@ -656,7 +1552,7 @@ func ResolvePlatforms(names []string) ([]Platform, error) {
 		platforms := make([]Platform, 0, len(detected))
 		for _, d := range detected {
 			// Only include platforms we have adapters for
-			p, err := NewPlatform(d.Name)
+			p, err := NewPlatform(d.Name, opts...)
 			if err != nil {
 				continue // Skip platforms without adapters
 			}
@@ -679,7 +1575,7 @@ func ResolvePlatforms(names []string) ([]Platform, error) {
 			continue
 		}
 
-		p, err := NewPlatform(name)
+		p, err := NewPlatform(name, opts...)
 		if err != nil {
 			invalid = append(invalid, name)
 			continue
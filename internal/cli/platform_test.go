@@ -2,9 +2,19 @@ package cli
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
+	aixerrors "github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/plugin"
+	"github.com/thoreinstein/aix/internal/resource"
 )
 
 func TestNewPlatform(t *testing.T) {
@@ -32,6 +42,30 @@ func TestNewPlatform(t *testing.T) {
 			wantName:    "gemini",
 			wantErr:     nil,
 		},
+		{
+			name:        "codex platform",
+			platformArg: "codex",
+			wantName:    "codex",
+			wantErr:     nil,
+		},
+		{
+			name:        "cursor platform",
+			platformArg: "cursor",
+			wantName:    "cursor",
+			wantErr:     nil,
+		},
+		{
+			name:        "copilot platform",
+			platformArg: "copilot",
+			wantName:    "copilot",
+			wantErr:     nil,
+		},
+		{
+			name:        "zed platform from a definition",
+			platformArg: "zed",
+			wantName:    "zed",
+			wantErr:     nil,
+		},
 		{
 			name:        "unknown platform",
 			platformArg: "unknown",
@@ -44,12 +78,6 @@ func TestNewPlatform(t *testing.T) {
 			wantName:    "",
 			wantErr:     ErrUnknownPlatform,
 		},
-		{
-			name:        "codex not supported yet",
-			platformArg: "codex",
-			wantName:    "",
-			wantErr:     ErrUnknownPlatform,
-		},
 	}
 
 	for _, tt := range tests {
@@ -96,12 +124,51 @@ func TestResolvePlatforms_EmptyNames(t *testing.T) {
 	// Verify all returned platforms have adapters
 	for _, p := range platforms {
 		name := p.Name()
-		if name != paths.PlatformClaude && name != paths.PlatformOpenCode && name != paths.PlatformGemini {
+		switch name {
+		case paths.PlatformClaude, paths.PlatformOpenCode, paths.PlatformCodex, paths.PlatformGemini, paths.PlatformCursor,
+			paths.PlatformCopilot:
+		default:
 			t.Errorf("ResolvePlatforms(nil) returned unsupported platform: %q", name)
 		}
 	}
 }
 
+func TestResolvePlatforms_DefaultPlatforms(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("CLAUDE_CONFIG_DIR", "")
+	t.Setenv("CODEX_HOME", "")
+	for _, dir := range []string{".claude", ".codex", ".gemini"} {
+		if err := os.Mkdir(filepath.Join(home, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defaults := WithDefaultPlatforms([]string{paths.PlatformCodex, paths.PlatformClaude, paths.PlatformOpenCode})
+	platforms, err := ResolvePlatforms(nil, defaults)
+	if err != nil {
+		t.Fatalf("ResolvePlatforms(nil) error = %v", err)
+	}
+	var got []string
+	for _, p := range platforms {
+		got = append(got, p.Name())
+	}
+	// Detection order; opencode is not installed.
+	want := []string{paths.PlatformClaude, paths.PlatformCodex}
+	if !slices.Equal(got, want) {
+		t.Errorf("ResolvePlatforms(nil) = %v, want %v", got, want)
+	}
+
+	if _, err := ResolvePlatforms(nil, WithDefaultPlatforms([]string{paths.PlatformOpenCode})); !errors.Is(err, ErrNoPlatformsAvailable) {
+		t.Errorf("ResolvePlatforms(nil) with no default detected error = %v, want ErrNoPlatformsAvailable", err)
+	}
+
+	// Named platforms are not limited to the defaults.
+	if platforms, err := ResolvePlatforms([]string{paths.PlatformGemini}, defaults); err != nil || len(platforms) != 1 {
+		t.Errorf("ResolvePlatforms([gemini]) = %v, %v", platforms, err)
+	}
+}
+
 func TestResolvePlatforms_ValidNames(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -117,8 +184,8 @@ func TestResolvePlatforms_ValidNames(t *testing.T) {
 		},
 		{
 			name:      "multiple valid platforms",
-			names:     []string{"claude", "opencode", "gemini"},
-			wantCount: 3,
+			names:     []string{"claude", "opencode", "codex", "gemini"},
+			wantCount: 4,
 			wantErr:   false,
 		},
 	}
@@ -159,10 +226,6 @@ func TestResolvePlatforms_InvalidNames(t *testing.T) {
 			name:  "mix of valid and invalid",
 			names: []string{"claude", "invalid"},
 		},
-		{
-			name:  "unsupported platform (codex)",
-			names: []string{"codex"},
-		},
 	}
 
 	for _, tt := range tests {
@@ -186,6 +249,7 @@ func TestPlatformInterface(t *testing.T) {
 	platforms := []Platform{
 		&claudeAdapter{},
 		&opencodeAdapter{},
+		&codexAdapter{},
 		&geminiAdapter{},
 	}
 
@@ -254,6 +318,18 @@ func TestOpencodeAdapter_InstallCommand_WrongType(t *testing.T) {
 	}
 }
 
+func TestCodexAdapter_InstallCommand_WrongType(t *testing.T) {
+	p, err := NewPlatform("codex")
+	if err != nil {
+		t.Fatalf("NewPlatform(codex) unexpected error: %v", err)
+	}
+
+	err = p.InstallCommand("not a command")
+	if err == nil {
+		t.Error("InstallCommand with wrong type expected error, got nil")
+	}
+}
+
 func TestGeminiAdapter_InstallCommand_WrongType(t *testing.T) {
 	p, err := NewPlatform("gemini")
 	if err != nil {
@@ -265,3 +341,180 @@ func TestGeminiAdapter_InstallCommand_WrongType(t *testing.T) {
 		t.Error("InstallCommand with wrong type expected error, got nil")
 	}
 }
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Scope
+		wantErr bool
+	}{
+		{input: "", want: ScopeUser},
+		{input: "user", want: ScopeUser},
+		{input: "project", want: ScopeProject},
+		{input: "global", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseScope(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidScope) {
+					t.Errorf("ParseScope(%q) error = %v, want ErrInvalidScope", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseScope(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseScope(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPlatform_ProjectScope(t *testing.T) {
+	root := t.TempDir()
+
+	tests := []struct {
+		name    string
+		wantDir string
+	}{
+		{name: "claude", wantDir: filepath.Join(root, ".claude")},
+		{name: "opencode", wantDir: root},
+		{name: "codex", wantDir: filepath.Join(root, ".codex")},
+		{name: "gemini", wantDir: filepath.Join(root, ".gemini")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPlatform(tt.name, WithScope(ScopeProject), WithProjectRoot(root))
+			if err != nil {
+				t.Fatalf("NewPlatform(%q) unexpected error: %v", tt.name, err)
+			}
+			if dir := p.SkillDir(); !strings.HasPrefix(dir, tt.wantDir+string(filepath.Separator)) {
+				t.Errorf("SkillDir() = %q, want under %q", dir, tt.wantDir)
+			}
+			if path := p.MCPConfigPath(); !strings.HasPrefix(path, root) {
+				t.Errorf("MCPConfigPath() = %q, want under %q", path, root)
+			}
+		})
+	}
+}
+
+func TestGenericAdapter(t *testing.T) {
+	root := t.TempDir()
+	p, err := NewPlatform("zed", WithScope(ScopeProject), WithProjectRoot(root))
+	if err != nil {
+		t.Fatalf("NewPlatform(zed) unexpected error: %v", err)
+	}
+
+	if err := p.InstallSkill(nil); !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("InstallSkill() error = %v, want ErrNotSupported", err)
+	}
+	if skills, err := p.ListSkills(); err != nil || len(skills) != 0 {
+		t.Errorf("ListSkills() = %v, %v; want nothing installed", skills, err)
+	}
+	if Supports(p, resource.TypeSkill) || !Supports(p, resource.TypeMCP) {
+		t.Error("Supports() should report MCP servers only")
+	}
+
+	server := &mcp.Server{Name: "github", Command: "npx"}
+	if err := p.AddMCP(server); err != nil {
+		t.Fatalf("AddMCP() error = %v", err)
+	}
+	if path := p.MCPConfigPath(); path != filepath.Join(root, ".zed", "settings.json") {
+		t.Errorf("MCPConfigPath() = %q", path)
+	}
+	infos, err := p.ListMCP()
+	if err != nil || len(infos) != 1 || infos[0].Command != "npx" {
+		t.Errorf("ListMCP() = %+v, %v", infos, err)
+	}
+	got, err := CanonicalMCP(mustGetMCP(t, p, "github"))
+	if err != nil || got.Command != "npx" {
+		t.Errorf("CanonicalMCP() = %+v, %v", got, err)
+	}
+	if err := p.AddMCP(&struct{}{}); err == nil {
+		t.Error("AddMCP() accepted a non-canonical server")
+	}
+}
+
+// shellPlugin is a plugin with a single MCP server that supports nothing
+// else.
+const shellPlugin = `#!/bin/sh
+read -r req
+case "$req" in
+*'"method":"describe"'*)
+	echo '{"result":{"protocol_version":1,"display_name":"Shell Assistant","config_dir":".shell-assistant"}}' ;;
+*'"method":"mcp.list"'*)
+	echo '{"result":[{"name":"remote","url":"https://example.com/mcp"}]}' ;;
+*)
+	echo '{"error":{"code":"not_supported","message":"not supported"}}'
+	exit 1 ;;
+esac
+`
+
+func TestPluginAdapter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test plugin is a shell script")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, plugin.ExecutablePrefix+"shell-assistant"), []byte(shellPlugin), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := plugin.Load([]string{dir}); err != nil {
+		t.Fatalf("plugin.Load() error = %v", err)
+	}
+
+	p, err := NewPlatform("shell-assistant")
+	if err != nil {
+		t.Fatalf("NewPlatform(shell-assistant) unexpected error: %v", err)
+	}
+	if p.DisplayName() != "Shell Assistant" {
+		t.Errorf("DisplayName() = %q", p.DisplayName())
+	}
+
+	// Unsupported resources are reported on install and absent otherwise
+	if err := p.InstallAgent(&claude.Agent{Name: "a"}); !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("InstallAgent() error = %v, want ErrNotSupported", err)
+	}
+	if agents, err := p.ListAgents(); err != nil || len(agents) != 0 {
+		t.Errorf("ListAgents() = %v, %v; want nothing installed", agents, err)
+	}
+	if Supports(p, resource.TypeAgent) || !Supports(p, resource.TypeMCP) {
+		t.Error("Supports() should report MCP servers only")
+	}
+	if err := p.UninstallAgent("a"); err != nil {
+		t.Errorf("UninstallAgent() error = %v", err)
+	}
+	if err := p.InstallSkill(&struct{}{}); err == nil {
+		t.Error("InstallSkill() accepted a non-canonical skill")
+	}
+
+	infos, err := p.ListMCP()
+	if err != nil || len(infos) != 1 || infos[0].Transport != mcp.TransportHTTP {
+		t.Errorf("ListMCP() = %+v, %v", infos, err)
+	}
+}
+
+// mustGetMCP returns the named MCP server, failing the test if it is missing.
+func mustGetMCP(t *testing.T, p Platform, name string) any {
+	t.Helper()
+	s, err := p.GetMCP(name)
+	if err != nil {
+		t.Fatalf("GetMCP(%q) error = %v", name, err)
+	}
+	return s
+}
+
+func TestNewPlatform_ProjectScopeRequiresRoot(t *testing.T) {
+	_, err := NewPlatform("claude", WithScope(ScopeProject))
+	if !errors.Is(err, ErrProjectRootRequired) {
+		t.Errorf("NewPlatform() error = %v, want ErrProjectRootRequired", err)
+	}
+
+	_, err = ResolvePlatforms([]string{"claude"}, WithScope(ScopeProject))
+	if !errors.Is(err, ErrProjectRootRequired) {
+		t.Errorf("ResolvePlatforms() error = %v, want ErrProjectRootRequired", err)
+	}
+}
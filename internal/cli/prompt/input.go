@@ -0,0 +1,59 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// ErrInputCancelled is returned when input ends before an answer is given.
+var ErrInputCancelled = errors.New("input cancelled")
+
+// Input asks free-form questions.
+type Input struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+// NewInput creates an Input using stdin and stdout.
+func NewInput() *Input {
+	return NewInputWithIO(os.Stdin, os.Stdout)
+}
+
+// NewInputWithIO creates an Input with custom reader and writer for testing.
+func NewInputWithIO(r io.Reader, w io.Writer) *Input {
+	return &Input{
+		reader: bufio.NewReader(r),
+		writer: w,
+	}
+}
+
+// Ask writes question, followed by def in brackets when it is not empty,
+// and returns the trimmed answer, or def if the answer is empty.
+//
+// Returns ErrInputCancelled if input is EOF (e.g., Ctrl+D).
+func (in *Input) Ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(in.writer, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(in.writer, "%s: ", question)
+	}
+
+	answer, err := in.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && answer == "" {
+		return "", ErrInputCancelled
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", errors.Wrap(err, "reading answer")
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
@@ -0,0 +1,38 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInput_Ask(t *testing.T) {
+	var out bytes.Buffer
+	in := NewInputWithIO(strings.NewReader("acme\n\n  spaced  \nlast"), &out)
+
+	tests := []struct {
+		question, def, want string
+	}{
+		{"Organization", "", "acme"},
+		{"Branch", "main", "main"},
+		{"Label", "", "spaced"},
+		{"Final", "", "last"},
+	}
+	for _, tt := range tests {
+		got, err := in.Ask(tt.question, tt.def)
+		if err != nil {
+			t.Fatalf("Ask(%q) error = %v", tt.question, err)
+		}
+		if got != tt.want {
+			t.Errorf("Ask(%q) = %q, want %q", tt.question, got, tt.want)
+		}
+	}
+
+	if _, err := in.Ask("More", ""); !errors.Is(err, ErrInputCancelled) {
+		t.Errorf("Ask() at EOF error = %v, want ErrInputCancelled", err)
+	}
+	if !strings.Contains(out.String(), "Organization: Branch [main]: ") {
+		t.Errorf("prompt output = %q", out.String())
+	}
+}
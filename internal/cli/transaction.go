@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+// AbortTransaction rolls back every platform changed in tx after a platform
+// failed with err. The returned error wraps err and says whether the change
+// was undone everywhere, so the caller can return it directly.
+func AbortTransaction(tx *backup.Transaction, err error) error {
+	names := tx.Platforms()
+	if rbErr := tx.Rollback(); rbErr != nil {
+		return errors.NewExitErrorWithSuggestion(
+			errors.Newf("%w; rolling back failed, so some platforms may be partly changed: %w", err, rbErr),
+			errors.ExitSystem,
+			"Inspect the affected platforms with 'aix backup diff <platform>' and restore them with 'aix backup restore --exact'",
+		)
+	}
+	if len(names) == 0 {
+		return err
+	}
+	return errors.Newf("%w (rolled back %s; no platform was changed)", err, strings.Join(names, ", "))
+}
+
+// BeginChange adds p to tx, taking its session backup, and tracks the
+// resource of type t named name that is about to change on it; see
+// TrackResource.
+func BeginChange(tx *backup.Transaction, p Platform, t resource.ResourceType, name string) error {
+	if err := tx.Begin(p.Name(), p.BackupPaths()); err != nil {
+		return err
+	}
+	return TrackResource(tx, p, t, name)
+}
+
+// TrackResource records in tx the files on p that a change to the resource
+// of type t named name writes, so that rolling back restores only them:
+// the resource's files for skills, commands and agents, and the MCP config
+// file for servers. Where p does not say where it keeps such resources,
+// its backup paths are tracked whole.
+func TrackResource(tx *backup.Transaction, p Platform, t resource.ResourceType, name string) error {
+	var dir string
+	switch t {
+	case resource.TypeSkill:
+		dir = p.SkillDir()
+	case resource.TypeCommand:
+		dir = p.CommandDir()
+	case resource.TypeAgent:
+		dir = p.AgentDir()
+	case resource.TypeMCP:
+		if path := p.MCPConfigPath(); path != "" {
+			return tx.Track(p.Name(), path)
+		}
+	default:
+		return errors.Newf("unknown resource type %q", t)
+	}
+	if dir != "" {
+		return tx.TrackResource(p.Name(), dir, name)
+	}
+	for _, path := range p.BackupPaths() {
+		if err := tx.Track(p.Name(), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestTrackResource(t *testing.T) {
+	root := t.TempDir()
+	p, err := NewPlatform("claude", WithScope(ScopeProject), WithProjectRoot(root))
+	if err != nil {
+		t.Fatalf("NewPlatform(claude) unexpected error: %v", err)
+	}
+
+	// A file the assistant writes next to the resources is not tracked
+	other := filepath.Join(root, ".claude", "settings.local.json")
+	if err := os.MkdirAll(filepath.Dir(other), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := backup.NewTransaction()
+	if err := TrackResource(tx, p, resource.TypeSkill, "review"); err != nil {
+		t.Fatalf("TrackResource(skill) error = %v", err)
+	}
+	if err := TrackResource(tx, p, resource.TypeMCP, "github"); err != nil {
+		t.Fatalf("TrackResource(mcp) error = %v", err)
+	}
+	skill := &claude.Skill{Name: "review", Description: "Reviews code", Instructions: "Review."}
+	if err := p.InstallSkill(skill); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(p.SkillDir(), "review")); !os.IsNotExist(err) {
+		t.Errorf("installed skill should be rolled back, stat error = %v", err)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("untracked file should be kept, stat error = %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -22,7 +23,10 @@ var repoNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
 
 // Config represents the top-level configuration structure.
 type Config struct {
-	Version          int                         `mapstructure:"version" yaml:"version"`
+	Version int `mapstructure:"version" yaml:"version"`
+
+	// DefaultPlatforms are the platforms targeted when none are named. Empty
+	// means every platform.
 	DefaultPlatforms []string                    `mapstructure:"default_platforms" yaml:"default_platforms"`
 	Platforms        map[string]PlatformOverride `mapstructure:"platforms" yaml:"platforms"`
 	Repos            map[string]RepoConfig       `mapstructure:"repos" yaml:"repos"`
@@ -39,6 +43,10 @@ type RepoConfig struct {
 	Name    string    `mapstructure:"name" yaml:"name"`
 	Path    string    `mapstructure:"path" yaml:"path"`
 	AddedAt time.Time `mapstructure:"added_at" yaml:"added_at"`
+
+	// Ref pins the repository to a branch, tag, or commit SHA.
+	// Empty means the clone tracks the remote's default branch.
+	Ref string `mapstructure:"ref" yaml:"ref,omitempty"`
 }
 
 // Validate checks the configuration for errors.
@@ -53,10 +61,13 @@ func (c *Config) Validate() error {
 		}
 	}
 
-	for p := range c.Platforms {
+	for p, o := range c.Platforms {
 		if !paths.ValidPlatform(p) {
 			return errors.Newf("invalid platform override key: %s", p)
 		}
+		if o.ConfigDir != "" && !filepath.IsAbs(o.ConfigDir) && o.ConfigDir != "~" && !strings.HasPrefix(o.ConfigDir, "~/") {
+			return errors.Newf("config_dir for platform %s must be an absolute path: %s", p, o.ConfigDir)
+		}
 	}
 
 	for name := range c.Repos {
@@ -68,6 +79,18 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ConfigDirs returns the config_dir overrides, keyed by platform name, for
+// paths.SetConfigDirOverrides.
+func (c *Config) ConfigDirs() map[string]string {
+	dirs := make(map[string]string, len(c.Platforms))
+	for p, o := range c.Platforms {
+		if o.ConfigDir != "" {
+			dirs[p] = o.ConfigDir
+		}
+	}
+	return dirs
+}
+
 // Init initializes Viper with default configuration.
 // Call this once at application startup before accessing config values.
 func Init() {
@@ -100,7 +123,6 @@ func Init() {
 
 	// Defaults
 	viper.SetDefault("version", 1)
-	viper.SetDefault("default_platforms", paths.Platforms())
 }
 
 // ActiveConfigPath returns the path to the configuration file currently in use.
@@ -136,6 +158,18 @@ func DefaultConfigPath() string {
 	return filepath.Join(paths.ConfigHome(), AppName, "config.yaml")
 }
 
+// PlatformsDir returns the directory holding user platform definitions, the
+// platforms directory next to the default config file.
+func PlatformsDir() string {
+	return filepath.Join(filepath.Dir(DefaultConfigPath()), "platforms")
+}
+
+// PluginsDir returns the directory searched for platform plugins before
+// PATH, the plugins directory next to the default config file.
+func PluginsDir() string {
+	return filepath.Join(filepath.Dir(DefaultConfigPath()), "plugins")
+}
+
 // Load reads the configuration file.
 // If path is provided, it reads from that specific file.
 // If path is empty, it searches in the default locations.
@@ -169,10 +203,6 @@ func Load(path string) (*Config, error) {
 	if cfg.Version == 0 {
 		cfg.Version = 1
 	}
-	if len(cfg.DefaultPlatforms) == 0 {
-		cfg.DefaultPlatforms = paths.Platforms()
-	}
-
 	if err := cfg.Validate(); err != nil {
 		return nil, errors.Wrap(err, "validating config")
 	}
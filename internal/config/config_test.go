@@ -19,9 +19,28 @@ func TestInit(t *testing.T) {
 		t.Errorf("expected version default 1, got %d", viper.GetInt("version"))
 	}
 
-	platforms := viper.GetStringSlice("default_platforms")
-	if len(platforms) == 0 {
-		t.Error("expected default_platforms to have values")
+	// Unset default_platforms means every platform; listing them would
+	// load the plugins on every run
+	if viper.IsSet("default_platforms") {
+		t.Errorf("expected default_platforms to be unset, got %v", viper.GetStringSlice("default_platforms"))
+	}
+}
+
+func TestPlatformsDir(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("AIX_CONFIG_DIR", tempDir)
+
+	if got, want := PlatformsDir(), filepath.Join(tempDir, "platforms"); got != want {
+		t.Errorf("PlatformsDir() = %q, want %q", got, want)
+	}
+}
+
+func TestPluginsDir(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("AIX_CONFIG_DIR", tempDir)
+
+	if got, want := PluginsDir(), filepath.Join(tempDir, "plugins"); got != want {
+		t.Errorf("PluginsDir() = %q, want %q", got, want)
 	}
 }
 
@@ -99,6 +118,11 @@ func TestLoad_InvalidConfig(t *testing.T) {
 			content: "platforms:\n  invalid_platform:\n    config_dir: /tmp\n",
 			wantErr: "invalid platform override key: invalid_platform",
 		},
+		{
+			name:    "relative platform config_dir",
+			content: "platforms:\n  claude:\n    config_dir: claude-config\n",
+			wantErr: "config_dir for platform claude must be an absolute path: claude-config",
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,16 +3,27 @@ package doctor
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 
 	toml "github.com/pelletier/go-toml/v2"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 	"github.com/thoreinstein/aix/internal/paths"
 	"github.com/thoreinstein/aix/internal/platform"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/platform/codex"
+	"github.com/thoreinstein/aix/internal/platform/copilot"
+	"github.com/thoreinstein/aix/internal/platform/cursor"
+	"github.com/thoreinstein/aix/internal/platform/gemini"
+	"github.com/thoreinstein/aix/internal/platform/generic"
+	"github.com/thoreinstein/aix/internal/platform/opencode"
+	"github.com/thoreinstein/aix/internal/secret"
 )
 
 // maxSecureFilePerm is the maximum secure permission for config files (-rw-------).
@@ -437,7 +448,12 @@ func (c *ConfigSyntaxCheck) Run() *CheckResult {
 		// Check MCP config file
 		mcpPath := p.MCPConfig
 		if mcpPath != "" {
-			fr := c.validateFile(mcpPath)
+			var fr syntaxFileResult
+			if def, ok := generic.Lookup(p.Name); ok {
+				fr = c.validateDefinedFile(def, mcpPath)
+			} else {
+				fr = c.validateFile(mcpPath)
+			}
 			fileResults = append(fileResults, fr)
 			switch fr.Status {
 			case "pass":
@@ -505,18 +521,25 @@ func (c *ConfigSyntaxCheck) getGlobalConfigPath(platformName string) string {
 		// OpenCode's main config is config.toml
 		return filepath.Join(globalDir, "config.toml")
 	case paths.PlatformCodex:
-		// Codex settings file
-		return filepath.Join(globalDir, "settings.json")
+		// Codex keeps all settings, including MCP servers, in config.toml
+		return filepath.Join(globalDir, "config.toml")
 	case paths.PlatformGemini:
 		// Gemini uses settings.toml (which is also MCP config)
 		return filepath.Join(globalDir, "settings.toml")
+	case paths.PlatformCursor:
+		// Cursor keeps its aix-managed settings in mcp.json
+		return filepath.Join(globalDir, "mcp.json")
+	case paths.PlatformCopilot:
+		// VS Code keeps MCP servers in the user profile's mcp.json
+		return filepath.Join(globalDir, "mcp.json")
 	default:
 		return ""
 	}
 }
 
-// validateFile checks if a file is syntactically valid.
-func (c *ConfigSyntaxCheck) validateFile(filePath string) syntaxFileResult {
+// readConfigFile reads a file for syntax validation. It returns false with
+// a finished result when there is nothing to parse.
+func readConfigFile(filePath string) ([]byte, syntaxFileResult, bool) {
 	fr := syntaxFileResult{Path: filePath}
 
 	data, err := os.ReadFile(filePath)
@@ -524,22 +547,49 @@ func (c *ConfigSyntaxCheck) validateFile(filePath string) syntaxFileResult {
 		if errors.Is(err, os.ErrNotExist) {
 			fr.Status = "info"
 			fr.Message = "file does not exist (not configured)"
-			return fr
+			return nil, fr, false
 		}
 		if errors.Is(err, os.ErrPermission) {
 			fr.Status = "error"
 			fr.Message = fmt.Sprintf("permission denied: %v", err)
-			return fr
+			return nil, fr, false
 		}
 		fr.Status = "error"
 		fr.Message = fmt.Sprintf("read error: %v", err)
-		return fr
+		return nil, fr, false
 	}
 
 	// Empty files are valid (no content to parse)
 	if len(data) == 0 {
 		fr.Status = "pass"
 		fr.Message = "empty file"
+		return nil, fr, false
+	}
+
+	return data, fr, true
+}
+
+// validateDefinedFile checks the MCP config of a platform described by a
+// definition, which may be JSON with comments or YAML.
+func (c *ConfigSyntaxCheck) validateDefinedFile(def *generic.Definition, filePath string) syntaxFileResult {
+	data, fr, ok := readConfigFile(filePath)
+	if !ok {
+		return fr
+	}
+
+	if _, err := def.ParseServers(data); err != nil {
+		fr.Status = "error"
+		fr.Message = err.Error()
+		return fr
+	}
+	fr.Status = "pass"
+	return fr
+}
+
+// validateFile checks if a file is syntactically valid.
+func (c *ConfigSyntaxCheck) validateFile(filePath string) syntaxFileResult {
+	data, fr, ok := readConfigFile(filePath)
+	if !ok {
 		return fr
 	}
 
@@ -671,7 +721,7 @@ type semanticIssue struct {
 	Path       string   `json:"path"`
 	Platform   string   `json:"platform"`
 	Server     string   `json:"server,omitempty"`
-	Type       string   `json:"type"` // "missing_command", "transport_mismatch", "parse_error"
+	Type       string   `json:"type"` // "missing_command", "transport_mismatch", "plaintext_secret", "parse_error"
 	Problem    string   `json:"problem"`
 	Severity   Severity `json:"-"`
 	Suggestion string   `json:"suggestion,omitempty"`
@@ -761,6 +811,8 @@ type mcpServerInfo struct {
 	Args      []string
 	URL       string
 	Transport string
+	Env       map[string]string
+	Headers   map[string]string
 }
 
 // parseServers extracts MCP server configurations from platform-specific formats.
@@ -774,23 +826,61 @@ func (c *ConfigSemanticCheck) parseServers(data []byte, configPath, platformName
 		return c.parseOpenCodeServers(data)
 	case paths.PlatformCodex:
 		return c.parseCodexServers(data)
+	case paths.PlatformCursor:
+		// Cursor uses the same mcpServers layout as Claude Code
+		return c.parseClaudeServers(data)
+	case paths.PlatformCopilot:
+		return c.parseVSCodeServers(data)
 	case paths.PlatformGemini:
 		// Gemini uses TOML, skip for now as MCP support may differ
 		return servers, nil
 	default:
+		if def, ok := generic.Lookup(platformName); ok {
+			return c.parseDefinedServers(def, data)
+		}
 		return servers, nil
 	}
 }
 
+// parseDefinedServers parses the MCP config of a platform described by a
+// definition. Servers with a transport aix does not know, such as Goose's
+// built-in extensions, are left out.
+func (c *ConfigSemanticCheck) parseDefinedServers(def *generic.Definition, data []byte) (map[string]*mcpServerInfo, error) {
+	parsed, err := def.ParseServers(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s config", def.DisplayName)
+	}
+
+	servers := make(map[string]*mcpServerInfo)
+	for name, s := range parsed {
+		switch s.EffectiveTransport() {
+		case mcp.TransportStdio, mcp.TransportHTTP, mcp.TransportSSE:
+		default:
+			continue
+		}
+		servers[name] = &mcpServerInfo{
+			Command:   s.Command,
+			Args:      s.Args,
+			URL:       s.URL,
+			Transport: s.Transport,
+			Env:       s.Env,
+			Headers:   s.Headers,
+		}
+	}
+	return servers, nil
+}
+
 // parseClaudeServers parses Claude Code's MCP config format.
 // Format: { "mcpServers": { "name": { "command": "...", "args": [...] } } }
 func (c *ConfigSemanticCheck) parseClaudeServers(data []byte) (map[string]*mcpServerInfo, error) {
 	var config struct {
 		MCPServers map[string]struct {
-			Command string   `json:"command"`
-			Args    []string `json:"args"`
-			URL     string   `json:"url"`
-			Type    string   `json:"type"`
+			Command string            `json:"command"`
+			Args    []string          `json:"args"`
+			URL     string            `json:"url"`
+			Type    string            `json:"type"`
+			Env     map[string]string `json:"env"`
+			Headers map[string]string `json:"headers"`
 		} `json:"mcpServers"`
 	}
 
@@ -805,6 +895,40 @@ func (c *ConfigSemanticCheck) parseClaudeServers(data []byte) (map[string]*mcpSe
 			Args:      s.Args,
 			URL:       s.URL,
 			Transport: s.Type,
+			Env:       s.Env,
+			Headers:   s.Headers,
+		}
+	}
+	return servers, nil
+}
+
+// parseVSCodeServers parses VS Code's MCP config format.
+// Format: { "servers": { "name": { "type": "stdio", "command": "...", "args": [...] } } }
+func (c *ConfigSemanticCheck) parseVSCodeServers(data []byte) (map[string]*mcpServerInfo, error) {
+	var config struct {
+		Servers map[string]struct {
+			Type    string            `json:"type"`
+			Command string            `json:"command"`
+			Args    []string          `json:"args"`
+			URL     string            `json:"url"`
+			Env     map[string]string `json:"env"`
+			Headers map[string]string `json:"headers"`
+		} `json:"servers"`
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "parsing VS Code MCP config")
+	}
+
+	servers := make(map[string]*mcpServerInfo)
+	for name, s := range config.Servers {
+		servers[name] = &mcpServerInfo{
+			Command:   s.Command,
+			Args:      s.Args,
+			URL:       s.URL,
+			Transport: s.Type,
+			Env:       s.Env,
+			Headers:   s.Headers,
 		}
 	}
 	return servers, nil
@@ -819,6 +943,7 @@ func (c *ConfigSemanticCheck) parseOpenCodeServers(data []byte) (map[string]*mcp
 			URL         string            `json:"url"`
 			Type        string            `json:"type"`
 			Environment map[string]string `json:"environment"`
+			Headers     map[string]string `json:"headers"`
 		} `json:"mcp"`
 	}
 
@@ -831,6 +956,8 @@ func (c *ConfigSemanticCheck) parseOpenCodeServers(data []byte) (map[string]*mcp
 		info := &mcpServerInfo{
 			URL:       s.URL,
 			Transport: s.Type,
+			Env:       s.Environment,
+			Headers:   s.Headers,
 		}
 		if len(s.Command) > 0 {
 			info.Command = s.Command[0]
@@ -844,28 +971,30 @@ func (c *ConfigSemanticCheck) parseOpenCodeServers(data []byte) (map[string]*mcp
 }
 
 // parseCodexServers parses Codex's MCP config format.
-// Format: { "servers": { "name": { "command": "...", "args": [...] } } }
+// Format: [mcp_servers.name] tables in config.toml with command/args or url.
 func (c *ConfigSemanticCheck) parseCodexServers(data []byte) (map[string]*mcpServerInfo, error) {
 	var config struct {
-		Servers map[string]struct {
-			Command string   `json:"command"`
-			Args    []string `json:"args"`
-			URL     string   `json:"url"`
-			Type    string   `json:"type"`
-		} `json:"servers"`
+		MCPServers map[string]struct {
+			Command     string            `toml:"command"`
+			Args        []string          `toml:"args"`
+			URL         string            `toml:"url"`
+			Env         map[string]string `toml:"env"`
+			HTTPHeaders map[string]string `toml:"http_headers"`
+		} `toml:"mcp_servers"`
 	}
 
-	if err := json.Unmarshal(data, &config); err != nil {
+	if err := toml.Unmarshal(data, &config); err != nil {
 		return nil, errors.Wrap(err, "parsing Codex config")
 	}
 
 	servers := make(map[string]*mcpServerInfo)
-	for name, s := range config.Servers {
+	for name, s := range config.MCPServers {
 		servers[name] = &mcpServerInfo{
-			Command:   s.Command,
-			Args:      s.Args,
-			URL:       s.URL,
-			Transport: s.Type,
+			Command: s.Command,
+			Args:    s.Args,
+			URL:     s.URL,
+			Env:     s.Env,
+			Headers: s.HTTPHeaders,
 		}
 	}
 	return servers, nil
@@ -891,6 +1020,8 @@ func (c *ConfigSemanticCheck) validateServer(configPath, platformName, serverNam
 		})
 	}
 
+	issues = append(issues, c.findPlaintextSecrets(configPath, platformName, serverName, server)...)
+
 	// Determine effective transport type
 	isLocal := c.isLocalServer(server)
 	isRemote := c.isRemoteServer(server)
@@ -918,6 +1049,81 @@ func (c *ConfigSemanticCheck) validateServer(configPath, platformName, serverNam
 	return issues
 }
 
+// findPlaintextSecrets warns about env and header values that hold a token
+// with a known prefix in plaintext instead of a secret reference.
+func (c *ConfigSemanticCheck) findPlaintextSecrets(configPath, platformName, serverName string, server *mcpServerInfo) []semanticIssue {
+	var issues []semanticIssue
+	for _, field := range []struct {
+		name   string
+		values map[string]string
+	}{
+		{"env", server.Env},
+		{"header", server.Headers},
+	} {
+		for _, key := range slices.Sorted(maps.Keys(field.values)) {
+			value := field.values[key]
+			if !ContainsTokenPrefix(value) && !ContainsTokenPrefix(strings.TrimPrefix(value, "Bearer ")) {
+				continue
+			}
+			issues = append(issues, semanticIssue{
+				Path:       configPath,
+				Platform:   platformName,
+				Server:     serverName,
+				Type:       "plaintext_secret",
+				Problem:    field.name + " " + key + " contains a plaintext token",
+				Severity:   SeverityWarning,
+				Suggestion: plaintextSecretSuggestion(platformName, field.name, key),
+			})
+		}
+	}
+	return issues
+}
+
+// plaintextSecretSuggestion returns how to keep a token out of a platform's
+// config. Only references the platform interpolates itself are suggested:
+// aix resolves any other reference, including every ${cmd:...}, to
+// plaintext when it writes the server.
+func plaintextSecretSuggestion(platformName, field, key string) string {
+	syn := secretSyntax(platformName)
+	switch {
+	case syn.Env != "" && syn.File != "":
+		return "replace the token with ${env:NAME} or ${file:PATH} and re-add the server"
+	case syn.Env != "":
+		return "replace the token with ${env:NAME} and re-add the server"
+	case platformName == paths.PlatformCodex && field == "header" && key == "Authorization":
+		return "replace the header with 'Authorization: Bearer ${env:NAME}' and re-add the server; aix writes it as bearer_token_env_var"
+	case platformName == paths.PlatformCodex && field == "header":
+		return "move header " + key + " to env_http_headers so Codex reads it from its own environment"
+	case platformName == paths.PlatformCodex:
+		return "remove " + key + " from env and list it under env_vars so Codex passes it through from its own environment"
+	default:
+		return "this platform cannot reference secrets, so aix writes them resolved; remove the token or restrict who can read the config"
+	}
+}
+
+// secretSyntax returns the secret references platformName interpolates in
+// MCP server env and header values.
+func secretSyntax(platformName string) secret.Syntax {
+	switch platformName {
+	case paths.PlatformClaude:
+		return claude.SecretSyntax
+	case paths.PlatformOpenCode:
+		return opencode.SecretSyntax
+	case paths.PlatformCodex:
+		return codex.SecretSyntax
+	case paths.PlatformCursor:
+		return cursor.SecretSyntax
+	case paths.PlatformCopilot:
+		return copilot.SecretSyntax
+	case paths.PlatformGemini:
+		return gemini.SecretSyntax
+	}
+	if def, ok := generic.Lookup(platformName); ok {
+		return secret.Syntax{Env: def.MCP.Secrets.Env, File: def.MCP.Secrets.File}
+	}
+	return secret.Syntax{}
+}
+
 // isLocalServer returns true if the server is configured for local (stdio) transport.
 func (c *ConfigSemanticCheck) isLocalServer(server *mcpServerInfo) bool {
 	if server.Transport == "stdio" || server.Transport == "local" {
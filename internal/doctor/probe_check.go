@@ -0,0 +1,142 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/mcp/probe"
+)
+
+// maxConcurrentProbes bounds how many servers are probed at once.
+const maxConcurrentProbes = 4
+
+// MCPProbeTarget is a configured MCP server to probe.
+type MCPProbeTarget struct {
+	// Platform is the platform the server is configured on.
+	Platform string
+
+	// Server is the server's canonical configuration.
+	Server *mcp.Server
+}
+
+// MCPProbeCheck starts or connects to each MCP server and performs the MCP
+// handshake. It catches servers whose configuration looks valid but which
+// fail when an assistant launches them, such as servers with bad arguments.
+type MCPProbeCheck struct {
+	targets []MCPProbeTarget
+	prober  *probe.Prober
+	timeout time.Duration
+}
+
+var _ Check = (*MCPProbeCheck)(nil)
+
+// NewMCPProbeCheck creates a check that probes targets with prober, giving
+// each server timeout to answer.
+func NewMCPProbeCheck(targets []MCPProbeTarget, prober *probe.Prober, timeout time.Duration) *MCPProbeCheck {
+	return &MCPProbeCheck{targets: targets, prober: prober, timeout: timeout}
+}
+
+// Name returns the unique identifier for this check.
+func (c *MCPProbeCheck) Name() string {
+	return "mcp-probe"
+}
+
+// Category returns the grouping for this check.
+func (c *MCPProbeCheck) Category() string {
+	return "mcp"
+}
+
+// probeOutcome is the result of probing one target.
+type probeOutcome struct {
+	target  MCPProbeTarget
+	result  *probe.Result
+	err     error
+	skipped string
+}
+
+// Run probes every target that is enabled and allowed on this OS.
+func (c *MCPProbeCheck) Run() *CheckResult {
+	result := &CheckResult{
+		Name:     c.Name(),
+		Category: c.Category(),
+		Status:   SeverityPass,
+		Details:  make(map[string]any),
+	}
+
+	outcomes := make([]probeOutcome, len(c.targets))
+	sem := make(chan struct{}, maxConcurrentProbes)
+	var wg sync.WaitGroup
+	for i, t := range c.targets {
+		outcomes[i].target = t
+		switch {
+		case t.Server.Disabled:
+			outcomes[i].skipped = "disabled"
+			continue
+		case len(t.Server.Platforms) > 0 && !slices.Contains(t.Server.Platforms, runtime.GOOS):
+			outcomes[i].skipped = "not enabled on " + runtime.GOOS
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+			outcomes[i].result, outcomes[i].err = c.prober.Probe(ctx, t.Server)
+		}()
+	}
+	wg.Wait()
+
+	var probed, skipped int
+	var failures []string
+	servers := make([]map[string]any, 0, len(outcomes))
+	for _, o := range outcomes {
+		detail := map[string]any{
+			"platform": o.target.Platform,
+			"server":   o.target.Server.Name,
+		}
+		switch {
+		case o.skipped != "":
+			skipped++
+			detail["status"] = "skipped"
+			detail["reason"] = o.skipped
+		case o.err != nil:
+			probed++
+			detail["status"] = "failed"
+			detail["error"] = o.err.Error()
+			failures = append(failures, fmt.Sprintf("%s on %s (%v)", o.target.Server.Name, o.target.Platform, o.err))
+		default:
+			probed++
+			detail["status"] = "ok"
+			detail["protocol_version"] = o.result.ProtocolVersion
+			detail["server_name"] = o.result.ServerName
+			detail["server_version"] = o.result.ServerVersion
+			detail["tools"] = o.result.Tools
+		}
+		servers = append(servers, detail)
+	}
+	result.Details["servers"] = servers
+	result.Details["probed"] = probed
+	result.Details["skipped"] = skipped
+
+	switch {
+	case len(failures) > 0:
+		result.Status = SeverityError
+		result.Message = fmt.Sprintf("%d of %d MCP server(s) failed: %s",
+			len(failures), probed, strings.Join(failures, "; "))
+		result.FixHint = "run 'aix mcp test <name>' after correcting the server's command, args, env, or URL"
+	case probed == 0:
+		result.Status = SeverityInfo
+		result.Message = "no MCP servers to probe"
+	default:
+		result.Message = fmt.Sprintf("all %d MCP server(s) responded", probed)
+	}
+	return result
+}
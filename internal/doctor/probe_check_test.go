@@ -0,0 +1,48 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/mcp/probe"
+)
+
+func TestMCPProbeCheck_NoTargets(t *testing.T) {
+	c := NewMCPProbeCheck(nil, probe.New(), time.Second)
+	result := c.Run()
+
+	if result.Name != "mcp-probe" || result.Category != "mcp" {
+		t.Errorf("result = %s/%s, want mcp/mcp-probe", result.Category, result.Name)
+	}
+	if result.Status != SeverityInfo {
+		t.Errorf("result.Status = %v, want %v", result.Status, SeverityInfo)
+	}
+}
+
+func TestMCPProbeCheck_Run(t *testing.T) {
+	targets := []MCPProbeTarget{
+		{Platform: "claude", Server: &mcp.Server{Name: "off", Command: "aix-probe-no-such-command", Disabled: true}},
+		{Platform: "claude", Server: &mcp.Server{Name: "elsewhere", Command: "aix-probe-no-such-command", Platforms: []string{"plan9"}}},
+		{Platform: "opencode", Server: &mcp.Server{Name: "broken", Command: "aix-probe-no-such-command"}},
+	}
+	c := NewMCPProbeCheck(targets, probe.New(), 5*time.Second)
+	result := c.Run()
+
+	if result.Status != SeverityError {
+		t.Fatalf("result.Status = %v, want %v", result.Status, SeverityError)
+	}
+	if !strings.Contains(result.Message, "1 of 1") || !strings.Contains(result.Message, "broken on opencode") {
+		t.Errorf("result.Message = %q, want the broken server named", result.Message)
+	}
+	if result.FixHint == "" {
+		t.Error("result.FixHint should not be empty")
+	}
+	if got := result.Details["skipped"]; got != 2 {
+		t.Errorf("Details[skipped] = %v, want 2", got)
+	}
+	if got := result.Details["probed"]; got != 1 {
+		t.Errorf("Details[probed] = %v, want 1", got)
+	}
+}
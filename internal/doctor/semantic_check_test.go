@@ -3,6 +3,7 @@ package doctor
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -85,6 +86,71 @@ func TestConfigSemanticCheck_parseClaudeServers(t *testing.T) {
 	}
 }
 
+func TestConfigSemanticCheck_parseVSCodeServers(t *testing.T) {
+	c := NewConfigSemanticCheck()
+
+	input := `{
+  "inputs": [{"type": "promptString", "id": "gh-token", "password": true}],
+  "servers": {
+    "github": {"type": "stdio", "command": "npx", "env": {"TOKEN": "${input:gh-token}"}},
+    "api": {"type": "sse", "url": "https://api.example.com/sse"}
+  }
+}`
+	servers, err := c.parseVSCodeServers([]byte(input))
+	if err != nil {
+		t.Fatalf("parseVSCodeServers() error = %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("parseVSCodeServers() got %d servers, want 2", len(servers))
+	}
+	if got := servers["api"].Transport; got != "sse" {
+		t.Errorf("api transport = %q, want sse", got)
+	}
+	if got := servers["github"].Command; got != "npx" {
+		t.Errorf("github command = %q, want npx", got)
+	}
+
+	if _, err := c.parseVSCodeServers([]byte(`{invalid}`)); err == nil {
+		t.Error("parseVSCodeServers() expected error for invalid JSON")
+	}
+}
+
+func TestConfigSemanticCheck_parseDefinedServers(t *testing.T) {
+	c := NewConfigSemanticCheck()
+
+	input := `extensions:
+  developer:
+    enabled: true
+    name: developer
+    type: builtin
+  github:
+    cmd: npx
+    args: [-y, server-github]
+    enabled: true
+    type: stdio
+  remote:
+    uri: https://example.com/mcp
+    type: streamable_http
+`
+	servers, err := c.parseServers([]byte(input), "config.yaml", "goose")
+	if err != nil {
+		t.Fatalf("parseServers() error = %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("parseServers() got %d servers, want 2 (built-in extensions left out)", len(servers))
+	}
+	if got := servers["github"].Command; got != "npx" {
+		t.Errorf("github command = %q, want npx", got)
+	}
+	if got := servers["remote"].Transport; got != "http" {
+		t.Errorf("remote transport = %q, want http", got)
+	}
+
+	if _, err := c.parseServers([]byte("extensions: [\n"), "config.yaml", "goose"); err == nil {
+		t.Error("parseServers() expected error for invalid YAML")
+	}
+}
+
 func TestConfigSemanticCheck_parseOpenCodeServers(t *testing.T) {
 	c := NewConfigSemanticCheck()
 
@@ -141,6 +207,65 @@ func TestConfigSemanticCheck_parseOpenCodeServers(t *testing.T) {
 	}
 }
 
+func TestConfigSemanticCheck_parseCodexServers(t *testing.T) {
+	c := NewConfigSemanticCheck()
+
+	tests := []struct {
+		name        string
+		input       string
+		wantServers int
+		wantCmd     string
+		wantURL     string
+	}{
+		{
+			name: "local server alongside other settings",
+			input: `model = "o3"
+
+[mcp_servers.github]
+command = "npx"
+args = ["-y", "@mcp/server-github"]
+`,
+			wantServers: 1,
+			wantCmd:     "npx",
+		},
+		{
+			name: "remote server",
+			input: `[mcp_servers.api]
+url = "https://api.example.com/mcp"
+`,
+			wantServers: 1,
+			wantURL:     "https://api.example.com/mcp",
+		},
+		{
+			name:        "no mcp_servers table",
+			input:       `model = "o3"`,
+			wantServers: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			servers, err := c.parseCodexServers([]byte(tt.input))
+			if err != nil {
+				t.Errorf("parseCodexServers() error = %v", err)
+				return
+			}
+			if len(servers) != tt.wantServers {
+				t.Errorf("parseCodexServers() got %d servers, want %d", len(servers), tt.wantServers)
+				return
+			}
+			for _, s := range servers {
+				if s.Command != tt.wantCmd {
+					t.Errorf("parseCodexServers() command = %q, want %q", s.Command, tt.wantCmd)
+				}
+				if s.URL != tt.wantURL {
+					t.Errorf("parseCodexServers() url = %q, want %q", s.URL, tt.wantURL)
+				}
+			}
+		})
+	}
+}
+
 func TestConfigSemanticCheck_isLocalServer(t *testing.T) {
 	c := NewConfigSemanticCheck()
 
@@ -276,6 +401,26 @@ func TestConfigSemanticCheck_validateServer(t *testing.T) {
 			wantIssues: 1,
 			wantTypes:  []string{"missing_command"},
 		},
+		{
+			name:       "plaintext token in env",
+			server:     &mcpServerInfo{Command: "ls", Env: map[string]string{"GITHUB_TOKEN": "ghp_abc123"}},
+			wantIssues: 1,
+			wantTypes:  []string{"plaintext_secret"},
+		},
+		{
+			name: "plaintext bearer token in header",
+			server: &mcpServerInfo{
+				URL:     "https://api.example.com",
+				Headers: map[string]string{"Authorization": "Bearer sk-abc123"},
+			},
+			wantIssues: 1,
+			wantTypes:  []string{"plaintext_secret"},
+		},
+		{
+			name:       "secret reference",
+			server:     &mcpServerInfo{Command: "ls", Env: map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}"}},
+			wantIssues: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -299,6 +444,35 @@ func TestConfigSemanticCheck_validateServer(t *testing.T) {
 	}
 }
 
+func TestPlaintextSecretSuggestion(t *testing.T) {
+	tests := []struct {
+		platform, field, key string
+		want                 string
+		notWant              []string
+	}{
+		{"claude", "env", "GITHUB_TOKEN", "${env:NAME}", []string{"${file:", "${cmd:"}},
+		{"opencode", "env", "GITHUB_TOKEN", "${file:PATH}", []string{"${cmd:"}},
+		{"codex", "header", "Authorization", "bearer_token_env_var", []string{"${file:", "${cmd:"}},
+		{"codex", "header", "X-Api-Key", "env_http_headers", []string{"${"}},
+		{"codex", "env", "GITHUB_TOKEN", "env_vars", []string{"${"}},
+		{"unknown", "env", "GITHUB_TOKEN", "cannot reference secrets", []string{"${"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform+"/"+tt.field+"/"+tt.key, func(t *testing.T) {
+			got := plaintextSecretSuggestion(tt.platform, tt.field, tt.key)
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("plaintextSecretSuggestion() = %q, want it to contain %q", got, tt.want)
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("plaintextSecretSuggestion() = %q, should not suggest %q", got, s)
+				}
+			}
+		})
+	}
+}
+
 func TestConfigSemanticCheck_validateCommand(t *testing.T) {
 	c := NewConfigSemanticCheck()
 	tempDir := t.TempDir()
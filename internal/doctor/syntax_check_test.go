@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/thoreinstein/aix/internal/platform/generic"
+
 	toml "github.com/pelletier/go-toml/v2"
 )
 
@@ -171,6 +173,35 @@ func TestConfigSyntaxCheck_validateFile_permission(t *testing.T) {
 	}
 }
 
+func TestConfigSyntaxCheck_validateDefinedFile(t *testing.T) {
+	c := NewConfigSyntaxCheck()
+	def, ok := generic.Lookup("zed")
+	if !ok {
+		t.Fatal("no zed definition")
+	}
+	tmpDir := t.TempDir()
+
+	valid := filepath.Join(tmpDir, "settings.json")
+	if err := os.WriteFile(valid, []byte("// comment\n{\"context_servers\": {},}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.validateDefinedFile(def, valid); got.Status != "pass" {
+		t.Errorf("validateDefinedFile() on JSONC status = %q (%s), want pass", got.Status, got.Message)
+	}
+
+	invalid := filepath.Join(tmpDir, "broken.json")
+	if err := os.WriteFile(invalid, []byte(`{"context_servers": `), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.validateDefinedFile(def, invalid); got.Status != "error" || got.Message == "" {
+		t.Errorf("validateDefinedFile() on invalid JSON = %+v, want an error", got)
+	}
+
+	if got := c.validateDefinedFile(def, filepath.Join(tmpDir, "missing.json")); got.Status != "info" {
+		t.Errorf("validateDefinedFile() on missing file status = %q, want info", got.Status)
+	}
+}
+
 func TestConfigSyntaxCheck_validateJSON(t *testing.T) {
 	c := NewConfigSyntaxCheck()
 
@@ -394,7 +425,7 @@ func TestConfigSyntaxCheck_getGlobalConfigPath(t *testing.T) {
 	}{
 		{"claude", "settings.json"},
 		{"opencode", "config.toml"},
-		{"codex", "settings.json"},
+		{"codex", "config.toml"},
 		{"gemini", "settings.toml"},
 		{"unknown", ""},
 	}
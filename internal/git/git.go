@@ -73,6 +73,7 @@ func IsURL(s string) bool {
 }
 
 // Clone clones a git repository from url to dest with the specified depth.
+// A depth of zero or less clones the full history.
 // It validates the URL before execution to prevent injection attacks.
 // Output is streamed to os.Stdout and os.Stderr. Stdin is connected to os.Stdin
 // to support interactive authentication (e.g., SSH passphrase, credentials).
@@ -81,8 +82,12 @@ func Clone(url, dest string, depth int) error {
 		return errors.Wrap(err, "validating git URL")
 	}
 
-	depthArg := fmt.Sprintf("--depth=%d", depth)
-	cmd := exec.Command("git", "clone", depthArg, url, dest)
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", depth))
+	}
+	args = append(args, url, dest)
+	cmd := exec.Command("git", args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -124,3 +129,99 @@ func ValidateRemote(repoPath string) error {
 	}
 	return nil
 }
+
+// TopLevel returns the absolute path of the top-level directory of the
+// working tree containing dir.
+func TopLevel(dir string) (string, error) {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "%s is not inside a git working tree", dir)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Head returns the full SHA of the commit checked out in repoPath.
+func Head(repoPath string) (string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "reading HEAD of %s", repoPath)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Checkout detaches the working tree in repoPath at ref.
+func Checkout(repoPath, ref string) error {
+	if strings.HasPrefix(ref, "-") {
+		return errors.Newf("git ref cannot start with '-': %s", ref)
+	}
+	cmd := exec.Command("git", "-C", repoPath, "checkout", "--quiet", "--detach", ref)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "git checkout %s failed: %s", ref, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Fetch downloads all branches and tags from origin in repoPath. Shallow
+// clones are deepened to full history, and widened to every branch, so that
+// any ref can be checked out.
+// Output is streamed to os.Stdout and os.Stderr. Stdin is connected to os.Stdin
+// to support interactive authentication (e.g., SSH passphrase, credentials).
+func Fetch(repoPath string) error {
+	args := []string{"-C", repoPath, "fetch", "--tags", "--force"}
+	out, err := exec.Command("git", "-C", repoPath, "rev-parse", "--is-shallow-repository").Output()
+	if err == nil && strings.TrimSpace(string(out)) == "true" {
+		// Shallow clones are also single-branch.
+		widen := exec.Command("git", "-C", repoPath, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+		if out, err := widen.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "configuring fetch refspec: %s", strings.TrimSpace(string(out)))
+		}
+		args = append(args, "--unshallow")
+	}
+	args = append(args, "origin")
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "git fetch failed")
+	}
+	return nil
+}
+
+// ResolveRef returns the commit SHA that ref names in repoPath. A ref may be
+// a branch on origin, a tag, or a (possibly abbreviated) commit SHA; remote
+// branches take precedence so that a pinned branch follows its upstream.
+func ResolveRef(repoPath, ref string) (string, error) {
+	if ref == "" || strings.HasPrefix(ref, "-") {
+		return "", errors.Newf("invalid git ref %q", ref)
+	}
+	for _, candidate := range []string{"refs/remotes/origin/" + ref, "refs/tags/" + ref, ref} {
+		cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", candidate+"^{commit}")
+		if out, err := cmd.Output(); err == nil {
+			return strings.TrimSpace(string(out)), nil
+		}
+	}
+	return "", errors.Newf("ref %q not found in %s", ref, repoPath)
+}
+
+// CheckoutDefaultBranch checks out origin's default branch in repoPath,
+// resetting the local branch to it, so that Pull can follow it again after
+// the working tree was detached by Checkout.
+func CheckoutDefaultBranch(repoPath string) error {
+	out, err := exec.Command("git", "-C", repoPath, "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output()
+	if err != nil {
+		return errors.Wrapf(err, "determining default branch of %s", repoPath)
+	}
+	remote := strings.TrimSpace(string(out))
+	branch := strings.TrimPrefix(remote, "origin/")
+
+	cmd := exec.Command("git", "-C", repoPath, "checkout", "--quiet", "-B", branch, remote)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "git checkout %s failed: %s", branch, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
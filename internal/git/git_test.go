@@ -147,3 +147,146 @@ func runGit(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, out)
 	}
 }
+
+func TestTopLevel(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if _, err := TopLevel(tmpDir); err == nil {
+		t.Error("expected error for non-git directory, got nil")
+	}
+
+	repo := filepath.Join(tmpDir, "repo")
+	createLocalGitRepo(t, repo)
+	sub := filepath.Join(repo, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := TopLevel(sub)
+	if err != nil {
+		t.Fatalf("TopLevel() error = %v", err)
+	}
+
+	want, err := filepath.EvalSymlinks(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = filepath.EvalSymlinks(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("TopLevel() = %q, want %q", got, want)
+	}
+}
+
+func TestHeadAndCheckout(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := filepath.Join(tmpDir, "repo")
+	createLocalGitRepo(t, repo)
+
+	first, err := Head(repo)
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if len(first) != 40 {
+		t.Errorf("Head() = %q, want a full SHA", first)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "second.txt"), []byte("2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "second.txt")
+	runGit(t, repo, "commit", "-m", "second")
+
+	clone := filepath.Join(tmpDir, "clone")
+	if err := Clone("file://"+repo, clone, 0); err != nil {
+		t.Fatalf("Clone() full error = %v", err)
+	}
+	if err := Checkout(clone, first); err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	got, err := Head(clone)
+	if err != nil {
+		t.Fatalf("Head() error = %v", err)
+	}
+	if got != first {
+		t.Errorf("Head() after checkout = %q, want %q", got, first)
+	}
+	if _, err := os.Stat(filepath.Join(clone, "second.txt")); !os.IsNotExist(err) {
+		t.Error("second.txt should not exist at the first commit")
+	}
+
+	if err := Checkout(clone, "--orphan"); err == nil {
+		t.Error("Checkout() should reject refs starting with '-'")
+	}
+}
+
+func TestResolveRefAndDefaultBranch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "source")
+	createLocalGitRepo(t, source)
+	first, err := Head(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, source, "tag", "v1.0.0")
+	runGit(t, source, "branch", "stable")
+	if err := os.WriteFile(filepath.Join(source, "second.txt"), []byte("2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, source, "add", "second.txt")
+	runGit(t, source, "commit", "-m", "second")
+	second, err := Head(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := filepath.Join(tmpDir, "clone")
+	if err := Clone("file://"+source, clone, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := Fetch(clone); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"v1.0.0", first},
+		{"stable", first},
+		{first[:10], first},
+		{second, second},
+	}
+	for _, tt := range tests {
+		got, err := ResolveRef(clone, tt.ref)
+		if err != nil {
+			t.Errorf("ResolveRef(%q) error = %v", tt.ref, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveRef(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+	if _, err := ResolveRef(clone, "no-such-ref"); err == nil {
+		t.Error("ResolveRef() expected error for unknown ref")
+	}
+
+	if err := Checkout(clone, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckoutDefaultBranch(clone); err != nil {
+		t.Fatalf("CheckoutDefaultBranch() error = %v", err)
+	}
+	if got, _ := Head(clone); got != second {
+		t.Errorf("Head() after CheckoutDefaultBranch = %q, want %q", got, second)
+	}
+	if err := Pull(clone); err != nil {
+		t.Errorf("Pull() after CheckoutDefaultBranch error = %v", err)
+	}
+}
@@ -2,36 +2,129 @@ package install
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/thoreinstein/aix/internal/cli"
 	cliprompt "github.com/thoreinstein/aix/internal/cli/prompt"
 	"github.com/thoreinstein/aix/internal/config"
 	"github.com/thoreinstein/aix/internal/errors"
 	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/logging"
+	"github.com/thoreinstein/aix/internal/params"
 	"github.com/thoreinstein/aix/internal/repo"
 	"github.com/thoreinstein/aix/internal/resource"
 )
 
-// LocalInstaller is a function that installs a resource from a local path.
-type LocalInstaller func(sourcePath string) error
+// LocalInstaller is a function that installs a resource from a local path
+// and returns the name it was installed under.
+type LocalInstaller func(sourcePath string) (string, error)
+
+// InstallFunc installs the resource at path to platforms, overwriting any
+// existing copy, writes progress to w, and returns the name it was
+// installed under.
+type InstallFunc func(w io.Writer, path string, platforms []cli.Platform) (string, error)
 
 // Installer handles shared logic for installing resources from repositories.
 type Installer struct {
 	resourceType resource.ResourceType
 	resourceName string // e.g., "skill", "agent", "MCP server"
 	localInstall LocalInstaller
+	installTo    InstallFunc
+	deps         Set
+	platforms    func() ([]cli.Platform, error)
+	recorder     Recorder
+	forgetter    Forgetter
+	set          func() []string
+}
+
+// Recorder is called after a resource from a repository is installed, with
+// the platforms it was installed to and the parameter values it was
+// rendered with.
+type Recorder func(res resource.Resource, platforms []cli.Platform, values map[string]string) error
+
+// Forgetter is called after a resource is installed from a local path or
+// git URL, which replaces any copy that was installed from a repository.
+type Forgetter func(t resource.ResourceType, name string) error
+
+// Option configures an Installer.
+type Option func(*Installer)
+
+// WithPlatforms sets how the installer finds the platforms it installs to.
+// It is used to skip dependencies that are already installed on all of
+// them.
+func WithPlatforms(fn func() ([]cli.Platform, error)) Option {
+	return func(i *Installer) {
+		i.platforms = fn
+	}
+}
+
+// WithInstallTo sets how the installer installs a resource to platforms it
+// is given, rather than those the flags select. Dependencies are installed
+// with it.
+func WithInstallTo(fn InstallFunc) Option {
+	return func(i *Installer) {
+		i.installTo = fn
+	}
+}
+
+// WithRecorder sets a function that records where resources installed
+// from repositories came from.
+func WithRecorder(r Recorder) Option {
+	return func(i *Installer) {
+		i.recorder = r
+	}
+}
+
+// WithForgetter sets a function that drops the record of where a resource
+// came from when it is replaced by one installed from a path.
+func WithForgetter(f Forgetter) Option {
+	return func(i *Installer) {
+		i.forgetter = f
+	}
+}
+
+// WithParams sets where the installer finds the key=value assignments given
+// with --set for the parameters of the resource being installed. Parameters
+// without a value are prompted for when stdin is a terminal, and otherwise
+// take their defaults.
+func WithParams(set func() []string) Option {
+	return func(i *Installer) {
+		i.set = set
+	}
 }
 
 // NewInstaller creates a new Installer for a specific resource type.
-func NewInstaller(t resource.ResourceType, name string, local LocalInstaller) *Installer {
-	return &Installer{
+func NewInstaller(t resource.ResourceType, name string, local LocalInstaller, opts ...Option) *Installer {
+	i := &Installer{
 		resourceType: t,
 		resourceName: name,
 		localInstall: local,
 	}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// Set holds the installer for each resource type. The resources another
+// resource requires are installed with the installer in the Set for their
+// type.
+type Set map[resource.ResourceType]*Installer
+
+// NewSet returns a Set of installers, and has each of them install the
+// dependencies of resources it installs from repositories with the Set.
+func NewSet(installers ...*Installer) Set {
+	s := make(Set, len(installers))
+	for _, i := range installers {
+		s[i.resourceType] = i
+		i.deps = s
+	}
+	return s
 }
 
 // InstallFromRepo installs a resource from a list of matches (usually from repo lookup).
+// Resources the selected one requires are installed first, transitively.
 func (i *Installer) InstallFromRepo(name string, matches []resource.Resource) error {
 	var selected *resource.Resource
 
@@ -46,8 +139,232 @@ func (i *Installer) InstallFromRepo(name string, matches []resource.Resource) er
 		selected = choice
 	}
 
+	if len(selected.Requires) > 0 {
+		if i.platforms == nil {
+			return errors.Newf("cannot install the dependencies of %s %s: no platforms", selected.Type, selected.Name)
+		}
+		platforms, err := i.platforms()
+		if err != nil {
+			return errors.Wrap(err, "resolving platforms")
+		}
+		if err := i.deps.InstallDependencies(os.Stdout, *selected, platforms); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("Installing from repository: %s\n", selected.RepoName)
-	return i.localInstall(selected.SourcePath())
+	path, values, cleanup, err := i.render(selected.SourcePath(), true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := i.localInstall(path); err != nil {
+		return err
+	}
+	i.record(*selected, values)
+	return nil
+}
+
+// InstallFromPath installs the resource at path, rendering its parameters
+// first. Nothing is recorded, and any record of a copy installed from a
+// repository is dropped.
+func (i *Installer) InstallFromPath(path string) error {
+	rendered, _, cleanup, err := i.render(path, true)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	name, err := i.localInstall(rendered)
+	if err != nil {
+		return err
+	}
+	if i.forgetter != nil {
+		if err := i.forgetter(i.resourceType, name); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update install registry: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// render resolves the parameters the resource at path declares and renders
+// a copy of it; see params.Render. Values given with --set apply to the
+// resource named on the command line, root, and not to its dependencies.
+func (i *Installer) render(path string, root bool) (string, map[string]string, func(), error) {
+	decl, err := params.Declared(i.resourceType, path)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var given map[string]string
+	if root && i.set != nil {
+		if given, err = params.ParseSet(i.set()); err != nil {
+			return "", nil, nil, err
+		}
+		if err := params.CheckNames(decl, given); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	if len(decl) == 0 {
+		return path, nil, func() {}, nil
+	}
+
+	var prompt params.Prompter
+	if logging.IsTTY(os.Stdin) {
+		prompt = askParameter
+	}
+	values, err := params.Resolve(decl, given, prompt)
+	if err != nil {
+		return "", nil, nil, errors.NewUserError(err, "Set parameter values with --set key=value")
+	}
+	rendered, cleanup, err := params.Render(i.resourceType, path, values)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return rendered, values, cleanup, nil
+}
+
+// askParameter prompts on stdin for the value of p.
+func askParameter(p params.Parameter) (string, error) {
+	question := p.Name
+	if p.Description != "" {
+		question += " (" + p.Description + ")"
+	}
+	return cliprompt.NewInput().Ask(question, p.Default)
+}
+
+// InstallDependencies resolves what root requires, writes the plan to w,
+// and installs every dependency that is not already on all of platforms
+// with the installer for its type, recording it as that installer does.
+func (s Set) InstallDependencies(w io.Writer, root resource.Resource, platforms []cli.Platform) error {
+	all, err := resource.ScanConfigured()
+	if err != nil {
+		return err
+	}
+	plan, err := resource.ResolveRequirements(root, all)
+	if err != nil {
+		return errors.Wrapf(err, "resolving requirements of %s", root.Name)
+	}
+
+	fmt.Fprintln(w, "Install plan:")
+	for n, r := range plan {
+		fmt.Fprintf(w, "  %d. %s %s (%s)\n", n+1, r.Type, r.Name, r.RepoName)
+	}
+
+	for _, dep := range plan[:len(plan)-1] {
+		inst, ok := s[dep.Type]
+		if !ok || inst.installTo == nil {
+			return errors.Newf("cannot install %s %s: no installer for %ss", dep.Type, dep.Name, dep.Type)
+		}
+		if len(platforms) > 0 && len(InstalledOn(platforms, dep.Type, dep.Name)) == len(platforms) {
+			fmt.Fprintf(w, "%s %q is already installed\n", dep.Type, dep.Name)
+			continue
+		}
+		fmt.Fprintf(w, "\nInstalling dependency %s %q from repository: %s\n", dep.Type, dep.Name, dep.RepoName)
+		if err := inst.installDependency(w, dep, platforms); err != nil {
+			return errors.Wrapf(err, "installing dependency %s %s", dep.Type, dep.Name)
+		}
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// installDependency renders and installs dep to platforms, and records it.
+func (i *Installer) installDependency(w io.Writer, dep resource.Resource, platforms []cli.Platform) error {
+	path, values, cleanup, err := i.render(dep.SourcePath(), false)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	if _, err := i.installTo(w, path, platforms); err != nil {
+		return err
+	}
+	i.recordTo(dep, platforms, values)
+	return nil
+}
+
+// record passes res to the installer's Recorder with the platforms the
+// installer targets.
+func (i *Installer) record(res resource.Resource, values map[string]string) {
+	var platforms []cli.Platform
+	if i.platforms != nil {
+		platforms, _ = i.platforms()
+	}
+	i.recordTo(res, platforms, values)
+}
+
+// recordTo passes res to the installer's Recorder. Failures are reported
+// but do not fail the install, which has already succeeded.
+func (i *Installer) recordTo(res resource.Resource, platforms []cli.Platform, values map[string]string) {
+	if i.recorder == nil {
+		return
+	}
+	if err := i.recorder(res, platforms, values); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record install of %s %q: %v\n", res.Type, res.Name, err)
+	}
+}
+
+// InstalledOn returns the platforms on which the resource of type t named
+// name is installed.
+func InstalledOn(platforms []cli.Platform, t resource.ResourceType, name string) []cli.Platform {
+	var out []cli.Platform
+	for _, p := range platforms {
+		var err error
+		switch t {
+		case resource.TypeSkill:
+			_, err = p.GetSkill(name)
+		case resource.TypeCommand:
+			_, err = p.GetCommand(name)
+		case resource.TypeAgent:
+			_, err = p.GetAgent(name)
+		case resource.TypeMCP:
+			_, err = p.GetMCP(name)
+		default:
+			continue
+		}
+		if err == nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Dependents returns the resources in configured repositories that require
+// the resource of type t named name and are installed on any of platforms.
+// Commands use it to warn before removing something that is still needed.
+// It returns nothing when no repositories are configured.
+func Dependents(platforms []cli.Platform, t resource.ResourceType, name string) ([]resource.Resource, error) {
+	all, err := resource.ScanConfigured()
+	if errors.Is(err, resource.ErrNoReposConfigured) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []resource.Resource
+	seen := make(map[string]bool)
+	for _, r := range resource.Dependents(all, t, name) {
+		key := string(r.Type) + ":" + r.Name
+		if seen[key] || len(InstalledOn(platforms, r.Type, r.Name)) == 0 {
+			continue
+		}
+		seen[key] = true
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// WarnDependents writes a warning to w listing the installed resources that
+// require the resource being removed. Lookup failures are ignored, since
+// the warning is advisory.
+func WarnDependents(w io.Writer, platforms []cli.Platform, t resource.ResourceType, name string) {
+	deps, err := Dependents(platforms, t, name)
+	if err != nil || len(deps) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Warning: these installed resources require %s %q and may stop working:\n", t, name)
+	for _, d := range deps {
+		fmt.Fprintf(w, "  - %s %s (%s)\n", d.Type, d.Name, d.RepoName)
+	}
 }
 
 // InstallAllFromRepo installs all resources of the configured type from a specific repository.
@@ -126,5 +443,5 @@ func (i *Installer) InstallFromGit(url string) error {
 		return errors.Wrap(err, "cloning repository")
 	}
 
-	return i.localInstall(tempDir)
+	return i.InstallFromPath(tempDir)
 }
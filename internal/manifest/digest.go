@@ -0,0 +1,193 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+)
+
+// The digest functions hash the whole canonical form of a resource, every
+// field it has but its name. Platforms that cannot represent some fields
+// drop them, so a resource installed there only compares equal to its
+// source once the source has been converted for that platform and back.
+
+// SkillDigest returns the content digest of a canonical skill. The
+// directory it was read from is not content.
+func SkillDigest(s *claude.Skill) string {
+	return fieldsDigest(skillFields(s))
+}
+
+// CommandDigest returns the content digest of a canonical command.
+func CommandDigest(c *claude.Command) string {
+	return fieldsDigest(commandFields(c))
+}
+
+// AgentDigest returns the content digest of a canonical agent.
+func AgentDigest(a *claude.Agent) string {
+	return fieldsDigest(agentFields(a))
+}
+
+// MCPDigest returns the content digest of a canonical MCP server.
+// The enabled state is not included, since it is a local preference.
+func MCPDigest(s *mcp.Server) string {
+	return fieldsDigest(mcpFields(s))
+}
+
+// Digest returns the content digest of a canonical resource: a
+// *claude.Skill, *claude.Command, *claude.Agent or *mcp.Server.
+func Digest(v any) (string, error) {
+	fields, err := contentFields(v)
+	if err != nil {
+		return "", err
+	}
+	return fieldsDigest(fields), nil
+}
+
+// DiffFields returns the names of the digested fields that differ between
+// two canonical resources of the same type, in a stable order. It returns
+// nil when the resources have the same digest.
+func DiffFields(a, b any) ([]string, error) {
+	fa, err := contentFields(a)
+	if err != nil {
+		return nil, err
+	}
+	fb, err := contentFields(b)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return nil, errors.Newf("cannot compare %T with %T", a, b)
+	}
+
+	var names []string
+	for i := range fa {
+		if !slices.Equal(fa[i].values, fb[i].values) {
+			names = append(names, fa[i].name)
+		}
+	}
+	return names, nil
+}
+
+// field is a named part of a resource's content.
+type field struct {
+	name   string
+	values []string
+}
+
+func contentFields(v any) ([]field, error) {
+	switch r := v.(type) {
+	case *claude.Skill:
+		return skillFields(r), nil
+	case *claude.Command:
+		return commandFields(r), nil
+	case *claude.Agent:
+		return agentFields(r), nil
+	case *mcp.Server:
+		return mcpFields(r), nil
+	default:
+		return nil, errors.Newf("unsupported resource type %T", v)
+	}
+}
+
+func skillFields(s *claude.Skill) []field {
+	c := *s
+	c.Instructions = normalizeBody(c.Instructions)
+	return structFields(&c, "Name", "SourceDir")
+}
+
+func commandFields(c *claude.Command) []field {
+	n := *c
+	n.Instructions = normalizeBody(n.Instructions)
+	return structFields(&n, "Name")
+}
+
+func agentFields(a *claude.Agent) []field {
+	n := *a
+	n.Instructions = normalizeBody(n.Instructions)
+	return structFields(&n, "Name")
+}
+
+func mcpFields(s *mcp.Server) []field {
+	n := *s
+	n.Transport = s.EffectiveTransport()
+	return structFields(&n, "Name", "Disabled")
+}
+
+// structFields returns the exported fields of the struct v points to, but
+// those named in skip, in declaration order. Fields are named by their yaml
+// or json key and hold their JSON encoding, which sorts map keys; empty
+// collections encode like absent ones.
+func structFields(v any, skip ...string) []field {
+	rv := reflect.ValueOf(v).Elem()
+	var fields []field
+	for i := range rv.NumField() {
+		f := rv.Type().Field(i)
+		if !f.IsExported() || slices.Contains(skip, f.Name) {
+			continue
+		}
+		var value string
+		if fv := rv.Field(i); !isEmpty(fv) {
+			// Canonical fields are strings, booleans, and collections of
+			// strings, which always encode.
+			data, _ := json.Marshal(fv.Interface())
+			value = string(data)
+		}
+		fields = append(fields, field{fieldKey(f), []string{value}})
+	}
+	return fields
+}
+
+// isEmpty reports whether v is the zero value or an empty collection.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// fieldKey returns the name a user writes for a struct field: its yaml key,
+// else its json key, else its lowercased Go name.
+func fieldKey(f reflect.StructField) string {
+	for _, tag := range []string{"yaml", "json"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(f.Name)
+}
+
+func fieldsDigest(fields []field) string {
+	var parts []string
+	for _, f := range fields {
+		parts = append(parts, f.name, strconv.Itoa(len(f.values)))
+		parts = append(parts, f.values...)
+	}
+	return digest(parts...)
+}
+
+// normalizeBody trims surrounding whitespace, which platforms add or strip
+// when writing markdown bodies.
+func normalizeBody(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func digest(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = io.WriteString(h, p)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,180 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// LockFileName is the lockfile written next to the manifest.
+const LockFileName = "aix.lock"
+
+// Sentinel errors for lockfile operations.
+var (
+	ErrLockNotFound = errors.New("lockfile not found")
+	ErrLockMismatch = errors.New("lockfile does not match")
+)
+
+// Lock is the parsed form of an aix.lock file. It pins the sources of a
+// manifest: the commit and content hash each entry resolved to when aix
+// apply last ran, so that a frozen apply can reproduce them on another
+// machine. It is committed with the manifest and written only by an apply
+// that is neither a dry run nor frozen.
+//
+// A lock does not record what is installed. That is the install registry
+// (installed.json, see package registry), which every install path
+// updates, apply included, with the same commit and hash it locks. A
+// resource upgraded or reinstalled outside apply therefore differs from
+// its pin until apply runs again: a frozen apply returns it to the locked
+// version, and an apply without --frozen moves the pin.
+type Lock struct {
+	Version   int              `yaml:"version"`
+	Resources []LockedResource `yaml:"resources"`
+}
+
+// LockedResource pins a single manifest entry.
+type LockedResource struct {
+	Type resource.ResourceType `yaml:"type"`
+	Name string                `yaml:"name"`
+
+	// Source is the manifest source the entry was resolved from.
+	Source string `yaml:"source,omitempty"`
+
+	// URL and Commit identify the repository revision the resource came
+	// from. Both are empty for local sources.
+	URL    string `yaml:"url,omitempty"`
+	Commit string `yaml:"commit,omitempty"`
+
+	// SHA256 is the content hash of the resource's source files; see
+	// HashPath. It is not a hash of the installed copies, which each
+	// platform converts differently.
+	SHA256 string `yaml:"sha256"`
+}
+
+// LockPath returns the lockfile path for a manifest.
+func (m *Manifest) LockPath() string {
+	return filepath.Join(m.Dir, LockFileName)
+}
+
+// LoadLock reads the lockfile at path.
+func LoadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(ErrLockNotFound, "%s", path)
+		}
+		return nil, errors.Wrap(err, "reading lockfile")
+	}
+
+	var l Lock
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	if l.Version != CurrentVersion {
+		return nil, errors.Newf("%s: unsupported lockfile version %d (expected %d)", path, l.Version, CurrentVersion)
+	}
+	return &l, nil
+}
+
+// Write saves the lockfile to path with resources in a stable order.
+func (l *Lock) Write(path string) error {
+	l.Version = CurrentVersion
+	sort.Slice(l.Resources, func(i, j int) bool {
+		a, b := l.Resources[i], l.Resources[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	return fileutil.AtomicWriteYAML(path, l)
+}
+
+// Find returns the locked entry for a resource, or nil if there is none.
+func (l *Lock) Find(t resource.ResourceType, name string) *LockedResource {
+	for i := range l.Resources {
+		if l.Resources[i].Type == t && l.Resources[i].Name == name {
+			return &l.Resources[i]
+		}
+	}
+	return nil
+}
+
+// Update merges resolved entries into the lock and drops entries that are
+// no longer declared in m.
+func (l *Lock) Update(m *Manifest, resolved []*Resolved) {
+	declared := make(map[string]bool)
+	for _, e := range m.Entries() {
+		declared[string(e.Type)+"/"+e.Name] = true
+	}
+
+	kept := l.Resources[:0]
+	for _, r := range l.Resources {
+		if declared[string(r.Type)+"/"+r.Name] {
+			kept = append(kept, r)
+		}
+	}
+	l.Resources = kept
+
+	for _, r := range resolved {
+		locked := r.Locked()
+		if existing := l.Find(locked.Type, locked.Name); existing != nil {
+			*existing = locked
+			continue
+		}
+		l.Resources = append(l.Resources, locked)
+	}
+}
+
+// HashPath returns the SHA-256 of a resource's files. A single file hashes
+// its content; a directory hashes every regular file's relative path and
+// content in lexical order, so renames and additions change the result.
+func HashPath(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", errors.Wrap(err, "stat resource")
+	}
+	if !info.IsDir() {
+		return backup.HashFile(path)
+	}
+
+	h := sha256.New()
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		sum, err := backup.HashFile(p)
+		if err != nil {
+			return err
+		}
+		_, _ = io.WriteString(h, filepath.ToSlash(rel)+"\x00"+sum+"\x00")
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "hashing %s", path)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,155 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestHashPath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := HashPath(dir)
+	if err != nil {
+		t.Fatalf("HashPath() error = %v", err)
+	}
+	again, _ := HashPath(dir)
+	if first != again {
+		t.Error("HashPath() is not deterministic")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "extra.md"), []byte("b"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	added, _ := HashPath(dir)
+	if added == first {
+		t.Error("HashPath() did not change when a file was added")
+	}
+
+	fileSum, err := HashPath(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		t.Fatalf("HashPath(file) error = %v", err)
+	}
+	// sha256("a")
+	if want := "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"; fileSum != want {
+		t.Errorf("HashPath(file) = %s, want %s", fileSum, want)
+	}
+}
+
+func TestLock_WriteLoadUpdate(t *testing.T) {
+	m := &Manifest{
+		Version: 1,
+		Skills:  []Entry{{Type: resource.TypeSkill, Name: "review", Source: "official"}},
+	}
+	l := &Lock{Resources: []LockedResource{
+		{Type: resource.TypeSkill, Name: "review", SHA256: "old"},
+		{Type: resource.TypeCommand, Name: "gone", SHA256: "x"},
+	}}
+	l.Update(m, []*Resolved{{
+		Entry:  m.Skills[0],
+		URL:    "https://example.com/repo.git",
+		Commit: "abc",
+		SHA256: "new",
+	}})
+
+	if len(l.Resources) != 1 {
+		t.Fatalf("Update() kept %d resources, want 1", len(l.Resources))
+	}
+	if got := l.Find(resource.TypeSkill, "review"); got == nil || got.SHA256 != "new" || got.Commit != "abc" {
+		t.Errorf("Update() did not refresh the entry: %+v", got)
+	}
+
+	path := filepath.Join(t.TempDir(), LockFileName)
+	if err := l.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	loaded, err := LoadLock(path)
+	if err != nil {
+		t.Fatalf("LoadLock() error = %v", err)
+	}
+	if got := loaded.Find(resource.TypeSkill, "review"); got == nil || *got != l.Resources[0] {
+		t.Errorf("LoadLock() = %+v, want %+v", got, l.Resources[0])
+	}
+
+	if _, err := LoadLock(filepath.Join(t.TempDir(), LockFileName)); !errors.Is(err, ErrLockNotFound) {
+		t.Errorf("LoadLock() missing file error = %v, want ErrLockNotFound", err)
+	}
+}
+
+func TestResolver_Frozen(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	src := t.TempDir()
+	cmdFile := filepath.Join(src, "commands", "deploy.md")
+	if err := os.MkdirAll(filepath.Dir(cmdFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = src
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(cmdFile, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-m", "update")
+	}
+	git("init")
+	git("config", "user.email", "test@example.com")
+	git("config", "user.name", "Test User")
+	commit("---\ndescription: v1\n---\nDeploy\n")
+
+	entry := Entry{Type: resource.TypeCommand, Name: "deploy", Source: "file://" + src}
+	m := &Manifest{Version: 1, Commands: []Entry{entry}}
+
+	r := NewResolver(m)
+	v1, err := r.Resolve(entry)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	lock := &Lock{}
+	lock.Update(m, []*Resolved{v1})
+	_ = r.Close()
+
+	commit("---\ndescription: v2\n---\nDeploy\n")
+
+	frozen := NewResolver(m, WithLock(lock))
+	defer frozen.Close()
+	got, err := frozen.Resolve(entry)
+	if err != nil {
+		t.Fatalf("frozen Resolve() error = %v", err)
+	}
+	if got.Commit != v1.Commit || got.SHA256 != v1.SHA256 {
+		t.Errorf("frozen Resolve() = %s/%s, want locked %s/%s", got.Commit, got.SHA256, v1.Commit, v1.SHA256)
+	}
+
+	lock.Resources[0].SHA256 = "tampered"
+	tampered := NewResolver(m, WithLock(lock))
+	defer tampered.Close()
+	if _, err := tampered.Resolve(entry); !errors.Is(err, ErrLockMismatch) {
+		t.Errorf("Resolve() with wrong hash error = %v, want ErrLockMismatch", err)
+	}
+
+	other := Entry{Type: resource.TypeSkill, Name: "unlocked", Source: "./x"}
+	if _, err := frozen.Resolve(other); !errors.Is(err, ErrLockMismatch) {
+		t.Errorf("Resolve() of unlocked entry error = %v, want ErrLockMismatch", err)
+	}
+}
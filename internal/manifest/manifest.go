@@ -0,0 +1,255 @@
+// Package manifest implements the aix.yaml project manifest.
+//
+// A manifest is committed to a repository and declares the skills, slash
+// commands, agents and MCP servers the project expects to be installed,
+// where each one comes from, and which platforms it targets. The apply
+// command compares that desired state with what each platform reports and
+// computes a [Plan] of installs, updates and removals.
+//
+// Example aix.yaml:
+//
+//	version: 1
+//	platforms: [claude, opencode]
+//	skills:
+//	  - name: code-review
+//	    source: official          # configured repository
+//	    params:
+//	      org: acme
+//	commands:
+//	  - name: deploy
+//	    source: ./aix/commands/deploy.md
+//	mcp:
+//	  - name: github
+//	    source: https://github.com/acme/mcp-servers.git
+//	    platforms: [claude]
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/install"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+// FileName is the conventional manifest file name.
+const FileName = "aix.yaml"
+
+// CurrentVersion is the manifest schema version understood by this build.
+const CurrentVersion = 1
+
+// Sentinel errors for manifest operations.
+var (
+	ErrNotFound = errors.New("manifest not found")
+	ErrInvalid  = errors.New("invalid manifest")
+)
+
+// Manifest is the parsed form of an aix.yaml file.
+type Manifest struct {
+	// Version is the manifest schema version. Must be 1.
+	Version int `yaml:"version"`
+
+	// Platforms lists the default target platforms for every entry.
+	// Empty means the platforms selected on the command line, or all
+	// detected platforms.
+	Platforms []string `yaml:"platforms,omitempty"`
+
+	Skills   []Entry `yaml:"skills,omitempty"`
+	Commands []Entry `yaml:"commands,omitempty"`
+	Agents   []Entry `yaml:"agents,omitempty"`
+	MCP      []Entry `yaml:"mcp,omitempty"`
+
+	// Dir is the directory containing the manifest file. Relative local
+	// sources are resolved against it.
+	Dir string `yaml:"-"`
+}
+
+// Entry declares a single resource.
+type Entry struct {
+	// Type is the resource type, derived from the section the entry is in.
+	Type resource.ResourceType `yaml:"-"`
+
+	// Name is the resource name as installed on each platform.
+	Name string `yaml:"name"`
+
+	// Source is where the resource comes from: the name of a configured
+	// repository, a git URL, or a local path relative to the manifest.
+	// Empty means search all configured repositories by name.
+	Source string `yaml:"source,omitempty"`
+
+	// Platforms overrides the manifest-level platform list for this entry.
+	Platforms []string `yaml:"platforms,omitempty"`
+
+	// Params sets the parameters the resource declares; see package params.
+	// Parameters without a value take their defaults, and apply fails if a
+	// required one has none.
+	Params map[string]string `yaml:"params,omitempty"`
+}
+
+// SourceKind classifies an entry's Source.
+type SourceKind int
+
+const (
+	// SourceRepo resolves the entry from configured repositories.
+	SourceRepo SourceKind = iota
+	// SourceGit clones the entry's source URL.
+	SourceGit
+	// SourceLocal reads the entry from the filesystem.
+	SourceLocal
+)
+
+// SourceKind reports how the entry's Source should be resolved.
+func (e Entry) SourceKind() SourceKind {
+	switch {
+	case e.Source == "":
+		return SourceRepo
+	case git.IsURL(e.Source):
+		return SourceGit
+	case install.LooksLikePath(e.Source):
+		return SourceLocal
+	default:
+		return SourceRepo
+	}
+}
+
+// Load reads and validates the manifest at path.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Wrapf(ErrNotFound, "%s", path)
+		}
+		return nil, errors.Wrap(err, "reading manifest")
+	}
+
+	m, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", path)
+	}
+
+	abs, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving manifest directory")
+	}
+	m.Dir = abs
+	return m, nil
+}
+
+// Parse decodes and validates manifest YAML.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, errors.Wrapf(ErrInvalid, "parsing YAML: %v", err)
+	}
+	m.setTypes()
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// setTypes fills in Entry.Type from the section each entry was declared in.
+func (m *Manifest) setTypes() {
+	for i := range m.Skills {
+		m.Skills[i].Type = resource.TypeSkill
+	}
+	for i := range m.Commands {
+		m.Commands[i].Type = resource.TypeCommand
+	}
+	for i := range m.Agents {
+		m.Agents[i].Type = resource.TypeAgent
+	}
+	for i := range m.MCP {
+		m.MCP[i].Type = resource.TypeMCP
+	}
+}
+
+// Validate checks the manifest for structural errors.
+func (m *Manifest) Validate() error {
+	if m.Version != CurrentVersion {
+		return errors.Wrapf(ErrInvalid, "unsupported version %d (expected %d)", m.Version, CurrentVersion)
+	}
+	if err := validatePlatforms(m.Platforms); err != nil {
+		return err
+	}
+
+	seen := make(map[resource.ResourceType]map[string]bool)
+	for _, e := range m.Entries() {
+		if e.Name == "" {
+			return errors.Wrapf(ErrInvalid, "%s entry without a name", e.Type)
+		}
+		if seen[e.Type] == nil {
+			seen[e.Type] = make(map[string]bool)
+		}
+		if seen[e.Type][e.Name] {
+			return errors.Wrapf(ErrInvalid, "duplicate %s %q", e.Type, e.Name)
+		}
+		seen[e.Type][e.Name] = true
+
+		if err := validatePlatforms(e.Platforms); err != nil {
+			return errors.Wrapf(err, "%s %q", e.Type, e.Name)
+		}
+	}
+	return nil
+}
+
+func validatePlatforms(names []string) error {
+	for _, p := range names {
+		if !paths.ValidPlatform(p) {
+			return errors.Wrapf(ErrInvalid, "unknown platform %q", p)
+		}
+	}
+	return nil
+}
+
+// Entries returns every entry in the manifest, ordered skills, commands,
+// agents, then MCP servers.
+func (m *Manifest) Entries() []Entry {
+	out := make([]Entry, 0, len(m.Skills)+len(m.Commands)+len(m.Agents)+len(m.MCP))
+	out = append(out, m.Skills...)
+	out = append(out, m.Commands...)
+	out = append(out, m.Agents...)
+	return append(out, m.MCP...)
+}
+
+// Types returns the resource types that have at least one entry.
+func (m *Manifest) Types() []resource.ResourceType {
+	var types []resource.ResourceType
+	for _, e := range m.Entries() {
+		if !slices.Contains(types, e.Type) {
+			types = append(types, e.Type)
+		}
+	}
+	return types
+}
+
+// LocalPath returns the filesystem path of a SourceLocal entry, resolving
+// relative paths against the manifest directory.
+func (m *Manifest) LocalPath(e Entry) string {
+	if filepath.IsAbs(e.Source) {
+		return e.Source
+	}
+	return filepath.Join(m.Dir, e.Source)
+}
+
+// Targets returns the platforms e should be installed on, given the
+// platforms apply is operating on. An entry-level platform list narrows
+// the available set; otherwise every available platform is targeted.
+func (e Entry) Targets(available []string) []string {
+	if len(e.Platforms) == 0 {
+		return available
+	}
+	var out []string
+	for _, p := range available {
+		if slices.Contains(e.Platforms, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
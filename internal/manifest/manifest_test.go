@@ -0,0 +1,127 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestParse(t *testing.T) {
+	data := []byte(`version: 1
+platforms: [claude, opencode]
+skills:
+  - name: code-review
+    source: official
+    params:
+      org: acme
+commands:
+  - name: deploy
+    source: ./commands/deploy.md
+    platforms: [claude]
+mcp:
+  - name: github
+    source: https://github.com/acme/mcp.git
+`)
+
+	m, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	entries := m.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Entries() returned %d entries, want 3", len(entries))
+	}
+
+	wantTypes := []resource.ResourceType{resource.TypeSkill, resource.TypeCommand, resource.TypeMCP}
+	if got := m.Types(); !reflect.DeepEqual(got, wantTypes) {
+		t.Errorf("Types() = %v, want %v", got, wantTypes)
+	}
+
+	if got := entries[0].Params["org"]; got != "acme" {
+		t.Errorf("code-review params[org] = %q, want acme", got)
+	}
+
+	kinds := []SourceKind{SourceRepo, SourceLocal, SourceGit}
+	for i, e := range entries {
+		if e.Type != wantTypes[i] {
+			t.Errorf("entry %q type = %q, want %q", e.Name, e.Type, wantTypes[i])
+		}
+		if e.SourceKind() != kinds[i] {
+			t.Errorf("entry %q SourceKind() = %v, want %v", e.Name, e.SourceKind(), kinds[i])
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing version", data: "skills:\n  - name: a\n"},
+		{name: "unsupported version", data: "version: 2\n"},
+		{name: "entry without name", data: "version: 1\nskills:\n  - source: official\n"},
+		{name: "duplicate entry", data: "version: 1\nskills:\n  - name: a\n  - name: a\n"},
+		{name: "unknown platform", data: "version: 1\nplatforms: [vim]\n"},
+		{name: "unknown entry platform", data: "version: 1\nmcp:\n  - name: a\n    platforms: [vim]\n"},
+		{name: "malformed yaml", data: "version: [1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data)); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Parse() error = %v, want ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestParse_SameNameDifferentTypes(t *testing.T) {
+	data := []byte("version: 1\nskills:\n  - name: deploy\ncommands:\n  - name: deploy\n")
+	if _, err := Parse(data); err != nil {
+		t.Errorf("Parse() error = %v, want nil", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+
+	if _, err := Load(path); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load() missing file error = %v, want ErrNotFound", err)
+	}
+
+	data := []byte("version: 1\nagents:\n  - name: reviewer\n    source: agents/reviewer.md\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if m.Dir != dir {
+		t.Errorf("Dir = %q, want %q", m.Dir, dir)
+	}
+	want := filepath.Join(dir, "agents", "reviewer.md")
+	if got := m.LocalPath(m.Agents[0]); got != want {
+		t.Errorf("LocalPath() = %q, want %q", got, want)
+	}
+}
+
+func TestEntry_Targets(t *testing.T) {
+	available := []string{"claude", "opencode", "gemini"}
+
+	if got := (Entry{}).Targets(available); !reflect.DeepEqual(got, available) {
+		t.Errorf("Targets() without platforms = %v, want %v", got, available)
+	}
+
+	e := Entry{Platforms: []string{"gemini", "codex"}}
+	if got := e.Targets(available); !reflect.DeepEqual(got, []string{"gemini"}) {
+		t.Errorf("Targets() = %v, want [gemini]", got)
+	}
+}
@@ -0,0 +1,140 @@
+package manifest
+
+import (
+	"sort"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+// Action is the reconciliation step planned for a resource on a platform.
+type Action string
+
+// Plan actions.
+const (
+	// ActionInstall installs a declared resource that is missing.
+	ActionInstall Action = "install"
+	// ActionUpdate reinstalls a declared resource whose content differs.
+	ActionUpdate Action = "update"
+	// ActionRemove uninstalls a resource that is not declared (prune only).
+	ActionRemove Action = "remove"
+	// ActionNone means the installed resource already matches.
+	ActionNone Action = "unchanged"
+)
+
+// Desired is a manifest entry targeted at a single platform, together with
+// the digest of the content it should have there.
+type Desired struct {
+	Entry    Entry
+	Platform string
+	Digest   string
+}
+
+// Installed describes a resource currently present on a platform.
+type Installed struct {
+	Type     resource.ResourceType
+	Name     string
+	Platform string
+	Digest   string
+}
+
+// Change is a single planned step.
+type Change struct {
+	Action   Action
+	Type     resource.ResourceType
+	Name     string
+	Platform string
+
+	// Entry is the manifest entry driving the change. It is nil for removals.
+	Entry *Entry
+}
+
+// Plan is the ordered set of changes that reconciles platforms with a manifest.
+type Plan struct {
+	Changes []Change
+}
+
+// Pending returns the changes that modify something.
+func (p *Plan) Pending() []Change {
+	var out []Change
+	for _, c := range p.Changes {
+		if c.Action != ActionNone {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// HasChanges reports whether applying the plan would modify anything.
+func (p *Plan) HasChanges() bool {
+	return len(p.Pending()) > 0
+}
+
+// Compute builds a plan from the desired and installed state.
+//
+// Every desired resource that is not installed is planned for install, and
+// every one whose digest differs is planned for update. When prune is set,
+// installed resources that no desired entry covers are planned for removal;
+// otherwise they are left alone.
+func Compute(desired []Desired, installed []Installed, prune bool) *Plan {
+	type key struct {
+		typ      resource.ResourceType
+		name     string
+		platform string
+	}
+
+	current := make(map[key]Installed, len(installed))
+	for _, in := range installed {
+		current[key{in.Type, in.Name, in.Platform}] = in
+	}
+
+	plan := &Plan{}
+	wanted := make(map[key]bool, len(desired))
+	for _, d := range desired {
+		k := key{d.Entry.Type, d.Entry.Name, d.Platform}
+		wanted[k] = true
+
+		entry := d.Entry
+		change := Change{
+			Action:   ActionInstall,
+			Type:     d.Entry.Type,
+			Name:     d.Entry.Name,
+			Platform: d.Platform,
+			Entry:    &entry,
+		}
+		if in, ok := current[k]; ok {
+			change.Action = ActionNone
+			if in.Digest != d.Digest {
+				change.Action = ActionUpdate
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	if prune {
+		var removals []Change
+		for k := range current {
+			if wanted[k] {
+				continue
+			}
+			removals = append(removals, Change{
+				Action:   ActionRemove,
+				Type:     k.typ,
+				Name:     k.name,
+				Platform: k.platform,
+			})
+		}
+		sort.Slice(removals, func(i, j int) bool {
+			a, b := removals[i], removals[j]
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Platform < b.Platform
+		})
+		plan.Changes = append(plan.Changes, removals...)
+	}
+
+	return plan
+}
@@ -0,0 +1,132 @@
+package manifest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestCompute(t *testing.T) {
+	skill := Entry{Type: resource.TypeSkill, Name: "review"}
+	server := Entry{Type: resource.TypeMCP, Name: "github"}
+
+	desired := []Desired{
+		{Entry: skill, Platform: "claude", Digest: "a"},
+		{Entry: skill, Platform: "opencode", Digest: "a"},
+		{Entry: server, Platform: "claude", Digest: "b"},
+	}
+	installed := []Installed{
+		{Type: resource.TypeSkill, Name: "review", Platform: "claude", Digest: "a"},
+		{Type: resource.TypeMCP, Name: "github", Platform: "claude", Digest: "old"},
+		{Type: resource.TypeMCP, Name: "stale", Platform: "claude"},
+	}
+
+	got := func(plan *Plan) map[string]Action {
+		out := make(map[string]Action)
+		for _, c := range plan.Changes {
+			out[string(c.Type)+"/"+c.Name+"@"+c.Platform] = c.Action
+		}
+		return out
+	}
+
+	t.Run("without prune", func(t *testing.T) {
+		plan := Compute(desired, installed, false)
+		actions := got(plan)
+
+		want := map[string]Action{
+			"skill/review@claude":   ActionNone,
+			"skill/review@opencode": ActionInstall,
+			"mcp/github@claude":     ActionUpdate,
+		}
+		if len(actions) != len(want) {
+			t.Fatalf("plan has %d changes, want %d: %v", len(actions), len(want), actions)
+		}
+		for k, a := range want {
+			if actions[k] != a {
+				t.Errorf("%s: action = %q, want %q", k, actions[k], a)
+			}
+		}
+		if len(plan.Pending()) != 2 || !plan.HasChanges() {
+			t.Errorf("Pending() = %v, want 2 changes", plan.Pending())
+		}
+	})
+
+	t.Run("with prune", func(t *testing.T) {
+		plan := Compute(desired, installed, true)
+		if got(plan)["mcp/stale@claude"] != ActionRemove {
+			t.Errorf("undeclared server not planned for removal: %v", got(plan))
+		}
+		last := plan.Changes[len(plan.Changes)-1]
+		if last.Action != ActionRemove || last.Entry != nil {
+			t.Errorf("removals should come last without an entry, got %+v", last)
+		}
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		plan := Compute(desired[:1], installed[:1], false)
+		if plan.HasChanges() {
+			t.Errorf("HasChanges() = true for matching state: %v", plan.Pending())
+		}
+	})
+}
+
+func TestDigests(t *testing.T) {
+	a := &claude.Skill{Name: "review", Description: "d", Instructions: "body\n", SourceDir: "/src/review"}
+	b := &claude.Skill{Description: "d", Instructions: "\nbody", AllowedTools: claude.ToolList{}}
+	if SkillDigest(a) != SkillDigest(b) {
+		t.Error("SkillDigest should ignore the name, source directory, surrounding whitespace and empty fields")
+	}
+	if SkillDigest(a) == SkillDigest(&claude.Skill{Description: "d", Instructions: "other"}) {
+		t.Error("SkillDigest should change with the instructions")
+	}
+	for name, c := range map[string]*claude.Skill{
+		"license":       {Description: "d", Instructions: "body", License: "MIT"},
+		"allowed tools": {Description: "d", Instructions: "body", AllowedTools: claude.ToolList{"Read"}},
+		"metadata":      {Description: "d", Instructions: "body", Metadata: map[string]string{"team": "x"}},
+	} {
+		if SkillDigest(a) == SkillDigest(c) {
+			t.Errorf("SkillDigest should change with the %s", name)
+		}
+	}
+	if CommandDigest(&claude.Command{Instructions: "x"}) == CommandDigest(&claude.Command{Instructions: "x", Model: "opus"}) {
+		t.Error("CommandDigest should change with the model")
+	}
+
+	s1 := &mcp.Server{Name: "x", Command: "npx", Args: []string{"-y", "x"}, Env: map[string]string{"A": "1", "B": "2"}}
+	s2 := &mcp.Server{Command: "npx", Args: []string{"-y", "x"}, Transport: mcp.TransportStdio,
+		Env: map[string]string{"B": "2", "A": "1"}, Disabled: true}
+	if MCPDigest(s1) != MCPDigest(s2) {
+		t.Error("MCPDigest should ignore the name, map order, default transport and disabled")
+	}
+	s2.Platforms = []string{"darwin"}
+	if MCPDigest(s1) == MCPDigest(s2) {
+		t.Error("MCPDigest should change with the platforms")
+	}
+	s2.Platforms = nil
+	s2.Args = []string{"-y", "y"}
+	if MCPDigest(s1) == MCPDigest(s2) {
+		t.Error("MCPDigest should change with the args")
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	a := &mcp.Server{Command: "npx", Args: []string{"-y", "x"}, Env: map[string]string{"A": "1"}}
+	b := &mcp.Server{Command: "npx", Args: []string{"-y", "y"}, Env: map[string]string{"B": "1"}, Platforms: []string{"darwin"}}
+	got, err := DiffFields(a, b)
+	if err != nil {
+		t.Fatalf("DiffFields() error = %v", err)
+	}
+	if want := []string{"args", "env", "platforms"}; !slices.Equal(got, want) {
+		t.Errorf("DiffFields() = %v, want %v", got, want)
+	}
+
+	if got, _ := DiffFields(a, a); got != nil {
+		t.Errorf("DiffFields(a, a) = %v, want nil", got)
+	}
+	if _, err := DiffFields(a, &claude.Skill{}); err == nil {
+		t.Error("DiffFields() across types should fail")
+	}
+}
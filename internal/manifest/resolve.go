@@ -0,0 +1,295 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+// Resolved is a manifest entry located on disk, together with the
+// repository revision and content hash it resolved to.
+type Resolved struct {
+	Entry Entry
+
+	// Path is the local path of the resource's file or directory.
+	Path string
+
+	// URL and Commit identify the repository revision Path was read from.
+	// Both are empty for local sources.
+	URL    string
+	Commit string
+
+	// SHA256 is the content hash of Path; see HashPath.
+	SHA256 string
+
+	// Resource is the resource in the configured repositories the entry
+	// refers to. It is nil for git URLs and local sources.
+	Resource *resource.Resource
+}
+
+// Locked returns the lockfile record for r.
+func (r *Resolved) Locked() LockedResource {
+	return LockedResource{
+		Type:   r.Entry.Type,
+		Name:   r.Entry.Name,
+		Source: r.Entry.Source,
+		URL:    r.URL,
+		Commit: r.Commit,
+		SHA256: r.SHA256,
+	}
+}
+
+// Resolver turns manifest entries into local paths that the install
+// commands can read. Git sources are cloned once per URL into temporary
+// directories, which Close removes.
+type Resolver struct {
+	manifest *Manifest
+	lock     *Lock
+	clones   map[string]string
+}
+
+// ResolverOption configures a Resolver.
+type ResolverOption func(*Resolver)
+
+// WithLock freezes the resolver to a lockfile. Every entry must be locked;
+// repository sources are checked out at their locked commit, and resolution
+// fails with ErrLockMismatch if the content hash differs from the lock.
+func WithLock(l *Lock) ResolverOption {
+	return func(r *Resolver) {
+		r.lock = l
+	}
+}
+
+// NewResolver creates a Resolver for entries of m.
+func NewResolver(m *Manifest, opts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		manifest: m,
+		clones:   make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve locates the resource an entry refers to.
+func (r *Resolver) Resolve(e Entry) (*Resolved, error) {
+	if r.lock != nil {
+		return r.resolveLocked(e)
+	}
+
+	var (
+		res *Resolved
+		err error
+	)
+	switch e.SourceKind() {
+	case SourceLocal:
+		res, err = r.resolveLocal(e)
+	case SourceGit:
+		res, err = r.resolveGit(e)
+	default:
+		res, err = resolveRepo(e)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.SHA256, err = HashPath(res.Path); err != nil {
+		return nil, errors.Wrapf(err, "%s %q", e.Type, e.Name)
+	}
+	return res, nil
+}
+
+func (r *Resolver) resolveLocal(e Entry) (*Resolved, error) {
+	path := r.manifest.LocalPath(e)
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrapf(err, "%s %q: local source", e.Type, e.Name)
+	}
+	return &Resolved{Entry: e, Path: path}, nil
+}
+
+// resolveRepo finds the entry in the configured repositories, restricted to
+// the repository named by Source when one is given.
+func resolveRepo(e Entry) (*Resolved, error) {
+	res, err := findInRepos(e)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := git.Head(filepath.Join(paths.ReposCacheDir(), res.RepoName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q: repository %s", e.Type, e.Name, res.RepoName)
+	}
+	return &Resolved{Entry: e, Path: res.SourcePath(), URL: res.RepoURL, Commit: commit, Resource: res}, nil
+}
+
+// findInRepos returns the configured repository resource an entry refers to.
+func findInRepos(e Entry) (*resource.Resource, error) {
+	var res *resource.Resource
+	if e.Source != "" {
+		found, err := resource.FindByNameInRepo(e.Name, e.Type, e.Source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %q", e.Type, e.Name)
+		}
+		if found == nil {
+			return nil, errors.Newf("%s %q not found in repository %q", e.Type, e.Name, e.Source)
+		}
+		res = found
+	} else {
+		matches, err := resource.FindByName(e.Name, e.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s %q", e.Type, e.Name)
+		}
+		switch len(matches) {
+		case 0:
+			return nil, errors.Newf("%s %q not found in any configured repository", e.Type, e.Name)
+		case 1:
+			res = &matches[0]
+		default:
+			repos := make([]string, len(matches))
+			for i, m := range matches {
+				repos[i] = m.RepoName
+			}
+			return nil, errors.Newf("%s %q exists in several repositories (%s); set source to one of them",
+				e.Type, e.Name, strings.Join(repos, ", "))
+		}
+	}
+	return res, nil
+}
+
+// resolveGit clones the entry's URL (once per URL) and locates the resource
+// in the clone.
+func (r *Resolver) resolveGit(e Entry) (*Resolved, error) {
+	dir, err := r.clone(e.Source, "")
+	if err != nil {
+		return nil, err
+	}
+	commit, err := git.Head(dir)
+	if err != nil {
+		return nil, err
+	}
+	path, err := findInClone(dir, e, e.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolved{Entry: e, Path: path, URL: e.Source, Commit: commit}, nil
+}
+
+// resolveLocked resolves e exactly as recorded in the lock.
+func (r *Resolver) resolveLocked(e Entry) (*Resolved, error) {
+	locked := r.lock.Find(e.Type, e.Name)
+	if locked == nil {
+		return nil, errors.Wrapf(ErrLockMismatch, "%s %q is not locked", e.Type, e.Name)
+	}
+	if locked.Source != e.Source {
+		return nil, errors.Wrapf(ErrLockMismatch, "%s %q: source changed from %q to %q",
+			e.Type, e.Name, locked.Source, e.Source)
+	}
+
+	var res *Resolved
+	if e.SourceKind() == SourceLocal {
+		var err error
+		if res, err = r.resolveLocal(e); err != nil {
+			return nil, err
+		}
+	} else {
+		if locked.URL == "" || locked.Commit == "" {
+			return nil, errors.Wrapf(ErrLockMismatch, "%s %q: missing url or commit", e.Type, e.Name)
+		}
+		dir, err := r.clone(locked.URL, locked.Commit)
+		if err != nil {
+			return nil, err
+		}
+		path, err := findInClone(dir, e, locked.URL)
+		if err != nil {
+			return nil, err
+		}
+		res = &Resolved{Entry: e, Path: path, URL: locked.URL, Commit: locked.Commit}
+		if e.SourceKind() == SourceRepo {
+			// The locked copy is recorded against the repository it came
+			// from, if that is still configured
+			res.Resource, _ = findInRepos(e)
+		}
+	}
+
+	sum, err := HashPath(res.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %q", e.Type, e.Name)
+	}
+	if sum != locked.SHA256 {
+		return nil, errors.Wrapf(ErrLockMismatch, "%s %q: content hash %s, locked %s",
+			e.Type, e.Name, sum, locked.SHA256)
+	}
+	res.SHA256 = sum
+	return res, nil
+}
+
+// clone returns a temporary clone of url, checked out at commit when one is
+// given. Clones are shared between entries with the same URL and commit.
+func (r *Resolver) clone(url, commit string) (string, error) {
+	key := url + "@" + commit
+	if dir, ok := r.clones[key]; ok {
+		return dir, nil
+	}
+
+	dir, err := os.MkdirTemp("", "aix-apply-*")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temp directory")
+	}
+
+	// A pinned commit may be anywhere in history, so fetch all of it.
+	depth := 1
+	if commit != "" {
+		depth = 0
+	}
+	if err := git.Clone(url, dir, depth); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", errors.Wrapf(err, "cloning %s", url)
+	}
+	if commit != "" {
+		if err := git.Checkout(dir, commit); err != nil {
+			_ = os.RemoveAll(dir)
+			return "", errors.Wrapf(err, "checking out %s in %s", commit, url)
+		}
+	}
+
+	r.clones[key] = dir
+	return dir, nil
+}
+
+// findInClone locates e in a cloned repository. A repository that is itself
+// a single skill resolves to its root, matching `aix skill install <url>`.
+func findInClone(dir string, e Entry, url string) (string, error) {
+	resources, err := resource.NewScanner().ScanRepo(dir, "", url)
+	if err != nil {
+		return "", errors.Wrapf(err, "scanning %s", url)
+	}
+	for _, res := range resources {
+		if res.Type == e.Type && res.Name == e.Name {
+			return filepath.Join(dir, res.Path), nil
+		}
+	}
+
+	if e.Type == resource.TypeSkill {
+		if _, err := os.Stat(filepath.Join(dir, "SKILL.md")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", errors.Newf("%s %q not found in %s", e.Type, e.Name, url)
+}
+
+// Close removes any temporary clones created by Resolve.
+func (r *Resolver) Close() error {
+	var errs []error
+	for key, dir := range r.clones {
+		if err := os.RemoveAll(dir); err != nil {
+			errs = append(errs, errors.Wrapf(err, "removing clone of %s", key))
+		}
+		delete(r.clones, key)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestResolver_Local(t *testing.T) {
+	dir := t.TempDir()
+	skillDir := filepath.Join(dir, "skills", "review")
+	if err := os.MkdirAll(skillDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manifest{Version: 1, Dir: dir}
+	r := NewResolver(m)
+	defer r.Close()
+
+	got, err := r.Resolve(Entry{Type: resource.TypeSkill, Name: "review", Source: "./skills/review"})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Path != skillDir {
+		t.Errorf("Resolve().Path = %q, want %q", got.Path, skillDir)
+	}
+	if got.URL != "" || got.Commit != "" {
+		t.Errorf("local source should have no origin, got %q@%q", got.URL, got.Commit)
+	}
+	if got.SHA256 == "" {
+		t.Error("Resolve().SHA256 is empty")
+	}
+
+	if _, err := r.Resolve(Entry{Type: resource.TypeSkill, Name: "missing", Source: "./skills/missing"}); err == nil {
+		t.Error("Resolve() expected error for missing local source")
+	}
+}
+
+func TestResolver_Git(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	src := t.TempDir()
+	cmdDir := filepath.Join(src, "commands")
+	if err := os.MkdirAll(cmdDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\ndescription: Deploy\n---\nDeploy $ARGUMENTS\n"
+	if err := os.WriteFile(filepath.Join(cmdDir, "deploy.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"add", "."},
+		{"commit", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = src
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	r := NewResolver(&Manifest{Version: 1})
+	got, err := r.Resolve(Entry{Type: resource.TypeCommand, Name: "deploy", Source: "file://" + src})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if filepath.Base(got.Path) != "deploy.md" {
+		t.Errorf("Resolve().Path = %q, want a path to deploy.md", got.Path)
+	}
+	if got.URL != "file://"+src || len(got.Commit) != 40 {
+		t.Errorf("Resolve() origin = %q@%q, want the source URL and a full SHA", got.URL, got.Commit)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(got.Path); !os.IsNotExist(err) {
+		t.Errorf("clone not removed by Close(): %v", err)
+	}
+}
@@ -9,7 +9,7 @@
 // # Server Configuration
 //
 // The [Server] type represents a single MCP server with support for both
-// local (stdio) and remote (SSE) transports:
+// local (stdio) and remote (Streamable HTTP or SSE) transports:
 //
 //	// Local stdio server
 //	server := &mcp.Server{
@@ -19,20 +19,21 @@
 //	    Env:     map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}"},
 //	}
 //
-//	// Remote SSE server
+//	// Remote Streamable HTTP server
 //	server := &mcp.Server{
 //	    Name:      "remote-api",
 //	    URL:       "https://api.example.com/mcp",
-//	    Transport: mcp.TransportSSE,
+//	    Transport: mcp.TransportHTTP,
 //	    Headers:   map[string]string{"Authorization": "Bearer ${API_KEY}"},
 //	}
 //
 // # Transport Types
 //
-// MCP supports two transport mechanisms:
+// MCP supports three transport mechanisms:
 //
 //   - [TransportStdio]: Local process communication via stdin/stdout (default)
-//   - [TransportSSE]: Remote server communication via Server-Sent Events
+//   - [TransportHTTP]: Remote server communication via Streamable HTTP
+//   - [TransportSSE]: Remote server communication via the legacy HTTP+SSE transport
 //
 // Use the [Server.IsLocal] and [Server.IsRemote] helper methods to determine
 // the transport type:
@@ -0,0 +1,409 @@
+package probe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// maxErrorBody bounds how much of an HTTP error response is quoted.
+const maxErrorBody = 512
+
+// streamableHTTPConn speaks the Streamable HTTP transport: every message is
+// POSTed to the endpoint, and responses arrive either as a JSON body or as
+// an event stream.
+type streamableHTTPConn struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+
+	sessionID       string
+	protocolVersion string
+	nextID          int64
+}
+
+var _ conn = (*streamableHTTPConn)(nil)
+
+// newStreamableHTTP returns a connection to endpoint. No request is made
+// until the first call.
+func newStreamableHTTP(client *http.Client, endpoint string, headers map[string]string) *streamableHTTPConn {
+	return &streamableHTTPConn{client: client, endpoint: endpoint, headers: headers}
+}
+
+func (c *streamableHTTPConn) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	c.nextID++
+	id := c.nextID
+	resp, err := c.post(ctx, newRequest(id, method, params))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if sid := resp.Header.Get("Mcp-Session-Id"); sid != "" {
+		c.sessionID = sid
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		var m message
+		if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+			return nil, errors.Wrap(err, "decoding response")
+		}
+		if !m.isResponseTo(id) {
+			return nil, errors.New("response does not match request")
+		}
+		return m.outcome()
+	case "text/event-stream":
+		var out json.RawMessage
+		var outErr error
+		found := false
+		err := readEvents(resp.Body, func(_, data string) bool {
+			var m message
+			if json.Unmarshal([]byte(data), &m) != nil || !m.isResponseTo(id) {
+				return true
+			}
+			out, outErr = m.outcome()
+			found = true
+			return false
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "reading event stream")
+		}
+		if !found {
+			return nil, errors.New("event stream ended without a response")
+		}
+		return out, outErr
+	default:
+		return nil, errors.Newf("unexpected response content type %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+func (c *streamableHTTPConn) notify(ctx context.Context, method string) error {
+	resp, err := c.post(ctx, newRequest(0, method, nil))
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+func (c *streamableHTTPConn) setProtocolVersion(version string) {
+	c.protocolVersion = version
+}
+
+// close ends the session, if the server started one. Servers may refuse
+// the request, which is harmless.
+func (c *streamableHTTPConn) close() {
+	if c.sessionID == "" {
+		return
+	}
+	req, err := http.NewRequest(http.MethodDelete, c.endpoint, nil)
+	if err != nil {
+		return
+	}
+	c.setHeaders(req)
+	if resp, err := c.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// post sends one message and checks the response status.
+func (c *streamableHTTPConn) post(ctx context.Context, msg request) (*http.Response, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding message")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	c.setHeaders(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// setHeaders adds the configured headers and the session headers.
+func (c *streamableHTTPConn) setHeaders(req *http.Request) {
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	if c.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", c.sessionID)
+	}
+	if c.protocolVersion != "" {
+		req.Header.Set("MCP-Protocol-Version", c.protocolVersion)
+	}
+}
+
+// sseConn speaks the legacy HTTP+SSE transport: a long-lived GET stream
+// carries every server message, and the first event names the endpoint that
+// client messages are POSTed to.
+type sseConn struct {
+	client   *http.Client
+	endpoint string
+	headers  map[string]string
+
+	msgs   chan *message
+	done   chan struct{} // closed when the stream ends
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	streamErr error
+
+	nextID int64
+}
+
+var _ conn = (*sseConn)(nil)
+
+// openSSE opens the event stream at streamURL and waits for the endpoint
+// event.
+func openSSE(ctx context.Context, client *http.Client, streamURL string, headers map[string]string) (*sseConn, error) {
+	base, err := url.Parse(streamURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	// The stream outlives ctx, so connecting is bounded separately.
+	type opened struct {
+		resp *http.Response
+		err  error
+	}
+	ch := make(chan opened, 1)
+	go func() {
+		resp, err := client.Do(req)
+		ch <- opened{resp, err}
+	}()
+	var resp *http.Response
+	select {
+	case o := <-ch:
+		if o.err != nil {
+			cancel()
+			return nil, o.err
+		}
+		resp = o.resp
+	case <-ctx.Done():
+		cancel()
+		return nil, errors.Wrap(ctx.Err(), "connecting")
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		cancel()
+		return nil, err
+	}
+
+	c := &sseConn{
+		client:  client,
+		headers: headers,
+		msgs:    make(chan *message),
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+	endpoint := make(chan string, 1)
+	go c.read(resp.Body, endpoint)
+
+	select {
+	case e := <-endpoint:
+		ref, err := url.Parse(e)
+		if err != nil {
+			c.close()
+			return nil, errors.Wrap(err, "parsing endpoint event")
+		}
+		c.endpoint = base.ResolveReference(ref).String()
+		return c, nil
+	case <-c.done:
+		c.close()
+		return nil, c.closedError("stream ended before the endpoint event")
+	case <-ctx.Done():
+		c.close()
+		return nil, errors.Wrap(ctx.Err(), "waiting for endpoint event")
+	}
+}
+
+// read delivers the endpoint event and then messages until the stream ends.
+func (c *sseConn) read(body io.ReadCloser, endpoint chan<- string) {
+	defer close(c.done)
+	defer body.Close()
+	sentEndpoint := false
+	err := readEvents(body, func(event, data string) bool {
+		if event == "endpoint" {
+			if !sentEndpoint {
+				endpoint <- strings.TrimSpace(data)
+				sentEndpoint = true
+			}
+			return true
+		}
+		var m message
+		if json.Unmarshal([]byte(data), &m) != nil {
+			return true
+		}
+		c.msgs <- &m
+		return true
+	})
+	c.mu.Lock()
+	c.streamErr = err
+	c.mu.Unlock()
+}
+
+func (c *sseConn) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	c.nextID++
+	id := c.nextID
+	if err := c.post(ctx, newRequest(id, method, params)); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case m := <-c.msgs:
+			if m.isResponseTo(id) {
+				return m.outcome()
+			}
+			if m.Method != "" && len(m.ID) > 0 {
+				_ = c.post(ctx, replyTo(m))
+			}
+		case <-c.done:
+			return nil, c.closedError("stream ended before the response")
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "no response")
+		}
+	}
+}
+
+func (c *sseConn) notify(ctx context.Context, method string) error {
+	return c.post(ctx, newRequest(0, method, nil))
+}
+
+func (c *sseConn) setProtocolVersion(string) {}
+
+// close ends the event stream. Closing the body would block until the next
+// event, so the stream's request is canceled instead; read drains any
+// message it was delivering.
+func (c *sseConn) close() {
+	c.cancel()
+	go func() {
+		for range c.msgs {
+		}
+	}()
+	<-c.done
+	close(c.msgs)
+}
+
+// post sends one message to the endpoint.
+func (c *sseConn) post(ctx context.Context, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "encoding message")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// closedError describes a stream that ended, with the read error if any.
+func (c *sseConn) closedError(msg string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.streamErr != nil {
+		return errors.Wrap(c.streamErr, msg)
+	}
+	return errors.New(msg)
+}
+
+// checkStatus returns an error describing a non-2xx response.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		return errors.Newf("HTTP %s", resp.Status)
+	}
+	return errors.Newf("HTTP %s: %s", resp.Status, msg)
+}
+
+// readEvents parses a text/event-stream body, calling fn with each event's
+// type and data until fn returns false or the stream ends. Events without
+// an explicit type have type "message".
+func readEvents(r io.Reader, fn func(event, data string) bool) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxMessageSize)
+	event := ""
+	var data []string
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			if len(data) > 0 {
+				if event == "" {
+					event = "message"
+				}
+				if !fn(event, strings.Join(data, "\n")) {
+					return nil
+				}
+			}
+			event, data = "", nil
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if event == "" {
+			event = "message"
+		}
+		fn(event, strings.Join(data, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+// Package probe checks that an MCP server actually works by connecting to
+// it, performing the initialize handshake, and listing its tools.
+//
+// Local servers are started as a child process and spoken to over stdio.
+// Remote servers are reached over Streamable HTTP or the legacy HTTP+SSE
+// transport. Secret references in env and header values are resolved before
+// connecting. Command references are refused unless WithCommands is given:
+// servers are probed as read from a platform's configuration, which aix
+// does not run commands from without the user's consent.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/secret"
+)
+
+// ProtocolVersion is the MCP protocol version aix requests during the
+// initialize handshake. Servers may answer with an older version.
+const ProtocolVersion = "2025-06-18"
+
+// Sentinel errors for probe operations.
+var (
+	// ErrNoEndpoint indicates the server has neither a command nor a URL.
+	ErrNoEndpoint = errors.New("server has neither command nor URL")
+
+	// ErrServerExited indicates a stdio server exited before answering.
+	ErrServerExited = errors.New("server exited")
+)
+
+// Result describes a server that completed the handshake.
+type Result struct {
+	// ProtocolVersion is the protocol version the server agreed to.
+	ProtocolVersion string `json:"protocol_version"`
+
+	// ServerName and ServerVersion are the serverInfo reported by the server.
+	ServerName    string `json:"server_name"`
+	ServerVersion string `json:"server_version,omitempty"`
+
+	// Tools is the number of tools the server offers. It is zero when the
+	// server does not declare the tools capability.
+	Tools int `json:"tools"`
+
+	// Duration is how long the probe took, including process start-up.
+	Duration time.Duration `json:"duration"`
+}
+
+// Option configures a Prober.
+type Option func(*Prober)
+
+// Prober connects to MCP servers.
+type Prober struct {
+	clientName    string
+	clientVersion string
+	httpClient    *http.Client
+	commands      bool
+}
+
+// New creates a Prober with the given options.
+func New(opts ...Option) *Prober {
+	p := &Prober{
+		clientName:    "aix",
+		clientVersion: "dev",
+		httpClient:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// WithClientInfo sets the clientInfo sent in the initialize request.
+func WithClientInfo(name, version string) Option {
+	return func(p *Prober) {
+		p.clientName = name
+		p.clientVersion = version
+	}
+}
+
+// WithCommands sets whether command references in env and header values
+// are run. The user must have opted in to running them.
+func WithCommands(allow bool) Option {
+	return func(p *Prober) {
+		p.commands = allow
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for remote servers.
+func WithHTTPClient(c *http.Client) Option {
+	return func(p *Prober) {
+		p.httpClient = c
+	}
+}
+
+// Probe connects to the server, performs the initialize handshake, and
+// lists its tools. The context bounds the whole probe; callers should set a
+// deadline, since a misbehaving server may never answer. Unless the Prober
+// was created WithCommands, a server whose env or headers reference a
+// command is refused with secret.ErrUntrustedCommand.
+func (p *Prober) Probe(ctx context.Context, server *mcp.Server) (*Result, error) {
+	start := time.Now()
+
+	if !p.commands {
+		if err := server.CheckUntrusted(); err != nil {
+			return nil, err
+		}
+	}
+	env, err := secret.RenderMap(server.Env, secret.Syntax{})
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving env")
+	}
+	headers, err := secret.RenderMap(server.Headers, secret.Syntax{})
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving headers")
+	}
+
+	var c conn
+	switch server.EffectiveTransport() {
+	case mcp.TransportStdio:
+		c, err = startStdio(server.Command, server.Args, env)
+	case mcp.TransportHTTP:
+		c = newStreamableHTTP(p.httpClient, server.URL, headers)
+	case mcp.TransportSSE:
+		c, err = openSSE(ctx, p.httpClient, server.URL, headers)
+	case "":
+		return nil, ErrNoEndpoint
+	default:
+		return nil, errors.Newf("unsupported transport %q", server.Transport)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	result, err := p.session(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// session runs the handshake and tools/list over an open connection.
+func (p *Prober) session(ctx context.Context, c conn) (*Result, error) {
+	params := map[string]any{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]string{"name": p.clientName, "version": p.clientVersion},
+	}
+	raw, err := c.call(ctx, "initialize", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "initialize")
+	}
+	var init struct {
+		ProtocolVersion string                     `json:"protocolVersion"`
+		Capabilities    map[string]json.RawMessage `json:"capabilities"`
+		ServerInfo      struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(raw, &init); err != nil {
+		return nil, errors.Wrap(err, "parsing initialize result")
+	}
+	if init.ProtocolVersion == "" {
+		return nil, errors.New("initialize result has no protocolVersion")
+	}
+	c.setProtocolVersion(init.ProtocolVersion)
+
+	if err := c.notify(ctx, "notifications/initialized"); err != nil {
+		return nil, errors.Wrap(err, "notifications/initialized")
+	}
+
+	result := &Result{
+		ProtocolVersion: init.ProtocolVersion,
+		ServerName:      init.ServerInfo.Name,
+		ServerVersion:   init.ServerInfo.Version,
+	}
+	if _, ok := init.Capabilities["tools"]; !ok {
+		return result, nil
+	}
+
+	cursor := ""
+	for {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+		raw, err := c.call(ctx, "tools/list", params)
+		if err != nil {
+			return nil, errors.Wrap(err, "tools/list")
+		}
+		var page struct {
+			Tools      []json.RawMessage `json:"tools"`
+			NextCursor string            `json:"nextCursor"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return nil, errors.Wrap(err, "parsing tools/list result")
+		}
+		result.Tools += len(page.Tools)
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	return result, nil
+}
+
+// conn is a JSON-RPC connection to a server over one transport.
+type conn interface {
+	// call sends a request and waits for the matching response.
+	call(ctx context.Context, method string, params any) (json.RawMessage, error)
+
+	// notify sends a notification, which has no response.
+	notify(ctx context.Context, method string) error
+
+	// setProtocolVersion records the negotiated version for transports
+	// that send it with every request.
+	setProtocolVersion(version string)
+
+	// close releases the connection, stopping a stdio server.
+	close()
+}
+
+// request is an outgoing JSON-RPC request or notification.
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// message is an incoming JSON-RPC message: a response to one of our
+// requests, or a request or notification from the server.
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *rpcError       `json:"error,omitempty"`
+}
+
+// isResponseTo reports whether m is the response to request id.
+func (m *message) isResponseTo(id int64) bool {
+	return m.Method == "" && string(m.ID) == fmt.Sprint(id)
+}
+
+// outcome returns the result of a response, or its error.
+func (m *message) outcome() (json.RawMessage, error) {
+	if m.Error != nil {
+		return nil, m.Error
+	}
+	return m.Result, nil
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("server error %d: %s", e.Code, e.Message)
+}
+
+// newRequest builds a request; a zero id makes it a notification.
+func newRequest(id int64, method string, params any) request {
+	r := request{JSONRPC: "2.0", Method: method, Params: params}
+	if id != 0 {
+		r.ID = &id
+	}
+	return r
+}
+
+// replyTo builds the response aix sends to a request from the server.
+// Probing never needs the server's requests to succeed, so only ping is
+// answered; anything else is declined.
+func replyTo(m *message) map[string]any {
+	reply := map[string]any{"jsonrpc": "2.0", "id": m.ID}
+	if m.Method == "ping" {
+		reply["result"] = map[string]any{}
+	} else {
+		reply["error"] = rpcError{Code: -32601, Message: "method not found"}
+	}
+	return reply
+}
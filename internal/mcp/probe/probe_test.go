@@ -0,0 +1,284 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/secret"
+)
+
+// fakeServerEnv makes the test binary act as a stdio MCP server. Its value
+// selects the behavior: "ok" or "crash".
+const fakeServerEnv = "AIX_PROBE_FAKE_SERVER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(fakeServerEnv) {
+	case "ok":
+		runFakeStdioServer()
+		os.Exit(0)
+	case "crash":
+		fmt.Fprintln(os.Stderr, "fatal: missing --token")
+		os.Exit(2)
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeStdioServer answers requests on stdin until it is closed.
+func runFakeStdioServer() {
+	// A banner on stdout must not confuse the client.
+	fmt.Println("fake server starting")
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		var msg map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &msg); err != nil {
+			continue
+		}
+		if reply := fakeReply(msg); reply != nil {
+			data, _ := json.Marshal(reply)
+			fmt.Println(string(data))
+		}
+	}
+}
+
+// fakeReply returns the response to a request, or nil for a notification.
+// The server has three tools spread over two pages.
+func fakeReply(msg map[string]any) map[string]any {
+	id, ok := msg["id"]
+	if !ok {
+		return nil
+	}
+	reply := map[string]any{"jsonrpc": "2.0", "id": id}
+	switch msg["method"] {
+	case "initialize":
+		reply["result"] = map[string]any{
+			"protocolVersion": "2025-03-26",
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "fake", "version": "1.2.3"},
+		}
+	case "tools/list":
+		params, _ := msg["params"].(map[string]any)
+		if params["cursor"] == "page2" {
+			reply["result"] = map[string]any{"tools": []any{map[string]any{"name": "c"}}}
+		} else {
+			reply["result"] = map[string]any{
+				"tools":      []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+				"nextCursor": "page2",
+			}
+		}
+	default:
+		reply["error"] = map[string]any{"code": -32601, "message": "method not found"}
+	}
+	return reply
+}
+
+func checkResult(t *testing.T, got *Result) {
+	t.Helper()
+	if got.ProtocolVersion != "2025-03-26" || got.ServerName != "fake" || got.ServerVersion != "1.2.3" {
+		t.Errorf("Probe() = %+v, want fake 1.2.3 speaking 2025-03-26", got)
+	}
+	if got.Tools != 3 {
+		t.Errorf("Probe() Tools = %d, want 3", got.Tools)
+	}
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestProbe_Stdio(t *testing.T) {
+	t.Setenv("AIX_PROBE_MODE", "ok")
+	server := &mcp.Server{
+		Name:    "fake",
+		Command: os.Args[0],
+		Env:     map[string]string{fakeServerEnv: "${env:AIX_PROBE_MODE}"},
+	}
+	got, err := New().Probe(testContext(t), server)
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	checkResult(t, got)
+}
+
+func TestProbe_StdioExit(t *testing.T) {
+	server := &mcp.Server{
+		Name:    "fake",
+		Command: os.Args[0],
+		Env:     map[string]string{fakeServerEnv: "crash"},
+	}
+	_, err := New().Probe(testContext(t), server)
+	if !errors.Is(err, ErrServerExited) {
+		t.Fatalf("Probe() error = %v, want ErrServerExited", err)
+	}
+	if !strings.Contains(err.Error(), "missing --token") {
+		t.Errorf("Probe() error = %v, want stderr included", err)
+	}
+}
+
+func TestProbe_StdioMissingCommand(t *testing.T) {
+	server := &mcp.Server{Name: "fake", Command: "aix-probe-no-such-command"}
+	if _, err := New().Probe(testContext(t), server); err == nil {
+		t.Fatal("Probe() should fail for a missing command")
+	}
+}
+
+func TestProbe_RefusesCommandReferences(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	server := &mcp.Server{
+		Name:    "fake",
+		Command: os.Args[0],
+		Env:     map[string]string{fakeServerEnv: "ok", "TOKEN": "${cmd:touch " + marker + "}"},
+	}
+	_, err := New().Probe(testContext(t), server)
+	if !errors.Is(err, secret.ErrUntrustedCommand) {
+		t.Fatalf("Probe() error = %v, want ErrUntrustedCommand", err)
+	}
+	if !strings.Contains(err.Error(), `server "fake" env: TOKEN references`) {
+		t.Errorf("Probe() error = %v, want the key named", err)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("the referenced command was run, stat error = %v", err)
+	}
+}
+
+func TestProbe_WithCommands(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	server := &mcp.Server{
+		Name:    "fake",
+		Command: os.Args[0],
+		Env:     map[string]string{fakeServerEnv: "ok", "TOKEN": "${cmd:touch " + marker + "}"},
+	}
+	if _, err := New(WithCommands(true)).Probe(testContext(t), server); err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("the referenced command was not run: %v", err)
+	}
+}
+
+func TestProbe_StreamableHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var msg map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if msg["method"] != "initialize" && r.Header.Get("Mcp-Session-Id") != "s1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		reply := fakeReply(msg)
+		if reply == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		data, _ := json.Marshal(reply)
+		if msg["method"] == "initialize" {
+			w.Header().Set("Mcp-Session-Id", "s1")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	}))
+	defer srv.Close()
+
+	server := &mcp.Server{
+		Name:    "fake",
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	}
+	got, err := New().Probe(testContext(t), server)
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	checkResult(t, got)
+
+	server.Headers = nil
+	if _, err := New().Probe(testContext(t), server); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Probe() without auth error = %v, want HTTP 401", err)
+	}
+}
+
+func TestProbe_SSE(t *testing.T) {
+	replies := make(chan []byte, 4)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: endpoint\ndata: /messages?session=1\n\n")
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case data := <-replies:
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/messages", func(w http.ResponseWriter, r *http.Request) {
+		var msg map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if reply := fakeReply(msg); reply != nil {
+			data, _ := json.Marshal(reply)
+			replies <- data
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	server := &mcp.Server{Name: "fake", URL: srv.URL + "/sse", Transport: mcp.TransportSSE}
+	got, err := New().Probe(testContext(t), server)
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	checkResult(t, got)
+}
+
+func TestProbe_NoEndpoint(t *testing.T) {
+	if _, err := New().Probe(testContext(t), &mcp.Server{Name: "empty"}); !errors.Is(err, ErrNoEndpoint) {
+		t.Errorf("Probe() error = %v, want ErrNoEndpoint", err)
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	stream := ": comment\nevent: endpoint\ndata: /a\n\ndata: line1\ndata: line2\n\ndata: tail"
+	var got []string
+	err := readEvents(strings.NewReader(stream), func(event, data string) bool {
+		got = append(got, event+"="+data)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"endpoint=/a", "message=line1\nline2", "message=tail"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("readEvents() = %q, want %q", got, want)
+	}
+}
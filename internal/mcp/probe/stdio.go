@@ -0,0 +1,209 @@
+package probe
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// maxMessageSize bounds a single line of server output. Tool lists with
+// large input schemas can run to several megabytes.
+const maxMessageSize = 16 << 20
+
+// stderrTail is how much of a server's stderr is kept for error messages.
+const stderrTail = 2048
+
+// stdioConn speaks newline-delimited JSON-RPC to a child process.
+type stdioConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *io.PipeReader
+	stderr *tailBuffer
+
+	msgs chan *message
+	done chan struct{} // closed when stdout reaches EOF
+	stop chan struct{} // closed by close
+
+	exited  chan struct{} // closed when the process has exited
+	waitErr error
+
+	nextID int64
+}
+
+var _ conn = (*stdioConn)(nil)
+
+// startStdio starts command with args and env added to the environment.
+func startStdio(command string, args []string, env map[string]string) (*stdioConn, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating stdin pipe")
+	}
+	// An io.Pipe rather than StdoutPipe lets Wait run concurrently with
+	// reads: exec copies into it and Wait returns once the copy is done.
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	c := &stdioConn{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: pr,
+		stderr: &tailBuffer{max: stderrTail},
+		msgs:   make(chan *message),
+		done:   make(chan struct{}),
+		stop:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	cmd.Stderr = c.stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "starting %s", command)
+	}
+	go func() {
+		c.waitErr = cmd.Wait()
+		_ = pw.Close()
+		close(c.exited)
+	}()
+	go c.read()
+	return c, nil
+}
+
+// read delivers messages from stdout until EOF or close.
+func (c *stdioConn) read() {
+	defer close(c.done)
+	sc := bufio.NewScanner(c.stdout)
+	sc.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for sc.Scan() {
+		var m message
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			// Servers should only write JSON-RPC to stdout, but some
+			// print banners; skip anything that is not a message.
+			continue
+		}
+		select {
+		case c.msgs <- &m:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *stdioConn) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	c.nextID++
+	id := c.nextID
+	if err := c.send(newRequest(id, method, params)); err != nil {
+		return nil, c.exitError()
+	}
+	for {
+		select {
+		case m := <-c.msgs:
+			if m.isResponseTo(id) {
+				return m.outcome()
+			}
+			if m.Method != "" && len(m.ID) > 0 {
+				_ = c.send(replyTo(m))
+			}
+		case <-c.done:
+			return nil, c.exitError()
+		case <-ctx.Done():
+			return nil, c.timeoutError(ctx.Err())
+		}
+	}
+}
+
+func (c *stdioConn) notify(_ context.Context, method string) error {
+	if err := c.send(newRequest(0, method, nil)); err != nil {
+		return c.exitError()
+	}
+	return nil
+}
+
+func (c *stdioConn) setProtocolVersion(string) {}
+
+// close shuts the server down: closing stdin asks it to exit, and it is
+// killed if it has not done so shortly after.
+func (c *stdioConn) close() {
+	close(c.stop)
+	_ = c.stdout.Close()
+	_ = c.stdin.Close()
+	select {
+	case <-c.exited:
+	case <-time.After(2 * time.Second):
+		_ = c.cmd.Process.Kill()
+		<-c.exited
+	}
+}
+
+// send writes one message followed by a newline.
+func (c *stdioConn) send(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "encoding message")
+	}
+	_, err = c.stdin.Write(append(data, '\n'))
+	return err
+}
+
+// exitError describes a server that stopped answering, including its exit
+// status and the end of its stderr.
+func (c *stdioConn) exitError() error {
+	status := "still running"
+	select {
+	case <-c.exited:
+		status = "exit status 0"
+		if c.waitErr != nil {
+			status = c.waitErr.Error()
+		}
+	case <-time.After(time.Second):
+	}
+	if tail := c.stderr.String(); tail != "" {
+		return errors.Newf("%w (%s): %s", ErrServerExited, status, tail)
+	}
+	return errors.Newf("%w (%s)", ErrServerExited, status)
+}
+
+// timeoutError wraps a context error with the end of the server's stderr,
+// which often explains why it never answered.
+func (c *stdioConn) timeoutError(err error) error {
+	if tail := c.stderr.String(); tail != "" {
+		return errors.Wrapf(err, "no response (stderr: %s)", tail)
+	}
+	return errors.Wrap(err, "no response")
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+// Write implements io.Writer.
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+// String returns the buffered output with surrounding whitespace removed.
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.TrimSpace(string(b.buf))
+}
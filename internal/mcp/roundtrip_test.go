@@ -159,8 +159,9 @@ func TestRoundTrip_Canonical_OpenCode_Canonical(t *testing.T) {
 			server: localStdioServer,
 		},
 		{
-			name:   "remote SSE server with headers",
-			server: remoteSSEServer,
+			name:           "remote SSE server with headers - read back as http",
+			server:         remoteSSEServer,
+			inferTransport: true, // OpenCode "remote" does not distinguish SSE from HTTP
 		},
 		{
 			name:        "platform restricted server - platforms lost",
@@ -227,7 +228,7 @@ func TestRoundTrip_Canonical_OpenCode_Canonical(t *testing.T) {
 			if tt.inferTransport && expected.Command != "" {
 				expected.Transport = mcp.TransportStdio
 			} else if tt.inferTransport && expected.URL != "" {
-				expected.Transport = mcp.TransportSSE
+				expected.Transport = mcp.TransportHTTP
 			}
 
 			assertServerEqual(t, expected, resultServer)
@@ -308,8 +309,8 @@ func TestRoundTrip_Claude_OpenCode_CrossPlatform(t *testing.T) {
 		if remoteAPI.URL != "https://api.example.com/mcp" {
 			t.Errorf("remote-api.URL = %q, want %q", remoteAPI.URL, "https://api.example.com/mcp")
 		}
-		if remoteAPI.Transport != mcp.TransportSSE {
-			t.Errorf("remote-api.Transport = %q, want %q", remoteAPI.Transport, mcp.TransportSSE)
+		if remoteAPI.Transport != mcp.TransportHTTP {
+			t.Errorf("remote-api.Transport = %q, want %q", remoteAPI.Transport, mcp.TransportHTTP)
 		}
 		if remoteAPI.Headers["Authorization"] != "Bearer secret" {
 			t.Errorf("remote-api.Headers[Authorization] = %q, want %q", remoteAPI.Headers["Authorization"], "Bearer secret")
@@ -389,8 +390,8 @@ func TestRoundTrip_OpenCode_Claude_CrossPlatform(t *testing.T) {
 		if webSearch.URL != "https://search.example.com/mcp" {
 			t.Errorf("web-search.URL = %q, want %q", webSearch.URL, "https://search.example.com/mcp")
 		}
-		if webSearch.Transport != mcp.TransportSSE {
-			t.Errorf("web-search.Transport = %q, want %q", webSearch.Transport, mcp.TransportSSE)
+		if webSearch.Transport != mcp.TransportHTTP {
+			t.Errorf("web-search.Transport = %q, want %q", webSearch.Transport, mcp.TransportHTTP)
 		}
 		if webSearch.Headers["API-Key"] != "key123" {
 			t.Errorf("web-search.Headers[API-Key] = %q, want %q", webSearch.Headers["API-Key"], "key123")
@@ -82,7 +82,7 @@ type Translator interface {
 	// Platform returns the name of the platform this translator handles.
 	//
 	// This is used for error messages, logging, and registry lookups.
-	// Expected values: "claude", "opencode", "codex", "gemini"
+	// Expected values: "claude", "opencode", "codex", "gemini", "cursor", "copilot"
 	Platform() string
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
 )
 
 // Transport type constants for MCP server communication.
@@ -12,9 +13,14 @@ const (
 	// This is the default transport when a Command is specified.
 	TransportStdio = "stdio"
 
-	// TransportSSE indicates remote server communication via Server-Sent Events.
-	// This transport is used when connecting to remote MCP servers via HTTP.
+	// TransportSSE indicates remote server communication via the legacy
+	// HTTP+SSE transport (Server-Sent Events).
 	TransportSSE = "sse"
+
+	// TransportHTTP indicates remote server communication via Streamable HTTP,
+	// the primary remote transport in current MCP specifications. This is the
+	// default transport when only a URL is specified.
+	TransportHTTP = "http"
 )
 
 // Server represents a canonical MCP server configuration that can be
@@ -32,19 +38,19 @@ type Server struct {
 	// Only applicable for local servers.
 	Args []string `json:"args,omitempty"`
 
-	// URL is the server endpoint for remote (SSE) servers.
+	// URL is the server endpoint for remote (HTTP or SSE) servers.
 	// Required for remote servers, empty for local servers.
 	URL string `json:"url,omitempty"`
 
-	// Transport specifies the communication protocol: "stdio" or "sse".
-	// Defaults to "stdio" if Command is set, "sse" if URL is set.
+	// Transport specifies the communication protocol: "stdio", "http", or "sse".
+	// Defaults to "stdio" if Command is set, "http" if URL is set.
 	Transport string `json:"transport,omitempty"`
 
 	// Env contains environment variables passed to the server process.
 	// Only applicable for local servers.
 	Env map[string]string `json:"env,omitempty"`
 
-	// Headers contains HTTP headers for SSE transport connections.
+	// Headers contains HTTP headers for remote transport connections.
 	// Only applicable for remote servers.
 	Headers map[string]string `json:"headers,omitempty"`
 
@@ -73,10 +79,10 @@ func (s *Server) IsLocal() bool {
 	return false
 }
 
-// IsRemote returns true if this server uses remote (SSE) transport.
-// A server is considered remote if it has a URL or explicit SSE transport.
+// IsRemote returns true if this server uses a remote (HTTP or SSE) transport.
+// A server is considered remote if it has a URL or an explicit remote transport.
 func (s *Server) IsRemote() bool {
-	if s.Transport == TransportSSE {
+	if s.Transport == TransportHTTP || s.Transport == TransportSSE {
 		return true
 	}
 	if s.Transport == "" && s.URL != "" && s.Command == "" {
@@ -85,6 +91,36 @@ func (s *Server) IsRemote() bool {
 	return false
 }
 
+// EffectiveTransport returns the explicit Transport, or the transport
+// inferred from the other fields: "stdio" for a Command and "http" for a
+// URL. It returns "" when neither is set.
+func (s *Server) EffectiveTransport() string {
+	switch {
+	case s.Transport != "":
+		return s.Transport
+	case s.Command != "":
+		return TransportStdio
+	case s.URL != "":
+		return TransportHTTP
+	default:
+		return ""
+	}
+}
+
+// CheckUntrusted returns an error naming the key of an env or header value
+// that references a command. It is called on servers read from a file,
+// which aix does not run commands from unless asked to; see
+// secret.CheckUntrusted.
+func (s *Server) CheckUntrusted() error {
+	if err := secret.CheckUntrusted(s.Env); err != nil {
+		return errors.Wrapf(err, "server %q env", s.Name)
+	}
+	if err := secret.CheckUntrusted(s.Headers); err != nil {
+		return errors.Wrapf(err, "server %q headers", s.Name)
+	}
+	return nil
+}
+
 // MarshalJSON implements json.Marshaler to include unknown fields in output.
 func (s *Server) MarshalJSON() ([]byte, error) {
 	// Build a map with all fields
@@ -3,7 +3,11 @@ package mcp
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
 )
 
 func TestServer_JSONRoundTrip(t *testing.T) {
@@ -506,6 +510,14 @@ func TestServer_IsRemote(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "explicit http transport",
+			server: &Server{
+				Name:      "test",
+				Transport: TransportHTTP,
+			},
+			want: true,
+		},
 		{
 			name: "url with sse transport",
 			server: &Server{
@@ -558,6 +570,68 @@ func TestServer_IsRemote(t *testing.T) {
 	}
 }
 
+func TestServer_EffectiveTransport(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *Server
+		want   string
+	}{
+		{
+			name:   "explicit transport wins",
+			server: &Server{URL: "https://example.com/mcp", Transport: TransportSSE},
+			want:   TransportSSE,
+		},
+		{
+			name:   "command infers stdio",
+			server: &Server{Command: "some-cmd"},
+			want:   TransportStdio,
+		},
+		{
+			name:   "url infers http",
+			server: &Server{URL: "https://example.com/mcp"},
+			want:   TransportHTTP,
+		},
+		{
+			name:   "command takes precedence over url",
+			server: &Server{Command: "some-cmd", URL: "https://example.com/mcp"},
+			want:   TransportStdio,
+		},
+		{
+			name:   "empty server",
+			server: &Server{},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.server.EffectiveTransport(); got != tt.want {
+				t.Errorf("EffectiveTransport() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_CheckUntrusted(t *testing.T) {
+	ok := &Server{
+		Name:    "github",
+		Env:     map[string]string{"TOKEN": "${env:GITHUB_TOKEN}"},
+		Headers: map[string]string{"Authorization": "Bearer ${file:~/token}"},
+	}
+	if err := ok.CheckUntrusted(); err != nil {
+		t.Errorf("CheckUntrusted() = %v, want nil", err)
+	}
+
+	bad := &Server{Name: "github", Headers: map[string]string{"Authorization": "Bearer ${cmd:gh auth token}"}}
+	err := bad.CheckUntrusted()
+	if !errors.Is(err, secret.ErrUntrustedCommand) {
+		t.Fatalf("CheckUntrusted() = %v, want ErrUntrustedCommand", err)
+	}
+	if want := `server "github" headers: Authorization references ${cmd:gh auth token}`; !strings.Contains(err.Error(), want) {
+		t.Errorf("CheckUntrusted() = %q, want it to contain %q", err, want)
+	}
+}
+
 func TestNewConfig(t *testing.T) {
 	config := NewConfig()
 
@@ -584,4 +658,7 @@ func TestTransportConstants(t *testing.T) {
 	if TransportSSE != "sse" {
 		t.Errorf("TransportSSE = %q, want %q", TransportSSE, "sse")
 	}
+	if TransportHTTP != "http" {
+		t.Errorf("TransportHTTP = %q, want %q", TransportHTTP, "http")
+	}
 }
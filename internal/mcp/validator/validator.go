@@ -11,7 +11,7 @@ import (
 var validPlatforms = []string{"darwin", "linux", "windows"}
 
 // validTransports is the set of valid transport values.
-var validTransports = []string{mcp.TransportStdio, mcp.TransportSSE, ""}
+var validTransports = []string{mcp.TransportStdio, mcp.TransportHTTP, mcp.TransportSSE, ""}
 
 // Option configures a Validator.
 type Option func(*Validator)
@@ -83,7 +83,7 @@ func (v *Validator) validateServer(name string, server *mcp.Server, result *vali
 		result.Issues = append(result.Issues, validator.Issue{
 			Severity: validator.SeverityError,
 			Field:    "transport",
-			Message:  "transport must be 'stdio', 'sse', or empty",
+			Message:  "transport must be 'stdio', 'http', 'sse', or empty",
 			Context:  context,
 		})
 	}
@@ -121,12 +121,12 @@ func (v *Validator) validateTransportFields(name string, server *mcp.Server, res
 				Context:  context,
 			})
 		}
-	case mcp.TransportSSE:
+	case mcp.TransportHTTP, mcp.TransportSSE:
 		if server.URL == "" {
 			result.Issues = append(result.Issues, validator.Issue{
 				Severity: validator.SeverityError,
 				Field:    "url",
-				Message:  "sse transport requires URL",
+				Message:  server.Transport + " transport requires URL",
 				Context:  context,
 			})
 		}
@@ -148,7 +148,7 @@ func (v *Validator) validateTransportFields(name string, server *mcp.Server, res
 		if isLocal {
 			msg += "; transport=stdio means command will be used"
 		} else if isRemote {
-			msg += "; transport=sse means URL will be used"
+			msg += "; transport=" + server.Transport + " means URL will be used"
 		} else {
 			msg += "; without explicit transport, command takes precedence"
 		}
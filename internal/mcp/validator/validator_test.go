@@ -61,7 +61,21 @@ func TestValidator_Validate(t *testing.T) {
 			wantWarnCount: 0,
 		},
 		{
-			name: "valid sse server with URL only (no explicit transport)",
+			name: "valid http server",
+			config: &mcp.Config{
+				Servers: map[string]*mcp.Server{
+					"remote": {
+						Name:      "remote",
+						URL:       "https://api.example.com/mcp",
+						Transport: mcp.TransportHTTP,
+					},
+				},
+			},
+			wantErrCount:  0,
+			wantWarnCount: 0,
+		},
+		{
+			name: "valid remote server with URL only (no explicit transport)",
 			config: &mcp.Config{
 				Servers: map[string]*mcp.Server{
 					"remote": {
@@ -192,6 +206,21 @@ func TestValidator_Validate(t *testing.T) {
 			wantField:      "url",
 			wantMsgContain: "requires URL",
 		},
+		{
+			name: "http transport missing URL",
+			config: &mcp.Config{
+				Servers: map[string]*mcp.Server{
+					"test": {
+						Name:      "test",
+						Transport: mcp.TransportHTTP,
+					},
+				},
+			},
+			wantErrCount:   1,
+			wantServerName: "test",
+			wantField:      "url",
+			wantMsgContain: "http transport requires URL",
+		},
 		{
 			name: "no command or URL with no transport",
 			config: &mcp.Config{
@@ -0,0 +1,168 @@
+// Package params renders resources that declare install-time parameters.
+//
+// A skill, command, or agent declares parameters in its frontmatter, and an
+// MCP server in its JSON definition:
+//
+//	parameters:
+//	  - name: org
+//	    description: GitHub organization
+//	    required: true
+//	  - name: db_host
+//	    default: localhost
+//
+// Text in the resource refers to them as {{ .Params.org }}. When the
+// resource is installed, the values are resolved from --set flags, prompts,
+// and defaults, and a rendered copy is installed in place of the source.
+package params
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// Sentinel errors for parameter resolution.
+var (
+	// ErrMissingValue indicates that a required parameter has no value.
+	ErrMissingValue = errors.New("missing value for required parameter")
+
+	// ErrUnknownParameter indicates that a value was given for a parameter
+	// the resource does not declare.
+	ErrUnknownParameter = errors.New("unknown parameter")
+
+	// ErrInvalidParameter indicates a malformed parameter declaration or
+	// key=value assignment.
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+// namePattern matches parameter names that can be used in templates as
+// {{ .Params.name }}.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Parameter is a value a resource needs at install time.
+type Parameter struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"`
+	Required    bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// Prompter asks the user for the value of p. An empty answer selects the
+// default.
+type Prompter func(p Parameter) (string, error)
+
+// Declared returns the parameters declared by the resource of type t at
+// path: a skill directory, a command or agent file or directory, or an MCP
+// server JSON file. A resource whose definition file does not exist
+// declares none; installing it reports the problem.
+func Declared(t resource.ResourceType, path string) ([]Parameter, error) {
+	var decl struct {
+		Parameters []Parameter `yaml:"parameters" json:"parameters"`
+	}
+	if t == resource.TypeMCP {
+		data, err := fileutil.ReadFileWithLimit(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+		if err := json.Unmarshal(data, &decl); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", path)
+		}
+	} else {
+		file := resource.MainFile(t, path)
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", file)
+		}
+		defer f.Close()
+		if err := frontmatter.ParseHeader(f, &decl); err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", file)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range decl.Parameters {
+		if !namePattern.MatchString(p.Name) {
+			return nil, errors.Wrapf(ErrInvalidParameter, "name %q must be letters, digits, and underscores", p.Name)
+		}
+		if seen[p.Name] {
+			return nil, errors.Wrapf(ErrInvalidParameter, "%q is declared twice", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return decl.Parameters, nil
+}
+
+// ParseSet parses key=value assignments, as given to --set.
+func ParseSet(args []string) (map[string]string, error) {
+	values := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, errors.Wrapf(ErrInvalidParameter, "%q must be written as key=value", arg)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// CheckNames returns ErrUnknownParameter if values sets a parameter that
+// is not in decl.
+func CheckNames(decl []Parameter, values map[string]string) error {
+	var unknown []string
+	for key := range values {
+		if !slices.ContainsFunc(decl, func(p Parameter) bool { return p.Name == key }) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+	return errors.Wrapf(ErrUnknownParameter, "%s", strings.Join(unknown, ", "))
+}
+
+// Resolve returns a value for every parameter in decl: the one in given,
+// else the answer to prompt if prompt is not nil, else the default. Values
+// in given for undeclared parameters are ignored. Every required parameter
+// left without a value is named in the ErrMissingValue returned.
+func Resolve(decl []Parameter, given map[string]string, prompt Prompter) (map[string]string, error) {
+	values := make(map[string]string, len(decl))
+	var missing []string
+	for _, p := range decl {
+		v, ok := given[p.Name]
+		if !ok && prompt != nil {
+			answer, err := prompt(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading parameter %q", p.Name)
+			}
+			v, ok = answer, answer != ""
+		}
+		if !ok {
+			v = p.Default
+		}
+		if v == "" && p.Required {
+			missing = append(missing, strconv.Quote(p.Name))
+			continue
+		}
+		values[p.Name] = v
+	}
+	if len(missing) > 0 {
+		return nil, errors.Wrapf(ErrMissingValue, "%s", strings.Join(missing, ", "))
+	}
+	return values, nil
+}
@@ -0,0 +1,116 @@
+package params
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestDeclared(t *testing.T) {
+	dir := t.TempDir()
+	skill := filepath.Join(dir, "review")
+	if err := os.Mkdir(skill, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(skill, "SKILL.md"), "---\nname: review\nparameters:\n  - name: org\n    required: true\n  - name: branch\n    default: main\n---\n\nBody\n")
+	writeFile(t, filepath.Join(dir, "db.json"), `{"command": "db", "parameters": [{"name": "host", "default": "localhost"}]}`)
+	writeFile(t, filepath.Join(dir, "bad.md"), "---\nparameters:\n  - name: not-valid\n---\n")
+	writeFile(t, filepath.Join(dir, "twice.md"), "---\nparameters:\n  - name: a\n  - name: a\n---\n")
+
+	got, err := Declared(resource.TypeSkill, skill)
+	if err != nil {
+		t.Fatalf("Declared(skill) error = %v", err)
+	}
+	want := []Parameter{{Name: "org", Required: true}, {Name: "branch", Default: "main"}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Declared(skill) = %+v, want %+v", got, want)
+	}
+
+	got, err = Declared(resource.TypeMCP, filepath.Join(dir, "db.json"))
+	if err != nil || len(got) != 1 || got[0].Default != "localhost" {
+		t.Errorf("Declared(mcp) = %+v, %v", got, err)
+	}
+
+	for _, name := range []string{"bad.md", "twice.md"} {
+		if _, err := Declared(resource.TypeCommand, filepath.Join(dir, name)); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("Declared(%s) error = %v, want ErrInvalidParameter", name, err)
+		}
+	}
+
+	if got, err := Declared(resource.TypeAgent, filepath.Join(dir, "missing.md")); err != nil || got != nil {
+		t.Errorf("Declared(missing) = %v, %v, want nothing", got, err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	decl := []Parameter{{Name: "org", Required: true}, {Name: "branch", Default: "main"}, {Name: "note"}}
+
+	got, err := Resolve(decl, map[string]string{"org": "acme", "extra": "x"}, nil)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got["org"] != "acme" || got["branch"] != "main" || got["note"] != "" || len(got) != 3 {
+		t.Errorf("Resolve() = %v", got)
+	}
+
+	if _, err := Resolve(decl, nil, nil); !errors.Is(err, ErrMissingValue) {
+		t.Errorf("Resolve() without required value error = %v, want ErrMissingValue", err)
+	}
+	two := append([]Parameter{{Name: "team", Required: true}}, decl...)
+	if _, err := Resolve(two, nil, nil); err == nil || !strings.Contains(err.Error(), `"team", "org"`) {
+		t.Errorf("Resolve() error = %v, want both missing parameters named", err)
+	}
+
+	var asked []string
+	prompt := func(p Parameter) (string, error) {
+		asked = append(asked, p.Name)
+		if p.Name == "org" {
+			return "prompted", nil
+		}
+		return "", nil
+	}
+	got, err = Resolve(decl, map[string]string{"note": "given"}, prompt)
+	if err != nil {
+		t.Fatalf("Resolve() with prompt error = %v", err)
+	}
+	if got["org"] != "prompted" || got["branch"] != "main" || got["note"] != "given" {
+		t.Errorf("Resolve() with prompt = %v", got)
+	}
+	if strings.Join(asked, ",") != "org,branch" {
+		t.Errorf("prompted for %v, want org and branch", asked)
+	}
+}
+
+func TestParseSetAndCheckNames(t *testing.T) {
+	got, err := ParseSet([]string{"org=acme", "url=https://x?a=b", "empty="})
+	if err != nil {
+		t.Fatalf("ParseSet() error = %v", err)
+	}
+	if got["org"] != "acme" || got["url"] != "https://x?a=b" || got["empty"] != "" {
+		t.Errorf("ParseSet() = %v", got)
+	}
+	for _, arg := range []string{"org", "=acme"} {
+		if _, err := ParseSet([]string{arg}); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("ParseSet(%q) error = %v, want ErrInvalidParameter", arg, err)
+		}
+	}
+
+	decl := []Parameter{{Name: "org"}}
+	if err := CheckNames(decl, got); !errors.Is(err, ErrUnknownParameter) || !strings.Contains(err.Error(), "empty, url") {
+		t.Errorf("CheckNames() error = %v, want unknown empty, url", err)
+	}
+	if err := CheckNames(decl, map[string]string{"org": "x"}); err != nil {
+		t.Errorf("CheckNames() error = %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
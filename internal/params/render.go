@@ -0,0 +1,215 @@
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// declarationKey is the frontmatter or JSON key that declares parameters.
+// It is dropped from rendered copies.
+const declarationKey = "parameters"
+
+// templateData is what templates see as their dot.
+type templateData struct {
+	Params map[string]string
+}
+
+// RenderString executes text as a template with values as .Params. Text
+// without template actions is returned as is.
+func RenderString(text string, values map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("resource").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing template")
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, templateData{Params: values}); err != nil {
+		return "", errors.Wrap(err, "rendering template")
+	}
+	return sb.String(), nil
+}
+
+// Render copies the resource of type t at path to a temporary directory and
+// renders it with values: the frontmatter fields and body of its markdown
+// file, or the string values of its MCP server JSON. Other files are copied
+// unchanged. It returns the path of the copy and a function that removes
+// it.
+//
+// Resources that declare no parameters are not copied; path is returned
+// with a no-op cleanup.
+func Render(t resource.ResourceType, path string, values map[string]string) (string, func(), error) {
+	decl, err := Declared(t, path)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(decl) == 0 {
+		return path, func() {}, nil
+	}
+
+	copied, cleanup, err := Copy(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if t == resource.TypeMCP {
+		err = renderJSON(copied, values)
+	} else {
+		err = renderMarkdown(resource.MainFile(t, copied), values)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return copied, cleanup, nil
+}
+
+// Copy copies the file or directory at path into a new temporary
+// directory, keeping its base name, which validators compare with resource
+// names. It returns the path of the copy and a function that removes it.
+func Copy(path string) (string, func(), error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "checking source path")
+	}
+	tempDir, err := os.MkdirTemp("", "aix-render-*")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating temp directory")
+	}
+	cleanup := func() { _ = os.RemoveAll(tempDir) }
+
+	dst := filepath.Join(tempDir, filepath.Base(path))
+	if info.IsDir() {
+		if err := os.Mkdir(dst, 0o755); err == nil {
+			err = resource.CopyDir(path, dst)
+		}
+	} else {
+		var data []byte
+		if data, err = fileutil.ReadFileWithLimit(path); err == nil {
+			err = os.WriteFile(dst, data, info.Mode().Perm())
+		}
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Wrap(err, "copying resource")
+	}
+	return dst, cleanup, nil
+}
+
+// renderMarkdown renders the string fields of the frontmatter and the body
+// of the markdown file at path in place.
+func renderMarkdown(path string, values map[string]string) error {
+	data, err := fileutil.ReadFileWithLimit(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	var doc yaml.Node
+	body, err := frontmatter.Parse(bytes.NewReader(data), &doc)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s", path)
+	}
+	if len(doc.Content) > 0 {
+		root := doc.Content[0]
+		removeKey(root, declarationKey)
+		if err := renderNode(root, values); err != nil {
+			return errors.Wrapf(err, "rendering %s", path)
+		}
+	}
+	rendered, err := RenderString(string(body), values)
+	if err != nil {
+		return errors.Wrapf(err, "rendering %s", path)
+	}
+
+	out, err := frontmatter.Format(&doc, strings.TrimLeft(rendered, "\n"))
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(os.WriteFile(path, out, 0o644), "writing %s", path)
+}
+
+// renderNode renders every string scalar under n.
+func renderNode(n *yaml.Node, values map[string]string) error {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!str" {
+		v, err := RenderString(n.Value, values)
+		if err != nil {
+			return err
+		}
+		n.Value = v
+		return nil
+	}
+	for _, c := range n.Content {
+		if err := renderNode(c, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeKey deletes key from mapping.
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// renderJSON renders the string values of the JSON file at path in place.
+func renderJSON(path string, values map[string]string) error {
+	data, err := fileutil.ReadFileWithLimit(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return errors.Wrapf(err, "parsing %s", path)
+	}
+	delete(doc, declarationKey)
+	rendered, err := renderValue(doc, values)
+	if err != nil {
+		return errors.Wrapf(err, "rendering %s", path)
+	}
+
+	out, err := json.MarshalIndent(rendered, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "encoding %s", path)
+	}
+	return errors.Wrapf(os.WriteFile(path, append(out, '\n'), 0o644), "writing %s", path)
+}
+
+// renderValue renders every string in a decoded JSON value.
+func renderValue(v any, values map[string]string) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return RenderString(v, values)
+	case []any:
+		for i, e := range v {
+			r, err := renderValue(e, values)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = r
+		}
+	case map[string]any:
+		for k, e := range v {
+			r, err := renderValue(e, values)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = r
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,116 @@
+package params
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestRenderString(t *testing.T) {
+	values := map[string]string{"org": "acme"}
+
+	got, err := RenderString("Open issues in {{ .Params.org }}.", values)
+	if err != nil || got != "Open issues in acme." {
+		t.Errorf("RenderString() = %q, %v", got, err)
+	}
+	if _, err := RenderString("{{ .Params.missing }}", values); err == nil {
+		t.Error("RenderString() with an undeclared parameter succeeded")
+	}
+	if got, err := RenderString("no templates", nil); err != nil || got != "no templates" {
+		t.Errorf("RenderString() = %q, %v", got, err)
+	}
+}
+
+func TestRender_Skill(t *testing.T) {
+	skill := filepath.Join(t.TempDir(), "triage")
+	if err := os.MkdirAll(filepath.Join(skill, "scripts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	source := "---\nname: triage\ndescription: Triage {{ .Params.org }} issues\nparameters:\n  - name: org\n---\n\nList issues in {{ .Params.org }}.\n"
+	writeFile(t, filepath.Join(skill, "SKILL.md"), source)
+	writeFile(t, filepath.Join(skill, "scripts", "run.sh"), "echo {{ .Params.org }}\n")
+
+	path, cleanup, err := Render(resource.TypeSkill, skill, map[string]string{"org": "acme: corp"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	defer cleanup()
+
+	if filepath.Base(path) != "triage" || path == skill {
+		t.Errorf("Render() path = %s, want a copy named triage", path)
+	}
+	data, err := os.ReadFile(filepath.Join(path, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"name: triage", `description: 'Triage acme: corp issues'`, "List issues in acme: corp."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered SKILL.md missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "parameters") {
+		t.Errorf("rendered SKILL.md kept the parameter declarations:\n%s", got)
+	}
+	if script, _ := os.ReadFile(filepath.Join(path, "scripts", "run.sh")); string(script) != "echo {{ .Params.org }}\n" {
+		t.Errorf("Render() changed another file: %q", script)
+	}
+	if orig, _ := os.ReadFile(filepath.Join(skill, "SKILL.md")); string(orig) != source {
+		t.Error("Render() changed the source")
+	}
+
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("cleanup left %s", path)
+	}
+}
+
+func TestRender_MCP(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "db.json")
+	writeFile(t, file, `{
+  "command": "db-server",
+  "args": ["--host", "{{ .Params.host }}"],
+  "env": {"DB_HOST": "{{ .Params.host }}"},
+  "parameters": [{"name": "host", "default": "localhost"}]
+}`)
+
+	path, cleanup, err := Render(resource.TypeMCP, file, map[string]string{"host": `db."prod"`})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	defer cleanup()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Args       []string          `json:"args"`
+		Env        map[string]string `json:"env"`
+		Parameters []Parameter       `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("rendered JSON is invalid: %v\n%s", err, data)
+	}
+	if got.Args[1] != `db."prod"` || got.Env["DB_HOST"] != `db."prod"` || got.Parameters != nil {
+		t.Errorf("rendered server = %+v", got)
+	}
+}
+
+func TestRender_Undeclared(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "tmpl.md")
+	writeFile(t, file, "---\ndescription: Go templates\n---\n\nUse {{ .Field }} in templates.\n")
+
+	path, cleanup, err := Render(resource.TypeCommand, file, nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	cleanup()
+	if path != file {
+		t.Errorf("Render() of a resource without parameters = %s, want the source", path)
+	}
+}
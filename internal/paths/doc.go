@@ -2,8 +2,9 @@
 // coding assistant configuration directories.
 //
 // This package abstracts the differences between operating systems and AI
-// assistant platforms (Claude Code, OpenCode, Codex, Gemini CLI) for
-// consistent path resolution across all environments.
+// assistant platforms (Claude Code, OpenCode, Codex, Gemini CLI, Cursor,
+// GitHub Copilot in VS Code) for consistent path resolution across all
+// environments.
 //
 // # XDG Base Directory Compliance
 //
@@ -22,12 +23,20 @@
 //
 // Each AI assistant platform uses different directory structures:
 //
-//	| Platform  | Global Config       | Project Config    | Instructions |
-//	|-----------|---------------------|-------------------|--------------|
-//	| Claude    | ~/.claude/          | .claude/          | CLAUDE.md    |
-//	| OpenCode  | ~/.config/opencode/ | (project root)    | AGENTS.md    |
-//	| Codex     | ~/.codex/           | .codex/           | AGENTS.md    |
-//	| Gemini    | ~/.gemini/          | .gemini/          | GEMINI.md    |
+//	| Platform  | Global Config        | Project Config | Instructions                    |
+//	|-----------|----------------------|----------------|---------------------------------|
+//	| Claude    | ~/.claude/           | .claude/       | CLAUDE.md                       |
+//	| OpenCode  | ~/.config/opencode/  | (project root) | AGENTS.md                       |
+//	| Codex     | ~/.codex/            | .codex/        | AGENTS.md                       |
+//	| Gemini    | ~/.gemini/           | .gemini/       | GEMINI.md                       |
+//	| Cursor    | ~/.cursor/           | .cursor/       | AGENTS.md                       |
+//	| Copilot   | ~/.config/Code/User/ | (project root) | .github/copilot-instructions.md |
+//
+// Global config directories can be relocated through the aix config file or
+// environment variables; see [ConfigDirOverride].
+//
+// Further platforms are added at startup with [RegisterPlatform]. From then
+// on they are returned by [Platforms] and resolved like the built-in ones.
 //
 // # Standard Directory Helpers
 //
@@ -1,9 +1,11 @@
 package paths
 
 import (
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/adrg/xdg"
 
@@ -16,6 +18,8 @@ const (
 	PlatformOpenCode = "opencode"
 	PlatformCodex    = "codex"
 	PlatformGemini   = "gemini"
+	PlatformCursor   = "cursor"
+	PlatformCopilot  = "copilot"
 )
 
 // platformGlobalConfigs maps platform names to their global config directories.
@@ -25,6 +29,8 @@ var platformGlobalConfigs = map[string]string{
 	PlatformOpenCode: ".config/opencode",
 	PlatformCodex:    ".codex",
 	PlatformGemini:   ".gemini",
+	PlatformCursor:   ".cursor",
+	PlatformCopilot:  ".config/Code/User", // VS Code user profile; see GlobalConfigDir
 }
 
 // platformProjectConfigs maps platform names to their project config directories.
@@ -34,6 +40,8 @@ var platformProjectConfigs = map[string]string{
 	PlatformOpenCode: "", // OpenCode uses project root
 	PlatformCodex:    ".codex",
 	PlatformGemini:   ".gemini",
+	PlatformCursor:   ".cursor",
+	PlatformCopilot:  "", // VS Code splits project config between .vscode/ and .github/
 }
 
 // platformInstructionFiles maps platform names to their instruction file names.
@@ -42,6 +50,8 @@ var platformInstructionFiles = map[string]string{
 	PlatformOpenCode: "AGENTS.md",
 	PlatformCodex:    "AGENTS.md",
 	PlatformGemini:   "GEMINI.md",
+	PlatformCursor:   "AGENTS.md",
+	PlatformCopilot:  "copilot-instructions.md", // in .github/
 }
 
 // platformMCPConfigs maps platform names to their MCP config file paths
@@ -49,10 +59,24 @@ var platformInstructionFiles = map[string]string{
 var platformMCPConfigs = map[string]string{
 	PlatformClaude:   ".mcp.json",
 	PlatformOpenCode: "opencode.json", // MCP config is in the main config file
-	PlatformCodex:    "mcp.json",      // Assumed, may need verification
+	PlatformCodex:    "config.toml",   // MCP servers live in [mcp_servers] tables
 	PlatformGemini:   "settings.json", // MCP config is in the main settings file
+	PlatformCursor:   "mcp.json",
+	PlatformCopilot:  "mcp.json",
 }
 
+// platformConfigDirEnv maps platform names to the environment variable each
+// assistant reads to relocate its global config directory.
+var platformConfigDirEnv = map[string]string{
+	PlatformClaude:   "CLAUDE_CONFIG_DIR",
+	PlatformOpenCode: "OPENCODE_CONFIG_DIR",
+	PlatformCodex:    "CODEX_HOME",
+}
+
+// configDirOverrides holds the global config directories set by
+// platforms.<name>.config_dir in the aix config file.
+var configDirOverrides map[string]string
+
 // Sentinel errors for path resolution.
 var (
 	// ErrHomeDirNotFound indicates the user's home directory could not be determined.
@@ -155,20 +179,85 @@ func ReposCacheDir() string {
 	return filepath.Join(CacheHome(), "aix", "repos")
 }
 
-// ValidPlatform returns true if the platform name is recognized.
+// ValidPlatform returns true if the platform name is recognized. A name
+// that is not known yet runs the loader set with SetLoader first.
 func ValidPlatform(platform string) bool {
+	if known(platform) {
+		return true
+	}
+	runLoader()
+	return known(platform)
+}
+
+// known reports whether platform is built in or registered, without
+// running the loader.
+func known(platform string) bool {
 	_, ok := platformGlobalConfigs[platform]
 	return ok
 }
 
-// Platforms returns a slice of all supported platform identifiers.
+// Platforms returns a slice of all supported platform identifiers: the
+// built-in platforms followed by those added with RegisterPlatform. It runs
+// the loader set with SetLoader first.
 func Platforms() []string {
-	return []string{
+	runLoader()
+	return append([]string{
 		PlatformClaude,
 		PlatformOpenCode,
 		PlatformCodex,
 		PlatformGemini,
+		PlatformCursor,
+		PlatformCopilot,
+	}, registeredPlatforms...)
+}
+
+// SetConfigDirOverrides sets the global config directories configured for
+// platforms in the aix config file, keyed by platform name. The root command
+// calls it once the config is loaded. A nil map clears them.
+func SetConfigDirOverrides(dirs map[string]string) {
+	configDirOverrides = maps.Clone(dirs)
+}
+
+// ConfigDirOverride returns the relocated global config directory of a
+// platform, if any. In order of precedence it is taken from:
+//   - AIX_<PLATFORM>_CONFIG_DIR (e.g. AIX_CLAUDE_CONFIG_DIR), with any
+//     hyphens in the platform name replaced by underscores
+//   - platforms.<name>.config_dir in the aix config file
+//   - the assistant's own variable: CLAUDE_CONFIG_DIR, OPENCODE_CONFIG_DIR,
+//     CODEX_HOME, or the one named by a registered PlatformSpec
+//
+// A leading ~ is expanded to the home directory and relative paths are made
+// absolute.
+func ConfigDirOverride(platform string) (string, bool) {
+	if !ValidPlatform(platform) {
+		return "", false
 	}
+	dir := os.Getenv("AIX_" + strings.ToUpper(strings.ReplaceAll(platform, "-", "_")) + "_CONFIG_DIR")
+	if dir == "" {
+		dir = configDirOverrides[platform]
+	}
+	if dir == "" {
+		if env, ok := platformConfigDirEnv[platform]; ok {
+			dir = os.Getenv(env)
+		}
+	}
+	if dir == "" {
+		return "", false
+	}
+	return absPath(dir), true
+}
+
+// absPath expands a leading ~ in path and makes it absolute.
+func absPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home := Home(); home != "" {
+			path = filepath.Join(home, path[1:])
+		}
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
 }
 
 // GlobalConfigDir returns the global config directory for a platform.
@@ -178,13 +267,21 @@ func Platforms() []string {
 //   - opencode: ~/.config/opencode/
 //   - codex: ~/.codex/
 //   - gemini: ~/.gemini/ (or $XDG_CONFIG_HOME/gemini if set)
+//   - cursor: ~/.cursor/
+//   - copilot: the VS Code user profile, ~/.config/Code/User/ (or
+//     $XDG_CONFIG_HOME/Code/User if set); ~/Library/Application Support/Code/User/
+//     on macOS and %APPDATA%\Code\User\ on Windows
 //
+// A directory set with ConfigDirOverride takes precedence.
 // Returns an empty string for unknown platforms.
 func GlobalConfigDir(platform string) string {
 	relPath, ok := platformGlobalConfigs[platform]
 	if !ok {
 		return ""
 	}
+	if dir, ok := ConfigDirOverride(platform); ok {
+		return dir
+	}
 
 	// Gemini CLI respects XDG_CONFIG_HOME if set.
 	if platform == PlatformGemini {
@@ -193,6 +290,12 @@ func GlobalConfigDir(platform string) string {
 		}
 	}
 
+	if platform == PlatformCopilot {
+		if dir := vscodeUserDir(); dir != "" {
+			return dir
+		}
+	}
+
 	home := Home()
 	if home == "" {
 		return ""
@@ -200,6 +303,26 @@ func GlobalConfigDir(platform string) string {
 	return filepath.Join(home, relPath)
 }
 
+// vscodeUserDir returns the VS Code user profile directory where it is not
+// under the home directory, or "" to use the default.
+func vscodeUserDir() string {
+	switch runtime.GOOS {
+	case "darwin":
+		if home := Home(); home != "" {
+			return filepath.Join(home, "Library", "Application Support", "Code", "User")
+		}
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "Code", "User")
+		}
+	default:
+		if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
+			return filepath.Join(xdgConfig, "Code", "User")
+		}
+	}
+	return ""
+}
+
 // ProjectConfigDir returns the project-scoped config directory.
 //
 // Platform paths:
@@ -207,8 +330,11 @@ func GlobalConfigDir(platform string) string {
 //   - opencode: <projectRoot>/ (root of project)
 //   - codex: <projectRoot>/.codex/
 //   - gemini: <projectRoot>/.gemini/
+//   - cursor: <projectRoot>/.cursor/
+//   - copilot: <projectRoot>/ (root of project)
 //
-// Returns an empty string for unknown platforms or empty projectRoot.
+// Returns an empty string for unknown platforms, platforms without project
+// configuration, or empty projectRoot.
 func ProjectConfigDir(platform, projectRoot string) string {
 	if projectRoot == "" {
 		return ""
@@ -217,7 +343,7 @@ func ProjectConfigDir(platform, projectRoot string) string {
 	if !ok {
 		return ""
 	}
-	// OpenCode uses project root directly
+	// OpenCode and Copilot use project root directly
 	if relPath == "" {
 		return projectRoot
 	}
@@ -231,6 +357,8 @@ func ProjectConfigDir(platform, projectRoot string) string {
 //   - opencode: <projectRoot>/AGENTS.md
 //   - codex: <projectRoot>/AGENTS.md
 //   - gemini: <projectRoot>/GEMINI.md
+//   - cursor: <projectRoot>/AGENTS.md
+//   - copilot: <projectRoot>/.github/copilot-instructions.md
 //
 // Returns an empty string for unknown platforms or empty projectRoot.
 func InstructionsPath(platform, projectRoot string) string {
@@ -241,6 +369,9 @@ func InstructionsPath(platform, projectRoot string) string {
 	if !ok {
 		return ""
 	}
+	if platform == PlatformCopilot {
+		return filepath.Join(projectRoot, ".github", filename)
+	}
 	return filepath.Join(projectRoot, filename)
 }
 
@@ -273,18 +404,25 @@ func CommandDir(platform string) string {
 // Platform paths:
 //   - claude: ~/.claude.json (main user config file, NOT in .claude directory)
 //   - opencode: ~/.config/opencode/opencode.json
-//   - codex: ~/.codex/mcp.json
+//   - codex: ~/.codex/config.toml
 //   - gemini: ~/.gemini/settings.toml
+//   - cursor: ~/.cursor/mcp.json
+//   - copilot: <VS Code user profile>/mcp.json
+//
+// When Claude's config directory is relocated, Claude Code keeps
+// .claude.json inside it.
 //
 // Returns an empty string for unknown platforms.
 func MCPConfigPath(platform string) string {
-	home := Home()
-	if home == "" {
-		return ""
-	}
-
 	// Claude is special: MCP config is in ~/.claude.json (not in .claude directory)
 	if platform == PlatformClaude {
+		if dir, ok := ConfigDirOverride(platform); ok {
+			return filepath.Join(dir, ".claude.json")
+		}
+		home := Home()
+		if home == "" {
+			return ""
+		}
 		return filepath.Join(home, ".claude.json")
 	}
 
@@ -307,6 +445,8 @@ func MCPConfigPath(platform string) string {
 //   - opencode: AGENTS.md
 //   - codex: AGENTS.md
 //   - gemini: GEMINI.md
+//   - cursor: AGENTS.md
+//   - copilot: copilot-instructions.md
 //
 // Returns an empty string for unknown platforms.
 func InstructionFilename(platform string) string {
@@ -115,6 +115,16 @@ func TestValidPlatform(t *testing.T) {
 			platform: PlatformGemini,
 			want:     true,
 		},
+		{
+			name:     "cursor is valid",
+			platform: PlatformCursor,
+			want:     true,
+		},
+		{
+			name:     "copilot is valid",
+			platform: PlatformCopilot,
+			want:     true,
+		},
 		{
 			name:     "unknown platform is invalid",
 			platform: "unknown",
@@ -145,8 +155,8 @@ func TestValidPlatform(t *testing.T) {
 func TestPlatforms(t *testing.T) {
 	platforms := Platforms()
 
-	if len(platforms) != 4 {
-		t.Errorf("Platforms() returned %d platforms, want 4", len(platforms))
+	if len(platforms) != 6 {
+		t.Errorf("Platforms() returned %d platforms, want 6", len(platforms))
 	}
 
 	// Verify all expected platforms are present
@@ -155,6 +165,8 @@ func TestPlatforms(t *testing.T) {
 		PlatformOpenCode: false,
 		PlatformCodex:    false,
 		PlatformGemini:   false,
+		PlatformCursor:   false,
+		PlatformCopilot:  false,
 	}
 
 	for _, p := range platforms {
@@ -203,6 +215,11 @@ func TestGlobalConfigDir(t *testing.T) {
 			platform: PlatformGemini,
 			want:     filepath.Join(home, ".gemini"),
 		},
+		{
+			name:     "cursor global config",
+			platform: PlatformCursor,
+			want:     filepath.Join(home, ".cursor"),
+		},
 		{
 			name:      "gemini global config with XDG_CONFIG_HOME",
 			platform:  PlatformGemini,
@@ -238,6 +255,29 @@ func TestGlobalConfigDir(t *testing.T) {
 	}
 }
 
+func TestGlobalConfigDir_Copilot(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("VS Code keeps its user profile outside the home directory on " + runtime.GOOS)
+	}
+	home := Home()
+	if home == "" {
+		t.Skip("Could not determine home directory")
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if got, want := GlobalConfigDir(PlatformCopilot), filepath.Join(home, ".config", "Code", "User"); got != want {
+		t.Errorf("GlobalConfigDir(copilot) = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+	if got, want := GlobalConfigDir(PlatformCopilot), filepath.Join("/custom/config", "Code", "User"); got != want {
+		t.Errorf("GlobalConfigDir(copilot) with XDG_CONFIG_HOME = %q, want %q", got, want)
+	}
+	if got, want := MCPConfigPath(PlatformCopilot), filepath.Join("/custom/config", "Code", "User", "mcp.json"); got != want {
+		t.Errorf("MCPConfigPath(copilot) = %q, want %q", got, want)
+	}
+}
+
 func TestProjectConfigDir(t *testing.T) {
 	projectRoot := "/home/user/myproject"
 	if runtime.GOOS == "windows" {
@@ -274,6 +314,12 @@ func TestProjectConfigDir(t *testing.T) {
 			projectRoot: projectRoot,
 			want:        filepath.Join(projectRoot, ".gemini"),
 		},
+		{
+			name:        "copilot uses project root",
+			platform:    PlatformCopilot,
+			projectRoot: projectRoot,
+			want:        projectRoot,
+		},
 		{
 			name:        "unknown platform returns empty",
 			platform:    "unknown",
@@ -340,6 +386,12 @@ func TestInstructionsPath(t *testing.T) {
 			projectRoot: projectRoot,
 			want:        filepath.Join(projectRoot, "GEMINI.md"),
 		},
+		{
+			name:        "copilot instructions",
+			platform:    PlatformCopilot,
+			projectRoot: projectRoot,
+			want:        filepath.Join(projectRoot, ".github", "copilot-instructions.md"),
+		},
 		{
 			name:        "unknown platform returns empty",
 			platform:    "unknown",
@@ -487,13 +539,18 @@ func TestMCPConfigPath(t *testing.T) {
 		{
 			name:     "codex MCP config",
 			platform: PlatformCodex,
-			want:     filepath.Join(home, ".codex", "mcp.json"),
+			want:     filepath.Join(home, ".codex", "config.toml"),
 		},
 		{
 			name:     "gemini MCP config",
 			platform: PlatformGemini,
 			want:     filepath.Join(home, ".gemini", "settings.json"),
 		},
+		{
+			name:     "cursor MCP config",
+			platform: PlatformCursor,
+			want:     filepath.Join(home, ".cursor", "mcp.json"),
+		},
 		{
 			name:     "unknown platform returns empty",
 			platform: "unknown",
@@ -511,6 +568,49 @@ func TestMCPConfigPath(t *testing.T) {
 	}
 }
 
+func TestConfigDirOverride(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	for _, env := range []string{"AIX_CLAUDE_CONFIG_DIR", "CLAUDE_CONFIG_DIR", "AIX_CODEX_CONFIG_DIR", "CODEX_HOME"} {
+		t.Setenv(env, "")
+	}
+	t.Cleanup(func() { SetConfigDirOverrides(nil) })
+
+	if _, ok := ConfigDirOverride(PlatformClaude); ok {
+		t.Fatal("ConfigDirOverride(claude) reported an override with none set")
+	}
+
+	t.Setenv("CLAUDE_CONFIG_DIR", "/native/claude")
+	if got := GlobalConfigDir(PlatformClaude); got != "/native/claude" {
+		t.Errorf("GlobalConfigDir(claude) with CLAUDE_CONFIG_DIR = %q, want /native/claude", got)
+	}
+	if got := MCPConfigPath(PlatformClaude); got != filepath.Join("/native/claude", ".claude.json") {
+		t.Errorf("MCPConfigPath(claude) with CLAUDE_CONFIG_DIR = %q, want it inside the config dir", got)
+	}
+
+	SetConfigDirOverrides(map[string]string{PlatformClaude: "~/relocated/claude"})
+	if got, want := GlobalConfigDir(PlatformClaude), filepath.Join(home, "relocated", "claude"); got != want {
+		t.Errorf("GlobalConfigDir(claude) with config override = %q, want %q", got, want)
+	}
+	if got, want := SkillDir(PlatformClaude), filepath.Join(home, "relocated", "claude", "skills"); got != want {
+		t.Errorf("SkillDir(claude) with config override = %q, want %q", got, want)
+	}
+
+	t.Setenv("AIX_CLAUDE_CONFIG_DIR", "/aix/claude")
+	if got := GlobalConfigDir(PlatformClaude); got != "/aix/claude" {
+		t.Errorf("GlobalConfigDir(claude) with AIX_CLAUDE_CONFIG_DIR = %q, want /aix/claude", got)
+	}
+
+	t.Setenv("CODEX_HOME", "/native/codex")
+	if got := MCPConfigPath(PlatformCodex); got != filepath.Join("/native/codex", "config.toml") {
+		t.Errorf("MCPConfigPath(codex) with CODEX_HOME = %q", got)
+	}
+	if got := GlobalConfigDir(PlatformGemini); got != filepath.Join(home, ".gemini") {
+		t.Errorf("GlobalConfigDir(gemini) = %q, want the default", got)
+	}
+}
+
 func TestInstructionFilename(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -648,7 +748,7 @@ func TestXDGHomeConsistency(t *testing.T) {
 // TestPlatformConstantsMatchMaps verifies that the platform constants
 // are properly registered in all lookup maps.
 func TestPlatformConstantsMatchMaps(t *testing.T) {
-	platforms := []string{PlatformClaude, PlatformOpenCode, PlatformCodex, PlatformGemini}
+	platforms := []string{PlatformClaude, PlatformOpenCode, PlatformCodex, PlatformGemini, PlatformCursor, PlatformCopilot}
 
 	for _, p := range platforms {
 		t.Run(p, func(t *testing.T) {
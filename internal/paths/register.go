@@ -0,0 +1,126 @@
+package paths
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Sentinel errors for platform registration.
+var (
+	// ErrPlatformExists indicates a platform with the same name is already known.
+	ErrPlatformExists = errors.New("platform already exists")
+
+	// ErrInvalidPlatformSpec indicates a platform spec is missing required fields
+	// or has a malformed name.
+	ErrInvalidPlatformSpec = errors.New("invalid platform spec")
+)
+
+// platformNamePattern restricts platform names to what can appear in an
+// environment variable (after upper-casing and replacing hyphens) and in a
+// file name.
+var platformNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// registeredPlatforms lists the platforms added with RegisterPlatform, in
+// registration order.
+var registeredPlatforms []string
+
+// loader registers the platforms that are loaded on demand; see SetLoader.
+var loader func()
+
+// SetLoader sets a function that registers further platforms with
+// RegisterPlatform, such as plugins, which are costly to load. It runs once,
+// the first time Platforms is called or ValidPlatform is asked about a name
+// that is not known yet, so commands that only name built-in platforms never
+// run it.
+//
+// SetLoader is meant to be called while the program initializes; it is not
+// safe for concurrent use.
+func SetLoader(fn func()) {
+	loader = fn
+}
+
+// runLoader runs the loader if it has not run yet.
+func runLoader() {
+	if fn := loader; fn != nil {
+		// Cleared first, as the loader registers platforms itself
+		loader = nil
+		fn()
+	}
+}
+
+// PlatformSpec describes the locations used by a platform that is not built
+// into aix, such as one described by a platform definition file.
+type PlatformSpec struct {
+	// Name is the platform identifier: lowercase letters, digits and hyphens.
+	Name string
+
+	// GlobalConfigDir is the global config directory, relative to the home
+	// directory.
+	GlobalConfigDir string
+
+	// ProjectConfigDir is the project config directory, relative to the
+	// project root. Empty means the platform has no project configuration;
+	// use "." for the project root itself.
+	ProjectConfigDir string
+
+	// InstructionsFile is the instructions file name, relative to the
+	// project root. Empty if the platform has none.
+	InstructionsFile string
+
+	// MCPConfigFile is the MCP config file, relative to the global config
+	// directory.
+	MCPConfigFile string
+
+	// ConfigDirEnv is the environment variable the assistant reads to
+	// relocate its global config directory, if any.
+	ConfigDirEnv string
+}
+
+// RegisterPlatform adds a platform so that ValidPlatform, Platforms and the
+// path functions know about it. Registered platforms are listed after the
+// built-in ones.
+//
+// RegisterPlatform is meant to be called while the program initializes,
+// before any paths are resolved; it is not safe for concurrent use.
+//
+// Returns ErrPlatformExists if the name is taken and ErrInvalidPlatformSpec
+// if the name is malformed or GlobalConfigDir is empty.
+func RegisterPlatform(spec PlatformSpec) error {
+	if !platformNamePattern.MatchString(spec.Name) {
+		return errors.Wrapf(ErrInvalidPlatformSpec, "platform name %q must be lowercase letters, digits and hyphens", spec.Name)
+	}
+	if spec.GlobalConfigDir == "" {
+		return errors.Wrapf(ErrInvalidPlatformSpec, "platform %q has no global config directory", spec.Name)
+	}
+	if known(spec.Name) {
+		return errors.Wrapf(ErrPlatformExists, "%q", spec.Name)
+	}
+
+	platformGlobalConfigs[spec.Name] = spec.GlobalConfigDir
+	switch spec.ProjectConfigDir {
+	case "":
+	case ".":
+		platformProjectConfigs[spec.Name] = ""
+	default:
+		platformProjectConfigs[spec.Name] = spec.ProjectConfigDir
+	}
+	if spec.InstructionsFile != "" {
+		platformInstructionFiles[spec.Name] = spec.InstructionsFile
+	}
+	if spec.MCPConfigFile != "" {
+		platformMCPConfigs[spec.Name] = spec.MCPConfigFile
+	}
+	if spec.ConfigDirEnv != "" {
+		platformConfigDirEnv[spec.Name] = spec.ConfigDirEnv
+	}
+	registeredPlatforms = append(registeredPlatforms, spec.Name)
+	return nil
+}
+
+// Registered reports whether platform was added with RegisterPlatform
+// rather than being built into aix.
+func Registered(platform string) bool {
+	return slices.Contains(registeredPlatforms, platform)
+}
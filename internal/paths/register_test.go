@@ -0,0 +1,145 @@
+package paths
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// registerForTest registers spec and removes it again when the test ends.
+func registerForTest(t *testing.T, spec PlatformSpec) {
+	t.Helper()
+	if err := RegisterPlatform(spec); err != nil {
+		t.Fatalf("RegisterPlatform() error = %v", err)
+	}
+	t.Cleanup(func() {
+		delete(platformGlobalConfigs, spec.Name)
+		delete(platformProjectConfigs, spec.Name)
+		delete(platformInstructionFiles, spec.Name)
+		delete(platformMCPConfigs, spec.Name)
+		delete(platformConfigDirEnv, spec.Name)
+		registeredPlatforms = slices.DeleteFunc(registeredPlatforms, func(n string) bool {
+			return n == spec.Name
+		})
+	})
+}
+
+func TestRegisterPlatform(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	registerForTest(t, PlatformSpec{
+		Name:             "zed",
+		GlobalConfigDir:  ".config/zed",
+		ProjectConfigDir: ".zed",
+		InstructionsFile: ".rules",
+		MCPConfigFile:    "settings.json",
+	})
+
+	if !ValidPlatform("zed") {
+		t.Error("ValidPlatform(zed) = false, want true")
+	}
+	if !Registered("zed") || Registered(PlatformClaude) {
+		t.Error("Registered() should report only platforms added at run time")
+	}
+	if got := Platforms(); got[len(got)-1] != "zed" {
+		t.Errorf("Platforms() = %v, want zed last", got)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"GlobalConfigDir", GlobalConfigDir("zed"), filepath.Join(home, ".config", "zed")},
+		{"ProjectConfigDir", ProjectConfigDir("zed", "/proj"), filepath.Join("/proj", ".zed")},
+		{"InstructionsPath", InstructionsPath("zed", "/proj"), filepath.Join("/proj", ".rules")},
+		{"MCPConfigPath", MCPConfigPath("zed"), filepath.Join(home, ".config", "zed", "settings.json")},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRegisterPlatform_NoProjectConfig(t *testing.T) {
+	registerForTest(t, PlatformSpec{Name: "windsurf", GlobalConfigDir: ".codeium/windsurf"})
+
+	if got := ProjectConfigDir("windsurf", "/proj"); got != "" {
+		t.Errorf("ProjectConfigDir() = %q, want empty", got)
+	}
+	if got := InstructionsPath("windsurf", "/proj"); got != "" {
+		t.Errorf("InstructionsPath() = %q, want empty", got)
+	}
+}
+
+func TestRegisterPlatform_ProjectRoot(t *testing.T) {
+	registerForTest(t, PlatformSpec{Name: "rootcfg", GlobalConfigDir: ".rootcfg", ProjectConfigDir: "."})
+
+	if got := ProjectConfigDir("rootcfg", "/proj"); got != "/proj" {
+		t.Errorf("ProjectConfigDir() = %q, want /proj", got)
+	}
+}
+
+func TestRegisterPlatform_ConfigDirOverride(t *testing.T) {
+	dir := t.TempDir()
+	registerForTest(t, PlatformSpec{Name: "my-tool", GlobalConfigDir: ".my-tool", ConfigDirEnv: "MY_TOOL_HOME"})
+
+	t.Setenv("MY_TOOL_HOME", filepath.Join(dir, "native"))
+	if got := GlobalConfigDir("my-tool"); got != filepath.Join(dir, "native") {
+		t.Errorf("GlobalConfigDir() = %q, want the assistant's variable", got)
+	}
+
+	t.Setenv("AIX_MY_TOOL_CONFIG_DIR", filepath.Join(dir, "aix"))
+	if got := GlobalConfigDir("my-tool"); got != filepath.Join(dir, "aix") {
+		t.Errorf("GlobalConfigDir() = %q, want AIX_MY_TOOL_CONFIG_DIR", got)
+	}
+}
+
+func TestRegisterPlatform_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    PlatformSpec
+		wantErr error
+	}{
+		{"built-in name", PlatformSpec{Name: PlatformClaude, GlobalConfigDir: ".x"}, ErrPlatformExists},
+		{"empty name", PlatformSpec{GlobalConfigDir: ".x"}, ErrInvalidPlatformSpec},
+		{"uppercase name", PlatformSpec{Name: "Zed", GlobalConfigDir: ".x"}, ErrInvalidPlatformSpec},
+		{"path in name", PlatformSpec{Name: "../zed", GlobalConfigDir: ".x"}, ErrInvalidPlatformSpec},
+		{"no config dir", PlatformSpec{Name: "zed"}, ErrInvalidPlatformSpec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterPlatform(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RegisterPlatform() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+	if ValidPlatform("zed") {
+		t.Error("a rejected spec should not be registered")
+	}
+}
+
+func TestSetLoader(t *testing.T) {
+	t.Cleanup(func() { loader = nil })
+	calls := 0
+	SetLoader(func() {
+		calls++
+		registerForTest(t, PlatformSpec{Name: "acme", GlobalConfigDir: ".acme"})
+	})
+
+	if !ValidPlatform(PlatformClaude) || calls != 0 {
+		t.Errorf("a built-in platform ran the loader %d times, want none", calls)
+	}
+	if !ValidPlatform("acme") || calls != 1 {
+		t.Errorf("ValidPlatform(acme) ran the loader %d times, want once and acme known", calls)
+	}
+	if got := Platforms(); got[len(got)-1] != "acme" || calls != 1 {
+		t.Errorf("Platforms() = %v after %d loader runs, want acme last and one run", got, calls)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
 	"github.com/thoreinstein/aix/pkg/fileutil"
 )
 
@@ -16,6 +17,11 @@ var (
 	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
 )
 
+// SecretSyntax is how Claude Code interpolates environment variables in MCP
+// server env and header values. Other secret references are resolved when a
+// server is added.
+var SecretSyntax = secret.Syntax{Env: "${%s}"}
+
 // MCPManager provides CRUD operations for MCP server configurations.
 type MCPManager struct {
 	paths *ClaudePaths
@@ -73,27 +79,24 @@ func (m *MCPManager) Add(server *MCPServer) error {
 		return ErrInvalidMCPServer
 	}
 
-	config, err := m.loadConfig()
+	server, err := renderSecrets(server)
 	if err != nil {
 		return err
 	}
 
-	config.MCPServers[server.Name] = server
-
-	return m.saveConfig(config)
+	return m.update(func(config *MCPConfig) error {
+		config.MCPServers[server.Name] = server
+		return nil
+	})
 }
 
 // Remove removes an MCP server from the configuration by name.
 // This operation is idempotent - removing a non-existent server does not error.
 func (m *MCPManager) Remove(name string) error {
-	config, err := m.loadConfig()
-	if err != nil {
-		return err
-	}
-
-	delete(config.MCPServers, name)
-
-	return m.saveConfig(config)
+	return m.update(func(config *MCPConfig) error {
+		delete(config.MCPServers, name)
+		return nil
+	})
 }
 
 // Enable sets Disabled=false for the specified server.
@@ -110,19 +113,25 @@ func (m *MCPManager) Disable(name string) error {
 
 // setDisabled is a helper to toggle the Disabled field.
 func (m *MCPManager) setDisabled(name string, disabled bool) error {
-	config, err := m.loadConfig()
-	if err != nil {
-		return err
-	}
-
-	server, ok := config.MCPServers[name]
-	if !ok {
-		return ErrMCPServerNotFound
-	}
+	return m.update(func(config *MCPConfig) error {
+		server, ok := config.MCPServers[name]
+		if !ok {
+			return ErrMCPServerNotFound
+		}
 
-	server.Disabled = disabled
+		server.Disabled = disabled
+		return nil
+	})
+}
 
-	return m.saveConfig(config)
+// update applies fn to the MCP configuration and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(config *MCPConfig) error) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+	return fileutil.Update(configPath, m.loadConfig, fn, m.saveConfig)
 }
 
 // loadConfig reads the MCP configuration from disk.
@@ -177,3 +186,17 @@ func (m *MCPManager) saveConfig(config *MCPConfig) error {
 
 	return errors.Wrap(fileutil.AtomicWriteJSON(configPath, config), "writing MCP config")
 }
+
+// renderSecrets returns a copy of server with the secret references in its
+// env and headers rewritten for Claude Code.
+func renderSecrets(server *MCPServer) (*MCPServer, error) {
+	out := *server
+	var err error
+	if out.Env, err = secret.RenderMap(server.Env, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q env", server.Name)
+	}
+	if out.Headers, err = secret.RenderMap(server.Headers, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q headers", server.Name)
+	}
+	return &out, nil
+}
@@ -187,6 +187,37 @@ func TestMCPManager_Add_NewServer(t *testing.T) {
 	}
 }
 
+func TestMCPManager_Add_SecretReferences(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(secretFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mgr := NewMCPManager(NewClaudePaths(ScopeProject, dir))
+
+	server := &MCPServer{
+		Name:    "api",
+		Type:    "http",
+		URL:     "https://api.example.com/mcp",
+		Headers: map[string]string{"Authorization": "Bearer ${env:API_TOKEN}"},
+		Env:     map[string]string{"KEY": "${file:" + secretFile + "}"},
+	}
+	if err := mgr.Add(server); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := mgr.Get("api")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Headers["Authorization"] != "Bearer ${API_TOKEN}" {
+		t.Errorf("Headers[Authorization] = %q, want Claude Code interpolation", got.Headers["Authorization"])
+	}
+	if got.Env["KEY"] != "from-file" {
+		t.Errorf("Env[KEY] = %q, want resolved file contents", got.Env["KEY"])
+	}
+}
+
 func TestMCPManager_Add_OverwriteExisting(t *testing.T) {
 	dir := t.TempDir()
 	configPath := filepath.Join(dir, ".claude", ".mcp.json")
@@ -11,16 +11,16 @@ import (
 const (
 	// ClaudeTypeStdio is the Claude Code type for local process servers.
 	ClaudeTypeStdio = "stdio"
-	// ClaudeTypeHTTP is the Claude Code type for remote HTTP servers.
-	// Note: canonical format uses "sse" for this transport type.
+	// ClaudeTypeHTTP is the Claude Code type for Streamable HTTP servers.
 	ClaudeTypeHTTP = "http"
+	// ClaudeTypeSSE is the Claude Code type for legacy HTTP+SSE servers.
+	ClaudeTypeSSE = "sse"
 )
 
 // MCPTranslator converts between canonical and Claude Code MCP formats.
 //
 // Claude Code uses a "mcpServers" key with these differences from canonical:
 //   - Field "type" instead of "transport"
-//   - Value "http" instead of "sse" for remote servers
 //   - Name is stored as map key only, not inside server object
 type MCPTranslator struct{}
 
@@ -41,7 +41,8 @@ func NewMCPTranslator() *MCPTranslator {
 //
 // Mapping:
 //   - Claude "type" -> canonical "transport"
-//   - Claude "http" -> canonical "sse"
+//   - Claude "http" -> canonical "http"
+//   - Claude "sse" -> canonical "sse"
 //   - Claude "stdio" -> canonical "stdio"
 func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 	// First try to parse as MCPConfig (with mcpServers wrapper)
@@ -88,17 +89,18 @@ func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 }
 
 // claudeTypeToCanonicalTransport converts Claude Code's "type" to canonical "transport".
-// Claude uses "http" for remote servers, canonical uses "sse".
 func claudeTypeToCanonicalTransport(claudeType, url, command string) string {
 	switch claudeType {
 	case ClaudeTypeStdio:
 		return mcp.TransportStdio
 	case ClaudeTypeHTTP:
+		return mcp.TransportHTTP
+	case ClaudeTypeSSE:
 		return mcp.TransportSSE
 	default:
 		// Infer from URL/Command if type not specified
 		if url != "" {
-			return mcp.TransportSSE
+			return mcp.TransportHTTP
 		}
 		if command != "" {
 			return mcp.TransportStdio
@@ -115,7 +117,8 @@ func claudeTypeToCanonicalTransport(claudeType, url, command string) string {
 //
 // Mapping:
 //   - canonical "transport" -> Claude "type"
-//   - canonical "sse" -> Claude "http"
+//   - canonical "http" -> Claude "http"
+//   - canonical "sse" -> Claude "sse"
 //   - canonical "stdio" -> Claude "stdio"
 //
 // The output is formatted with 2-space indentation for readability.
@@ -157,13 +160,14 @@ func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
 }
 
 // canonicalTransportToClaudeType converts canonical "transport" to Claude Code's "type".
-// Canonical uses "sse" for remote servers, Claude uses "http".
 func canonicalTransportToClaudeType(transport, url, command string) string {
 	switch transport {
 	case mcp.TransportStdio:
 		return ClaudeTypeStdio
-	case mcp.TransportSSE:
+	case mcp.TransportHTTP:
 		return ClaudeTypeHTTP
+	case mcp.TransportSSE:
+		return ClaudeTypeSSE
 	default:
 		// Infer from URL/Command if transport not specified
 		if url != "" {
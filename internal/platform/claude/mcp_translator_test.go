@@ -74,9 +74,8 @@ func TestMCPTranslator_ToCanonical(t *testing.T) {
 				if server.URL != "https://api.example.com/mcp" {
 					t.Errorf("URL = %q, want %q", server.URL, "https://api.example.com/mcp")
 				}
-				// Claude "http" should be translated to canonical "sse"
-				if server.Transport != "sse" {
-					t.Errorf("Transport = %q, want %q", server.Transport, "sse")
+				if server.Transport != "http" {
+					t.Errorf("Transport = %q, want %q", server.Transport, "http")
 				}
 				if server.Headers["Authorization"] != "Bearer token" {
 					t.Errorf("Headers[Authorization] = %q, want %q", server.Headers["Authorization"], "Bearer token")
@@ -213,7 +212,7 @@ func TestMCPTranslator_FromCanonical(t *testing.T) {
 			},
 		},
 		{
-			name: "valid sse server translates to http type",
+			name: "valid sse server keeps sse type",
 			config: &mcp.Config{
 				Servers: map[string]*mcp.Server{
 					"remote": {
@@ -238,9 +237,8 @@ func TestMCPTranslator_FromCanonical(t *testing.T) {
 				if server["url"] != "https://api.example.com" {
 					t.Errorf("url = %v, want %q", server["url"], "https://api.example.com")
 				}
-				// Canonical "sse" should be translated to Claude "http"
-				if server["type"] != "http" {
-					t.Errorf("type = %v, want %q", server["type"], "http")
+				if server["type"] != "sse" {
+					t.Errorf("type = %v, want %q", server["type"], "sse")
 				}
 			},
 		},
@@ -464,7 +462,7 @@ func TestMCPTranslator_RoundTrip_ClaudeToCanonical(t *testing.T) {
 		t.Errorf("github.len(Platforms) = %d, want 3", len(github.Platforms))
 	}
 
-	// Check api-server - note: canonical Transport should be "sse" (mapped from Claude's "http")
+	// Check api-server
 	apiServer := result.Servers["api-server"]
 	if apiServer == nil {
 		t.Fatal("api-server not found")
@@ -472,8 +470,8 @@ func TestMCPTranslator_RoundTrip_ClaudeToCanonical(t *testing.T) {
 	if apiServer.URL != "https://api.example.com/mcp" {
 		t.Errorf("api-server.URL = %q, want %q", apiServer.URL, "https://api.example.com/mcp")
 	}
-	if apiServer.Transport != "sse" {
-		t.Errorf("api-server.Transport = %q, want %q", apiServer.Transport, "sse")
+	if apiServer.Transport != "http" {
+		t.Errorf("api-server.Transport = %q, want %q", apiServer.Transport, "http")
 	}
 	if !apiServer.Disabled {
 		t.Error("api-server.Disabled = false, want true")
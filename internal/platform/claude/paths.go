@@ -2,7 +2,6 @@
 package claude
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/thoreinstein/aix/internal/paths"
@@ -86,15 +85,13 @@ func (p *ClaudePaths) AgentDir() string {
 // For ScopeProject: <projectRoot>/.claude/.mcp.json
 //
 // Note: Claude Code stores user-level MCP servers in the main user config file
-// at ~/.claude.json, not in a separate file within the .claude directory.
+// at ~/.claude.json, not in a separate file within the .claude directory. When
+// its config directory is relocated, the file moves inside it; see
+// paths.ConfigDirOverride.
 func (p *ClaudePaths) MCPConfigPath() string {
 	switch p.scope {
 	case ScopeUser:
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return ""
-		}
-		return filepath.Join(home, ".claude.json")
+		return paths.MCPConfigPath(paths.PlatformClaude)
 	case ScopeProject:
 		base := p.BaseDir()
 		if base == "" {
@@ -16,8 +16,7 @@ type MCPServer struct {
 	// Not serialized to JSON as it's the map key itself.
 	Name string `json:"-"`
 
-	// Type specifies the server transport type: "stdio" or "http".
-	// Note: Claude Code uses "http" for remote servers, while canonical uses "sse".
+	// Type specifies the server transport type: "stdio", "http", or "sse".
 	Type string `json:"type,omitempty"`
 
 	// Command is the executable to run for stdio transport.
@@ -0,0 +1,77 @@
+package codex
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAgentManager(t *testing.T) {
+	paths := NewCodexPaths(ScopeProject, t.TempDir())
+	mgr := NewAgentManager(paths)
+
+	agent := &Agent{
+		Name:         "reviewer",
+		Description:  "Reviews code: carefully",
+		Instructions: "You review code.",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := mgr.Install(agent); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.AgentPath("reviewer"))
+		if err != nil {
+			t.Fatalf("Failed to read agent file: %v", err)
+		}
+		if !strings.HasPrefix(string(data), "---\n") {
+			t.Errorf("agent file missing frontmatter: %s", string(data))
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("reviewer")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Description != agent.Description {
+			t.Errorf("Description = %q, want %q", got.Description, agent.Description)
+		}
+		if got.Instructions != agent.Instructions {
+			t.Errorf("Instructions = %q, want %q", got.Instructions, agent.Instructions)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		agents, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(agents) != 1 || agents[0].Name != "reviewer" {
+			t.Errorf("List() = %v, want [reviewer]", agents)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("reviewer"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("reviewer"); !errors.Is(err, ErrAgentNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrAgentNotFound", err)
+		}
+	})
+}
+
+func TestAgentManager_List_MissingDir(t *testing.T) {
+	mgr := NewAgentManager(NewCodexPaths(ScopeProject, t.TempDir()))
+
+	agents, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(agents) != 0 {
+		t.Errorf("List() returned %d agents, want 0", len(agents))
+	}
+}
@@ -0,0 +1,207 @@
+package codex
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// Sentinel errors for command operations.
+var (
+	ErrCommandNotFound = errors.New("command not found")
+	ErrInvalidCommand  = errors.New("invalid command: name required")
+)
+
+// CommandManager provides CRUD operations for Codex CLI custom prompts.
+// Prompts are stored as markdown files in the prompts directory.
+type CommandManager struct {
+	paths *CodexPaths
+}
+
+// NewCommandManager creates a new CommandManager with the given paths configuration.
+func NewCommandManager(paths *CodexPaths) *CommandManager {
+	return &CommandManager{
+		paths: paths,
+	}
+}
+
+// List returns all prompts in the prompts directory.
+func (m *CommandManager) List() ([]*Command, error) {
+	cmdDir := m.paths.CommandDir()
+	if cmdDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(cmdDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading prompts directory")
+	}
+
+	mdCount := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			mdCount++
+		}
+	}
+
+	commands := make([]*Command, 0, mdCount)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		cmdPath := m.paths.CommandPath(name)
+
+		f, err := os.Open(cmdPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening command file %q", name)
+		}
+
+		cmd := &Command{}
+		if err := frontmatter.ParseHeader(f, cmd); err != nil {
+			f.Close()
+			return nil, errors.Wrapf(err, "parsing command header %q", name)
+		}
+		f.Close()
+
+		// Name is derived from filename, not frontmatter
+		cmd.Name = name
+		commands = append(commands, cmd)
+	}
+
+	return commands, nil
+}
+
+// Get retrieves a prompt by name.
+func (m *CommandManager) Get(name string) (*Command, error) {
+	if name == "" {
+		return nil, ErrInvalidCommand
+	}
+
+	cmdPath := m.paths.CommandPath(name)
+	if cmdPath == "" {
+		return nil, ErrCommandNotFound
+	}
+
+	data, err := os.ReadFile(cmdPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrCommandNotFound
+		}
+		return nil, errors.Wrap(err, "reading command file")
+	}
+
+	cmd, err := parseCommandFile(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing command file")
+	}
+
+	cmd.Name = name
+	return cmd, nil
+}
+
+// Install writes a prompt to disk.
+// Creates the prompts directory if it doesn't exist.
+// Overwrites any existing prompt with the same name.
+func (m *CommandManager) Install(c *Command) error {
+	if c == nil || c.Name == "" {
+		return ErrInvalidCommand
+	}
+
+	cmdDir := m.paths.CommandDir()
+	if cmdDir == "" {
+		return errors.New("command directory path is empty")
+	}
+
+	if err := os.MkdirAll(cmdDir, 0o755); err != nil {
+		return errors.Wrap(err, "creating prompts directory")
+	}
+
+	// Create a copy to avoid mutating the original
+	cmdToInstall := *c
+	cmdToInstall.Instructions = TranslateVariables(c.Instructions)
+
+	content, err := formatCommandFile(&cmdToInstall)
+	if err != nil {
+		return errors.Wrap(err, "formatting command content")
+	}
+
+	cmdPath := m.paths.CommandPath(c.Name)
+	if err := fileutil.AtomicWriteFile(cmdPath, content, 0o644); err != nil {
+		return errors.Wrap(err, "writing command file")
+	}
+
+	return nil
+}
+
+// Uninstall removes a prompt from disk.
+// This operation is idempotent; removing a non-existent prompt returns nil.
+func (m *CommandManager) Uninstall(name string) error {
+	if name == "" {
+		return ErrInvalidCommand
+	}
+
+	cmdPath := m.paths.CommandPath(name)
+	if cmdPath == "" {
+		return nil
+	}
+
+	if err := os.Remove(cmdPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return errors.Wrap(err, "removing command file")
+	}
+
+	return nil
+}
+
+// parseCommandFile parses a prompt markdown file.
+// Frontmatter is optional; without it the entire content is the prompt body.
+func parseCommandFile(data []byte) (*Command, error) {
+	cmd := &Command{}
+
+	body, err := frontmatter.Parse(bytes.NewReader(data), cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing frontmatter")
+	}
+
+	cmd.Instructions = TranslateToCanonical(strings.TrimSpace(string(body)))
+	return cmd, nil
+}
+
+// formatCommandFile formats a Command as a markdown prompt file.
+// Codex reads only description and argument-hint from the frontmatter, so
+// the name is omitted and the frontmatter is skipped entirely when both are empty.
+func formatCommandFile(c *Command) ([]byte, error) {
+	if c.Description == "" && c.ArgumentHint == "" {
+		res := c.Instructions
+		if !strings.HasSuffix(res, "\n") {
+			res += "\n"
+		}
+		return []byte(res), nil
+	}
+
+	meta := struct {
+		Description  string `yaml:"description,omitempty"`
+		ArgumentHint string `yaml:"argument-hint,omitempty"`
+	}{
+		Description:  c.Description,
+		ArgumentHint: c.ArgumentHint,
+	}
+
+	data, err := frontmatter.Format(meta, c.Instructions)
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting command content")
+	}
+	return data, nil
+}
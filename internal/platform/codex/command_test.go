@@ -0,0 +1,127 @@
+package codex
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCommandManager(t *testing.T) {
+	tmpDir := t.TempDir()
+	paths := NewCodexPaths(ScopeProject, tmpDir)
+	mgr := NewCommandManager(paths)
+
+	cmd := &Command{
+		Name:         "review",
+		Description:  "Review a file",
+		ArgumentHint: "FILE=<path>",
+		Instructions: "Review $FILE with $ARGUMENTS",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := mgr.Install(cmd); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.CommandPath(cmd.Name))
+		if err != nil {
+			t.Fatalf("Failed to read prompt file: %v", err)
+		}
+		content := string(data)
+
+		if !strings.Contains(content, "description: Review a file") {
+			t.Errorf("prompt missing description frontmatter: %s", content)
+		}
+		if !strings.Contains(content, "argument-hint: FILE=<path>") {
+			t.Errorf("prompt missing argument-hint frontmatter: %s", content)
+		}
+		if strings.Contains(content, "name:") {
+			t.Errorf("prompt frontmatter should not contain name: %s", content)
+		}
+		if !strings.Contains(content, "Review $FILE with $ARGUMENTS") {
+			t.Errorf("prompt body not preserved: %s", content)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		cmds, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(cmds) != 1 {
+			t.Fatalf("Expected 1 command, got %d", len(cmds))
+		}
+		if cmds[0].Name != "review" {
+			t.Errorf("Expected review, got %s", cmds[0].Name)
+		}
+		if cmds[0].Description != "Review a file" {
+			t.Errorf("Expected description, got %q", cmds[0].Description)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("review")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.ArgumentHint != "FILE=<path>" {
+			t.Errorf("ArgumentHint = %q, want %q", got.ArgumentHint, "FILE=<path>")
+		}
+		if got.Instructions != "Review $FILE with $ARGUMENTS" {
+			t.Errorf("Instructions = %q", got.Instructions)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("review"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("review"); !errors.Is(err, ErrCommandNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrCommandNotFound", err)
+		}
+		// Idempotent
+		if err := mgr.Uninstall("review"); err != nil {
+			t.Errorf("second Uninstall failed: %v", err)
+		}
+	})
+}
+
+func TestCommandManager_PlainPrompt(t *testing.T) {
+	paths := NewCodexPaths(ScopeProject, t.TempDir())
+	mgr := NewCommandManager(paths)
+
+	if err := mgr.Install(&Command{Name: "plain", Instructions: "Just do it"}); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	data, err := os.ReadFile(paths.CommandPath("plain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "Just do it\n" {
+		t.Errorf("prompt without metadata should have no frontmatter, got %q", string(data))
+	}
+
+	got, err := mgr.Get("plain")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Instructions != "Just do it" {
+		t.Errorf("Instructions = %q, want %q", got.Instructions, "Just do it")
+	}
+}
+
+func TestCommandManager_InvalidCommand(t *testing.T) {
+	mgr := NewCommandManager(NewCodexPaths(ScopeProject, t.TempDir()))
+
+	if err := mgr.Install(nil); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("Install(nil) error = %v, want ErrInvalidCommand", err)
+	}
+	if err := mgr.Install(&Command{}); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("Install(empty) error = %v, want ErrInvalidCommand", err)
+	}
+	if _, err := mgr.Get(""); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("Get(\"\") error = %v, want ErrInvalidCommand", err)
+	}
+}
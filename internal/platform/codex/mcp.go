@@ -0,0 +1,294 @@
+package codex
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// Sentinel errors for MCP operations.
+var (
+	ErrMCPServerNotFound = errors.New("MCP server not found")
+	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
+)
+
+// SecretSyntax is how Codex CLI interpolates secrets in MCP server env and
+// header values. Codex has no interpolation, so references are resolved
+// when a server is added, except for a bearer token read from the
+// environment, which is stored as bearer_token_env_var.
+var SecretSyntax = secret.Syntax{}
+
+// bearerPrefix is the Authorization header scheme Codex reads from
+// bearer_token_env_var.
+const bearerPrefix = "Bearer "
+
+// mcpServersKey is the config.toml table holding MCP server definitions.
+const mcpServersKey = "mcp_servers"
+
+// knownServerKeys are the per-server keys managed through MCPServer.
+// Any other keys in a server table (cwd, enabled_tools, ...) are preserved as-is.
+var knownServerKeys = []string{
+	"command", "args", "env", "url", "bearer_token_env_var",
+	"http_headers", "enabled", "startup_timeout_sec", "tool_timeout_sec",
+}
+
+// MCPManager provides CRUD operations for Codex CLI MCP server configurations.
+// Servers live in the [mcp_servers] tables of config.toml alongside the rest
+// of Codex's settings, which are preserved on every write.
+type MCPManager struct {
+	paths *CodexPaths
+}
+
+// NewMCPManager creates a new MCPManager instance.
+func NewMCPManager(paths *CodexPaths) *MCPManager {
+	return &MCPManager{
+		paths: paths,
+	}
+}
+
+// List returns all configured MCP servers sorted by name.
+func (m *MCPManager) List() ([]*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*MCPServer, 0, len(config.MCPServers))
+	for _, server := range config.MCPServers {
+		servers = append(servers, server)
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+// Get returns a single MCP server by name.
+func (m *MCPManager) Get(name string) (*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	server, ok := config.MCPServers[name]
+	if !ok {
+		return nil, ErrMCPServerNotFound
+	}
+
+	return server, nil
+}
+
+// Add adds or updates an MCP server configuration.
+func (m *MCPManager) Add(server *MCPServer) error {
+	if server == nil || server.Name == "" {
+		return ErrInvalidMCPServer
+	}
+
+	server, err := renderSecrets(server)
+	if err != nil {
+		return err
+	}
+
+	return m.update(func(config *Config) error {
+		if config.MCPServers == nil {
+			config.MCPServers = make(map[string]*MCPServer)
+		}
+		config.MCPServers[server.Name] = server
+		return nil
+	})
+}
+
+// Remove removes an MCP server configuration.
+// This operation is idempotent; removing a non-existent server returns nil.
+func (m *MCPManager) Remove(name string) error {
+	return m.update(func(config *Config) error {
+		delete(config.MCPServers, name)
+		return nil
+	})
+}
+
+// Enable activates an MCP server.
+func (m *MCPManager) Enable(name string) error {
+	return m.setEnabled(name, true)
+}
+
+// Disable deactivates an MCP server.
+func (m *MCPManager) Disable(name string) error {
+	return m.setEnabled(name, false)
+}
+
+func (m *MCPManager) setEnabled(name string, enabled bool) error {
+	return m.update(func(config *Config) error {
+		server, ok := config.MCPServers[name]
+		if !ok {
+			return ErrMCPServerNotFound
+		}
+
+		server.Enabled = &enabled
+		return nil
+	})
+}
+
+// update applies fn to config.toml and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(config *Config) error) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+	return fileutil.Update(configPath, m.loadConfig, fn, m.saveConfig)
+}
+
+// loadConfig reads config.toml from disk.
+// Returns an empty config if the file doesn't exist.
+func (m *MCPManager) loadConfig() (*Config, error) {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return nil, errors.New("MCP config path not configured")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{
+				MCPServers: make(map[string]*MCPServer),
+				Other:      make(map[string]any),
+			}, nil
+		}
+		return nil, errors.Wrap(err, "reading config file")
+	}
+
+	// Unmarshal into a raw map to preserve everything aix doesn't manage
+	var raw map[string]any
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "parsing config file")
+	}
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "parsing config file into struct")
+	}
+	config.Other = raw
+
+	if config.MCPServers == nil {
+		config.MCPServers = make(map[string]*MCPServer)
+	}
+
+	// Set server names from table keys (lost during unmarshal because Name has toml:"-")
+	for name, server := range config.MCPServers {
+		if server == nil {
+			delete(config.MCPServers, name)
+			continue
+		}
+		server.Name = name
+	}
+
+	return &config, nil
+}
+
+// saveConfig writes config.toml atomically, merging the typed servers back
+// into the preserved raw configuration.
+func (m *MCPManager) saveConfig(config *Config) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "creating directory %s", dir)
+	}
+
+	if config.Other == nil {
+		config.Other = make(map[string]any)
+	}
+
+	rawServers, _ := config.Other[mcpServersKey].(map[string]any)
+
+	if len(config.MCPServers) == 0 {
+		delete(config.Other, mcpServersKey)
+	} else {
+		merged := make(map[string]any, len(config.MCPServers))
+		for name, server := range config.MCPServers {
+			existing, _ := rawServers[name].(map[string]any)
+			table, err := mergeServerTable(existing, server)
+			if err != nil {
+				return errors.Wrapf(err, "encoding MCP server %q", name)
+			}
+			merged[name] = table
+		}
+		config.Other[mcpServersKey] = merged
+	}
+
+	return errors.Wrap(fileutil.AtomicWriteTOML(configPath, config.Other), "writing config file")
+}
+
+// mergeServerTable overlays the typed server fields onto an existing raw
+// server table, keeping keys that MCPServer does not model.
+func mergeServerTable(existing map[string]any, server *MCPServer) (map[string]any, error) {
+	data, err := toml.Marshal(server)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling server")
+	}
+
+	var typed map[string]any
+	if err := toml.Unmarshal(data, &typed); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling server")
+	}
+
+	table := make(map[string]any, len(existing)+len(typed))
+	for k, v := range existing {
+		table[k] = v
+	}
+	for _, k := range knownServerKeys {
+		delete(table, k)
+	}
+	for k, v := range typed {
+		table[k] = v
+	}
+
+	return table, nil
+}
+
+// renderSecrets returns a copy of server with the secret references in its
+// env and headers resolved. An "Authorization: Bearer ${env:NAME}" header
+// becomes bearer_token_env_var = "NAME" instead.
+func renderSecrets(server *MCPServer) (*MCPServer, error) {
+	out := *server
+	headers := server.HTTPHeaders
+	if token, ok := headers["Authorization"]; ok && out.BearerTokenEnvVar == "" {
+		refs := secret.Refs(token)
+		if len(refs) == 1 && refs[0].Kind == secret.KindEnv && token == bearerPrefix+refs[0].String() {
+			out.BearerTokenEnvVar = refs[0].Value
+			headers = make(map[string]string, len(server.HTTPHeaders))
+			for k, v := range server.HTTPHeaders {
+				if k != "Authorization" {
+					headers[k] = v
+				}
+			}
+		}
+	}
+
+	var err error
+	if out.Env, err = secret.RenderMap(server.Env, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q env", server.Name)
+	}
+	if out.HTTPHeaders, err = secret.RenderMap(headers, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q headers", server.Name)
+	}
+	if len(out.HTTPHeaders) == 0 {
+		out.HTTPHeaders = nil
+	}
+	return &out, nil
+}
@@ -0,0 +1,192 @@
+package codex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+func TestMCPManager(t *testing.T) {
+	paths := NewCodexPaths(ScopeProject, t.TempDir())
+	mgr := NewMCPManager(paths)
+
+	configPath := paths.MCPConfigPath()
+	initialConfig := `model = "o3"
+approval_policy = "on-request"
+
+[mcp_servers.existing]
+command = "docs-server"
+cwd = "/srv/docs"
+enabled_tools = ["search"]
+`
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(initialConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	readRaw := func(t *testing.T) map[string]any {
+		t.Helper()
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw map[string]any
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			t.Fatalf("Failed to unmarshal config: %v", err)
+		}
+		return raw
+	}
+
+	t.Run("Add preserves other settings", func(t *testing.T) {
+		err := mgr.Add(&MCPServer{
+			Name:    "github",
+			Command: "npx",
+			Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+			Env:     map[string]string{"GITHUB_TOKEN": "x"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		raw := readRaw(t)
+		if raw["model"] != "o3" || raw["approval_policy"] != "on-request" {
+			t.Errorf("top-level settings not preserved: %v", raw)
+		}
+
+		servers, ok := raw["mcp_servers"].(map[string]any)
+		if !ok {
+			t.Fatalf("mcp_servers table missing: %v", raw)
+		}
+		existing, ok := servers["existing"].(map[string]any)
+		if !ok {
+			t.Fatalf("existing server dropped: %v", servers)
+		}
+		if existing["cwd"] != "/srv/docs" {
+			t.Errorf("unmodeled server key cwd not preserved: %v", existing)
+		}
+		if _, ok := existing["enabled_tools"]; !ok {
+			t.Errorf("unmodeled server key enabled_tools not preserved: %v", existing)
+		}
+		if _, ok := servers["github"]; !ok {
+			t.Errorf("github server not written: %v", servers)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		servers, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(servers) != 2 {
+			t.Fatalf("Expected 2 servers, got %d", len(servers))
+		}
+		if servers[0].Name != "existing" || servers[1].Name != "github" {
+			t.Errorf("List() not sorted by name: %s, %s", servers[0].Name, servers[1].Name)
+		}
+	})
+
+	t.Run("Disable and Enable", func(t *testing.T) {
+		if err := mgr.Disable("github"); err != nil {
+			t.Fatalf("Disable failed: %v", err)
+		}
+		got, err := mgr.Get("github")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.IsEnabled() {
+			t.Error("server still enabled after Disable")
+		}
+
+		if err := mgr.Enable("github"); err != nil {
+			t.Fatalf("Enable failed: %v", err)
+		}
+		got, err = mgr.Get("github")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if !got.IsEnabled() {
+			t.Error("server still disabled after Enable")
+		}
+
+		if err := mgr.Enable("missing"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Enable(missing) error = %v, want ErrMCPServerNotFound", err)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		if err := mgr.Remove("github"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if err := mgr.Remove("existing"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+
+		raw := readRaw(t)
+		if _, ok := raw["mcp_servers"]; ok {
+			t.Errorf("empty mcp_servers table should be dropped: %v", raw)
+		}
+		if raw["model"] != "o3" {
+			t.Errorf("top-level settings not preserved after Remove: %v", raw)
+		}
+	})
+}
+
+func TestMCPManager_MissingConfig(t *testing.T) {
+	mgr := NewMCPManager(NewCodexPaths(ScopeProject, t.TempDir()))
+
+	servers, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(servers) != 0 {
+		t.Errorf("List() returned %d servers, want 0", len(servers))
+	}
+
+	if _, err := mgr.Get("missing"); !errors.Is(err, ErrMCPServerNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrMCPServerNotFound", err)
+	}
+
+	if err := mgr.Add(&MCPServer{}); !errors.Is(err, ErrInvalidMCPServer) {
+		t.Errorf("Add(unnamed) error = %v, want ErrInvalidMCPServer", err)
+	}
+}
+
+func TestMCPManager_AddRendersSecrets(t *testing.T) {
+	t.Setenv("AIX_TEST_DB_PASSWORD", "hunter2")
+	mgr := NewMCPManager(NewCodexPaths(ScopeProject, t.TempDir()))
+
+	server := &MCPServer{
+		Name: "api",
+		URL:  "https://api.example.com/mcp",
+		Env:  map[string]string{"DB_PASSWORD": "${env:AIX_TEST_DB_PASSWORD}"},
+		HTTPHeaders: map[string]string{
+			"Authorization": "Bearer ${env:API_TOKEN}",
+			"X-Team":        "core",
+		},
+	}
+	if err := mgr.Add(server); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if server.Env["DB_PASSWORD"] != "${env:AIX_TEST_DB_PASSWORD}" {
+		t.Error("Add modified the caller's server")
+	}
+
+	got, err := mgr.Get("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Env["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("Env[DB_PASSWORD] = %q, want resolved value", got.Env["DB_PASSWORD"])
+	}
+	if got.BearerTokenEnvVar != "API_TOKEN" {
+		t.Errorf("BearerTokenEnvVar = %q, want API_TOKEN", got.BearerTokenEnvVar)
+	}
+	if _, ok := got.HTTPHeaders["Authorization"]; ok || got.HTTPHeaders["X-Team"] != "core" {
+		t.Errorf("HTTPHeaders = %v, want only X-Team", got.HTTPHeaders)
+	}
+}
@@ -0,0 +1,115 @@
+package codex
+
+import (
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+// MCPTranslator converts between canonical and Codex CLI MCP formats.
+//
+// Codex stores servers as [mcp_servers.<name>] tables in config.toml:
+//   - Transport is inferred: "url" means Streamable HTTP, otherwise local.
+//     Codex does not speak SSE (LOSSY: "sse" servers read back as "http")
+//   - "http_headers" instead of "headers"
+//   - "enabled" (positive logic, default true) instead of "disabled"
+//   - No "platforms" field (LOSSY: this field is not preserved)
+//   - Codex-only keys (bearer_token_env_var, timeouts) have no canonical
+//     equivalent and are dropped when converting to canonical
+type MCPTranslator struct{}
+
+// NewMCPTranslator creates a new Codex CLI MCP translator.
+func NewMCPTranslator() *MCPTranslator {
+	return &MCPTranslator{}
+}
+
+// ToCanonical converts Codex CLI MCP configuration to canonical format.
+//
+// Input may be a full config.toml (servers under [mcp_servers]) or a bare
+// table of servers keyed by name.
+func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
+	var codexConfig MCPConfig
+	if err := toml.Unmarshal(platformData, &codexConfig); err != nil {
+		return nil, errors.Wrap(err, "parsing Codex CLI MCP config")
+	}
+
+	// If mcp_servers is absent, try parsing as a bare servers map
+	if codexConfig.Servers == nil {
+		var servers map[string]*MCPServer
+		if err := toml.Unmarshal(platformData, &servers); err != nil {
+			return nil, errors.Wrap(err, "parsing Codex CLI MCP servers map")
+		}
+		codexConfig.Servers = servers
+	}
+
+	config := mcp.NewConfig()
+	for name, codexServer := range codexConfig.Servers {
+		if codexServer == nil {
+			continue
+		}
+
+		transport := mcp.TransportStdio
+		if codexServer.URL != "" {
+			transport = mcp.TransportHTTP
+		}
+
+		config.Servers[name] = &mcp.Server{
+			Name:      name,
+			Command:   codexServer.Command,
+			Args:      codexServer.Args,
+			URL:       codexServer.URL,
+			Transport: transport,
+			Env:       codexServer.Env,
+			Headers:   codexServer.HTTPHeaders,
+			Disabled:  !codexServer.IsEnabled(),
+		}
+	}
+
+	return config, nil
+}
+
+// FromCanonical converts canonical MCP configuration to Codex CLI format.
+//
+// NOTE: The Platforms field from canonical format is NOT preserved.
+// Codex does not support platform restrictions.
+func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	codexConfig := &MCPConfig{
+		Servers: make(map[string]*MCPServer, len(cfg.Servers)),
+	}
+
+	for name, server := range cfg.Servers {
+		// Only write enabled when it differs from Codex's default
+		var enabled *bool
+		if server.Disabled {
+			f := false
+			enabled = &f
+		}
+
+		codexConfig.Servers[name] = &MCPServer{
+			Name:        name,
+			Command:     server.Command,
+			Args:        server.Args,
+			Env:         server.Env,
+			URL:         server.URL,
+			HTTPHeaders: server.Headers,
+			Enabled:     enabled,
+		}
+	}
+
+	data, err := toml.Marshal(codexConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling Codex CLI MCP config")
+	}
+
+	return data, nil
+}
+
+// Platform returns the platform identifier for this translator.
+func (t *MCPTranslator) Platform() string {
+	return "codex"
+}
@@ -0,0 +1,122 @@
+package codex
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+func TestMCPTranslator_ToCanonical(t *testing.T) {
+	translator := NewMCPTranslator()
+
+	input := `model = "o3"
+
+[mcp_servers.local]
+command = "node"
+args = ["server.js"]
+env = { API_KEY = "secret" }
+
+[mcp_servers.remote]
+url = "https://example.com/mcp"
+http_headers = { "X-Team" = "core" }
+enabled = false
+`
+	config, err := translator.ToCanonical([]byte(input))
+	if err != nil {
+		t.Fatalf("ToCanonical failed: %v", err)
+	}
+
+	local, ok := config.Servers["local"]
+	if !ok {
+		t.Fatal("server local not found")
+	}
+	if local.Command != "node" || len(local.Args) != 1 || local.Env["API_KEY"] != "secret" {
+		t.Errorf("local server not translated: %+v", local)
+	}
+	if local.Transport != mcp.TransportStdio {
+		t.Errorf("local transport = %q, want %q", local.Transport, mcp.TransportStdio)
+	}
+	if local.Disabled {
+		t.Error("server without enabled key should be enabled")
+	}
+
+	remote, ok := config.Servers["remote"]
+	if !ok {
+		t.Fatal("server remote not found")
+	}
+	if remote.Transport != mcp.TransportHTTP {
+		t.Errorf("remote transport = %q, want %q", remote.Transport, mcp.TransportHTTP)
+	}
+	if remote.Headers["X-Team"] != "core" {
+		t.Errorf("http_headers not mapped to Headers: %v", remote.Headers)
+	}
+	if !remote.Disabled {
+		t.Error("enabled = false should translate to Disabled")
+	}
+}
+
+func TestMCPTranslator_ToCanonical_BareServers(t *testing.T) {
+	translator := NewMCPTranslator()
+
+	input := `[myserver]
+command = "node"
+`
+	config, err := translator.ToCanonical([]byte(input))
+	if err != nil {
+		t.Fatalf("ToCanonical failed: %v", err)
+	}
+	if _, ok := config.Servers["myserver"]; !ok {
+		t.Errorf("bare server map not parsed: %v", config.Servers)
+	}
+}
+
+func TestMCPTranslator_FromCanonical(t *testing.T) {
+	translator := NewMCPTranslator()
+
+	config := mcp.NewConfig()
+	config.Servers["enabled"] = &mcp.Server{
+		Name:      "enabled",
+		Command:   "node",
+		Args:      []string{"server.js"},
+		Platforms: []string{"darwin"},
+	}
+	config.Servers["disabled"] = &mcp.Server{
+		Name:     "disabled",
+		URL:      "https://example.com/mcp",
+		Headers:  map[string]string{"Authorization": "Bearer x"},
+		Disabled: true,
+	}
+
+	data, err := translator.FromCanonical(config)
+	if err != nil {
+		t.Fatalf("FromCanonical failed: %v", err)
+	}
+
+	var out MCPConfig
+	if err := toml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("output is not valid TOML: %v", err)
+	}
+
+	if s := out.Servers["enabled"]; s == nil || s.Enabled != nil {
+		t.Errorf("enabled server should omit enabled key, got %+v", s)
+	}
+	s := out.Servers["disabled"]
+	if s == nil || s.IsEnabled() {
+		t.Errorf("disabled server should have enabled = false, got %+v", s)
+	}
+	if s != nil && s.HTTPHeaders["Authorization"] != "Bearer x" {
+		t.Errorf("Headers not mapped to http_headers: %v", s.HTTPHeaders)
+	}
+
+	if _, err := translator.FromCanonical(nil); err == nil {
+		t.Error("FromCanonical(nil) expected error")
+	}
+}
+
+func TestMCPTranslator_Platform(t *testing.T) {
+	if got := NewMCPTranslator().Platform(); got != "codex" {
+		t.Errorf("Platform() = %q, want %q", got, "codex")
+	}
+}
@@ -0,0 +1,155 @@
+// Package codex provides Codex CLI specific configuration and path handling.
+package codex
+
+import (
+	"path/filepath"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// Scope defines whether paths resolve to user-level or project-level configuration.
+type Scope int
+
+const (
+	// ScopeUser resolves paths relative to ~/.codex/
+	ScopeUser Scope = iota
+	// ScopeProject resolves paths relative to <projectRoot>/.codex/
+	ScopeProject
+)
+
+// CodexPaths provides Codex-specific path resolution.
+// It wraps the generic paths package with Codex-specific defaults.
+type CodexPaths struct {
+	scope       Scope
+	projectRoot string
+}
+
+// NewCodexPaths creates a new CodexPaths instance.
+// For ScopeProject, projectRoot must be non-empty.
+// For ScopeUser, projectRoot is ignored.
+func NewCodexPaths(scope Scope, projectRoot string) *CodexPaths {
+	return &CodexPaths{
+		scope:       scope,
+		projectRoot: projectRoot,
+	}
+}
+
+// BaseDir returns the base configuration directory.
+// For ScopeUser: ~/.codex/
+// For ScopeProject: <projectRoot>/.codex/
+// Returns empty string if projectRoot is empty for ScopeProject.
+func (p *CodexPaths) BaseDir() string {
+	switch p.scope {
+	case ScopeUser:
+		return paths.GlobalConfigDir(paths.PlatformCodex)
+	case ScopeProject:
+		return paths.ProjectConfigDir(paths.PlatformCodex, p.projectRoot)
+	default:
+		return ""
+	}
+}
+
+// SkillDir returns the skills directory.
+// Returns <base>/skills/
+func (p *CodexPaths) SkillDir() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "skills")
+}
+
+// CommandDir returns the custom prompts directory.
+// Codex exposes files in <base>/prompts/ as /prompts:<name> slash commands.
+// Returns <base>/prompts/
+func (p *CodexPaths) CommandDir() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "prompts")
+}
+
+// AgentDir returns the agents directory.
+// Returns <base>/agents/
+func (p *CodexPaths) AgentDir() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "agents")
+}
+
+// MCPConfigPath returns the path to the MCP servers configuration file.
+// Codex keeps MCP servers in the [mcp_servers] tables of its main config.
+// Returns <base>/config.toml
+func (p *CodexPaths) MCPConfigPath() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "config.toml")
+}
+
+// InstructionsPath returns the path to the AGENTS.md instructions file.
+// For ScopeUser: ~/.codex/AGENTS.md
+// For ScopeProject: <projectRoot>/AGENTS.md (note: at project root, not .codex/)
+func (p *CodexPaths) InstructionsPath() string {
+	switch p.scope {
+	case ScopeUser:
+		base := p.BaseDir()
+		if base == "" {
+			return ""
+		}
+		return filepath.Join(base, "AGENTS.md")
+	case ScopeProject:
+		if p.projectRoot == "" {
+			return ""
+		}
+		return filepath.Join(p.projectRoot, "AGENTS.md")
+	default:
+		return ""
+	}
+}
+
+// SkillPath returns the path to a specific skill's SKILL.md file.
+// Returns <skills>/<name>/SKILL.md
+// Returns empty string if name is empty.
+func (p *CodexPaths) SkillPath(name string) string {
+	if name == "" {
+		return ""
+	}
+	skillDir := p.SkillDir()
+	if skillDir == "" {
+		return ""
+	}
+	return filepath.Join(skillDir, name, "SKILL.md")
+}
+
+// CommandPath returns the path to a specific prompt file.
+// Returns <prompts>/<name>.md
+// Returns empty string if name is empty.
+func (p *CodexPaths) CommandPath(name string) string {
+	if name == "" {
+		return ""
+	}
+	cmdDir := p.CommandDir()
+	if cmdDir == "" {
+		return ""
+	}
+	return filepath.Join(cmdDir, name+".md")
+}
+
+// AgentPath returns the path to a specific agent file.
+// Returns <agents>/<name>.md
+// Returns empty string if name is empty.
+func (p *CodexPaths) AgentPath(name string) string {
+	if name == "" {
+		return ""
+	}
+	agentDir := p.AgentDir()
+	if agentDir == "" {
+		return ""
+	}
+	return filepath.Join(agentDir, name+".md")
+}
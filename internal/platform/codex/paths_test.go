@@ -0,0 +1,117 @@
+package codex
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCodexPaths_BaseDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		scope       Scope
+		projectRoot string
+		want        string
+	}{
+		{
+			name:  "User scope",
+			scope: ScopeUser,
+			want:  filepath.Join(home, ".codex"),
+		},
+		{
+			name:        "Project scope",
+			scope:       ScopeProject,
+			projectRoot: "/tmp/project",
+			want:        filepath.Join("/tmp/project", ".codex"),
+		},
+		{
+			name:        "Project scope empty root",
+			scope:       ScopeProject,
+			projectRoot: "",
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewCodexPaths(tt.scope, tt.projectRoot)
+			if got := p.BaseDir(); got != tt.want {
+				t.Errorf("BaseDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodexPaths_SubDirs(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	base := filepath.Join(home, ".codex")
+	p := NewCodexPaths(ScopeUser, "")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"SkillDir", p.SkillDir(), filepath.Join(base, "skills")},
+		{"CommandDir", p.CommandDir(), filepath.Join(base, "prompts")},
+		{"AgentDir", p.AgentDir(), filepath.Join(base, "agents")},
+		{"MCPConfigPath", p.MCPConfigPath(), filepath.Join(base, "config.toml")},
+		{"SkillPath", p.SkillPath("review"), filepath.Join(base, "skills", "review", "SKILL.md")},
+		{"CommandPath", p.CommandPath("review"), filepath.Join(base, "prompts", "review.md")},
+		{"AgentPath", p.AgentPath("review"), filepath.Join(base, "agents", "review.md")},
+		{"SkillPath empty name", p.SkillPath(""), ""},
+		{"CommandPath empty name", p.CommandPath(""), ""},
+		{"AgentPath empty name", p.AgentPath(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodexPaths_InstructionsPath(t *testing.T) {
+	home, _ := os.UserHomeDir()
+
+	tests := []struct {
+		name        string
+		scope       Scope
+		projectRoot string
+		want        string
+	}{
+		{
+			name:  "User scope",
+			scope: ScopeUser,
+			want:  filepath.Join(home, ".codex", "AGENTS.md"),
+		},
+		{
+			name:        "Project scope",
+			scope:       ScopeProject,
+			projectRoot: "/tmp/project",
+			want:        filepath.Join("/tmp/project", "AGENTS.md"),
+		},
+		{
+			name:        "Project scope empty root",
+			scope:       ScopeProject,
+			projectRoot: "",
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewCodexPaths(tt.scope, tt.projectRoot)
+			if got := p.InstructionsPath(); got != tt.want {
+				t.Errorf("InstructionsPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package codex
+
+import (
+	"os"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// CodexPlatform provides the unified platform adapter for Codex CLI.
+// It aggregates all Codex-specific managers and provides a single entry point
+// for skill, command, agent, and MCP operations.
+type CodexPlatform struct {
+	paths    *CodexPaths
+	skills   *SkillManager
+	commands *CommandManager
+	agents   *AgentManager
+	mcp      *MCPManager
+}
+
+// Option configures a CodexPlatform instance.
+type Option func(*CodexPlatform)
+
+// WithScope sets the scope (user or project) for path resolution.
+func WithScope(scope Scope) Option {
+	return func(p *CodexPlatform) {
+		p.paths = NewCodexPaths(scope, p.paths.projectRoot)
+	}
+}
+
+// WithProjectRoot sets the project root directory.
+func WithProjectRoot(root string) Option {
+	return func(p *CodexPlatform) {
+		p.paths = NewCodexPaths(p.paths.scope, root)
+	}
+}
+
+// NewCodexPlatform creates a new CodexPlatform with the given options.
+// By default, it uses user scope (~/.codex/).
+func NewCodexPlatform(opts ...Option) *CodexPlatform {
+	p := &CodexPlatform{
+		paths: NewCodexPaths(ScopeUser, ""),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.skills = NewSkillManager(p.paths)
+	p.commands = NewCommandManager(p.paths)
+	p.agents = NewAgentManager(p.paths)
+	p.mcp = NewMCPManager(p.paths)
+
+	return p
+}
+
+// Name returns the platform identifier.
+func (p *CodexPlatform) Name() string {
+	return "codex"
+}
+
+// DisplayName returns a human-readable platform name.
+func (p *CodexPlatform) DisplayName() string {
+	return "Codex CLI"
+}
+
+// --- Path Methods ---
+
+// GlobalConfigDir returns the global configuration directory (~/.codex/).
+func (p *CodexPlatform) GlobalConfigDir() string {
+	return paths.GlobalConfigDir(paths.PlatformCodex)
+}
+
+// ProjectConfigDir returns the project-scoped configuration directory.
+func (p *CodexPlatform) ProjectConfigDir(projectRoot string) string {
+	return paths.ProjectConfigDir(paths.PlatformCodex, projectRoot)
+}
+
+// SkillDir returns the skills directory for the current scope.
+func (p *CodexPlatform) SkillDir() string {
+	return p.paths.SkillDir()
+}
+
+// CommandDir returns the custom prompts directory for the current scope.
+func (p *CodexPlatform) CommandDir() string {
+	return p.paths.CommandDir()
+}
+
+// AgentDir returns the agents directory for the current scope.
+func (p *CodexPlatform) AgentDir() string {
+	return p.paths.AgentDir()
+}
+
+// MCPConfigPath returns the path to config.toml, which holds MCP servers.
+func (p *CodexPlatform) MCPConfigPath() string {
+	return p.paths.MCPConfigPath()
+}
+
+// InstructionsPath returns the path to the AGENTS.md instructions file.
+// If projectRoot is non-empty, the project-level file is returned.
+func (p *CodexPlatform) InstructionsPath(projectRoot string) string {
+	if projectRoot != "" {
+		projectPaths := NewCodexPaths(ScopeProject, projectRoot)
+		return projectPaths.InstructionsPath()
+	}
+	return p.paths.InstructionsPath()
+}
+
+// --- Skill Operations ---
+
+// InstallSkill installs a skill to the skills directory.
+func (p *CodexPlatform) InstallSkill(s *Skill) error {
+	return p.skills.Install(s)
+}
+
+// UninstallSkill removes a skill by name.
+func (p *CodexPlatform) UninstallSkill(name string) error {
+	return p.skills.Uninstall(name)
+}
+
+// ListSkills returns all installed skills.
+func (p *CodexPlatform) ListSkills() ([]*Skill, error) {
+	return p.skills.List()
+}
+
+// GetSkill retrieves a skill by name.
+func (p *CodexPlatform) GetSkill(name string) (*Skill, error) {
+	return p.skills.Get(name)
+}
+
+// --- Command Operations ---
+
+// InstallCommand installs a custom prompt to the prompts directory.
+func (p *CodexPlatform) InstallCommand(c *Command) error {
+	return p.commands.Install(c)
+}
+
+// UninstallCommand removes a custom prompt by name.
+func (p *CodexPlatform) UninstallCommand(name string) error {
+	return p.commands.Uninstall(name)
+}
+
+// ListCommands returns all installed custom prompts.
+func (p *CodexPlatform) ListCommands() ([]*Command, error) {
+	return p.commands.List()
+}
+
+// GetCommand retrieves a custom prompt by name.
+func (p *CodexPlatform) GetCommand(name string) (*Command, error) {
+	return p.commands.Get(name)
+}
+
+// --- Agent Operations ---
+
+// InstallAgent installs an agent to the agents directory.
+func (p *CodexPlatform) InstallAgent(a *Agent) error {
+	return p.agents.Install(a)
+}
+
+// UninstallAgent removes an agent by name.
+func (p *CodexPlatform) UninstallAgent(name string) error {
+	return p.agents.Uninstall(name)
+}
+
+// ListAgents returns all installed agents.
+func (p *CodexPlatform) ListAgents() ([]*Agent, error) {
+	return p.agents.List()
+}
+
+// GetAgent retrieves an agent by name.
+func (p *CodexPlatform) GetAgent(name string) (*Agent, error) {
+	return p.agents.Get(name)
+}
+
+// --- MCP Operations ---
+
+// AddMCP adds or updates an MCP server in config.toml.
+func (p *CodexPlatform) AddMCP(s *MCPServer) error {
+	return p.mcp.Add(s)
+}
+
+// RemoveMCP removes an MCP server from config.toml.
+func (p *CodexPlatform) RemoveMCP(name string) error {
+	return p.mcp.Remove(name)
+}
+
+// ListMCP returns all configured MCP servers.
+func (p *CodexPlatform) ListMCP() ([]*MCPServer, error) {
+	return p.mcp.List()
+}
+
+// GetMCP retrieves an MCP server by name.
+func (p *CodexPlatform) GetMCP(name string) (*MCPServer, error) {
+	return p.mcp.Get(name)
+}
+
+// EnableMCP enables an MCP server.
+func (p *CodexPlatform) EnableMCP(name string) error {
+	return p.mcp.Enable(name)
+}
+
+// DisableMCP disables an MCP server.
+func (p *CodexPlatform) DisableMCP(name string) error {
+	return p.mcp.Disable(name)
+}
+
+// --- Translation Methods ---
+
+// TranslateVariables converts canonical variables to Codex format.
+func (p *CodexPlatform) TranslateVariables(content string) string {
+	return TranslateVariables(content)
+}
+
+// TranslateToCanonical converts Codex variables to canonical format.
+func (p *CodexPlatform) TranslateToCanonical(content string) string {
+	return TranslateToCanonical(content)
+}
+
+// ValidateVariables checks if content contains only supported variables.
+func (p *CodexPlatform) ValidateVariables(content string) error {
+	return ValidateVariables(content)
+}
+
+// --- Backup Methods ---
+
+// BackupPaths returns all config files/directories that should be backed up.
+// For Codex, this includes:
+//   - ~/.codex/config.toml (MCP config)
+//   - ~/.codex/ directory (skills, prompts, agents)
+func (p *CodexPlatform) BackupPaths() []string {
+	return []string{
+		p.paths.MCPConfigPath(),
+		p.paths.BaseDir(),
+	}
+}
+
+// --- Status Methods ---
+
+// IsAvailable checks if Codex CLI is available on this system.
+// Returns true if the ~/.codex/ directory exists.
+func (p *CodexPlatform) IsAvailable() bool {
+	globalDir := p.GlobalConfigDir()
+	if globalDir == "" {
+		return false
+	}
+	info, err := os.Stat(globalDir)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
+
+// Version returns the Codex CLI version.
+// Currently returns an empty string as version detection is not yet implemented.
+func (p *CodexPlatform) Version() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,67 @@
+package codex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSkillManager(t *testing.T) {
+	paths := NewCodexPaths(ScopeProject, t.TempDir())
+	mgr := NewSkillManager(paths)
+
+	skill := &Skill{
+		Name:         "go-review",
+		Description:  "Reviews Go code",
+		AllowedTools: ToolList{"Read", "Grep"},
+		Instructions: "Review $ARGUMENTS",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := mgr.Install(skill); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+		if _, err := os.Stat(paths.SkillPath("go-review")); err != nil {
+			t.Errorf("SKILL.md not written: %v", err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("go-review")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Description != "Reviews Go code" {
+			t.Errorf("Description = %q", got.Description)
+		}
+		if got.AllowedTools.String() != "Read Grep" {
+			t.Errorf("AllowedTools = %q, want %q", got.AllowedTools.String(), "Read Grep")
+		}
+		if got.Instructions != "Review $ARGUMENTS" {
+			t.Errorf("Instructions = %q", got.Instructions)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		skills, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(skills) != 1 || skills[0].Name != "go-review" {
+			t.Errorf("List() = %v, want [go-review]", skills)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("go-review"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Dir(paths.SkillPath("go-review"))); !os.IsNotExist(err) {
+			t.Errorf("skill directory still exists after Uninstall")
+		}
+		if _, err := mgr.Get("go-review"); !errors.Is(err, ErrSkillNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrSkillNotFound", err)
+		}
+	})
+}
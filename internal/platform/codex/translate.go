@@ -0,0 +1,88 @@
+package codex
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Canonical variables and how Codex CLI handles them.
+const (
+	VarArguments = "$ARGUMENTS"
+	VarSelection = "$SELECTION"
+)
+
+// unsupportedVars lists canonical variables that Codex CLI cannot supply.
+// Codex expands $ARGUMENTS natively and treats any other uppercase
+// placeholder as a named argument (e.g. /prompts:review FILE=main.go),
+// so only variables tied to editor state are rejected.
+var unsupportedVars = map[string]struct{}{
+	VarSelection: {},
+}
+
+// varPattern matches variable syntax: $ followed by 2+ uppercase letters/underscores.
+var varPattern = regexp.MustCompile(`\$[A-Z][A-Z_]+\b`)
+
+// ErrUnsupportedVariable indicates content contains variables not supported by Codex CLI.
+var ErrUnsupportedVariable = errors.New("unsupported variable")
+
+// TranslateVariables converts canonical variable syntax to Codex CLI format.
+// Codex uses the canonical $ARGUMENTS syntax, so content is returned unchanged.
+func TranslateVariables(content string) string {
+	return content
+}
+
+// TranslateToCanonical converts Codex CLI variable syntax to canonical format.
+// Since Codex uses the canonical format, this is a pass-through.
+func TranslateToCanonical(content string) string {
+	return content
+}
+
+// ValidateVariables checks if content contains only supported variables.
+// Returns nil if valid, or an error listing unsupported variables.
+func ValidateVariables(content string) error {
+	vars := varPattern.FindAllString(content, -1)
+	if len(vars) == 0 {
+		return nil
+	}
+
+	var unsupported []string
+	seen := make(map[string]struct{})
+
+	for _, v := range vars {
+		if _, ok := unsupportedVars[v]; ok {
+			if _, alreadySeen := seen[v]; !alreadySeen {
+				unsupported = append(unsupported, v)
+				seen[v] = struct{}{}
+			}
+		}
+	}
+
+	if len(unsupported) == 0 {
+		return nil
+	}
+
+	return errors.Wrapf(ErrUnsupportedVariable, "%s", strings.Join(unsupported, ", "))
+}
+
+// ListVariables returns all variables found in the content.
+// The returned slice contains unique variables in the order they first appear.
+func ListVariables(content string) []string {
+	matches := varPattern.FindAllString(content, -1)
+	if len(matches) == 0 {
+		return []string{}
+	}
+
+	seen := make(map[string]struct{})
+	result := make([]string, 0, len(matches))
+
+	for _, v := range matches {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
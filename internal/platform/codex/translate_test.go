@@ -0,0 +1,53 @@
+package codex
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTranslateVariables_PassThrough(t *testing.T) {
+	input := "Run $ARGUMENTS against $FILE"
+	if got := TranslateVariables(input); got != input {
+		t.Errorf("TranslateVariables(%q) = %q, want unchanged", input, got)
+	}
+	if got := TranslateToCanonical(input); got != input {
+		t.Errorf("TranslateToCanonical(%q) = %q, want unchanged", input, got)
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "arguments", input: "Do $ARGUMENTS", wantErr: false},
+		{name: "named placeholder", input: "Review $FILE", wantErr: false},
+		{name: "selection", input: "Explain $SELECTION", wantErr: true},
+		{name: "no variables", input: "plain text", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVariables(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedVariable) {
+					t.Errorf("ValidateVariables(%q) error = %v, want ErrUnsupportedVariable", tt.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateVariables(%q) unexpected error: %v", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestListVariables(t *testing.T) {
+	got := ListVariables("$ARGUMENTS then $FILE then $ARGUMENTS")
+	want := []string{"$ARGUMENTS", "$FILE"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListVariables() = %v, want %v", got, want)
+	}
+}
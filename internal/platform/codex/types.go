@@ -0,0 +1,189 @@
+package codex
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// ToolList is a list of allowed tools.
+// It supports unmarshaling from both a space-delimited string and a list of strings.
+type ToolList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *ToolList) UnmarshalYAML(value *yaml.Node) error {
+	var multi []string
+	if err := value.Decode(&multi); err == nil {
+		*t = multi
+		return nil
+	}
+
+	var single string
+	if err := value.Decode(&single); err == nil {
+		if single == "" {
+			*t = nil
+			return nil
+		}
+		// Split space-delimited string
+		for _, part := range strings.Fields(single) {
+			*t = append(*t, part)
+		}
+		return nil
+	}
+
+	return errors.Newf("allowed-tools must be a string or list of strings, got %s", value.Tag)
+}
+
+// String returns the space-delimited string representation.
+func (t ToolList) String() string {
+	return strings.Join(t, " ")
+}
+
+// Skill represents a skill definition per the Agent Skills Specification.
+// Skills are markdown files with YAML frontmatter that define reusable capabilities.
+type Skill struct {
+	// Name is the skill's unique identifier (required).
+	Name string `yaml:"name" json:"name"`
+
+	// Description explains what the skill does (required).
+	Description string `yaml:"description" json:"description"`
+
+	// License is the SPDX license identifier (optional).
+	License string `yaml:"license,omitempty" json:"license,omitempty"`
+
+	// Compatibility lists compatible AI assistants (optional).
+	Compatibility []string `yaml:"compatibility,omitempty" json:"compatibility,omitempty"`
+
+	// Metadata contains optional key-value pairs like author, version, repository.
+	Metadata map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+
+	// AllowedTools lists the tool permissions required by this skill.
+	AllowedTools ToolList `yaml:"allowed-tools,omitempty" json:"allowed-tools,omitempty"`
+
+	// Instructions contains the skill's markdown body content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+
+	// SourceDir is the local directory the skill was installed from.
+	// Set during installation from local/git paths; not serialized.
+	SourceDir string `yaml:"-" json:"-"`
+}
+
+// Command represents a Codex CLI custom prompt.
+// Prompts are markdown files that Codex exposes as slash commands.
+type Command struct {
+	// Name is the prompt's identifier, derived from the filename.
+	Name string `yaml:"name" json:"name"`
+
+	// Description explains what the prompt does.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// ArgumentHint documents the arguments the prompt expects.
+	ArgumentHint string `yaml:"argument-hint,omitempty" json:"argumentHint,omitempty"`
+
+	// Instructions contains the prompt's markdown body content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
+
+// GetName returns the command's name.
+func (c *Command) GetName() string {
+	return c.Name
+}
+
+// SetName sets the command's name.
+func (c *Command) SetName(name string) {
+	c.Name = name
+}
+
+// SetInstructions sets the command's instructions.
+func (c *Command) SetInstructions(instructions string) {
+	c.Instructions = instructions
+}
+
+// Agent represents a Codex CLI agent definition.
+type Agent struct {
+	// Name is the agent's identifier.
+	Name string `yaml:"name" json:"name"`
+
+	// Description explains the agent's purpose.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Instructions contains the agent's markdown body content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
+
+// GetName returns the agent's name.
+func (a *Agent) GetName() string {
+	return a.Name
+}
+
+// GetDescription returns the agent's description.
+func (a *Agent) GetDescription() string {
+	return a.Description
+}
+
+// GetInstructions returns the agent's instructions.
+func (a *Agent) GetInstructions() string {
+	return a.Instructions
+}
+
+// MCPServer represents an MCP server entry in Codex's config.toml.
+// Codex infers the transport from the presence of command or url.
+type MCPServer struct {
+	// Name is the server's identifier, derived from the table key.
+	Name string `json:"-" toml:"-"`
+
+	// Command is the executable path for local servers.
+	Command string `json:"command,omitempty" toml:"command,omitempty"`
+
+	// Args are command-line arguments for the server process.
+	Args []string `json:"args,omitempty" toml:"args,omitempty"`
+
+	// Env contains environment variables for the server process.
+	Env map[string]string `json:"env,omitempty" toml:"env,omitempty"`
+
+	// URL is the server endpoint for remote servers.
+	URL string `json:"url,omitempty" toml:"url,omitempty"`
+
+	// BearerTokenEnvVar names an environment variable holding a bearer token
+	// for remote servers.
+	BearerTokenEnvVar string `json:"bearer_token_env_var,omitempty" toml:"bearer_token_env_var,omitempty"`
+
+	// HTTPHeaders contains HTTP headers for remote connections.
+	HTTPHeaders map[string]string `json:"http_headers,omitempty" toml:"http_headers,omitempty"`
+
+	// Enabled indicates whether the server is active.
+	// Pointer type to distinguish unset (enabled) from explicitly false.
+	Enabled *bool `json:"enabled,omitempty" toml:"enabled,omitempty"`
+
+	// StartupTimeoutSec overrides how long Codex waits for the server to start.
+	StartupTimeoutSec float64 `json:"startup_timeout_sec,omitempty" toml:"startup_timeout_sec,omitempty"`
+
+	// ToolTimeoutSec overrides how long Codex waits for a tool call to finish.
+	ToolTimeoutSec float64 `json:"tool_timeout_sec,omitempty" toml:"tool_timeout_sec,omitempty"`
+}
+
+// IsEnabled reports whether the server is active. Servers without an
+// explicit enabled key are enabled.
+func (s *MCPServer) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// MCPConfig represents the MCP portion of Codex's config.toml.
+type MCPConfig struct {
+	// Servers maps server names to their configurations.
+	Servers map[string]*MCPServer `json:"mcp_servers" toml:"mcp_servers"`
+}
+
+// Config represents the root structure of Codex's config.toml.
+type Config struct {
+	// MCPServers contains the MCP server configurations.
+	MCPServers map[string]*MCPServer `json:"mcp_servers,omitempty" toml:"mcp_servers,omitempty"`
+
+	// Other stores every other key in config.toml to preserve it on save.
+	Other map[string]any `json:"-" toml:"-"`
+}
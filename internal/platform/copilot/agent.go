@@ -0,0 +1,51 @@
+package copilot
+
+import "github.com/thoreinstein/aix/internal/errors"
+
+// Sentinel errors for agent operations.
+var (
+	ErrAgentNotFound = errors.New("agent not found")
+	ErrInvalidAgent  = errors.New("invalid agent: name required")
+)
+
+// AgentManager provides CRUD operations for agents, which are stored as
+// Copilot chat modes.
+type AgentManager struct {
+	store *markdownStore[ChatMode, *ChatMode]
+}
+
+// NewAgentManager creates a new AgentManager with the given paths configuration.
+func NewAgentManager(paths *CopilotPaths) *AgentManager {
+	return &AgentManager{
+		store: &markdownStore[ChatMode, *ChatMode]{
+			dir:      paths.AgentDir,
+			ext:      ChatModeExt,
+			notFound: ErrAgentNotFound,
+			invalid:  ErrInvalidAgent,
+		},
+	}
+}
+
+// List returns all chat modes, without their content.
+// Returns an empty slice if the directory doesn't exist.
+func (m *AgentManager) List() ([]*ChatMode, error) {
+	return m.store.list()
+}
+
+// Get retrieves an agent by name.
+// Returns ErrAgentNotFound if the chat mode file doesn't exist.
+func (m *AgentManager) Get(name string) (*ChatMode, error) {
+	return m.store.get(name)
+}
+
+// Install writes an agent to disk as a chat mode.
+// Overwrites any existing agent with the same name.
+func (m *AgentManager) Install(c *ChatMode) error {
+	return m.store.install(c)
+}
+
+// Uninstall removes an agent by name.
+// This operation is idempotent; removing a non-existent agent returns nil.
+func (m *AgentManager) Uninstall(name string) error {
+	return m.store.uninstall(name)
+}
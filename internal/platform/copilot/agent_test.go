@@ -0,0 +1,56 @@
+package copilot
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAgentManager(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewAgentManager(paths)
+
+	mode := &ChatMode{
+		Name:         "planner",
+		Description:  "Plans before coding",
+		Tools:        []string{"codebase", "search"},
+		Instructions: "Write a plan. Do not edit files.",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := mgr.Install(mode); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.AgentPath(mode.Name))
+		if err != nil {
+			t.Fatalf("Failed to read chat mode file: %v", err)
+		}
+		content := string(data)
+		for _, want := range []string{"description: Plans before coding", "- codebase", "Write a plan."} {
+			if !strings.Contains(content, want) {
+				t.Errorf("chat mode file missing %q:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("planner")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if len(got.Tools) != 2 || got.Instructions != mode.Instructions {
+			t.Errorf("Get() = %+v", got)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("planner"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("planner"); !errors.Is(err, ErrAgentNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrAgentNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package copilot
+
+import "github.com/thoreinstein/aix/internal/errors"
+
+// Sentinel errors for command operations.
+var (
+	ErrCommandNotFound = errors.New("command not found")
+	ErrInvalidCommand  = errors.New("invalid command: name required")
+)
+
+// CommandManager provides CRUD operations for slash commands, which are
+// stored as Copilot prompt files.
+type CommandManager struct {
+	store *markdownStore[Prompt, *Prompt]
+}
+
+// NewCommandManager creates a new CommandManager with the given paths configuration.
+func NewCommandManager(paths *CopilotPaths) *CommandManager {
+	return &CommandManager{
+		store: &markdownStore[Prompt, *Prompt]{
+			dir:      paths.CommandDir,
+			ext:      PromptExt,
+			notFound: ErrCommandNotFound,
+			invalid:  ErrInvalidCommand,
+		},
+	}
+}
+
+// List returns all prompt files, without their content.
+// Returns an empty slice if the directory doesn't exist.
+func (m *CommandManager) List() ([]*Prompt, error) {
+	return m.store.list()
+}
+
+// Get retrieves a command by name.
+// Returns ErrCommandNotFound if the prompt file doesn't exist.
+func (m *CommandManager) Get(name string) (*Prompt, error) {
+	return m.store.get(name)
+}
+
+// Install writes a command to disk as a prompt file.
+// Overwrites any existing command with the same name.
+func (m *CommandManager) Install(p *Prompt) error {
+	return m.store.install(p)
+}
+
+// Uninstall removes a command by name.
+// This operation is idempotent; removing a non-existent command returns nil.
+func (m *CommandManager) Uninstall(name string) error {
+	return m.store.uninstall(name)
+}
@@ -0,0 +1,57 @@
+package copilot
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCommandManager(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewCommandManager(paths)
+
+	cmd := &Prompt{
+		Name:         "review",
+		Description:  "Review a file",
+		ArgumentHint: "file",
+		Mode:         "agent",
+		Instructions: "Review ${input:file}.",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := mgr.Install(cmd); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.CommandPath(cmd.Name))
+		if err != nil {
+			t.Fatalf("Failed to read prompt file: %v", err)
+		}
+		content := string(data)
+		for _, want := range []string{"description: Review a file", "argument-hint: file", "mode: agent", "Review ${input:file}."} {
+			if !strings.Contains(content, want) {
+				t.Errorf("prompt file missing %q:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("review")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.ArgumentHint != "file" || got.Mode != "agent" || got.Instructions != cmd.Instructions {
+			t.Errorf("Get() = %+v", got)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("review"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("review"); !errors.Is(err, ErrCommandNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrCommandNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package copilot
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// document is a pointer to one of the markdown file types: Instruction,
+// Prompt, or ChatMode. Its frontmatter is the YAML encoding of the struct.
+type document[T any] interface {
+	*T
+	name() string
+	setName(name string)
+	body() string
+	setBody(body string)
+}
+
+func (i *Instruction) name() string        { return i.Name }
+func (i *Instruction) setName(name string) { i.Name = name }
+func (i *Instruction) body() string        { return i.Instructions }
+func (i *Instruction) setBody(body string) { i.Instructions = body }
+
+func (p *Prompt) name() string        { return p.Name }
+func (p *Prompt) setName(name string) { p.Name = name }
+func (p *Prompt) body() string        { return p.Instructions }
+func (p *Prompt) setBody(body string) { p.Instructions = body }
+
+func (c *ChatMode) name() string        { return c.Name }
+func (c *ChatMode) setName(name string) { c.Name = name }
+func (c *ChatMode) body() string        { return c.Instructions }
+func (c *ChatMode) setBody(body string) { c.Instructions = body }
+
+// markdownStore reads and writes the files of one document type: the files
+// in dir with extension ext. Files with other extensions in the same
+// directory are ignored, since at user scope all types share one.
+type markdownStore[T any, P document[T]] struct {
+	dir      func() string
+	ext      string
+	notFound error
+	invalid  error
+}
+
+// list returns every document in the directory, without its body.
+// Returns an empty slice if the directory doesn't exist.
+func (s *markdownStore[T, P]) list() ([]P, error) {
+	dir := s.dir()
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading directory")
+	}
+
+	docs := make([]P, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), s.ext) {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), s.ext)
+		f, err := os.Open(joinName(dir, name, s.ext))
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening %q", entry.Name())
+		}
+
+		doc := P(new(T))
+		err = frontmatter.ParseHeader(f, doc)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing header of %q", entry.Name())
+		}
+		doc.setName(name)
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// get reads a document by name.
+func (s *markdownStore[T, P]) get(name string) (P, error) {
+	if name == "" {
+		return nil, s.invalid
+	}
+
+	path := joinName(s.dir(), name, s.ext)
+	if path == "" {
+		return nil, s.notFound
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, s.notFound
+		}
+		return nil, errors.Wrap(err, "reading file")
+	}
+
+	doc := P(new(T))
+	body, err := frontmatter.Parse(bytes.NewReader(data), doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing file")
+	}
+	doc.setBody(strings.TrimSpace(string(body)))
+
+	// Name is derived from the file name
+	doc.setName(name)
+	return doc, nil
+}
+
+// install writes a document, overwriting any with the same name. A
+// document whose frontmatter would be empty is written as plain markdown.
+func (s *markdownStore[T, P]) install(doc P) error {
+	if doc == nil || doc.name() == "" {
+		return s.invalid
+	}
+
+	dir := s.dir()
+	if dir == "" {
+		return errors.New("directory path is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+
+	content := []byte(strings.TrimSpace(doc.body()) + "\n")
+	matter, err := yaml.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "encoding frontmatter")
+	}
+	if string(matter) != "{}\n" {
+		if content, err = frontmatter.Format(doc, doc.body()); err != nil {
+			return errors.Wrap(err, "formatting file")
+		}
+	}
+
+	if err := fileutil.AtomicWriteFile(joinName(dir, doc.name(), s.ext), content, 0o644); err != nil {
+		return errors.Wrap(err, "writing file")
+	}
+	return nil
+}
+
+// uninstall removes a document by name.
+// This operation is idempotent; removing a non-existent document returns nil.
+func (s *markdownStore[T, P]) uninstall(name string) error {
+	if name == "" {
+		return s.invalid
+	}
+
+	path := joinName(s.dir(), name, s.ext)
+	if path == "" {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "removing file")
+	}
+	return nil
+}
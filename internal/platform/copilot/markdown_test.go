@@ -0,0 +1,94 @@
+package copilot
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestManagers_SharedUserDirectory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("APPDATA", t.TempDir())
+	paths := NewCopilotPaths(ScopeUser, "")
+
+	skills := NewSkillManager(paths)
+	commands := NewCommandManager(paths)
+	agents := NewAgentManager(paths)
+
+	if err := skills.Install(&Instruction{Name: "go", Description: "Go style", Instructions: "Use gofmt."}); err != nil {
+		t.Fatalf("skills.Install failed: %v", err)
+	}
+	if err := commands.Install(&Prompt{Name: "review", Instructions: "Review the code."}); err != nil {
+		t.Fatalf("commands.Install failed: %v", err)
+	}
+	if err := agents.Install(&ChatMode{Name: "planner", Instructions: "Plan first."}); err != nil {
+		t.Fatalf("agents.Install failed: %v", err)
+	}
+
+	for name, list := range map[string]func() (int, error){
+		"skills":   func() (int, error) { l, err := skills.List(); return len(l), err },
+		"commands": func() (int, error) { l, err := commands.List(); return len(l), err },
+		"agents":   func() (int, error) { l, err := agents.List(); return len(l), err },
+	} {
+		n, err := list()
+		if err != nil {
+			t.Fatalf("%s.List failed: %v", name, err)
+		}
+		if n != 1 {
+			t.Errorf("%s.List returned %d entries, want 1", name, n)
+		}
+	}
+}
+
+func TestMarkdownStore_PlainMarkdown(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewCommandManager(paths)
+
+	if err := mgr.Install(&Prompt{Name: "review", Instructions: "Review the code."}); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	data, err := os.ReadFile(paths.CommandPath("review"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "Review the code.\n"; got != want {
+		t.Errorf("prompt file = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownStore_Invalid(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+
+	if err := NewSkillManager(paths).Install(&Instruction{}); !errors.Is(err, ErrInvalidSkill) {
+		t.Errorf("Install error = %v, want ErrInvalidSkill", err)
+	}
+	if _, err := NewAgentManager(paths).Get(""); !errors.Is(err, ErrInvalidAgent) {
+		t.Errorf("Get error = %v, want ErrInvalidAgent", err)
+	}
+	if err := NewCommandManager(paths).Uninstall(""); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("Uninstall error = %v, want ErrInvalidCommand", err)
+	}
+}
+
+func TestMarkdownStore_Frontmatter(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewSkillManager(paths)
+
+	if err := mgr.Install(&Instruction{Name: "go", Description: "Go style", ApplyTo: "**/*.go", Instructions: "Use gofmt."}); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	data, err := os.ReadFile(paths.SkillPath("go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{"description: Go style", "applyTo: '**/*.go'", "Use gofmt."} {
+		if !strings.Contains(content, want) {
+			t.Errorf("instructions file missing %q:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package copilot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+// Sentinel errors for MCP operations.
+var (
+	ErrMCPServerNotFound = errors.New("MCP server not found")
+	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
+
+	// ErrDisableNotSupported indicates an attempt to disable a server.
+	// mcp.json has no such setting; servers are stopped from VS Code itself.
+	ErrDisableNotSupported = errors.New("VS Code cannot disable MCP servers in mcp.json")
+)
+
+// SecretSyntax is how VS Code interpolates environment variables in MCP
+// server env and header values.
+//
+// File and command references are not resolved into mcp.json, which is
+// often committed. Each is replaced with an ${input:<id>} reference to a
+// password input instead, so VS Code asks for the value once and keeps it
+// in its secret storage.
+var SecretSyntax = secret.Syntax{Env: "${env:%s}"}
+
+// MCPManager provides CRUD operations for MCP server configurations.
+type MCPManager struct {
+	paths *CopilotPaths
+}
+
+// NewMCPManager creates a new MCPManager instance.
+func NewMCPManager(paths *CopilotPaths) *MCPManager {
+	return &MCPManager{
+		paths: paths,
+	}
+}
+
+// List returns all MCP servers from the configuration file.
+// Returns an empty slice if the config file does not exist.
+// The returned servers are sorted by name for deterministic ordering.
+func (m *MCPManager) List() ([]*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*MCPServer, 0, len(config.Servers))
+	for _, server := range config.Servers {
+		servers = append(servers, server)
+	}
+
+	// Sort by name for deterministic ordering
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+// Get returns a single MCP server by name.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Get(name string) (*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	server, ok := config.Servers[name]
+	if !ok {
+		return nil, ErrMCPServerNotFound
+	}
+
+	return server, nil
+}
+
+// Add adds or updates an MCP server in the configuration, together with
+// the inputs its secrets are prompted for.
+// Returns ErrInvalidMCPServer if the server name is empty.
+func (m *MCPManager) Add(server *MCPServer) error {
+	if server == nil || server.Name == "" {
+		return ErrInvalidMCPServer
+	}
+
+	server, inputs, err := renderSecrets(server)
+	if err != nil {
+		return err
+	}
+
+	return m.update(func(config *MCPConfig) error {
+		config.Servers[server.Name] = server
+		for _, input := range inputs {
+			if !slices.ContainsFunc(config.Inputs, func(in *MCPInput) bool { return in.ID == input.ID }) {
+				config.Inputs = append(config.Inputs, input)
+			}
+		}
+		pruneInputs(config)
+		return nil
+	})
+}
+
+// Remove removes an MCP server from the configuration by name, along with
+// any inputs no other server references.
+// This operation is idempotent - removing a non-existent server does not error.
+func (m *MCPManager) Remove(name string) error {
+	return m.update(func(config *MCPConfig) error {
+		delete(config.Servers, name)
+		pruneInputs(config)
+		return nil
+	})
+}
+
+// Enable checks that the specified server exists. Servers in mcp.json are
+// always enabled.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Enable(name string) error {
+	_, err := m.Get(name)
+	return err
+}
+
+// Disable returns ErrDisableNotSupported if the specified server exists.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Disable(name string) error {
+	if _, err := m.Get(name); err != nil {
+		return err
+	}
+	return ErrDisableNotSupported
+}
+
+// update applies fn to the MCP configuration and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(config *MCPConfig) error) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+	return fileutil.Update(configPath, m.loadConfig, fn, m.saveConfig)
+}
+
+// loadConfig reads the MCP configuration from disk. VS Code allows comments
+// and trailing commas in mcp.json.
+// Returns an empty config with initialized Servers map if the file doesn't exist.
+func (m *MCPManager) loadConfig() (*MCPConfig, error) {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return nil, errors.New("MCP config path not configured")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading MCP config")
+	}
+
+	doc, err := jsonc.Parse(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing MCP config")
+	}
+	config := &MCPConfig{doc: doc}
+	if _, err := doc.Get([]string{"servers"}, &config.Servers); err != nil {
+		return nil, errors.Wrap(err, "parsing MCP config")
+	}
+	if _, err := doc.Get([]string{"inputs"}, &config.Inputs); err != nil {
+		return nil, errors.Wrap(err, "parsing MCP config")
+	}
+
+	// Ensure the map is initialized
+	if config.Servers == nil {
+		config.Servers = make(map[string]*MCPServer)
+	}
+
+	// Populate Name field from map keys for consistency
+	for name, server := range config.Servers {
+		if server == nil {
+			server = &MCPServer{}
+			config.Servers[name] = server
+		}
+		server.Name = name
+	}
+
+	return config, nil
+}
+
+// saveConfig writes the MCP configuration to disk atomically. Only the
+// servers and inputs are rewritten; the rest of the file is kept as it was.
+func (m *MCPManager) saveConfig(config *MCPConfig) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+
+	doc := config.doc
+	if doc == nil {
+		doc, _ = jsonc.Parse(nil)
+	}
+	if err := doc.Set([]string{"servers"}, config.Servers); err != nil {
+		return errors.Wrap(err, "updating MCP config")
+	}
+	// An inputs list the file already has is kept, even when empty
+	if hasInputs, _ := doc.Get([]string{"inputs"}, new(json.RawMessage)); hasInputs || len(config.Inputs) > 0 {
+		inputs := config.Inputs
+		if inputs == nil {
+			inputs = []*MCPInput{}
+		}
+		if err := doc.Set([]string{"inputs"}, inputs); err != nil {
+			return errors.Wrap(err, "updating MCP config")
+		}
+	}
+
+	// Create parent directory if needed
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "creating directory %s", dir)
+	}
+
+	return errors.Wrap(fileutil.AtomicWriteFile(configPath, doc.Bytes(), 0o600), "writing MCP config")
+}
+
+// renderSecrets returns a copy of server with the secret references in its
+// env and headers rewritten for VS Code, and the inputs the rewritten
+// values reference.
+func renderSecrets(server *MCPServer) (*MCPServer, []*MCPInput, error) {
+	out := *server
+	var inputs []*MCPInput
+	var err error
+	if out.Env, err = renderSecretMap(server.Name, server.Env, &inputs); err != nil {
+		return nil, nil, errors.Wrapf(err, "MCP server %q env", server.Name)
+	}
+	if out.Headers, err = renderSecretMap(server.Name, server.Headers, &inputs); err != nil {
+		return nil, nil, errors.Wrapf(err, "MCP server %q headers", server.Name)
+	}
+	return &out, inputs, nil
+}
+
+// renderSecretMap rewrites the values of m for VS Code, replacing file and
+// command references with inputs, which it appends to inputs. The input
+// for a value is named <server>-<key>, with a suffix if the value has more
+// than one such reference.
+func renderSecretMap(serverName string, m map[string]string, inputs *[]*MCPInput) (map[string]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		n := 0
+		for _, ref := range secret.Refs(v) {
+			if ref.Kind == secret.KindEnv {
+				continue
+			}
+			n++
+			id := serverName + "-" + k
+			if n > 1 {
+				id += "-" + strconv.Itoa(n)
+			}
+			v = strings.Replace(v, ref.String(), inputRef(id), 1)
+			*inputs = append(*inputs, &MCPInput{
+				Type:        "promptString",
+				ID:          id,
+				Description: fmt.Sprintf("%s for MCP server %s", k, serverName),
+				Password:    true,
+			})
+		}
+
+		r, err := secret.Render(v, SecretSyntax)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving %s", k)
+		}
+		out[k] = r
+	}
+	return out, nil
+}
+
+// inputRef returns the reference to the input with the given id.
+func inputRef(id string) string {
+	return "${input:" + id + "}"
+}
+
+// pruneInputs removes the inputs that no server references.
+func pruneInputs(config *MCPConfig) {
+	data, err := json.Marshal(config.Servers)
+	if err != nil {
+		return
+	}
+	kept := config.Inputs[:0]
+	for _, input := range config.Inputs {
+		if strings.Contains(string(data), inputRef(input.ID)) {
+			kept = append(kept, input)
+		}
+	}
+	config.Inputs = kept
+}
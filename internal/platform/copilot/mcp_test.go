@@ -0,0 +1,221 @@
+package copilot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+func TestMCPManager(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewMCPManager(paths)
+
+	configPath := paths.MCPConfigPath()
+	initial := `{
+  "inputs": [{"type": "promptString", "id": "docs-key", "password": true}],
+  "servers": {"docs": {"type": "stdio", "command": "docs-server", "env": {"KEY": "${input:docs-key}"}}},
+  "sandbox": true
+}`
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	readConfig := func(t *testing.T) *MCPConfig {
+		t.Helper()
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var config MCPConfig
+		if err := json.Unmarshal(data, &config); err != nil {
+			t.Fatalf("Failed to unmarshal config: %v", err)
+		}
+		return &config
+	}
+
+	t.Run("Add preserves other settings", func(t *testing.T) {
+		err := mgr.Add(&MCPServer{
+			Name:    "github",
+			Type:    TypeHTTP,
+			URL:     "https://api.githubcopilot.com/mcp/",
+			Headers: map[string]string{"Authorization": "Bearer ${env:GITHUB_TOKEN}"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		config := readConfig(t)
+		if len(config.Servers) != 2 {
+			t.Errorf("servers has %d entries, want 2", len(config.Servers))
+		}
+		if len(config.Inputs) != 1 || config.Inputs[0].ID != "docs-key" {
+			t.Errorf("inputs = %+v, want the docs-key input kept", config.Inputs)
+		}
+		if _, ok := config.unknownFields["sandbox"]; !ok {
+			t.Error("unknown field sandbox was not preserved")
+		}
+		if got := config.Servers["github"].Headers["Authorization"]; got != "Bearer ${env:GITHUB_TOKEN}" {
+			t.Errorf("Authorization = %q", got)
+		}
+	})
+
+	t.Run("Add turns file secrets into inputs", func(t *testing.T) {
+		secretFile := filepath.Join(t.TempDir(), "token")
+		if err := os.WriteFile(secretFile, []byte("plaintext\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		err := mgr.Add(&MCPServer{
+			Name:    "jira",
+			Type:    TypeStdio,
+			Command: "jira-mcp",
+			Env:     map[string]string{"TOKEN": "${file:" + secretFile + "}"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		config := readConfig(t)
+		if got := config.Servers["jira"].Env["TOKEN"]; got != "${input:jira-TOKEN}" {
+			t.Errorf("Env[TOKEN] = %q, want ${input:jira-TOKEN}", got)
+		}
+		var found bool
+		for _, input := range config.Inputs {
+			if input.ID == "jira-TOKEN" {
+				found = input.Password && input.Type == "promptString"
+			}
+		}
+		if !found {
+			t.Errorf("inputs = %+v, want a password input jira-TOKEN", config.Inputs)
+		}
+	})
+
+	t.Run("Remove prunes unreferenced inputs", func(t *testing.T) {
+		if err := mgr.Remove("jira"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if err := mgr.Remove("docs"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		config := readConfig(t)
+		if len(config.Inputs) != 0 {
+			t.Errorf("inputs = %+v, want none", config.Inputs)
+		}
+		if _, err := mgr.Get("jira"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Get after Remove error = %v, want ErrMCPServerNotFound", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		servers, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(servers) != 1 || servers[0].Name != "github" {
+			t.Errorf("List() = %+v", servers)
+		}
+	})
+
+	t.Run("Enable and Disable", func(t *testing.T) {
+		if err := mgr.Enable("github"); err != nil {
+			t.Errorf("Enable failed: %v", err)
+		}
+		if err := mgr.Disable("github"); !errors.Is(err, ErrDisableNotSupported) {
+			t.Errorf("Disable error = %v, want ErrDisableNotSupported", err)
+		}
+		if err := mgr.Disable("missing"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Disable error = %v, want ErrMCPServerNotFound", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := mgr.Add(&MCPServer{}); !errors.Is(err, ErrInvalidMCPServer) {
+			t.Errorf("Add error = %v, want ErrInvalidMCPServer", err)
+		}
+	})
+}
+
+func TestMCPManager_KeepsCommentsAndUnknownFields(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewMCPManager(paths)
+
+	configPath := paths.MCPConfigPath()
+	initial := `// Shared with the team
+{
+  "inputs": [
+    {"type": "pickString", "id": "env", "options": ["dev", "prod"], "default": "dev"},
+    {"type": "command", "id": "token", "command": "secrets.get", "args": {"key": "api"}},
+  ],
+  "servers": {
+    "api": {
+      "command": "api-server",
+      "args": ["--env", "${input:env}"],
+      "env": {"TOKEN": "${input:token}"},
+      "envFile": "${workspaceFolder}/.env",
+      "dev": {"watch": "src/**/*.ts"}, // restart on change
+    },
+  },
+}
+`
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mgr.Get("api"); err != nil {
+		t.Fatalf("Get failed on a file with comments: %v", err)
+	}
+	if err := mgr.Add(&MCPServer{Name: "docs", Command: "docs-server"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	if !strings.HasPrefix(got, "// Shared with the team\n") {
+		t.Errorf("leading comment was not kept:\n%s", got)
+	}
+
+	var config MCPConfig
+	if err := json.Unmarshal(jsonc.Strip(data), &config); err != nil {
+		t.Fatalf("written config does not parse: %v\n%s", err, got)
+	}
+	api := config.Servers["api"]
+	if api == nil || api.EnvFile != "${workspaceFolder}/.env" {
+		t.Fatalf("servers.api = %+v, want envFile kept", api)
+	}
+	if compact(t, api.unknownFields["dev"]) != `{"watch":"src/**/*.ts"}` {
+		t.Errorf("servers.api.dev = %s, want it kept", api.unknownFields["dev"])
+	}
+	if len(config.Inputs) != 2 {
+		t.Fatalf("inputs = %+v, want both kept", config.Inputs)
+	}
+	if compact(t, config.Inputs[0].unknownFields["options"]) != `["dev","prod"]` || compact(t, config.Inputs[0].unknownFields["default"]) != `"dev"` {
+		t.Errorf("pickString input lost its fields: %v", config.Inputs[0].unknownFields)
+	}
+	if compact(t, config.Inputs[1].unknownFields["command"]) != `"secrets.get"` || compact(t, config.Inputs[1].unknownFields["args"]) != `{"key":"api"}` {
+		t.Errorf("command input lost its fields: %v", config.Inputs[1].unknownFields)
+	}
+}
+
+// compact returns raw without insignificant whitespace.
+func compact(t *testing.T, raw json.RawMessage) string {
+	t.Helper()
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		t.Fatalf("compacting %s: %v", raw, err)
+	}
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package copilot
+
+import (
+	"encoding/json"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+// MCPTranslator converts between canonical and VS Code MCP formats.
+//
+// VS Code uses a "servers" key with these differences from canonical:
+//   - "type" instead of "transport", with the same values
+//   - No "platforms" field (LOSSY: this field is not preserved)
+//   - No "disabled" field (LOSSY: disabled servers are written as enabled)
+//   - Name is stored as map key only, not inside server object
+type MCPTranslator struct{}
+
+// NewMCPTranslator creates a new VS Code MCP translator.
+func NewMCPTranslator() *MCPTranslator {
+	return &MCPTranslator{}
+}
+
+// ToCanonical converts VS Code MCP configuration to canonical format.
+// Comments and trailing commas are allowed, as in VS Code.
+//
+// Input format:
+//
+//	{"servers": {"name": {...}, ...}, "inputs": [...]}
+//
+// or just the servers map:
+//
+//	{"name": {...}, ...}
+func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
+	var vscodeConfig MCPConfig
+	if err := json.Unmarshal(jsonc.Strip(platformData), &vscodeConfig); err != nil {
+		return nil, errors.Wrap(err, "parsing VS Code MCP config")
+	}
+
+	// If servers is nil, try parsing as a bare servers map
+	if vscodeConfig.Servers == nil {
+		var servers map[string]*MCPServer
+		if err := json.Unmarshal(jsonc.Strip(platformData), &servers); err != nil {
+			return nil, errors.Wrap(err, "parsing VS Code MCP servers map")
+		}
+		vscodeConfig.Servers = servers
+	}
+
+	config := mcp.NewConfig()
+	for name, s := range vscodeConfig.Servers {
+		config.Servers[name] = CanonicalServer(name, s)
+	}
+	return config, nil
+}
+
+// CanonicalServer converts a VS Code MCP server to canonical form. Secret
+// references, including ${input:<id>}, are left in VS Code's syntax.
+func CanonicalServer(name string, s *MCPServer) *mcp.Server {
+	transport := s.Type
+	if transport == "" {
+		transport = mcp.TransportStdio
+		if s.URL != "" {
+			transport = mcp.TransportHTTP
+		}
+	}
+	return &mcp.Server{
+		Name:      name,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Transport: transport,
+		Env:       s.Env,
+		Headers:   s.Headers,
+	}
+}
+
+// FromCanonical converts canonical MCP configuration to VS Code format.
+//
+// Output format:
+//
+//	{"servers": {"name": {...}, ...}}
+//
+// NOTE: The Platforms and Disabled fields are NOT preserved.
+//
+// The output is formatted with 2-space indentation for readability.
+func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	vscodeConfig := &MCPConfig{
+		Servers: make(map[string]*MCPServer, len(cfg.Servers)),
+	}
+	for name, server := range cfg.Servers {
+		vscodeConfig.Servers[name] = &MCPServer{
+			Name:    name,
+			Type:    serverType(server),
+			Command: server.Command,
+			Args:    server.Args,
+			URL:     server.URL,
+			Env:     server.Env,
+			Headers: server.Headers,
+		}
+	}
+
+	data, err := json.MarshalIndent(vscodeConfig, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling VS Code MCP config")
+	}
+	return data, nil
+}
+
+// serverType returns the VS Code type of a canonical server, inferring it
+// when the transport is unset.
+func serverType(s *mcp.Server) string {
+	switch {
+	case s.Transport != "":
+		return s.Transport
+	case s.URL != "":
+		return TypeHTTP
+	default:
+		return TypeStdio
+	}
+}
+
+// Platform returns the platform identifier for this translator.
+func (t *MCPTranslator) Platform() string {
+	return "copilot"
+}
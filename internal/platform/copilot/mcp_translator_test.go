@@ -0,0 +1,78 @@
+package copilot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+func TestMCPTranslator_ToCanonical(t *testing.T) {
+	input := `{
+  "inputs": [{"type": "promptString", "id": "key", "password": true}],
+  "servers": {
+    "local": {"type": "stdio", "command": "node", "args": ["server.js"], "env": {"API_KEY": "${input:key}"}},
+    "events": {"type": "sse", "url": "https://example.com/sse"},
+    "untyped": {"url": "https://example.com/mcp"}
+  }
+}`
+	config, err := NewMCPTranslator().ToCanonical([]byte(input))
+	if err != nil {
+		t.Fatalf("ToCanonical failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		transport string
+	}{
+		{"local", mcp.TransportStdio},
+		{"events", mcp.TransportSSE},
+		{"untyped", mcp.TransportHTTP},
+	}
+	for _, tt := range tests {
+		s, ok := config.Servers[tt.name]
+		if !ok {
+			t.Fatalf("server %s not found", tt.name)
+		}
+		if s.Transport != tt.transport {
+			t.Errorf("%s transport = %q, want %q", tt.name, s.Transport, tt.transport)
+		}
+	}
+	if got := config.Servers["local"].Env["API_KEY"]; got != "${input:key}" {
+		t.Errorf("API_KEY = %q, want input reference kept", got)
+	}
+}
+
+func TestMCPTranslator_FromCanonical(t *testing.T) {
+	cfg := mcp.NewConfig()
+	cfg.Servers["local"] = &mcp.Server{Name: "local", Command: "node", Platforms: []string{"linux"}}
+	cfg.Servers["events"] = &mcp.Server{Name: "events", URL: "https://example.com/sse", Transport: mcp.TransportSSE}
+
+	data, err := NewMCPTranslator().FromCanonical(cfg)
+	if err != nil {
+		t.Fatalf("FromCanonical failed: %v", err)
+	}
+
+	var out MCPConfig
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if got := out.Servers["local"].Type; got != TypeStdio {
+		t.Errorf("local type = %q, want %q", got, TypeStdio)
+	}
+	if got := out.Servers["events"].Type; got != TypeSSE {
+		t.Errorf("events type = %q, want %q", got, TypeSSE)
+	}
+}
+
+func TestMCPTranslator_FromCanonical_Nil(t *testing.T) {
+	if _, err := NewMCPTranslator().FromCanonical(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+}
+
+func TestMCPTranslator_Platform(t *testing.T) {
+	if got := NewMCPTranslator().Platform(); got != "copilot" {
+		t.Errorf("Platform() = %q, want copilot", got)
+	}
+}
@@ -0,0 +1,156 @@
+// Package copilot provides GitHub Copilot specific configuration and path
+// handling for VS Code.
+//
+// VS Code spreads Copilot's configuration over several files. In a project,
+// MCP servers live in .vscode/mcp.json and everything else under .github/:
+// reusable prompts in prompts/*.prompt.md, custom chat modes in
+// chatmodes/*.chatmode.md, and instructions in copilot-instructions.md and
+// instructions/*.instructions.md. At user scope all of these sit in the VS
+// Code user profile, with the markdown files together in its prompts folder.
+//
+// aix installs commands as prompt files, agents as chat modes, and skills as
+// instructions files.
+package copilot
+
+import (
+	"path/filepath"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// Scope defines whether paths resolve to user-level or project-level configuration.
+type Scope int
+
+const (
+	// ScopeUser resolves paths relative to the VS Code user profile
+	ScopeUser Scope = iota
+	// ScopeProject resolves paths relative to <projectRoot>/
+	ScopeProject
+)
+
+// File extensions of the markdown files Copilot reads. They share a
+// directory at user scope and are told apart by extension.
+const (
+	InstructionsExt = ".instructions.md"
+	PromptExt       = ".prompt.md"
+	ChatModeExt     = ".chatmode.md"
+)
+
+// CopilotPaths provides Copilot-specific path resolution.
+// It wraps the generic paths package with Copilot-specific defaults.
+type CopilotPaths struct {
+	scope       Scope
+	projectRoot string
+}
+
+// NewCopilotPaths creates a new CopilotPaths instance.
+// For ScopeProject, projectRoot must be non-empty.
+// For ScopeUser, projectRoot is ignored.
+func NewCopilotPaths(scope Scope, projectRoot string) *CopilotPaths {
+	return &CopilotPaths{
+		scope:       scope,
+		projectRoot: projectRoot,
+	}
+}
+
+// BaseDir returns the base configuration directory.
+// For ScopeUser: the VS Code user profile (e.g. ~/.config/Code/User/)
+// For ScopeProject: <projectRoot>/
+// Returns empty string if projectRoot is empty for ScopeProject.
+func (p *CopilotPaths) BaseDir() string {
+	switch p.scope {
+	case ScopeUser:
+		return paths.GlobalConfigDir(paths.PlatformCopilot)
+	case ScopeProject:
+		return paths.ProjectConfigDir(paths.PlatformCopilot, p.projectRoot)
+	default:
+		return ""
+	}
+}
+
+// SkillDir returns the directory skills are installed to as instructions files.
+// For ScopeUser: <profile>/prompts/
+// For ScopeProject: <projectRoot>/.github/instructions/
+func (p *CopilotPaths) SkillDir() string {
+	return p.markdownDir("instructions")
+}
+
+// CommandDir returns the directory commands are installed to as prompt files.
+// For ScopeUser: <profile>/prompts/
+// For ScopeProject: <projectRoot>/.github/prompts/
+func (p *CopilotPaths) CommandDir() string {
+	return p.markdownDir("prompts")
+}
+
+// AgentDir returns the directory agents are installed to as chat modes.
+// For ScopeUser: <profile>/prompts/
+// For ScopeProject: <projectRoot>/.github/chatmodes/
+func (p *CopilotPaths) AgentDir() string {
+	return p.markdownDir("chatmodes")
+}
+
+// markdownDir returns the user profile's prompts folder, or the named
+// directory under .github/ for ScopeProject.
+func (p *CopilotPaths) markdownDir(projectDir string) string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	if p.scope == ScopeProject {
+		return filepath.Join(base, ".github", projectDir)
+	}
+	return filepath.Join(base, "prompts")
+}
+
+// MCPConfigPath returns the path to the MCP servers configuration file.
+// For ScopeUser: <profile>/mcp.json
+// For ScopeProject: <projectRoot>/.vscode/mcp.json
+func (p *CopilotPaths) MCPConfigPath() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	if p.scope == ScopeProject {
+		return filepath.Join(base, ".vscode", "mcp.json")
+	}
+	return filepath.Join(base, "mcp.json")
+}
+
+// InstructionsPath returns the path to the repository instructions file.
+// For ScopeProject: <projectRoot>/.github/copilot-instructions.md
+// For ScopeUser: empty, as VS Code keeps user instructions in its settings.
+func (p *CopilotPaths) InstructionsPath() string {
+	if p.scope != ScopeProject {
+		return ""
+	}
+	return paths.InstructionsPath(paths.PlatformCopilot, p.projectRoot)
+}
+
+// SkillPath returns the path to the instructions file a skill is installed as.
+// Returns <skills>/<name>.instructions.md
+// Returns empty string if name is empty.
+func (p *CopilotPaths) SkillPath(name string) string {
+	return joinName(p.SkillDir(), name, InstructionsExt)
+}
+
+// CommandPath returns the path to the prompt file a command is installed as.
+// Returns <commands>/<name>.prompt.md
+// Returns empty string if name is empty.
+func (p *CopilotPaths) CommandPath(name string) string {
+	return joinName(p.CommandDir(), name, PromptExt)
+}
+
+// AgentPath returns the path to the chat mode an agent is installed as.
+// Returns <agents>/<name>.chatmode.md
+// Returns empty string if name is empty.
+func (p *CopilotPaths) AgentPath(name string) string {
+	return joinName(p.AgentDir(), name, ChatModeExt)
+}
+
+// joinName returns dir/name+ext, or an empty string if dir or name is.
+func joinName(dir, name, ext string) string {
+	if dir == "" || name == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+ext)
+}
@@ -0,0 +1,74 @@
+package copilot
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCopilotPaths_Project(t *testing.T) {
+	root := filepath.Join("/tmp", "project")
+	p := NewCopilotPaths(ScopeProject, root)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"BaseDir", p.BaseDir(), root},
+		{"SkillDir", p.SkillDir(), filepath.Join(root, ".github", "instructions")},
+		{"CommandDir", p.CommandDir(), filepath.Join(root, ".github", "prompts")},
+		{"AgentDir", p.AgentDir(), filepath.Join(root, ".github", "chatmodes")},
+		{"MCPConfigPath", p.MCPConfigPath(), filepath.Join(root, ".vscode", "mcp.json")},
+		{"InstructionsPath", p.InstructionsPath(), filepath.Join(root, ".github", "copilot-instructions.md")},
+		{"SkillPath", p.SkillPath("go"), filepath.Join(root, ".github", "instructions", "go.instructions.md")},
+		{"CommandPath", p.CommandPath("review"), filepath.Join(root, ".github", "prompts", "review.prompt.md")},
+		{"AgentPath", p.AgentPath("planner"), filepath.Join(root, ".github", "chatmodes", "planner.chatmode.md")},
+		{"SkillPath empty name", p.SkillPath(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopilotPaths_User(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/custom/config")
+	p := NewCopilotPaths(ScopeUser, "")
+
+	base := p.BaseDir()
+	if base == "" {
+		t.Fatal("BaseDir() returned empty string")
+	}
+
+	// The markdown files share the profile's prompts folder
+	prompts := filepath.Join(base, "prompts")
+	for name, got := range map[string]string{
+		"SkillDir":   p.SkillDir(),
+		"CommandDir": p.CommandDir(),
+		"AgentDir":   p.AgentDir(),
+	} {
+		if got != prompts {
+			t.Errorf("%s = %v, want %v", name, got, prompts)
+		}
+	}
+	if got, want := p.MCPConfigPath(), filepath.Join(base, "mcp.json"); got != want {
+		t.Errorf("MCPConfigPath() = %v, want %v", got, want)
+	}
+	if got := p.InstructionsPath(); got != "" {
+		t.Errorf("InstructionsPath() = %q, want empty for user scope", got)
+	}
+}
+
+func TestCopilotPaths_EmptyProjectRoot(t *testing.T) {
+	p := NewCopilotPaths(ScopeProject, "")
+	if got := p.MCPConfigPath(); got != "" {
+		t.Errorf("MCPConfigPath() = %q, want empty", got)
+	}
+	if got := p.CommandDir(); got != "" {
+		t.Errorf("CommandDir() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,247 @@
+package copilot
+
+import (
+	"os"
+	"slices"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// CopilotPlatform provides the unified platform adapter for GitHub Copilot
+// in VS Code. It aggregates all Copilot-specific managers and provides a consistent
+// interface for the aix CLI.
+type CopilotPlatform struct {
+	paths    *CopilotPaths
+	skills   *SkillManager
+	commands *CommandManager
+	agents   *AgentManager
+	mcp      *MCPManager
+}
+
+// Option configures a CopilotPlatform instance.
+type Option func(*CopilotPlatform)
+
+// WithScope sets the scope (user or project) for path resolution.
+func WithScope(scope Scope) Option {
+	return func(p *CopilotPlatform) {
+		p.paths = NewCopilotPaths(scope, p.paths.projectRoot)
+	}
+}
+
+// WithProjectRoot sets the project root directory for project-scoped paths.
+func WithProjectRoot(root string) Option {
+	return func(p *CopilotPlatform) {
+		p.paths = NewCopilotPaths(p.paths.scope, root)
+	}
+}
+
+// NewCopilotPlatform creates a new CopilotPlatform with the given options.
+// Default configuration uses ScopeUser with no project root.
+func NewCopilotPlatform(opts ...Option) *CopilotPlatform {
+	p := &CopilotPlatform{
+		paths: NewCopilotPaths(ScopeUser, ""),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.skills = NewSkillManager(p.paths)
+	p.commands = NewCommandManager(p.paths)
+	p.agents = NewAgentManager(p.paths)
+	p.mcp = NewMCPManager(p.paths)
+
+	return p
+}
+
+// Name returns the platform identifier.
+func (p *CopilotPlatform) Name() string {
+	return "copilot"
+}
+
+// DisplayName returns a human-readable platform name.
+func (p *CopilotPlatform) DisplayName() string {
+	return "GitHub Copilot"
+}
+
+// --- Path Methods ---
+
+// GlobalConfigDir returns the VS Code user profile directory.
+func (p *CopilotPlatform) GlobalConfigDir() string {
+	return paths.GlobalConfigDir(paths.PlatformCopilot)
+}
+
+// ProjectConfigDir returns the project-scoped configuration directory.
+func (p *CopilotPlatform) ProjectConfigDir(projectRoot string) string {
+	return paths.ProjectConfigDir(paths.PlatformCopilot, projectRoot)
+}
+
+// SkillDir returns the directory skills are installed to as instructions files.
+func (p *CopilotPlatform) SkillDir() string {
+	return p.paths.SkillDir()
+}
+
+// CommandDir returns the directory commands are installed to as prompt files.
+func (p *CopilotPlatform) CommandDir() string {
+	return p.paths.CommandDir()
+}
+
+// AgentDir returns the directory agents are installed to as chat modes.
+func (p *CopilotPlatform) AgentDir() string {
+	return p.paths.AgentDir()
+}
+
+// MCPConfigPath returns the path to the MCP servers configuration file.
+func (p *CopilotPlatform) MCPConfigPath() string {
+	return p.paths.MCPConfigPath()
+}
+
+// InstructionsPath returns the path to the instructions file,
+// <projectRoot>/.github/copilot-instructions.md. VS Code has no user-level
+// instructions file.
+func (p *CopilotPlatform) InstructionsPath(projectRoot string) string {
+	if projectRoot != "" {
+		return NewCopilotPaths(ScopeProject, projectRoot).InstructionsPath()
+	}
+	return p.paths.InstructionsPath()
+}
+
+// --- Skill Operations ---
+
+// InstallSkill installs a skill as an instructions file.
+func (p *CopilotPlatform) InstallSkill(i *Instruction) error {
+	return p.skills.Install(i)
+}
+
+// UninstallSkill removes a skill by name.
+func (p *CopilotPlatform) UninstallSkill(name string) error {
+	return p.skills.Uninstall(name)
+}
+
+// ListSkills returns all installed skills.
+func (p *CopilotPlatform) ListSkills() ([]*Instruction, error) {
+	return p.skills.List()
+}
+
+// GetSkill retrieves a skill by name.
+func (p *CopilotPlatform) GetSkill(name string) (*Instruction, error) {
+	return p.skills.Get(name)
+}
+
+// --- Command Operations ---
+
+// InstallCommand installs a slash command as a prompt file.
+func (p *CopilotPlatform) InstallCommand(pr *Prompt) error {
+	return p.commands.Install(pr)
+}
+
+// UninstallCommand removes a command by name.
+func (p *CopilotPlatform) UninstallCommand(name string) error {
+	return p.commands.Uninstall(name)
+}
+
+// ListCommands returns all installed commands.
+func (p *CopilotPlatform) ListCommands() ([]*Prompt, error) {
+	return p.commands.List()
+}
+
+// GetCommand retrieves a command by name.
+func (p *CopilotPlatform) GetCommand(name string) (*Prompt, error) {
+	return p.commands.Get(name)
+}
+
+// --- Agent Operations ---
+
+// InstallAgent installs an agent as a chat mode.
+func (p *CopilotPlatform) InstallAgent(c *ChatMode) error {
+	return p.agents.Install(c)
+}
+
+// UninstallAgent removes an agent by name.
+func (p *CopilotPlatform) UninstallAgent(name string) error {
+	return p.agents.Uninstall(name)
+}
+
+// ListAgents returns all installed agents.
+func (p *CopilotPlatform) ListAgents() ([]*ChatMode, error) {
+	return p.agents.List()
+}
+
+// GetAgent retrieves an agent by name.
+func (p *CopilotPlatform) GetAgent(name string) (*ChatMode, error) {
+	return p.agents.Get(name)
+}
+
+// --- MCP Operations ---
+
+// AddMCP adds or updates an MCP server.
+func (p *CopilotPlatform) AddMCP(s *MCPServer) error {
+	return p.mcp.Add(s)
+}
+
+// RemoveMCP removes an MCP server by name.
+func (p *CopilotPlatform) RemoveMCP(name string) error {
+	return p.mcp.Remove(name)
+}
+
+// ListMCP returns all configured MCP servers.
+func (p *CopilotPlatform) ListMCP() ([]*MCPServer, error) {
+	return p.mcp.List()
+}
+
+// GetMCP retrieves an MCP server by name.
+func (p *CopilotPlatform) GetMCP(name string) (*MCPServer, error) {
+	return p.mcp.Get(name)
+}
+
+// EnableMCP enables an MCP server by name. Servers in mcp.json are always
+// enabled, so this only checks that the server exists.
+func (p *CopilotPlatform) EnableMCP(name string) error {
+	return p.mcp.Enable(name)
+}
+
+// DisableMCP returns ErrDisableNotSupported; see MCPManager.Disable.
+func (p *CopilotPlatform) DisableMCP(name string) error {
+	return p.mcp.Disable(name)
+}
+
+// --- Backup Methods ---
+
+// BackupPaths returns all config files/directories that should be backed up.
+// For GitHub Copilot, this includes:
+//   - mcp.json (MCP config): .vscode/mcp.json in a project
+//   - the directories of instructions files, prompt files, and chat modes,
+//     which are one prompts/ directory in the user profile
+//
+// The rest of the VS Code user profile is not aix's to back up.
+func (p *CopilotPlatform) BackupPaths() []string {
+	backup := []string{p.paths.MCPConfigPath()}
+	for _, dir := range []string{p.paths.SkillDir(), p.paths.CommandDir(), p.paths.AgentDir()} {
+		if !slices.Contains(backup, dir) {
+			backup = append(backup, dir)
+		}
+	}
+	return backup
+}
+
+// --- Status Methods ---
+
+// IsAvailable checks if VS Code is available on this system.
+// Returns true if the VS Code user profile directory exists.
+func (p *CopilotPlatform) IsAvailable() bool {
+	globalDir := p.GlobalConfigDir()
+	if globalDir == "" {
+		return false
+	}
+	info, err := os.Stat(globalDir)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
+
+// Version returns the VS Code version.
+// Currently returns an empty string as version detection is not yet implemented.
+func (p *CopilotPlatform) Version() (string, error) {
+	return "", nil
+}
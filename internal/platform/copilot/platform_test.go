@@ -0,0 +1,47 @@
+package copilot
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCopilotPlatform_BackupPaths(t *testing.T) {
+	root := t.TempDir()
+	p := NewCopilotPlatform(WithScope(ScopeProject), WithProjectRoot(root))
+
+	want := []string{
+		filepath.Join(root, ".vscode", "mcp.json"),
+		filepath.Join(root, ".github", "instructions"),
+		filepath.Join(root, ".github", "prompts"),
+		filepath.Join(root, ".github", "chatmodes"),
+	}
+	got := p.BackupPaths()
+	if len(got) != len(want) {
+		t.Fatalf("BackupPaths() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("BackupPaths()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCopilotPlatform_BackupPaths_User(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	p := NewCopilotPlatform()
+
+	// Skills, commands, and agents share the profile's prompts folder
+	if got := p.BackupPaths(); len(got) != 2 {
+		t.Errorf("BackupPaths() = %v, want mcp.json and the prompts folder", got)
+	}
+}
+
+func TestCopilotPlatform_Name(t *testing.T) {
+	p := NewCopilotPlatform()
+	if p.Name() != "copilot" {
+		t.Errorf("Name() = %q, want copilot", p.Name())
+	}
+	if p.DisplayName() != "GitHub Copilot" {
+		t.Errorf("DisplayName() = %q, want GitHub Copilot", p.DisplayName())
+	}
+}
@@ -0,0 +1,51 @@
+package copilot
+
+import "github.com/thoreinstein/aix/internal/errors"
+
+// Sentinel errors for skill operations.
+var (
+	ErrSkillNotFound = errors.New("skill not found")
+	ErrInvalidSkill  = errors.New("invalid skill: name required")
+)
+
+// SkillManager provides CRUD operations for skills, which are stored as
+// Copilot instructions files.
+type SkillManager struct {
+	store *markdownStore[Instruction, *Instruction]
+}
+
+// NewSkillManager creates a new SkillManager with the given paths configuration.
+func NewSkillManager(paths *CopilotPaths) *SkillManager {
+	return &SkillManager{
+		store: &markdownStore[Instruction, *Instruction]{
+			dir:      paths.SkillDir,
+			ext:      InstructionsExt,
+			notFound: ErrSkillNotFound,
+			invalid:  ErrInvalidSkill,
+		},
+	}
+}
+
+// List returns all instructions files, without their content.
+// Returns an empty slice if the directory doesn't exist.
+func (m *SkillManager) List() ([]*Instruction, error) {
+	return m.store.list()
+}
+
+// Get retrieves a skill by name.
+// Returns ErrSkillNotFound if the instructions file doesn't exist.
+func (m *SkillManager) Get(name string) (*Instruction, error) {
+	return m.store.get(name)
+}
+
+// Install writes a skill to disk as an instructions file.
+// Overwrites any existing skill with the same name.
+func (m *SkillManager) Install(i *Instruction) error {
+	return m.store.install(i)
+}
+
+// Uninstall removes a skill by name.
+// This operation is idempotent; removing a non-existent skill returns nil.
+func (m *SkillManager) Uninstall(name string) error {
+	return m.store.uninstall(name)
+}
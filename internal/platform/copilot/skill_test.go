@@ -0,0 +1,52 @@
+package copilot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSkillManager(t *testing.T) {
+	paths := NewCopilotPaths(ScopeProject, t.TempDir())
+	mgr := NewSkillManager(paths)
+
+	skill := &Instruction{
+		Name:         "go-style",
+		Description:  "Go style guide",
+		Instructions: "Use gofmt.",
+	}
+
+	t.Run("Install and Get", func(t *testing.T) {
+		if err := mgr.Install(skill); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+		got, err := mgr.Get("go-style")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Description != skill.Description || got.Instructions != skill.Instructions {
+			t.Errorf("Get() = %+v, want %+v", got, skill)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		skills, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(skills) != 1 || skills[0].Name != "go-style" || skills[0].Description != "Go style guide" {
+			t.Errorf("List() = %+v", skills)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("go-style"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("go-style"); !errors.Is(err, ErrSkillNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrSkillNotFound", err)
+		}
+		if err := mgr.Uninstall("go-style"); err != nil {
+			t.Errorf("second Uninstall failed: %v", err)
+		}
+	})
+}
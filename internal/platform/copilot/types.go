@@ -0,0 +1,297 @@
+package copilot
+
+import (
+	"encoding/json"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+// Server types in VS Code's mcp.json.
+const (
+	TypeStdio = "stdio"
+	TypeHTTP  = "http"
+	TypeSSE   = "sse"
+)
+
+// MCPServer represents an MCP server configuration in VS Code's mcp.json.
+type MCPServer struct {
+	// Name is the server's identifier, populated from the map key when loading.
+	// Not serialized to JSON as it's the map key itself.
+	Name string `json:"-"`
+
+	// Type is the transport: "stdio", "http", or "sse".
+	Type string `json:"type,omitempty"`
+
+	// Command is the executable to run for stdio transport.
+	Command string `json:"command,omitempty"`
+
+	// Args are command-line arguments passed to the command.
+	Args []string `json:"args,omitempty"`
+
+	// Env contains environment variables passed to the server process.
+	Env map[string]string `json:"env,omitempty"`
+
+	// EnvFile is a file of environment variables to load for stdio transport.
+	EnvFile string `json:"envFile,omitempty"`
+
+	// URL is the server endpoint for http and sse transport.
+	URL string `json:"url,omitempty"`
+
+	// Headers contains HTTP headers for remote transport connections.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// unknownFields stores JSON fields not explicitly defined in this struct,
+	// such as "dev", so that rewriting mcp.json keeps them.
+	unknownFields map[string]json.RawMessage
+}
+
+// mcpServerFields are the keys of the fields MCPServer defines.
+var mcpServerFields = []string{"type", "command", "args", "env", "envFile", "url", "headers"}
+
+// MarshalJSON implements json.Marshaler to include unknown fields in output.
+func (s *MCPServer) MarshalJSON() ([]byte, error) {
+	type server MCPServer
+	return marshalWithUnknown((*server)(s), s.unknownFields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler to capture unknown fields.
+func (s *MCPServer) UnmarshalJSON(data []byte) error {
+	type server MCPServer
+	unknown, err := unmarshalWithUnknown(data, (*server)(s), mcpServerFields)
+	if err != nil {
+		return errors.Wrap(err, "unmarshaling server")
+	}
+	s.unknownFields = unknown
+	return nil
+}
+
+// MCPInput is a value VS Code prompts for the first time a server that
+// references it as ${input:<id>} starts, and then stores securely.
+type MCPInput struct {
+	// Type is the kind of input; aix writes "promptString".
+	Type string `json:"type"`
+
+	// ID is the name servers reference the input by.
+	ID string `json:"id"`
+
+	// Description is shown in the prompt.
+	Description string `json:"description,omitempty"`
+
+	// Password hides the value as it is typed.
+	Password bool `json:"password,omitempty"`
+
+	// unknownFields stores JSON fields not explicitly defined in this struct,
+	// such as the "options" of a pickString input.
+	unknownFields map[string]json.RawMessage
+}
+
+// mcpInputFields are the keys of the fields MCPInput defines.
+var mcpInputFields = []string{"type", "id", "description", "password"}
+
+// MarshalJSON implements json.Marshaler to include unknown fields in output.
+func (in *MCPInput) MarshalJSON() ([]byte, error) {
+	type input MCPInput
+	return marshalWithUnknown((*input)(in), in.unknownFields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler to capture unknown fields.
+func (in *MCPInput) UnmarshalJSON(data []byte) error {
+	type input MCPInput
+	unknown, err := unmarshalWithUnknown(data, (*input)(in), mcpInputFields)
+	if err != nil {
+		return errors.Wrap(err, "unmarshaling input")
+	}
+	in.unknownFields = unknown
+	return nil
+}
+
+// marshalWithUnknown marshals v, a struct without a MarshalJSON method,
+// adding the unknown fields it does not set itself.
+func marshalWithUnknown(v any, unknown map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling known fields")
+	}
+	if len(unknown) == 0 {
+		return data, nil
+	}
+
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling known fields")
+	}
+	// Known fields take precedence
+	for k, raw := range unknown {
+		if _, ok := result[k]; !ok {
+			result[k] = raw
+		}
+	}
+
+	data, err = json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling result")
+	}
+	return data, nil
+}
+
+// unmarshalWithUnknown decodes data into v, a struct without an
+// UnmarshalJSON method, and returns the fields whose keys are not in known.
+func unmarshalWithUnknown(data []byte, v any, known []string) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		delete(raw, k)
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	return raw, nil
+}
+
+// MCPConfig represents the root structure of VS Code's mcp.json file.
+// It preserves unknown fields for forward compatibility with future versions.
+type MCPConfig struct {
+	// Servers maps server names to their configurations.
+	Servers map[string]*MCPServer `json:"servers"`
+
+	// Inputs are the values servers can reference as ${input:<id>}.
+	Inputs []*MCPInput `json:"inputs,omitempty"`
+
+	// unknownFields stores any JSON fields not explicitly defined in this struct.
+	unknownFields map[string]json.RawMessage
+
+	// doc is the file the config was loaded from. Saving edits the servers
+	// and inputs in it, keeping its comments and other settings.
+	doc *jsonc.Document
+}
+
+// MarshalJSON implements json.Marshaler to include unknown fields in output.
+func (c *MCPConfig) MarshalJSON() ([]byte, error) {
+	result := make(map[string]any)
+
+	// Copy unknown fields first (so known fields take precedence)
+	for k, v := range c.unknownFields {
+		var val any
+		if err := json.Unmarshal(v, &val); err != nil {
+			return nil, errors.Wrap(err, "unmarshaling unknown field")
+		}
+		result[k] = val
+	}
+
+	result["servers"] = c.Servers
+	if len(c.Inputs) > 0 {
+		result["inputs"] = c.Inputs
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling result")
+	}
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler to capture unknown fields.
+func (c *MCPConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "unmarshaling raw config")
+	}
+
+	if serversData, ok := raw["servers"]; ok {
+		if err := json.Unmarshal(serversData, &c.Servers); err != nil {
+			return errors.Wrap(err, "unmarshaling servers")
+		}
+		delete(raw, "servers")
+
+		for name, server := range c.Servers {
+			if server != nil {
+				server.Name = name
+			}
+		}
+	}
+
+	if inputsData, ok := raw["inputs"]; ok {
+		if err := json.Unmarshal(inputsData, &c.Inputs); err != nil {
+			return errors.Wrap(err, "unmarshaling inputs")
+		}
+		delete(raw, "inputs")
+	}
+
+	if len(raw) > 0 {
+		c.unknownFields = raw
+	}
+
+	return nil
+}
+
+// Instruction represents a Copilot instructions file. Copilot adds it to
+// requests about files matching ApplyTo, or when it is attached by hand.
+// Skills are installed as instructions files.
+type Instruction struct {
+	// Name is the file's identifier, derived from its file name.
+	Name string `yaml:"-" json:"name"`
+
+	// Description explains what the instructions are for.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// ApplyTo is a glob of the files the instructions apply to automatically.
+	ApplyTo string `yaml:"applyTo,omitempty" json:"applyTo,omitempty"`
+
+	// Instructions contains the markdown body content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
+
+// Prompt represents a Copilot prompt file, run in chat as /name.
+// Commands are installed as prompt files.
+type Prompt struct {
+	// Name is the prompt's identifier, derived from its file name.
+	Name string `yaml:"-" json:"name"`
+
+	// Description explains what the prompt does.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// ArgumentHint is shown in the chat input after /name.
+	ArgumentHint string `yaml:"argument-hint,omitempty" json:"argumentHint,omitempty"`
+
+	// Mode is the chat mode the prompt runs in: "ask", "edit", "agent", or
+	// the name of a custom chat mode.
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty"`
+
+	// Model overrides the model selected in chat.
+	Model string `yaml:"model,omitempty" json:"model,omitempty"`
+
+	// Tools lists the tools available while the prompt runs.
+	Tools []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+
+	// Instructions contains the prompt's markdown content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
+
+// ChatMode represents a custom Copilot chat mode: a persona with its own
+// instructions and tools, selected from the chat view. Agents are installed
+// as chat modes.
+type ChatMode struct {
+	// Name is the chat mode's identifier, derived from its file name.
+	Name string `yaml:"-" json:"name"`
+
+	// Description is shown in the chat mode picker.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Model overrides the model selected in chat.
+	Model string `yaml:"model,omitempty" json:"model,omitempty"`
+
+	// Tools lists the tools available in the chat mode.
+	Tools []string `yaml:"tools,omitempty" json:"tools,omitempty"`
+
+	// Instructions contains the chat mode's markdown content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
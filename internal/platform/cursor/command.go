@@ -0,0 +1,152 @@
+package cursor
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// Sentinel errors for command operations.
+var (
+	// ErrCommandNotFound indicates the requested command does not exist.
+	ErrCommandNotFound = errors.New("command not found")
+
+	// ErrInvalidCommand indicates the command is missing required fields.
+	ErrInvalidCommand = errors.New("invalid command: name required")
+)
+
+// CommandManager provides CRUD operations for Cursor slash commands.
+// Commands are stored as markdown files in the commands directory.
+type CommandManager struct {
+	paths *CursorPaths
+}
+
+// NewCommandManager creates a new CommandManager with the given paths configuration.
+func NewCommandManager(paths *CursorPaths) *CommandManager {
+	return &CommandManager{
+		paths: paths,
+	}
+}
+
+// List returns all commands in the commands directory.
+// Returns an empty slice if the directory doesn't exist or contains no .md files.
+func (m *CommandManager) List() ([]*Command, error) {
+	cmdDir := m.paths.CommandDir()
+	if cmdDir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(cmdDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading commands directory")
+	}
+
+	commands := make([]*Command, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".md")
+		f, err := os.Open(m.paths.CommandPath(name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening command file %q", name)
+		}
+
+		cmd := &Command{}
+		err = frontmatter.ParseHeader(f, cmd)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing command header %q", name)
+		}
+		cmd.Name = name
+
+		commands = append(commands, cmd)
+	}
+
+	return commands, nil
+}
+
+// Get retrieves a command by name.
+// Returns ErrCommandNotFound if the command file doesn't exist.
+func (m *CommandManager) Get(name string) (*Command, error) {
+	if name == "" {
+		return nil, ErrInvalidCommand
+	}
+
+	cmdPath := m.paths.CommandPath(name)
+	if cmdPath == "" {
+		return nil, ErrCommandNotFound
+	}
+
+	data, err := os.ReadFile(cmdPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrCommandNotFound
+		}
+		return nil, errors.Wrap(err, "reading command file")
+	}
+
+	cmd := &Command{}
+	body, err := frontmatter.Parse(bytes.NewReader(data), cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing command file")
+	}
+	cmd.Instructions = strings.TrimSpace(string(body))
+
+	// Name is derived from filename
+	cmd.Name = name
+	return cmd, nil
+}
+
+// Install writes a command to disk as plain markdown.
+// Creates the commands directory if it doesn't exist.
+// Overwrites any existing command with the same name.
+func (m *CommandManager) Install(c *Command) error {
+	if c == nil || c.Name == "" {
+		return ErrInvalidCommand
+	}
+
+	cmdDir := m.paths.CommandDir()
+	if cmdDir == "" {
+		return errors.New("command directory path is empty")
+	}
+
+	if err := os.MkdirAll(cmdDir, 0o755); err != nil {
+		return errors.Wrap(err, "creating commands directory")
+	}
+
+	content := strings.TrimSpace(c.Instructions) + "\n"
+	if err := fileutil.AtomicWriteFile(m.paths.CommandPath(c.Name), []byte(content), 0o644); err != nil {
+		return errors.Wrap(err, "writing command file")
+	}
+
+	return nil
+}
+
+// Uninstall removes a command from disk.
+// This operation is idempotent; removing a non-existent command returns nil.
+func (m *CommandManager) Uninstall(name string) error {
+	if name == "" {
+		return ErrInvalidCommand
+	}
+
+	cmdPath := m.paths.CommandPath(name)
+	if cmdPath == "" {
+		return nil
+	}
+
+	if err := os.Remove(cmdPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "removing command file")
+	}
+
+	return nil
+}
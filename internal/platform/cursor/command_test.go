@@ -0,0 +1,78 @@
+package cursor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommandManager(t *testing.T) {
+	paths := NewCursorPaths(ScopeProject, t.TempDir())
+	mgr := NewCommandManager(paths)
+
+	cmd := &Command{
+		Name:         "review",
+		Description:  "Review a file",
+		Instructions: "Review the selected code.",
+	}
+
+	t.Run("Install writes plain markdown", func(t *testing.T) {
+		if err := mgr.Install(cmd); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.CommandPath(cmd.Name))
+		if err != nil {
+			t.Fatalf("Failed to read command file: %v", err)
+		}
+		if got, want := string(data), "Review the selected code.\n"; got != want {
+			t.Errorf("command file = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("review")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Name != "review" || got.Instructions != cmd.Instructions {
+			t.Errorf("Get() = %+v", got)
+		}
+	})
+
+	t.Run("List reads hand-written frontmatter", func(t *testing.T) {
+		content := "---\ndescription: Ship it\n---\nDeploy to production.\n"
+		if err := os.WriteFile(filepath.Join(paths.CommandDir(), "deploy.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cmds, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(cmds) != 2 {
+			t.Fatalf("Expected 2 commands, got %d", len(cmds))
+		}
+		for _, c := range cmds {
+			if c.Name == "deploy" && c.Description != "Ship it" {
+				t.Errorf("deploy description = %q, want %q", c.Description, "Ship it")
+			}
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := mgr.Uninstall("review"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := mgr.Get("review"); !errors.Is(err, ErrCommandNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrCommandNotFound", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := mgr.Install(&Command{}); !errors.Is(err, ErrInvalidCommand) {
+			t.Errorf("Install error = %v, want ErrInvalidCommand", err)
+		}
+	})
+}
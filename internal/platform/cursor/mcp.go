@@ -0,0 +1,202 @@
+package cursor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// Sentinel errors for MCP operations.
+var (
+	ErrMCPServerNotFound = errors.New("MCP server not found")
+	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
+)
+
+// SecretSyntax is how Cursor interpolates environment variables in MCP
+// server env and header values. Other secret references are resolved when a
+// server is added.
+var SecretSyntax = secret.Syntax{Env: "${env:%s}"}
+
+// MCPManager provides CRUD operations for MCP server configurations.
+type MCPManager struct {
+	paths *CursorPaths
+}
+
+// NewMCPManager creates a new MCPManager instance.
+func NewMCPManager(paths *CursorPaths) *MCPManager {
+	return &MCPManager{
+		paths: paths,
+	}
+}
+
+// List returns all MCP servers from the configuration file.
+// Returns an empty slice if the config file does not exist.
+// The returned servers are sorted by name for deterministic ordering.
+func (m *MCPManager) List() ([]*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*MCPServer, 0, len(config.MCPServers))
+	for _, server := range config.MCPServers {
+		servers = append(servers, server)
+	}
+
+	// Sort by name for deterministic ordering
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+// Get returns a single MCP server by name.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Get(name string) (*MCPServer, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	server, ok := config.MCPServers[name]
+	if !ok {
+		return nil, ErrMCPServerNotFound
+	}
+
+	return server, nil
+}
+
+// Add adds or updates an MCP server in the configuration.
+// Returns ErrInvalidMCPServer if the server name is empty.
+func (m *MCPManager) Add(server *MCPServer) error {
+	if server == nil || server.Name == "" {
+		return ErrInvalidMCPServer
+	}
+
+	server, err := renderSecrets(server)
+	if err != nil {
+		return err
+	}
+
+	return m.update(func(config *MCPConfig) error {
+		config.MCPServers[server.Name] = server
+		return nil
+	})
+}
+
+// Remove removes an MCP server from the configuration by name.
+// This operation is idempotent - removing a non-existent server does not error.
+func (m *MCPManager) Remove(name string) error {
+	return m.update(func(config *MCPConfig) error {
+		delete(config.MCPServers, name)
+		return nil
+	})
+}
+
+// Enable sets Disabled=false for the specified server.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Enable(name string) error {
+	return m.setDisabled(name, false)
+}
+
+// Disable sets Disabled=true for the specified server.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Disable(name string) error {
+	return m.setDisabled(name, true)
+}
+
+// setDisabled is a helper to toggle the Disabled field.
+func (m *MCPManager) setDisabled(name string, disabled bool) error {
+	return m.update(func(config *MCPConfig) error {
+		server, ok := config.MCPServers[name]
+		if !ok {
+			return ErrMCPServerNotFound
+		}
+
+		server.Disabled = disabled
+		return nil
+	})
+}
+
+// update applies fn to the MCP configuration and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(config *MCPConfig) error) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+	return fileutil.Update(configPath, m.loadConfig, fn, m.saveConfig)
+}
+
+// loadConfig reads the MCP configuration from disk.
+// Returns an empty config with initialized MCPServers map if the file doesn't exist.
+func (m *MCPManager) loadConfig() (*MCPConfig, error) {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return nil, errors.New("MCP config path not configured")
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Return empty config if file doesn't exist
+			return &MCPConfig{
+				MCPServers: make(map[string]*MCPServer),
+			}, nil
+		}
+		return nil, errors.Wrap(err, "reading MCP config")
+	}
+
+	var config MCPConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "parsing MCP config")
+	}
+
+	// Ensure the map is initialized
+	if config.MCPServers == nil {
+		config.MCPServers = make(map[string]*MCPServer)
+	}
+
+	// Populate Name field from map keys for consistency
+	for name, server := range config.MCPServers {
+		server.Name = name
+	}
+
+	return &config, nil
+}
+
+// saveConfig writes the MCP configuration to disk atomically.
+func (m *MCPManager) saveConfig(config *MCPConfig) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+
+	// Create parent directory if needed
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "creating directory %s", dir)
+	}
+
+	return errors.Wrap(fileutil.AtomicWriteJSON(configPath, config), "writing MCP config")
+}
+
+// renderSecrets returns a copy of server with the secret references in its
+// env and headers rewritten for Cursor.
+func renderSecrets(server *MCPServer) (*MCPServer, error) {
+	out := *server
+	var err error
+	if out.Env, err = secret.RenderMap(server.Env, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q env", server.Name)
+	}
+	if out.Headers, err = secret.RenderMap(server.Headers, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q headers", server.Name)
+	}
+	return &out, nil
+}
@@ -0,0 +1,103 @@
+package cursor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMCPManager(t *testing.T) {
+	paths := NewCursorPaths(ScopeProject, t.TempDir())
+	mgr := NewMCPManager(paths)
+
+	configPath := paths.MCPConfigPath()
+	initial := `{"mcpServers": {"existing": {"command": "docs-server"}}, "theme": "dark"}`
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Add preserves other settings", func(t *testing.T) {
+		err := mgr.Add(&MCPServer{
+			Name:    "github",
+			Command: "npx",
+			Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+			Env:     map[string]string{"GITHUB_TOKEN": "${env:GITHUB_TOKEN}"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw map[string]any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatalf("Failed to unmarshal config: %v", err)
+		}
+		if raw["theme"] != "dark" {
+			t.Errorf("theme = %v, want dark", raw["theme"])
+		}
+		servers, _ := raw["mcpServers"].(map[string]any)
+		if len(servers) != 2 {
+			t.Errorf("mcpServers has %d entries, want 2", len(servers))
+		}
+	})
+
+	t.Run("Get renders env references", func(t *testing.T) {
+		got, err := mgr.Get("github")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Env["GITHUB_TOKEN"] != "${env:GITHUB_TOKEN}" {
+			t.Errorf("Env[GITHUB_TOKEN] = %q", got.Env["GITHUB_TOKEN"])
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		servers, err := mgr.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(servers) != 2 || servers[0].Name != "existing" || servers[1].Name != "github" {
+			t.Errorf("List() = %+v", servers)
+		}
+	})
+
+	t.Run("Disable and Enable", func(t *testing.T) {
+		if err := mgr.Disable("github"); err != nil {
+			t.Fatalf("Disable failed: %v", err)
+		}
+		got, _ := mgr.Get("github")
+		if !got.Disabled {
+			t.Error("server not disabled")
+		}
+		if err := mgr.Enable("github"); err != nil {
+			t.Fatalf("Enable failed: %v", err)
+		}
+		got, _ = mgr.Get("github")
+		if got.Disabled {
+			t.Error("server still disabled")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		if err := mgr.Remove("github"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if _, err := mgr.Get("github"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Get after Remove error = %v, want ErrMCPServerNotFound", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := mgr.Add(&MCPServer{}); !errors.Is(err, ErrInvalidMCPServer) {
+			t.Errorf("Add error = %v, want ErrInvalidMCPServer", err)
+		}
+	})
+}
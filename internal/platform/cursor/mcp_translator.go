@@ -0,0 +1,114 @@
+package cursor
+
+import (
+	"encoding/json"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+// MCPTranslator converts between canonical and Cursor MCP formats.
+//
+// Cursor uses a "mcpServers" key with these differences from canonical:
+//   - No "transport" field: a command means stdio, a url means remote
+//   - No distinction between "http" and "sse" (LOSSY: remote servers read back as "http")
+//   - No "platforms" field (LOSSY: this field is not preserved)
+//   - Name is stored as map key only, not inside server object
+type MCPTranslator struct{}
+
+// NewMCPTranslator creates a new Cursor MCP translator.
+func NewMCPTranslator() *MCPTranslator {
+	return &MCPTranslator{}
+}
+
+// ToCanonical converts Cursor MCP configuration to canonical format.
+//
+// Input format:
+//
+//	{"mcpServers": {"name": {...}, ...}}
+//
+// or just the servers map:
+//
+//	{"name": {...}, ...}
+func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
+	var cursorConfig MCPConfig
+	if err := json.Unmarshal(platformData, &cursorConfig); err != nil {
+		return nil, errors.Wrap(err, "parsing Cursor MCP config")
+	}
+
+	// If mcpServers is nil, try parsing as a bare servers map
+	if cursorConfig.MCPServers == nil {
+		var servers map[string]*MCPServer
+		if err := json.Unmarshal(platformData, &servers); err != nil {
+			return nil, errors.Wrap(err, "parsing Cursor MCP servers map")
+		}
+		cursorConfig.MCPServers = servers
+	}
+
+	config := mcp.NewConfig()
+	for name, s := range cursorConfig.MCPServers {
+		config.Servers[name] = CanonicalServer(name, s)
+	}
+	return config, nil
+}
+
+// CanonicalServer converts a Cursor MCP server to canonical form. Secret
+// references are left in Cursor's syntax.
+func CanonicalServer(name string, s *MCPServer) *mcp.Server {
+	transport := mcp.TransportStdio
+	if s.URL != "" {
+		transport = mcp.TransportHTTP
+	}
+	return &mcp.Server{
+		Name:      name,
+		Command:   s.Command,
+		Args:      s.Args,
+		URL:       s.URL,
+		Transport: transport,
+		Env:       s.Env,
+		Headers:   s.Headers,
+		Disabled:  s.Disabled,
+	}
+}
+
+// FromCanonical converts canonical MCP configuration to Cursor format.
+//
+// Output format:
+//
+//	{"mcpServers": {"name": {...}, ...}}
+//
+// NOTE: The Platforms field and the choice between "http" and "sse" are
+// NOT preserved; Cursor negotiates the remote transport itself.
+//
+// The output is formatted with 2-space indentation for readability.
+func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+
+	cursorConfig := &MCPConfig{
+		MCPServers: make(map[string]*MCPServer, len(cfg.Servers)),
+	}
+	for name, server := range cfg.Servers {
+		cursorConfig.MCPServers[name] = &MCPServer{
+			Name:     name,
+			Command:  server.Command,
+			Args:     server.Args,
+			URL:      server.URL,
+			Env:      server.Env,
+			Headers:  server.Headers,
+			Disabled: server.Disabled,
+		}
+	}
+
+	data, err := json.MarshalIndent(cursorConfig, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling Cursor MCP config")
+	}
+	return data, nil
+}
+
+// Platform returns the platform identifier for this translator.
+func (t *MCPTranslator) Platform() string {
+	return "cursor"
+}
@@ -0,0 +1,91 @@
+package cursor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+func TestMCPTranslator_ToCanonical(t *testing.T) {
+	translator := NewMCPTranslator()
+
+	input := `{
+  "mcpServers": {
+    "local": {"command": "node", "args": ["server.js"], "env": {"API_KEY": "secret"}},
+    "remote": {"url": "https://example.com/mcp", "headers": {"X-Team": "core"}, "disabled": true}
+  }
+}`
+	config, err := translator.ToCanonical([]byte(input))
+	if err != nil {
+		t.Fatalf("ToCanonical failed: %v", err)
+	}
+
+	local, ok := config.Servers["local"]
+	if !ok {
+		t.Fatal("server local not found")
+	}
+	if local.Command != "node" || len(local.Args) != 1 || local.Env["API_KEY"] != "secret" {
+		t.Errorf("local server not translated: %+v", local)
+	}
+	if local.Transport != mcp.TransportStdio {
+		t.Errorf("local transport = %q, want %q", local.Transport, mcp.TransportStdio)
+	}
+
+	remote, ok := config.Servers["remote"]
+	if !ok {
+		t.Fatal("server remote not found")
+	}
+	if remote.Transport != mcp.TransportHTTP || !remote.Disabled || remote.Headers["X-Team"] != "core" {
+		t.Errorf("remote server not translated: %+v", remote)
+	}
+}
+
+func TestMCPTranslator_ToCanonical_BareMap(t *testing.T) {
+	config, err := NewMCPTranslator().ToCanonical([]byte(`{"local": {"command": "node"}}`))
+	if err != nil {
+		t.Fatalf("ToCanonical failed: %v", err)
+	}
+	if s, ok := config.Servers["local"]; !ok || s.Command != "node" {
+		t.Errorf("Servers = %+v", config.Servers)
+	}
+}
+
+func TestMCPTranslator_FromCanonical(t *testing.T) {
+	cfg := mcp.NewConfig()
+	cfg.Servers["remote"] = &mcp.Server{
+		Name:      "remote",
+		URL:       "https://example.com/sse",
+		Transport: mcp.TransportSSE,
+		Platforms: []string{"darwin"},
+	}
+
+	data, err := NewMCPTranslator().FromCanonical(cfg)
+	if err != nil {
+		t.Fatalf("FromCanonical failed: %v", err)
+	}
+
+	var out MCPConfig
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	s, ok := out.MCPServers["remote"]
+	if !ok {
+		t.Fatal("server remote not found")
+	}
+	if s.URL != "https://example.com/sse" {
+		t.Errorf("URL = %q", s.URL)
+	}
+}
+
+func TestMCPTranslator_FromCanonical_Nil(t *testing.T) {
+	if _, err := NewMCPTranslator().FromCanonical(nil); err == nil {
+		t.Error("expected error for nil config")
+	}
+}
+
+func TestMCPTranslator_Platform(t *testing.T) {
+	if got := NewMCPTranslator().Platform(); got != "cursor" {
+		t.Errorf("Platform() = %q, want cursor", got)
+	}
+}
@@ -0,0 +1,138 @@
+// Package cursor provides Cursor specific configuration and path handling.
+//
+// Cursor keeps MCP servers in mcp.json and project knowledge in rules:
+// markdown files with an .mdc extension whose frontmatter tells Cursor when
+// to apply them. aix installs skills and agents as rules that the model
+// requests by description, and commands as plain markdown prompts.
+package cursor
+
+import (
+	"path/filepath"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// Scope defines whether paths resolve to user-level or project-level configuration.
+type Scope int
+
+const (
+	// ScopeUser resolves paths relative to ~/.cursor/
+	ScopeUser Scope = iota
+	// ScopeProject resolves paths relative to <projectRoot>/.cursor/
+	ScopeProject
+)
+
+// RuleExt is the file extension of Cursor rules.
+const RuleExt = ".mdc"
+
+// CursorPaths provides Cursor-specific path resolution.
+// It wraps the generic paths package with Cursor-specific defaults.
+type CursorPaths struct {
+	scope       Scope
+	projectRoot string
+}
+
+// NewCursorPaths creates a new CursorPaths instance.
+// For ScopeProject, projectRoot must be non-empty.
+// For ScopeUser, projectRoot is ignored.
+func NewCursorPaths(scope Scope, projectRoot string) *CursorPaths {
+	return &CursorPaths{
+		scope:       scope,
+		projectRoot: projectRoot,
+	}
+}
+
+// BaseDir returns the base configuration directory.
+// For ScopeUser: ~/.cursor/
+// For ScopeProject: <projectRoot>/.cursor/
+// Returns empty string if projectRoot is empty for ScopeProject.
+func (p *CursorPaths) BaseDir() string {
+	switch p.scope {
+	case ScopeUser:
+		return paths.GlobalConfigDir(paths.PlatformCursor)
+	case ScopeProject:
+		return paths.ProjectConfigDir(paths.PlatformCursor, p.projectRoot)
+	default:
+		return ""
+	}
+}
+
+// SkillDir returns the directory skills are installed to as rules.
+// Returns <base>/rules/
+func (p *CursorPaths) SkillDir() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "rules")
+}
+
+// AgentDir returns the directory agents are installed to as rules. It is
+// nested in the rules directory so that agents are not listed as skills.
+// Returns <base>/rules/agents/
+func (p *CursorPaths) AgentDir() string {
+	rules := p.SkillDir()
+	if rules == "" {
+		return ""
+	}
+	return filepath.Join(rules, "agents")
+}
+
+// CommandDir returns the commands directory.
+// Returns <base>/commands/
+func (p *CursorPaths) CommandDir() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "commands")
+}
+
+// MCPConfigPath returns the path to the MCP servers configuration file.
+// Returns <base>/mcp.json
+func (p *CursorPaths) MCPConfigPath() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, "mcp.json")
+}
+
+// InstructionsPath returns the path to the AGENTS.md instructions file.
+// For ScopeProject: <projectRoot>/AGENTS.md
+// For ScopeUser: empty, as Cursor keeps user rules in its settings.
+func (p *CursorPaths) InstructionsPath() string {
+	if p.scope != ScopeProject || p.projectRoot == "" {
+		return ""
+	}
+	return filepath.Join(p.projectRoot, "AGENTS.md")
+}
+
+// SkillPath returns the path to the rule a skill is installed as.
+// Returns <rules>/<name>.mdc
+// Returns empty string if name is empty.
+func (p *CursorPaths) SkillPath(name string) string {
+	return joinName(p.SkillDir(), name, RuleExt)
+}
+
+// AgentPath returns the path to the rule an agent is installed as.
+// Returns <rules>/agents/<name>.mdc
+// Returns empty string if name is empty.
+func (p *CursorPaths) AgentPath(name string) string {
+	return joinName(p.AgentDir(), name, RuleExt)
+}
+
+// CommandPath returns the path to a specific command file.
+// Returns <commands>/<name>.md
+// Returns empty string if name is empty.
+func (p *CursorPaths) CommandPath(name string) string {
+	return joinName(p.CommandDir(), name, ".md")
+}
+
+// joinName returns dir/name+ext, or an empty string if dir or name is.
+func joinName(dir, name, ext string) string {
+	if dir == "" || name == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+ext)
+}
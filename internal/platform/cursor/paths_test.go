@@ -0,0 +1,84 @@
+package cursor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCursorPaths_BaseDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		scope       Scope
+		projectRoot string
+		want        string
+	}{
+		{
+			name:  "User scope",
+			scope: ScopeUser,
+			want:  filepath.Join(home, ".cursor"),
+		},
+		{
+			name:        "Project scope",
+			scope:       ScopeProject,
+			projectRoot: "/tmp/project",
+			want:        filepath.Join("/tmp/project", ".cursor"),
+		},
+		{
+			name:        "Project scope empty root",
+			scope:       ScopeProject,
+			projectRoot: "",
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewCursorPaths(tt.scope, tt.projectRoot)
+			if got := p.BaseDir(); got != tt.want {
+				t.Errorf("BaseDir() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorPaths_SubDirs(t *testing.T) {
+	base := filepath.Join("/tmp/project", ".cursor")
+	p := NewCursorPaths(ScopeProject, "/tmp/project")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"SkillDir", p.SkillDir(), filepath.Join(base, "rules")},
+		{"AgentDir", p.AgentDir(), filepath.Join(base, "rules", "agents")},
+		{"CommandDir", p.CommandDir(), filepath.Join(base, "commands")},
+		{"MCPConfigPath", p.MCPConfigPath(), filepath.Join(base, "mcp.json")},
+		{"InstructionsPath", p.InstructionsPath(), filepath.Join("/tmp/project", "AGENTS.md")},
+		{"SkillPath", p.SkillPath("review"), filepath.Join(base, "rules", "review.mdc")},
+		{"AgentPath", p.AgentPath("planner"), filepath.Join(base, "rules", "agents", "planner.mdc")},
+		{"CommandPath", p.CommandPath("deploy"), filepath.Join(base, "commands", "deploy.md")},
+		{"SkillPath empty name", p.SkillPath(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorPaths_UserInstructions(t *testing.T) {
+	p := NewCursorPaths(ScopeUser, "")
+	if got := p.InstructionsPath(); got != "" {
+		t.Errorf("InstructionsPath() = %q, want empty for user scope", got)
+	}
+}
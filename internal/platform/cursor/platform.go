@@ -0,0 +1,243 @@
+package cursor
+
+import (
+	"os"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// CursorPlatform provides the unified platform adapter for Cursor.
+// It aggregates all Cursor-specific managers and provides a consistent
+// interface for the aix CLI.
+type CursorPlatform struct {
+	paths    *CursorPaths
+	skills   *RuleManager
+	agents   *RuleManager
+	commands *CommandManager
+	mcp      *MCPManager
+}
+
+// Option configures a CursorPlatform instance.
+type Option func(*CursorPlatform)
+
+// WithScope sets the scope (user or project) for path resolution.
+func WithScope(scope Scope) Option {
+	return func(p *CursorPlatform) {
+		p.paths = NewCursorPaths(scope, p.paths.projectRoot)
+	}
+}
+
+// WithProjectRoot sets the project root directory for project-scoped paths.
+func WithProjectRoot(root string) Option {
+	return func(p *CursorPlatform) {
+		p.paths = NewCursorPaths(p.paths.scope, root)
+	}
+}
+
+// NewCursorPlatform creates a new CursorPlatform with the given options.
+// Default configuration uses ScopeUser with no project root.
+func NewCursorPlatform(opts ...Option) *CursorPlatform {
+	p := &CursorPlatform{
+		paths: NewCursorPaths(ScopeUser, ""),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.skills = NewSkillManager(p.paths)
+	p.agents = NewAgentManager(p.paths)
+	p.commands = NewCommandManager(p.paths)
+	p.mcp = NewMCPManager(p.paths)
+
+	return p
+}
+
+// Name returns the platform identifier.
+func (p *CursorPlatform) Name() string {
+	return "cursor"
+}
+
+// DisplayName returns a human-readable platform name.
+func (p *CursorPlatform) DisplayName() string {
+	return "Cursor"
+}
+
+// --- Path Methods ---
+
+// GlobalConfigDir returns the global configuration directory (~/.cursor/).
+func (p *CursorPlatform) GlobalConfigDir() string {
+	return paths.GlobalConfigDir(paths.PlatformCursor)
+}
+
+// ProjectConfigDir returns the project-scoped configuration directory.
+func (p *CursorPlatform) ProjectConfigDir(projectRoot string) string {
+	return paths.ProjectConfigDir(paths.PlatformCursor, projectRoot)
+}
+
+// SkillDir returns the rules directory skills are installed to.
+func (p *CursorPlatform) SkillDir() string {
+	return p.paths.SkillDir()
+}
+
+// CommandDir returns the commands directory for the current scope.
+func (p *CursorPlatform) CommandDir() string {
+	return p.paths.CommandDir()
+}
+
+// AgentDir returns the rules directory agents are installed to.
+func (p *CursorPlatform) AgentDir() string {
+	return p.paths.AgentDir()
+}
+
+// MCPConfigPath returns the path to the MCP servers configuration file.
+func (p *CursorPlatform) MCPConfigPath() string {
+	return p.paths.MCPConfigPath()
+}
+
+// InstructionsPath returns the path to the instructions file,
+// <projectRoot>/AGENTS.md. Cursor has no user-level instructions file.
+func (p *CursorPlatform) InstructionsPath(projectRoot string) string {
+	if projectRoot != "" {
+		return NewCursorPaths(ScopeProject, projectRoot).InstructionsPath()
+	}
+	return p.paths.InstructionsPath()
+}
+
+// --- Skill Operations ---
+
+// InstallSkill installs a skill as a rule.
+func (p *CursorPlatform) InstallSkill(r *Rule) error {
+	return p.skills.Install(r)
+}
+
+// UninstallSkill removes a skill by name.
+func (p *CursorPlatform) UninstallSkill(name string) error {
+	return p.skills.Uninstall(name)
+}
+
+// ListSkills returns all installed skills.
+func (p *CursorPlatform) ListSkills() ([]*Rule, error) {
+	return p.skills.List()
+}
+
+// GetSkill retrieves a skill by name.
+func (p *CursorPlatform) GetSkill(name string) (*Rule, error) {
+	return p.skills.Get(name)
+}
+
+// --- Command Operations ---
+
+// InstallCommand installs a slash command.
+func (p *CursorPlatform) InstallCommand(c *Command) error {
+	return p.commands.Install(c)
+}
+
+// UninstallCommand removes a command by name.
+func (p *CursorPlatform) UninstallCommand(name string) error {
+	return p.commands.Uninstall(name)
+}
+
+// ListCommands returns all installed commands.
+func (p *CursorPlatform) ListCommands() ([]*Command, error) {
+	return p.commands.List()
+}
+
+// GetCommand retrieves a command by name.
+func (p *CursorPlatform) GetCommand(name string) (*Command, error) {
+	return p.commands.Get(name)
+}
+
+// --- Agent Operations ---
+
+// InstallAgent installs an agent as a rule.
+func (p *CursorPlatform) InstallAgent(r *Rule) error {
+	return p.agents.Install(r)
+}
+
+// UninstallAgent removes an agent by name.
+func (p *CursorPlatform) UninstallAgent(name string) error {
+	return p.agents.Uninstall(name)
+}
+
+// ListAgents returns all installed agents.
+func (p *CursorPlatform) ListAgents() ([]*Rule, error) {
+	return p.agents.List()
+}
+
+// GetAgent retrieves an agent by name.
+func (p *CursorPlatform) GetAgent(name string) (*Rule, error) {
+	return p.agents.Get(name)
+}
+
+// --- MCP Operations ---
+
+// AddMCP adds or updates an MCP server.
+func (p *CursorPlatform) AddMCP(s *MCPServer) error {
+	return p.mcp.Add(s)
+}
+
+// RemoveMCP removes an MCP server by name.
+func (p *CursorPlatform) RemoveMCP(name string) error {
+	return p.mcp.Remove(name)
+}
+
+// ListMCP returns all configured MCP servers.
+func (p *CursorPlatform) ListMCP() ([]*MCPServer, error) {
+	return p.mcp.List()
+}
+
+// GetMCP retrieves an MCP server by name.
+func (p *CursorPlatform) GetMCP(name string) (*MCPServer, error) {
+	return p.mcp.Get(name)
+}
+
+// EnableMCP enables an MCP server by name.
+func (p *CursorPlatform) EnableMCP(name string) error {
+	return p.mcp.Enable(name)
+}
+
+// DisableMCP disables an MCP server by name.
+func (p *CursorPlatform) DisableMCP(name string) error {
+	return p.mcp.Disable(name)
+}
+
+// --- Backup Methods ---
+
+// BackupPaths returns all config files/directories that should be backed up.
+// For Cursor, this includes:
+//   - ~/.cursor/mcp.json (MCP config)
+//   - ~/.cursor/rules/ directory (skills, agents)
+//   - ~/.cursor/commands/ directory
+//
+// The rest of ~/.cursor/, which holds Cursor's extensions, is not aix's to
+// back up.
+func (p *CursorPlatform) BackupPaths() []string {
+	return []string{
+		p.paths.MCPConfigPath(),
+		p.paths.SkillDir(),
+		p.paths.CommandDir(),
+	}
+}
+
+// --- Status Methods ---
+
+// IsAvailable checks if Cursor is available on this system.
+// Returns true if the ~/.cursor/ directory exists.
+func (p *CursorPlatform) IsAvailable() bool {
+	globalDir := p.GlobalConfigDir()
+	if globalDir == "" {
+		return false
+	}
+	info, err := os.Stat(globalDir)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
+
+// Version returns the Cursor version.
+// Currently returns an empty string as version detection is not yet implemented.
+func (p *CursorPlatform) Version() (string, error) {
+	return "", nil
+}
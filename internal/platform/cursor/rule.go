@@ -0,0 +1,178 @@
+package cursor
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// Sentinel errors for rule operations.
+var (
+	ErrRuleNotFound = errors.New("rule not found")
+	ErrInvalidRule  = errors.New("invalid rule: name required")
+)
+
+// RuleManager handles CRUD operations for the Cursor rules in one
+// directory. Skills and agents each have their own; see NewSkillManager and
+// NewAgentManager.
+type RuleManager struct {
+	dir func() string
+}
+
+// NewSkillManager creates a RuleManager for the rules skills are installed as.
+func NewSkillManager(paths *CursorPaths) *RuleManager {
+	return &RuleManager{dir: paths.SkillDir}
+}
+
+// NewAgentManager creates a RuleManager for the rules agents are installed as.
+func NewAgentManager(paths *CursorPaths) *RuleManager {
+	return &RuleManager{dir: paths.AgentDir}
+}
+
+// List returns all rules in the directory, without their instructions.
+// Returns an empty slice if the directory doesn't exist.
+func (m *RuleManager) List() ([]*Rule, error) {
+	dir := m.dir()
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading rules directory")
+	}
+
+	rules := make([]*Rule, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), RuleExt) {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), RuleExt)
+		f, err := os.Open(joinName(dir, name, RuleExt))
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening rule file %q", name)
+		}
+
+		rule := &Rule{}
+		err = frontmatter.ParseHeader(f, rule)
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing rule header %q", name)
+		}
+		rule.Name = name
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// Get retrieves a rule by name.
+// Returns ErrRuleNotFound if the rule doesn't exist.
+func (m *RuleManager) Get(name string) (*Rule, error) {
+	if name == "" {
+		return nil, ErrInvalidRule
+	}
+
+	rulePath := joinName(m.dir(), name, RuleExt)
+	if rulePath == "" {
+		return nil, ErrRuleNotFound
+	}
+
+	data, err := os.ReadFile(rulePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrRuleNotFound
+		}
+		return nil, errors.Wrap(err, "reading rule file")
+	}
+
+	rule, err := parseRuleFile(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing rule file")
+	}
+
+	// Name is derived from the file name
+	rule.Name = name
+	return rule, nil
+}
+
+// Install writes a rule to disk, overwriting any rule with the same name.
+// Creates the directory if it doesn't exist.
+func (m *RuleManager) Install(r *Rule) error {
+	if r == nil || r.Name == "" {
+		return ErrInvalidRule
+	}
+
+	dir := m.dir()
+	if dir == "" {
+		return errors.New("rules directory path is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "creating rules directory")
+	}
+
+	content, err := formatRuleFile(r)
+	if err != nil {
+		return errors.Wrap(err, "formatting rule file")
+	}
+
+	if err := fileutil.AtomicWriteFile(joinName(dir, r.Name, RuleExt), content, 0o644); err != nil {
+		return errors.Wrap(err, "writing rule file")
+	}
+	return nil
+}
+
+// Uninstall removes a rule by name.
+// This operation is idempotent; removing a non-existent rule returns nil.
+func (m *RuleManager) Uninstall(name string) error {
+	rulePath := joinName(m.dir(), name, RuleExt)
+	if rulePath == "" {
+		return nil
+	}
+
+	if err := os.Remove(rulePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, "removing rule file")
+	}
+	return nil
+}
+
+// parseRuleFile parses an .mdc file with optional YAML frontmatter.
+func parseRuleFile(data []byte) (*Rule, error) {
+	var rule Rule
+	body, err := frontmatter.Parse(bytes.NewReader(data), &rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing frontmatter")
+	}
+	rule.Instructions = strings.TrimSpace(string(body))
+	return &rule, nil
+}
+
+// formatRuleFile formats a Rule as an .mdc file. Cursor expects the three
+// frontmatter keys to be present, so globs is written even when empty.
+func formatRuleFile(r *Rule) ([]byte, error) {
+	meta := struct {
+		Description string `yaml:"description"`
+		Globs       string `yaml:"globs"`
+		AlwaysApply bool   `yaml:"alwaysApply"`
+	}{
+		Description: r.Description,
+		Globs:       strings.Join(r.Globs, ","),
+		AlwaysApply: r.AlwaysApply,
+	}
+
+	data, err := frontmatter.Format(meta, r.Instructions)
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting rule content")
+	}
+	return data, nil
+}
@@ -0,0 +1,138 @@
+package cursor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRuleManager(t *testing.T) {
+	paths := NewCursorPaths(ScopeProject, t.TempDir())
+	skills := NewSkillManager(paths)
+	agents := NewAgentManager(paths)
+
+	rule := &Rule{
+		Name:         "code-review",
+		Description:  "Review code for bugs",
+		Instructions: "Look for off-by-one errors.",
+	}
+
+	t.Run("Install", func(t *testing.T) {
+		if err := skills.Install(rule); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		data, err := os.ReadFile(paths.SkillPath(rule.Name))
+		if err != nil {
+			t.Fatalf("Failed to read rule file: %v", err)
+		}
+		content := string(data)
+
+		for _, want := range []string{
+			"description: Review code for bugs",
+			"globs: \"\"",
+			"alwaysApply: false",
+			"Look for off-by-one errors.",
+		} {
+			if !strings.Contains(content, want) {
+				t.Errorf("rule file missing %q:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		rules, err := skills.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(rules) != 1 {
+			t.Fatalf("Expected 1 rule, got %d", len(rules))
+		}
+		if rules[0].Name != "code-review" || rules[0].Description != "Review code for bugs" {
+			t.Errorf("List() = %+v", rules[0])
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := skills.Get("code-review")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Instructions != rule.Instructions {
+			t.Errorf("Instructions = %q, want %q", got.Instructions, rule.Instructions)
+		}
+	})
+
+	t.Run("Agents are kept apart from skills", func(t *testing.T) {
+		agent := &Rule{Name: "planner", Description: "Plans work", Instructions: "Plan."}
+		if err := agents.Install(agent); err != nil {
+			t.Fatalf("Install failed: %v", err)
+		}
+
+		rules, err := skills.List()
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(rules) != 1 {
+			t.Errorf("skills.List() returned %d rules, want 1", len(rules))
+		}
+		if _, err := agents.Get("planner"); err != nil {
+			t.Errorf("agents.Get failed: %v", err)
+		}
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		if err := skills.Uninstall("code-review"); err != nil {
+			t.Fatalf("Uninstall failed: %v", err)
+		}
+		if _, err := skills.Get("code-review"); !errors.Is(err, ErrRuleNotFound) {
+			t.Errorf("Get after Uninstall error = %v, want ErrRuleNotFound", err)
+		}
+		if err := skills.Uninstall("code-review"); err != nil {
+			t.Errorf("second Uninstall failed: %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := skills.Install(&Rule{}); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Install error = %v, want ErrInvalidRule", err)
+		}
+	})
+}
+
+func TestRuleManager_Globs(t *testing.T) {
+	paths := NewCursorPaths(ScopeProject, t.TempDir())
+	mgr := NewSkillManager(paths)
+
+	tests := []struct {
+		name  string
+		globs string
+		want  []string
+	}{
+		{"comma-separated", `"*.go, *.mod"`, []string{"*.go", "*.mod"}},
+		{"list", `["*.ts", "*.tsx"]`, []string{"*.ts", "*.tsx"}},
+		{"empty", `""`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "---\ndescription: Go files\nglobs: " + tt.globs + "\nalwaysApply: false\n---\nUse gofmt.\n"
+			if err := os.MkdirAll(paths.SkillDir(), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(paths.SkillDir(), "go.mdc"), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := mgr.Get("go")
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if strings.Join(got.Globs, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Globs = %q, want %q", got.Globs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package cursor
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// MCPServer represents an MCP server configuration in Cursor's mcp.json.
+// Cursor selects the transport from the fields that are set: a command
+// runs a local process, a url connects to a remote server.
+type MCPServer struct {
+	// Name is the server's identifier, populated from the map key when loading.
+	// Not serialized to JSON as it's the map key itself.
+	Name string `json:"-"`
+
+	// Command is the executable to run for stdio transport.
+	Command string `json:"command,omitempty"`
+
+	// Args are command-line arguments passed to the command.
+	Args []string `json:"args,omitempty"`
+
+	// URL is the server endpoint for remote transport. Cursor tries
+	// Streamable HTTP first and falls back to SSE.
+	URL string `json:"url,omitempty"`
+
+	// Env contains environment variables passed to the server process.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Headers contains HTTP headers for remote transport connections.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Disabled indicates whether the server is temporarily disabled.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// MCPConfig represents the root structure of Cursor's mcp.json file.
+// It preserves unknown fields for forward compatibility with future versions.
+type MCPConfig struct {
+	// MCPServers maps server names to their configurations.
+	MCPServers map[string]*MCPServer `json:"mcpServers"`
+
+	// unknownFields stores any JSON fields not explicitly defined in this struct.
+	unknownFields map[string]json.RawMessage
+}
+
+// MarshalJSON implements json.Marshaler to include unknown fields in output.
+func (c *MCPConfig) MarshalJSON() ([]byte, error) {
+	result := make(map[string]any)
+
+	// Copy unknown fields first (so known fields take precedence)
+	for k, v := range c.unknownFields {
+		var val any
+		if err := json.Unmarshal(v, &val); err != nil {
+			return nil, errors.Wrap(err, "unmarshaling unknown field")
+		}
+		result[k] = val
+	}
+
+	result["mcpServers"] = c.MCPServers
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling result")
+	}
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler to capture unknown fields.
+func (c *MCPConfig) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrap(err, "unmarshaling raw config")
+	}
+
+	if serversData, ok := raw["mcpServers"]; ok {
+		if err := json.Unmarshal(serversData, &c.MCPServers); err != nil {
+			return errors.Wrap(err, "unmarshaling mcpServers")
+		}
+		delete(raw, "mcpServers")
+
+		for name, server := range c.MCPServers {
+			if server != nil {
+				server.Name = name
+			}
+		}
+	}
+
+	if len(raw) > 0 {
+		c.unknownFields = raw
+	}
+
+	return nil
+}
+
+// GlobList is the file patterns a rule applies to. Cursor writes it as a
+// comma-separated string; a YAML list is accepted too.
+type GlobList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (g *GlobList) UnmarshalYAML(value *yaml.Node) error {
+	var list []string
+	if err := value.Decode(&list); err == nil {
+		*g = list
+		return nil
+	}
+
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return errors.Newf("globs must be a string or list, got %s", value.Tag)
+	}
+	*g = nil
+	for _, glob := range strings.Split(s, ",") {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			*g = append(*g, glob)
+		}
+	}
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler, writing the comma-separated form.
+func (g GlobList) MarshalYAML() (any, error) {
+	return strings.Join(g, ","), nil
+}
+
+// Rule represents a Cursor rule: an .mdc file whose frontmatter decides
+// when Cursor includes it. A rule that always applies is included in every
+// request, one with globs when matching files are referenced, and one with
+// only a description when the model asks for it. Skills and agents are
+// installed as rules of the last kind.
+type Rule struct {
+	// Name is the rule's identifier, derived from its file name.
+	Name string `yaml:"-" json:"name"`
+
+	// Description tells the model what the rule is for.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Globs are the file patterns that attach the rule automatically.
+	Globs GlobList `yaml:"globs,omitempty" json:"globs,omitempty"`
+
+	// AlwaysApply includes the rule in every request.
+	AlwaysApply bool `yaml:"alwaysApply" json:"alwaysApply"`
+
+	// Instructions contains the rule's markdown body content.
+	// This field is not part of the YAML frontmatter.
+	Instructions string `yaml:"-" json:"-"`
+}
+
+// Command represents a Cursor slash command. Commands are plain markdown
+// prompts; Cursor does not read frontmatter from them.
+type Command struct {
+	// Name is the command's identifier (used as /name in the interface).
+	Name string `yaml:"-" json:"name"`
+
+	// Description explains what the command does. It is read from
+	// frontmatter written by hand but not written, since Cursor would
+	// include it in the prompt.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Instructions contains the command's markdown content.
+	Instructions string `yaml:"-" json:"-"`
+}
@@ -23,7 +23,8 @@ const (
 
 // DetectionResult contains information about a detected platform.
 type DetectionResult struct {
-	// Name is the platform identifier (claude, opencode, codex, gemini).
+	// Name is the platform identifier (claude, opencode, codex, gemini, cursor, copilot,
+	// or one registered from a definition or plugin).
 	Name string
 
 	// GlobalConfig is the path to the global configuration directory.
@@ -73,8 +73,8 @@ func TestDetectPlatform_InvalidPlatform(t *testing.T) {
 func TestDetectAll_ReturnsAllPlatforms(t *testing.T) {
 	results := DetectAll()
 
-	if len(results) != 4 {
-		t.Errorf("DetectAll() returned %d platforms, want 4", len(results))
+	if len(results) != 6 {
+		t.Errorf("DetectAll() returned %d platforms, want 6", len(results))
 	}
 
 	// Verify all expected platforms are present
@@ -88,6 +88,8 @@ func TestDetectAll_ReturnsAllPlatforms(t *testing.T) {
 		paths.PlatformOpenCode,
 		paths.PlatformCodex,
 		paths.PlatformGemini,
+		paths.PlatformCursor,
+		paths.PlatformCopilot,
 	}
 
 	for _, name := range expected {
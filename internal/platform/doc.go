@@ -2,9 +2,12 @@
 // assistant configuration management.
 //
 // This package detects and manages configurations for supported AI coding
-// assistants: Claude Code, OpenCode, Codex, and Gemini CLI. It provides
-// detection capabilities to determine which platforms are installed on
-// the current system, and a registry for tracking registered platform names.
+// assistants: Claude Code, OpenCode, Codex, Gemini CLI, Cursor, and GitHub
+// Copilot in VS Code, as well as those described by a definition (see the
+// generic subpackage) or implemented by an external executable (see the
+// plugin subpackage). It provides detection capabilities to determine which
+// platforms are installed on the current system, and a registry for
+// tracking registered platform names.
 //
 // # Platform Registry
 //
@@ -70,7 +73,7 @@
 //
 // [DetectionResult] contains information about a detected platform:
 //
-//   - Name: Platform identifier (claude, opencode, codex, gemini)
+//   - Name: Platform identifier (claude, opencode, codex, gemini, cursor, copilot)
 //   - GlobalConfig: Path to global configuration directory
 //   - MCPConfig: Path to MCP configuration file
 //   - Status: Current installation status
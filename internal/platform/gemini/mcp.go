@@ -8,6 +8,7 @@ import (
 	"github.com/pelletier/go-toml/v2"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/secret"
 	"github.com/thoreinstein/aix/pkg/fileutil"
 )
 
@@ -17,6 +18,11 @@ var (
 	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
 )
 
+// SecretSyntax is how Gemini CLI interpolates environment variables in MCP
+// server env and header values. Other secret references are resolved when a
+// server is added.
+var SecretSyntax = secret.Syntax{Env: "${%s}"}
+
 // MCPManager provides CRUD operations for Gemini CLI MCP server configurations.
 type MCPManager struct {
 	paths *GeminiPaths
@@ -77,37 +83,34 @@ func (m *MCPManager) Add(server *MCPServer) error {
 		return ErrInvalidMCPServer
 	}
 
-	settings, err := m.loadSettings()
+	server, err := renderSecrets(server)
 	if err != nil {
 		return err
 	}
 
-	if settings.MCP == nil {
-		settings.MCP = &MCPConfig{
-			Servers: make(map[string]*MCPServer),
+	return m.update(func(settings *Settings) error {
+		if settings.MCP == nil {
+			settings.MCP = &MCPConfig{
+				Servers: make(map[string]*MCPServer),
+			}
+		}
+		if settings.MCP.Servers == nil {
+			settings.MCP.Servers = make(map[string]*MCPServer)
 		}
-	}
-	if settings.MCP.Servers == nil {
-		settings.MCP.Servers = make(map[string]*MCPServer)
-	}
-
-	settings.MCP.Servers[server.Name] = server
 
-	return m.saveSettings(settings)
+		settings.MCP.Servers[server.Name] = server
+		return nil
+	})
 }
 
 // Remove removes an MCP server configuration.
 func (m *MCPManager) Remove(name string) error {
-	settings, err := m.loadSettings()
-	if err != nil {
-		return err
-	}
-
-	if settings.MCP != nil && settings.MCP.Servers != nil {
-		delete(settings.MCP.Servers, name)
-	}
-
-	return m.saveSettings(settings)
+	return m.update(func(settings *Settings) error {
+		if settings.MCP != nil && settings.MCP.Servers != nil {
+			delete(settings.MCP.Servers, name)
+		}
+		return nil
+	})
 }
 
 // Enable activates an MCP server.
@@ -121,22 +124,29 @@ func (m *MCPManager) Disable(name string) error {
 }
 
 func (m *MCPManager) setEnabled(name string, enabled bool) error {
-	settings, err := m.loadSettings()
-	if err != nil {
-		return err
-	}
+	return m.update(func(settings *Settings) error {
+		if settings.MCP == nil || settings.MCP.Servers == nil {
+			return ErrMCPServerNotFound
+		}
 
-	if settings.MCP == nil || settings.MCP.Servers == nil {
-		return ErrMCPServerNotFound
-	}
+		server, ok := settings.MCP.Servers[name]
+		if !ok {
+			return ErrMCPServerNotFound
+		}
 
-	server, ok := settings.MCP.Servers[name]
-	if !ok {
-		return ErrMCPServerNotFound
-	}
+		server.Enabled = enabled
+		return nil
+	})
+}
 
-	server.Enabled = enabled
-	return m.saveSettings(settings)
+// update applies fn to the settings file and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(settings *Settings) error) error {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		return errors.New("MCP config path not configured")
+	}
+	return fileutil.Update(configPath, m.loadSettings, fn, m.saveSettings)
 }
 
 func (m *MCPManager) loadSettings() (*Settings, error) {
@@ -214,3 +224,17 @@ func (m *MCPManager) saveSettings(settings *Settings) error {
 
 	return errors.Wrap(fileutil.AtomicWriteTOML(configPath, settings.Other), "writing settings file")
 }
+
+// renderSecrets returns a copy of server with the secret references in its
+// env and headers rewritten for Gemini CLI.
+func renderSecrets(server *MCPServer) (*MCPServer, error) {
+	out := *server
+	var err error
+	if out.Env, err = secret.RenderMap(server.Env, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q env", server.Name)
+	}
+	if out.Headers, err = secret.RenderMap(server.Headers, SecretSyntax); err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q headers", server.Name)
+	}
+	return &out, nil
+}
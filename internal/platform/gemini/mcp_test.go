@@ -150,4 +150,47 @@ func TestMCPTranslator(t *testing.T) {
 			t.Errorf("Expected enabled=true, got enabled=false")
 		}
 	})
+
+	t.Run("RemoteTransports", func(t *testing.T) {
+		geminiTOML := `
+[servers]
+  [servers.streamable]
+    httpUrl = "https://example.com/mcp"
+  [servers.legacy]
+    url = "https://example.com/sse"
+`
+		config, err := translator.ToCanonical([]byte(geminiTOML))
+		if err != nil {
+			t.Fatalf("ToCanonical failed: %v", err)
+		}
+
+		want := map[string]struct{ transport, url string }{
+			"streamable": {mcp.TransportHTTP, "https://example.com/mcp"},
+			"legacy":     {mcp.TransportSSE, "https://example.com/sse"},
+		}
+		for name, w := range want {
+			server := config.Servers[name]
+			if server == nil {
+				t.Fatalf("server %s not found", name)
+			}
+			if server.Transport != w.transport || server.URL != w.url {
+				t.Errorf("%s = (%q, %q), want (%q, %q)", name, server.Transport, server.URL, w.transport, w.url)
+			}
+		}
+
+		data, err := translator.FromCanonical(config)
+		if err != nil {
+			t.Fatalf("FromCanonical failed: %v", err)
+		}
+		var geminiConfig MCPConfig
+		if err := toml.Unmarshal(data, &geminiConfig); err != nil {
+			t.Fatal(err)
+		}
+		if got := geminiConfig.Servers["streamable"]; got.HTTPURL != "https://example.com/mcp" || got.URL != "" {
+			t.Errorf("streamable: httpUrl = %q, url = %q", got.HTTPURL, got.URL)
+		}
+		if got := geminiConfig.Servers["legacy"]; got.URL != "https://example.com/sse" || got.HTTPURL != "" {
+			t.Errorf("legacy: httpUrl = %q, url = %q", got.HTTPURL, got.URL)
+		}
+	})
 }
@@ -8,6 +8,9 @@ import (
 )
 
 // MCPTranslator converts between canonical and Gemini CLI MCP formats.
+//
+// Gemini CLI selects the remote transport by field name: "httpUrl" for
+// Streamable HTTP and "url" for SSE.
 type MCPTranslator struct{}
 
 // NewMCPTranslator creates a new Gemini CLI MCP translator.
@@ -34,18 +37,12 @@ func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 
 	config := mcp.NewConfig()
 	for name, geminiServer := range geminiConfig.Servers {
-		// Infer transport
-		transport := mcp.TransportStdio
-		if geminiServer.URL != "" {
-			transport = mcp.TransportSSE
-		}
-
 		server := &mcp.Server{
 			Name:      name,
 			Command:   geminiServer.Command,
 			Args:      geminiServer.Args,
-			URL:       geminiServer.URL,
-			Transport: transport,
+			URL:       geminiServer.Endpoint(),
+			Transport: geminiServer.Transport(),
 			Env:       geminiServer.Env,
 			Headers:   geminiServer.Headers,
 			Disabled:  !geminiServer.Enabled, // Translate Enabled -> Disabled
@@ -70,11 +67,15 @@ func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
 		geminiServer := &MCPServer{
 			Command: server.Command,
 			Args:    server.Args,
-			URL:     server.URL,
 			Env:     server.Env,
 			Headers: server.Headers,
 			Enabled: !server.Disabled, // Translate Disabled -> Enabled
 		}
+		if server.EffectiveTransport() == mcp.TransportSSE {
+			geminiServer.URL = server.URL
+		} else {
+			geminiServer.HTTPURL = server.URL
+		}
 		geminiConfig.Servers[name] = geminiServer
 	}
 
@@ -6,6 +6,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
 )
 
 // ToolList is a list of allowed tools.
@@ -122,9 +123,12 @@ type MCPServer struct {
 	// Args are command-line arguments for the server process.
 	Args []string `json:"args,omitempty" toml:"args,omitempty"`
 
-	// URL is the server endpoint for remote servers.
+	// URL is the server endpoint for remote SSE servers.
 	URL string `json:"url,omitempty" toml:"url,omitempty"`
 
+	// HTTPURL is the server endpoint for remote Streamable HTTP servers.
+	HTTPURL string `json:"httpUrl,omitempty" toml:"httpUrl,omitempty"`
+
 	// Env contains environment variables for the server process.
 	Env map[string]string `json:"env,omitempty" toml:"env,omitempty"`
 
@@ -135,6 +139,28 @@ type MCPServer struct {
 	Enabled bool `json:"enabled" toml:"enabled"`
 }
 
+// Transport returns the canonical transport of the server: "http" when
+// HTTPURL is set, "sse" when URL is set, and "stdio" otherwise.
+func (s *MCPServer) Transport() string {
+	switch {
+	case s.HTTPURL != "":
+		return mcp.TransportHTTP
+	case s.URL != "":
+		return mcp.TransportSSE
+	default:
+		return mcp.TransportStdio
+	}
+}
+
+// Endpoint returns the remote endpoint of the server, whichever of HTTPURL
+// and URL is set.
+func (s *MCPServer) Endpoint() string {
+	if s.HTTPURL != "" {
+		return s.HTTPURL
+	}
+	return s.URL
+}
+
 // MCPConfig represents the MCP section in Gemini CLI's settings.toml.
 type MCPConfig struct {
 	// Servers maps server names to their configurations.
@@ -0,0 +1,243 @@
+// Package generic provides platform adapters described by data instead of
+// code.
+//
+// Many assistants keep their MCP servers in a map at some key of a JSON or
+// YAML settings file and read a single markdown rules file. A [Definition]
+// describes such an assistant: where its configuration lives, where the
+// server map is, what the server fields are called and which values name
+// each transport. [GenericPlatform] interprets a definition to manage the
+// assistant's MCP servers. Skills, commands and agents are not supported.
+//
+// Definitions for Zed, Goose and Windsurf are built in. More can be loaded
+// from YAML files with [LoadDir]; see [Register].
+package generic
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/secret"
+)
+
+// Config file formats.
+const (
+	// FormatJSON is JSON. Comments and trailing commas are accepted when
+	// reading, as many editors write them; see MCPManager for how they are
+	// kept.
+	FormatJSON = "json"
+
+	// FormatYAML is YAML.
+	FormatYAML = "yaml"
+)
+
+// ErrInvalidDefinition indicates a platform definition is malformed or
+// missing required fields.
+var ErrInvalidDefinition = errors.New("invalid platform definition")
+
+// Definition describes a platform. In YAML:
+//
+//	name: zed
+//	display_name: Zed
+//	config_dir: .config/zed
+//	project_config_dir: .zed
+//	instructions: .rules
+//	mcp:
+//	  file: settings.json
+//	  format: json
+//	  key: context_servers
+//	  fields:
+//	    enabled: enabled
+type Definition struct {
+	// Name is the platform identifier used with --platform.
+	Name string `yaml:"name"`
+
+	// DisplayName is the human-readable platform name.
+	DisplayName string `yaml:"display_name"`
+
+	// ConfigDir is the global config directory, relative to the home
+	// directory. Its existence is how the platform is detected.
+	ConfigDir string `yaml:"config_dir"`
+
+	// ConfigDirEnv is the environment variable the assistant reads to
+	// relocate ConfigDir, if any.
+	ConfigDirEnv string `yaml:"config_dir_env,omitempty"`
+
+	// ProjectConfigDir is the project config directory, relative to the
+	// project root ("." for the root itself). Empty if the platform has no
+	// project configuration.
+	ProjectConfigDir string `yaml:"project_config_dir,omitempty"`
+
+	// Instructions is the instructions file, relative to the project root.
+	Instructions string `yaml:"instructions,omitempty"`
+
+	// MCP describes the MCP server configuration.
+	MCP MCPDefinition `yaml:"mcp"`
+}
+
+// MCPDefinition describes where a platform keeps its MCP servers and how
+// they are written.
+type MCPDefinition struct {
+	// File is the config file, relative to the config directory of the
+	// current scope.
+	File string `yaml:"file"`
+
+	// Format is FormatJSON or FormatYAML. Defaults to the format implied by
+	// the file extension.
+	Format string `yaml:"format,omitempty"`
+
+	// Key is the dot-separated path of the server map in the file, for
+	// example "mcpServers" or "agent.servers". Each entry of the map is a
+	// server, keyed by its name.
+	Key string `yaml:"key"`
+
+	// Fields names the server fields.
+	Fields Fields `yaml:"fields,omitempty"`
+
+	// Transports maps the canonical transports (stdio, http and sse) to the
+	// values written to the transport field. Transports not listed are
+	// written as is.
+	Transports map[string]string `yaml:"transports,omitempty"`
+
+	// Defaults are fields written to every server, for values the platform
+	// requires but aix has no equivalent of.
+	Defaults map[string]any `yaml:"defaults,omitempty"`
+
+	// Secrets is how the platform interpolates secret references in env and
+	// header values. Kinds without a format are resolved when a server is
+	// added.
+	Secrets SecretSyntax `yaml:"secrets,omitempty"`
+}
+
+// Fields names the fields of a server entry. Command, Args, URL, Env and
+// Headers default to their canonical names; "-" means the platform has no
+// such field. The other fields are only written when named.
+type Fields struct {
+	// Name repeats the server name inside the entry.
+	Name string `yaml:"name,omitempty"`
+
+	Command string `yaml:"command,omitempty"`
+	Args    string `yaml:"args,omitempty"`
+	URL     string `yaml:"url,omitempty"`
+	Env     string `yaml:"env,omitempty"`
+	Headers string `yaml:"headers,omitempty"`
+
+	// Transport holds the transport, mapped through MCPDefinition.Transports.
+	// Without it the transport is inferred: a URL means Streamable HTTP.
+	Transport string `yaml:"transport,omitempty"`
+
+	// Enabled is a boolean that is false for disabled servers. Disabled is
+	// its inverse. At most one of them may be set; without either, servers
+	// cannot be disabled.
+	Enabled  string `yaml:"enabled,omitempty"`
+	Disabled string `yaml:"disabled,omitempty"`
+}
+
+// SecretSyntax is the YAML form of secret.Syntax.
+type SecretSyntax struct {
+	Env  string `yaml:"env,omitempty"`
+	File string `yaml:"file,omitempty"`
+}
+
+// Parse parses and validates a YAML platform definition, filling in the
+// defaults.
+func Parse(data []byte) (*Definition, error) {
+	var def Definition
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		return nil, errors.Wrapf(ErrInvalidDefinition, "parsing YAML: %v", err)
+	}
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// validate checks the required fields and fills in the defaults.
+func (d *Definition) validate() error {
+	if d.Name == "" {
+		return errors.Wrap(ErrInvalidDefinition, "name is required")
+	}
+	invalid := func(format string, args ...any) error {
+		return errors.Wrapf(ErrInvalidDefinition, "platform %q: "+format, append([]any{d.Name}, args...)...)
+	}
+	if d.ConfigDir == "" {
+		return invalid("config_dir is required")
+	}
+	if d.DisplayName == "" {
+		d.DisplayName = d.Name
+	}
+
+	m := &d.MCP
+	if m.File == "" {
+		return invalid("mcp.file is required")
+	}
+	if m.Key == "" {
+		return invalid("mcp.key is required")
+	}
+	if m.Format == "" {
+		switch strings.ToLower(m.File[strings.LastIndex(m.File, ".")+1:]) {
+		case "yaml", "yml":
+			m.Format = FormatYAML
+		default:
+			m.Format = FormatJSON
+		}
+	}
+	if m.Format != FormatJSON && m.Format != FormatYAML {
+		return invalid("mcp.format must be %s or %s, got %q", FormatJSON, FormatYAML, m.Format)
+	}
+	if m.Fields.Enabled != "" && m.Fields.Disabled != "" {
+		return invalid("mcp.fields.enabled and mcp.fields.disabled are mutually exclusive")
+	}
+	for canonical := range m.Transports {
+		switch canonical {
+		case mcp.TransportStdio, mcp.TransportHTTP, mcp.TransportSSE:
+		default:
+			return invalid("mcp.transports has unknown transport %q", canonical)
+		}
+	}
+
+	f := &m.Fields
+	for _, field := range []struct {
+		name *string
+		def  string
+	}{
+		{&f.Command, "command"},
+		{&f.Args, "args"},
+		{&f.URL, "url"},
+		{&f.Env, "env"},
+		{&f.Headers, "headers"},
+	} {
+		if *field.name == "" {
+			*field.name = field.def
+		}
+	}
+	return nil
+}
+
+// keyPath returns the MCP key split into its components.
+func (d *Definition) keyPath() []string {
+	return strings.Split(d.MCP.Key, ".")
+}
+
+// secretSyntax returns the platform's secret syntax.
+func (d *Definition) secretSyntax() secret.Syntax {
+	return secret.Syntax{Env: d.MCP.Secrets.Env, File: d.MCP.Secrets.File}
+}
+
+// spec returns the locations the paths package needs to know about.
+func (d *Definition) spec() paths.PlatformSpec {
+	return paths.PlatformSpec{
+		Name:             d.Name,
+		GlobalConfigDir:  d.ConfigDir,
+		ProjectConfigDir: d.ProjectConfigDir,
+		InstructionsFile: d.Instructions,
+		MCPConfigFile:    d.MCP.File,
+		ConfigDirEnv:     d.ConfigDirEnv,
+	}
+}
@@ -0,0 +1,64 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	def, err := Parse([]byte(`
+name: tool
+config_dir: .tool
+mcp:
+  file: config.yml
+  key: agent.servers
+  fields:
+    url: endpoint
+    args: "-"
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if def.DisplayName != "tool" {
+		t.Errorf("DisplayName = %q, want the name", def.DisplayName)
+	}
+	if def.MCP.Format != FormatYAML {
+		t.Errorf("Format = %q, want %q from the file extension", def.MCP.Format, FormatYAML)
+	}
+	f := def.MCP.Fields
+	if f.Command != "command" || f.Env != "env" || f.Headers != "headers" {
+		t.Errorf("Fields = %+v, want canonical defaults", f)
+	}
+	if f.URL != "endpoint" || f.Args != noField {
+		t.Errorf("Fields = %+v, want the names given", f)
+	}
+	if got := def.keyPath(); len(got) != 2 || got[0] != "agent" || got[1] != "servers" {
+		t.Errorf("keyPath() = %v", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"not YAML", "name: [x"},
+		{"unknown field", "name: x\nconfig_dir: .x\nmcp: {file: a.json, key: k}\nskills: y\n"},
+		{"no name", "config_dir: .x\nmcp: {file: a.json, key: k}\n"},
+		{"no config dir", "name: x\nmcp: {file: a.json, key: k}\n"},
+		{"no MCP file", "name: x\nconfig_dir: .x\nmcp: {key: k}\n"},
+		{"no MCP key", "name: x\nconfig_dir: .x\nmcp: {file: a.json}\n"},
+		{"bad format", "name: x\nconfig_dir: .x\nmcp: {file: a.toml, key: k, format: toml}\n"},
+		{"enabled and disabled", "name: x\nconfig_dir: .x\nmcp: {file: a.json, key: k, fields: {enabled: on, disabled: off}}\n"},
+		{"unknown transport", "name: x\nconfig_dir: .x\nmcp: {file: a.json, key: k, transports: {websocket: ws}}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if !errors.Is(err, ErrInvalidDefinition) {
+				t.Errorf("Parse() error = %v, want ErrInvalidDefinition", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package generic
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// document is an MCP config file. Only the server map is decoded; the rest
+// of the file is written back as it was read.
+type document interface {
+	// servers returns the server map at path, or nil if there is none.
+	servers(path []string) (map[string]map[string]any, error)
+
+	// setServers replaces the server map at path, creating the objects
+	// leading to it as needed.
+	setServers(path []string, servers map[string]map[string]any) error
+
+	// bytes returns the file contents.
+	bytes() ([]byte, error)
+}
+
+// parseDocument parses a config file in the given format. Empty data is an
+// empty document.
+func parseDocument(format string, data []byte) (document, error) {
+	if format == FormatYAML {
+		return parseYAMLDocument(data)
+	}
+	return parseJSONDocument(data)
+}
+
+// yamlDocument is a YAML config file, held as a node tree so that comments
+// and key order outside the server map are preserved.
+type yamlDocument struct {
+	root *yaml.Node
+}
+
+// parseYAMLDocument checks that data is a YAML mapping.
+func parseYAMLDocument(data []byte) (*yamlDocument, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, errors.Wrap(err, "parsing YAML")
+	}
+	if root.Kind == 0 {
+		// Empty file
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("parsing YAML: top level is not a mapping")
+	}
+	return &yamlDocument{root: &root}, nil
+}
+
+func (d *yamlDocument) servers(path []string) (map[string]map[string]any, error) {
+	node := d.root.Content[0]
+	for i, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return nil, errors.Newf("%s is not a mapping", joinPath(path[:i]))
+		}
+		if node = mappingValue(node, key); node == nil {
+			return nil, nil
+		}
+	}
+
+	var servers map[string]map[string]any
+	if err := node.Decode(&servers); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", joinPath(path))
+	}
+	return servers, nil
+}
+
+func (d *yamlDocument) setServers(path []string, servers map[string]map[string]any) error {
+	var value yaml.Node
+	if err := value.Encode(servers); err != nil {
+		return errors.Wrap(err, "encoding YAML")
+	}
+
+	node := d.root.Content[0]
+	for i, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return errors.Newf("%s is not a mapping", joinPath(path[:i]))
+		}
+		next := mappingValue(node, key)
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, next)
+		}
+		node = next
+	}
+	*node = value
+	return nil
+}
+
+func (d *yamlDocument) bytes() ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(d.root); err != nil {
+		return nil, errors.Wrap(err, "marshaling YAML")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "marshaling YAML")
+	}
+	return b.Bytes(), nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil. As with
+// decoding, the last of several equal keys wins.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	var value *yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value = node.Content[i+1]
+		}
+	}
+	return value
+}
+
+// joinPath formats a key path for error messages.
+func joinPath(path []string) string {
+	return strings.Join(path, ".")
+}
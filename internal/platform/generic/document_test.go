@@ -0,0 +1,87 @@
+package generic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLDocument(t *testing.T) {
+	input := `# Goose config
+GOOSE_PROVIDER: anthropic
+extensions:
+  developer:
+    enabled: true
+    type: builtin
+GOOSE_MODEL: claude
+`
+	doc, err := parseDocument(FormatYAML, []byte(input))
+	if err != nil {
+		t.Fatalf("parseDocument() error = %v", err)
+	}
+
+	path := []string{"extensions"}
+	servers, err := doc.servers(path)
+	if err != nil {
+		t.Fatalf("servers() error = %v", err)
+	}
+	if servers["developer"]["type"] != "builtin" {
+		t.Fatalf("servers() = %v", servers)
+	}
+
+	servers["github"] = map[string]any{"cmd": "npx", "args": []string{"-y", "server-github"}}
+	if err := doc.setServers(path, servers); err != nil {
+		t.Fatalf("setServers() error = %v", err)
+	}
+	data, err := doc.bytes()
+	if err != nil {
+		t.Fatalf("bytes() error = %v", err)
+	}
+	got := string(data)
+
+	for _, want := range []string{"# Goose config", "GOOSE_PROVIDER: anthropic", "  github:\n    args:\n      - -y"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	// Keys outside the server map keep their order
+	if strings.Index(got, "GOOSE_PROVIDER") > strings.Index(got, "GOOSE_MODEL") {
+		t.Errorf("key order changed:\n%s", got)
+	}
+}
+
+func TestYAMLDocument_MissingKeys(t *testing.T) {
+	for _, input := range []string{"", "other: 1\n"} {
+		doc, err := parseDocument(FormatYAML, []byte(input))
+		if err != nil {
+			t.Fatalf("parseDocument(%q) error = %v", input, err)
+		}
+		path := []string{"a", "servers"}
+		if servers, err := doc.servers(path); err != nil || servers != nil {
+			t.Errorf("servers() = %v, %v; want nil, nil", servers, err)
+		}
+		if err := doc.setServers(path, map[string]map[string]any{"s": {"cmd": "x"}}); err != nil {
+			t.Fatalf("setServers() error = %v", err)
+		}
+		data, _ := doc.bytes()
+		if !strings.Contains(string(data), "a:\n  servers:\n    s:\n      cmd: x\n") {
+			t.Errorf("setServers() wrote:\n%s", data)
+		}
+	}
+}
+
+func TestYAMLDocument_Errors(t *testing.T) {
+	if _, err := parseDocument(FormatYAML, []byte("- a\n- b\n")); err == nil {
+		t.Error("parseDocument() accepted a top-level sequence")
+	}
+
+	doc, err := parseDocument(FormatYAML, []byte("extensions: none\n"))
+	if err != nil {
+		t.Fatalf("parseDocument() error = %v", err)
+	}
+	if _, err := doc.servers([]string{"extensions"}); err == nil {
+		t.Error("servers() accepted a string as the server map")
+	}
+	if err := doc.setServers([]string{"extensions", "x"}, nil); err == nil {
+		t.Error("setServers() descended into a string")
+	}
+}
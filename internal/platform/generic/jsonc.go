@@ -0,0 +1,37 @@
+package generic
+
+import (
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+// jsonDocument is a JSON config file, which may contain comments and
+// trailing commas. Only the server map is rewritten; see jsonc.Document.
+type jsonDocument struct {
+	doc *jsonc.Document
+}
+
+// parseJSONDocument checks that data is a JSON object, allowing comments
+// and trailing commas.
+func parseJSONDocument(data []byte) (*jsonDocument, error) {
+	doc, err := jsonc.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonDocument{doc: doc}, nil
+}
+
+func (d *jsonDocument) servers(path []string) (map[string]map[string]any, error) {
+	var servers map[string]map[string]any
+	if _, err := d.doc.Get(path, &servers); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+func (d *jsonDocument) setServers(path []string, servers map[string]map[string]any) error {
+	return d.doc.Set(path, servers)
+}
+
+func (d *jsonDocument) bytes() ([]byte, error) {
+	return d.doc.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package generic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/pkg/jsonc"
+)
+
+func TestJSONDocument_SetServersKeepsComments(t *testing.T) {
+	input := `// Zed settings
+{
+  "theme": "One Dark", // the theme
+  "context_servers": {
+    "old": {"command": "old-server"}
+  },
+  "vim_mode": true,
+}
+`
+	doc, err := parseJSONDocument([]byte(input))
+	if err != nil {
+		t.Fatalf("parseJSONDocument() error = %v", err)
+	}
+
+	path := []string{"context_servers"}
+	servers, err := doc.servers(path)
+	if err != nil {
+		t.Fatalf("servers() error = %v", err)
+	}
+	if servers["old"]["command"] != "old-server" {
+		t.Fatalf("servers() = %v", servers)
+	}
+
+	servers["new"] = map[string]any{"command": "new-server"}
+	if err := doc.setServers(path, servers); err != nil {
+		t.Fatalf("setServers() error = %v", err)
+	}
+	data, _ := doc.bytes()
+	got := string(data)
+
+	for _, want := range []string{"// Zed settings", "// the theme", `"vim_mode": true,`, `"new": {`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	// The map is indented like the key it belongs to
+	if !strings.Contains(got, "  \"context_servers\": {\n    \"new\": {\n      \"command\": \"new-server\"\n    },") {
+		t.Errorf("server map not indented as expected:\n%s", got)
+	}
+
+	var v map[string]any
+	if err := json.Unmarshal(jsonc.Strip(data), &v); err != nil {
+		t.Errorf("output is not valid JSONC: %v", err)
+	}
+}
+
+func TestJSONDocument_SetServersAddsMissingKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		path  []string
+		want  string
+	}{
+		{
+			name:  "empty file",
+			input: "",
+			path:  []string{"mcpServers"},
+			want:  "{\n  \"mcpServers\": {\n    \"s\": {\n      \"command\": \"x\"\n    }\n  }\n}\n",
+		},
+		{
+			name:  "empty object",
+			input: "{}",
+			path:  []string{"mcpServers"},
+			want:  "{\n  \"mcpServers\": {\n    \"s\": {\n      \"command\": \"x\"\n    }\n  }\n}",
+		},
+		{
+			name:  "after existing member",
+			input: "{\n  \"theme\": \"dark\"\n}",
+			path:  []string{"mcpServers"},
+			want:  "{\n  \"theme\": \"dark\",\n  \"mcpServers\": {\n    \"s\": {\n      \"command\": \"x\"\n    }\n  }\n}",
+		},
+		{
+			name:  "nested path",
+			input: "{\n  \"agent\": {\n    \"model\": \"m\"\n  }\n}",
+			path:  []string{"agent", "servers"},
+			want:  "{\n  \"agent\": {\n    \"model\": \"m\",\n    \"servers\": {\n      \"s\": {\n        \"command\": \"x\"\n      }\n    }\n  }\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseJSONDocument([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parseJSONDocument() error = %v", err)
+			}
+			servers := map[string]map[string]any{"s": {"command": "x"}}
+			if err := doc.setServers(tt.path, servers); err != nil {
+				t.Fatalf("setServers() error = %v", err)
+			}
+			data, _ := doc.bytes()
+			if string(data) != tt.want {
+				t.Errorf("setServers() wrote\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONDocument_Errors(t *testing.T) {
+	if _, err := parseJSONDocument([]byte(`{"a": `)); err == nil {
+		t.Error("parseJSONDocument() accepted invalid JSON")
+	}
+	if _, err := parseJSONDocument([]byte(`[1, 2]`)); err == nil {
+		t.Error("parseJSONDocument() accepted a top-level array")
+	}
+
+	doc, err := parseJSONDocument([]byte(`{"mcpServers": "none"}`))
+	if err != nil {
+		t.Fatalf("parseJSONDocument() error = %v", err)
+	}
+	if _, err := doc.servers([]string{"mcpServers"}); err == nil {
+		t.Error("servers() accepted a string as the server map")
+	}
+}
@@ -0,0 +1,406 @@
+package generic
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/secret"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// Sentinel errors for MCP operations.
+var (
+	ErrMCPServerNotFound = errors.New("MCP server not found")
+	ErrInvalidMCPServer  = errors.New("invalid MCP server: name required")
+)
+
+// noField marks a server field the platform does not have.
+const noField = "-"
+
+// MCPManager provides CRUD operations for the MCP servers of a defined
+// platform. Servers are read and written as canonical mcp.Server values,
+// with secret references in canonical form; the definition decides how
+// they are laid out in the config file.
+//
+// Only the server map is rewritten. Other settings in the file, and their
+// comments, are kept. Fields of a server entry that the definition does not
+// name are kept when a server is enabled or disabled, but not when it is
+// added again.
+type MCPManager struct {
+	def   *Definition
+	paths *GenericPaths
+}
+
+// NewMCPManager creates a new MCPManager instance.
+func NewMCPManager(def *Definition, paths *GenericPaths) *MCPManager {
+	return &MCPManager{
+		def:   def,
+		paths: paths,
+	}
+}
+
+// mcpConfig is a loaded config file and the server map found in it.
+type mcpConfig struct {
+	doc     document
+	servers map[string]map[string]any
+}
+
+// List returns all MCP servers from the configuration file.
+// Returns an empty slice if the config file does not exist.
+// The returned servers are sorted by name for deterministic ordering.
+func (m *MCPManager) List() ([]*mcp.Server, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*mcp.Server, 0, len(config.servers))
+	for name, entry := range config.servers {
+		servers = append(servers, m.def.decodeServer(name, entry))
+	}
+
+	// Sort by name for deterministic ordering
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+// Get returns a single MCP server by name.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Get(name string) (*mcp.Server, error) {
+	config, err := m.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := config.servers[name]
+	if !ok {
+		return nil, ErrMCPServerNotFound
+	}
+
+	return m.def.decodeServer(name, entry), nil
+}
+
+// Add adds or updates an MCP server in the configuration.
+// Returns ErrInvalidMCPServer if the server name is empty.
+func (m *MCPManager) Add(server *mcp.Server) error {
+	if server == nil || server.Name == "" {
+		return ErrInvalidMCPServer
+	}
+
+	entry, err := m.def.encodeServer(server)
+	if err != nil {
+		return err
+	}
+
+	return m.update(func(config *mcpConfig) error {
+		config.servers[server.Name] = entry
+		return nil
+	})
+}
+
+// Remove removes an MCP server from the configuration by name.
+// This operation is idempotent - removing a non-existent server does not error.
+func (m *MCPManager) Remove(name string) error {
+	return m.update(func(config *mcpConfig) error {
+		delete(config.servers, name)
+		return nil
+	})
+}
+
+// Enable marks the specified server as enabled.
+// Returns ErrMCPServerNotFound if the server does not exist.
+func (m *MCPManager) Enable(name string) error {
+	return m.setDisabled(name, false)
+}
+
+// Disable marks the specified server as disabled.
+// Returns ErrMCPServerNotFound if the server does not exist, and
+// errors.ErrNotSupported if the platform cannot disable servers.
+func (m *MCPManager) Disable(name string) error {
+	return m.setDisabled(name, true)
+}
+
+// setDisabled sets the enabled or disabled field of a server, whichever the
+// definition names. Enabling a server on a platform with neither only
+// checks that it exists.
+func (m *MCPManager) setDisabled(name string, disabled bool) error {
+	fields := m.def.MCP.Fields
+	if disabled && fields.Enabled == "" && fields.Disabled == "" {
+		return errors.Wrapf(errors.ErrNotSupported, "%s cannot disable MCP servers", m.def.DisplayName)
+	}
+
+	return m.update(func(config *mcpConfig) error {
+		entry, ok := config.servers[name]
+		if !ok {
+			return ErrMCPServerNotFound
+		}
+
+		switch {
+		case fields.Enabled != "":
+			entry[fields.Enabled] = !disabled
+		case disabled:
+			entry[fields.Disabled] = true
+		default:
+			delete(entry, fields.Disabled)
+		}
+		return nil
+	})
+}
+
+// update applies fn to the MCP configuration and saves the result. See
+// fileutil.Update for how concurrent changes are handled.
+func (m *MCPManager) update(fn func(config *mcpConfig) error) error {
+	configPath, err := m.configPath()
+	if err != nil {
+		return err
+	}
+	return fileutil.Update(configPath, m.loadConfig, fn, m.saveConfig)
+}
+
+// configPath returns the path of the config file for the current scope.
+func (m *MCPManager) configPath() (string, error) {
+	configPath := m.paths.MCPConfigPath()
+	if configPath == "" {
+		if m.paths.scope == ScopeProject {
+			return "", errors.Wrapf(errors.ErrNotSupported, "%s has no project configuration", m.def.DisplayName)
+		}
+		return "", errors.New("MCP config path not configured")
+	}
+	return configPath, nil
+}
+
+// loadConfig reads the MCP configuration from disk.
+// Returns an empty config with an initialized server map if the file doesn't exist.
+func (m *MCPManager) loadConfig() (*mcpConfig, error) {
+	configPath, err := m.configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading MCP config")
+	}
+
+	config, err := m.def.parseConfig(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing MCP config")
+	}
+	return config, nil
+}
+
+// saveConfig writes the MCP configuration to disk atomically.
+func (m *MCPManager) saveConfig(config *mcpConfig) error {
+	configPath, err := m.configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := config.doc.setServers(m.def.keyPath(), config.servers); err != nil {
+		return errors.Wrap(err, "updating MCP config")
+	}
+	data, err := config.doc.bytes()
+	if err != nil {
+		return err
+	}
+
+	// Create parent directory if needed
+	dir := filepath.Dir(configPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "creating directory %s", dir)
+	}
+
+	return errors.Wrap(fileutil.AtomicWriteFile(configPath, data, 0o600), "writing MCP config")
+}
+
+// ParseServers returns the MCP servers in the contents of a config file of
+// the platform, keyed by name.
+func (d *Definition) ParseServers(data []byte) (map[string]*mcp.Server, error) {
+	config, err := d.parseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	servers := make(map[string]*mcp.Server, len(config.servers))
+	for name, entry := range config.servers {
+		servers[name] = d.decodeServer(name, entry)
+	}
+	return servers, nil
+}
+
+// parseConfig parses the contents of a config file. Empty data is an empty
+// config.
+func (d *Definition) parseConfig(data []byte) (*mcpConfig, error) {
+	doc, err := parseDocument(d.MCP.Format, data)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := doc.servers(d.keyPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if servers == nil {
+		servers = make(map[string]map[string]any)
+	}
+	for name, entry := range servers {
+		if entry == nil {
+			servers[name] = make(map[string]any)
+		}
+	}
+	return &mcpConfig{doc: doc, servers: servers}, nil
+}
+
+// decodeServer converts a server entry to canonical form.
+func (d *Definition) decodeServer(name string, entry map[string]any) *mcp.Server {
+	f := d.MCP.Fields
+	syn := d.secretSyntax()
+	s := &mcp.Server{
+		Name:    name,
+		Command: stringField(entry, f.Command),
+		Args:    stringsField(entry, f.Args),
+		URL:     stringField(entry, f.URL),
+		Env:     secret.CanonicalizeMap(stringMapField(entry, f.Env), syn),
+		Headers: secret.CanonicalizeMap(stringMapField(entry, f.Headers), syn),
+	}
+
+	if value := stringField(entry, f.Transport); value != "" {
+		s.Transport = value
+		// Sorted so that a value shared by several transports reads back
+		// as the same one every time
+		for _, canonical := range slices.Sorted(maps.Keys(d.MCP.Transports)) {
+			if d.MCP.Transports[canonical] == value {
+				s.Transport = canonical
+				break
+			}
+		}
+	}
+	if s.Transport == "" {
+		s.Transport = mcp.TransportStdio
+		if s.URL != "" {
+			s.Transport = mcp.TransportHTTP
+		}
+	}
+
+	switch {
+	case f.Enabled != "":
+		enabled, ok := entry[f.Enabled].(bool)
+		s.Disabled = ok && !enabled
+	case f.Disabled != "":
+		s.Disabled, _ = entry[f.Disabled].(bool)
+	}
+	return s
+}
+
+// encodeServer converts a canonical server to a server entry, rendering
+// its secret references in the platform's syntax.
+func (d *Definition) encodeServer(s *mcp.Server) (map[string]any, error) {
+	f := d.MCP.Fields
+	syn := d.secretSyntax()
+
+	env, err := secret.RenderMap(s.Env, syn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q env", s.Name)
+	}
+	headers, err := secret.RenderMap(s.Headers, syn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "MCP server %q headers", s.Name)
+	}
+
+	entry := maps.Clone(d.MCP.Defaults)
+	if entry == nil {
+		entry = make(map[string]any)
+	}
+	setField(entry, f.Name, s.Name)
+	setField(entry, f.Command, s.Command)
+	if len(s.Args) > 0 {
+		setField(entry, f.Args, s.Args)
+	}
+	setField(entry, f.URL, s.URL)
+	if len(env) > 0 {
+		setField(entry, f.Env, env)
+	}
+	if len(headers) > 0 {
+		setField(entry, f.Headers, headers)
+	}
+
+	if f.Transport != "" {
+		transport := s.Transport
+		if transport == "" {
+			transport = mcp.TransportStdio
+			if s.URL != "" {
+				transport = mcp.TransportHTTP
+			}
+		}
+		if native, ok := d.MCP.Transports[transport]; ok {
+			transport = native
+		}
+		entry[f.Transport] = transport
+	}
+
+	switch {
+	case f.Enabled != "":
+		entry[f.Enabled] = !s.Disabled
+	case f.Disabled != "" && s.Disabled:
+		entry[f.Disabled] = true
+	}
+	return entry, nil
+}
+
+// setField sets entry[name] unless the platform has no such field or value
+// is an empty string.
+func setField(entry map[string]any, name string, value any) {
+	if name == "" || name == noField || value == "" {
+		return
+	}
+	entry[name] = value
+}
+
+// stringField returns entry[name] if it is a string.
+func stringField(entry map[string]any, name string) string {
+	if name == "" || name == noField {
+		return ""
+	}
+	s, _ := entry[name].(string)
+	return s
+}
+
+// stringsField returns the strings in the list entry[name].
+func stringsField(entry map[string]any, name string) []string {
+	if name == "" || name == noField {
+		return nil
+	}
+	list, _ := entry[name].([]any)
+	var out []string
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// stringMapField returns the string values in the map entry[name].
+func stringMapField(entry map[string]any, name string) map[string]string {
+	if name == "" || name == noField {
+		return nil
+	}
+	m, _ := entry[name].(map[string]any)
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
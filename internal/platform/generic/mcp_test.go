@@ -0,0 +1,268 @@
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aixerrors "github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+// builtinDef returns a built-in definition, failing the test if it is missing.
+func builtinDef(t *testing.T, name string) *Definition {
+	t.Helper()
+	def, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("no built-in definition for %q", name)
+	}
+	return def
+}
+
+// writeConfig writes a config file, creating its directory.
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMCPManager_Zed(t *testing.T) {
+	def := builtinDef(t, "zed")
+	paths := NewGenericPaths(def, ScopeProject, t.TempDir())
+	mgr := NewMCPManager(def, paths)
+
+	configPath := paths.MCPConfigPath()
+	writeConfig(t, configPath, `// Project settings
+{
+  "tab_size": 2, // two spaces
+}
+`)
+
+	t.Run("Add keeps comments", func(t *testing.T) {
+		err := mgr.Add(&mcp.Server{
+			Name:    "github",
+			Command: "npx",
+			Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+			Env:     map[string]string{"TOKEN": "plain"},
+		})
+		if err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		data, _ := os.ReadFile(configPath)
+		for _, want := range []string{"// Project settings", "// two spaces", `"context_servers": {`, `"enabled": true`} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("config missing %q:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		got, err := mgr.Get("github")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Command != "npx" || len(got.Args) != 2 || got.Transport != mcp.TransportStdio || got.Env["TOKEN"] != "plain" {
+			t.Errorf("Get() = %+v", got)
+		}
+	})
+
+	t.Run("Disable and Enable", func(t *testing.T) {
+		if err := mgr.Disable("github"); err != nil {
+			t.Fatalf("Disable failed: %v", err)
+		}
+		got, _ := mgr.Get("github")
+		if !got.Disabled {
+			t.Error("server not disabled")
+		}
+		if err := mgr.Enable("github"); err != nil {
+			t.Fatalf("Enable failed: %v", err)
+		}
+		got, _ = mgr.Get("github")
+		if got.Disabled {
+			t.Error("server still disabled")
+		}
+		if err := mgr.Enable("missing"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Enable(missing) error = %v, want ErrMCPServerNotFound", err)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		if err := mgr.Remove("github"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if _, err := mgr.Get("github"); !errors.Is(err, ErrMCPServerNotFound) {
+			t.Errorf("Get after Remove error = %v, want ErrMCPServerNotFound", err)
+		}
+		data, _ := os.ReadFile(configPath)
+		if !strings.Contains(string(data), "// two spaces") {
+			t.Errorf("Remove dropped comments:\n%s", data)
+		}
+	})
+}
+
+func TestMCPManager_Goose(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	def := builtinDef(t, "goose")
+	paths := NewGenericPaths(def, ScopeUser, "")
+	mgr := NewMCPManager(def, paths)
+
+	configPath := paths.MCPConfigPath()
+	if configPath != filepath.Join(home, ".config", "goose", "config.yaml") {
+		t.Fatalf("MCPConfigPath() = %q", configPath)
+	}
+	writeConfig(t, configPath, "GOOSE_PROVIDER: anthropic\nextensions:\n  developer:\n    enabled: true\n    name: developer\n    type: builtin\n")
+
+	err := mgr.Add(&mcp.Server{
+		Name:      "remote",
+		URL:       "https://example.com/mcp",
+		Transport: mcp.TransportHTTP,
+		Headers:   map[string]string{"X-Team": "platform"},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, want := range []string{
+		"GOOSE_PROVIDER: anthropic",
+		"type: streamable_http",
+		"uri: https://example.com/mcp",
+		"name: remote",
+		"timeout: 300",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config missing %q:\n%s", want, data)
+		}
+	}
+
+	servers, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(servers) != 2 || servers[0].Name != "developer" || servers[1].Name != "remote" {
+		t.Fatalf("List() = %+v", servers)
+	}
+	if got := servers[1]; got.Transport != mcp.TransportHTTP || got.URL != "https://example.com/mcp" || got.Headers["X-Team"] != "platform" {
+		t.Errorf("remote = %+v", got)
+	}
+	// Values without a mapping read back as they are
+	if got := servers[0]; got.Transport != "builtin" {
+		t.Errorf("developer Transport = %q, want builtin", got.Transport)
+	}
+}
+
+func TestMCPManager_Windsurf(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	def := builtinDef(t, "windsurf")
+	mgr := NewMCPManager(def, NewGenericPaths(def, ScopeUser, ""))
+
+	err := mgr.Add(&mcp.Server{
+		Name:    "remote",
+		URL:     "https://example.com/mcp?a=1&b=2",
+		Headers: map[string]string{"Authorization": "Bearer ${env:API_TOKEN}"},
+	})
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"))
+	if !strings.Contains(string(data), `"serverUrl": "https://example.com/mcp?a=1&b=2"`) {
+		t.Errorf("config = %s", data)
+	}
+	if !strings.Contains(string(data), `"Authorization": "Bearer ${env:API_TOKEN}"`) {
+		t.Errorf("env reference not written in Windsurf syntax: %s", data)
+	}
+
+	got, err := mgr.Get("remote")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Transport != mcp.TransportHTTP || got.Headers["Authorization"] != "Bearer ${env:API_TOKEN}" {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	if err := mgr.Disable("remote"); err != nil {
+		t.Fatalf("Disable failed: %v", err)
+	}
+	if got, _ := mgr.Get("remote"); !got.Disabled {
+		t.Error("server not disabled")
+	}
+	if err := mgr.Enable("remote"); err != nil {
+		t.Fatalf("Enable failed: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"))
+	if strings.Contains(string(data), "disabled") {
+		t.Errorf("Enable left the disabled field: %s", data)
+	}
+}
+
+func TestMCPManager_NoProjectConfig(t *testing.T) {
+	def := builtinDef(t, "windsurf")
+	mgr := NewMCPManager(def, NewGenericPaths(def, ScopeProject, t.TempDir()))
+
+	if _, err := mgr.List(); !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("List() error = %v, want ErrNotSupported", err)
+	}
+	if err := mgr.Add(&mcp.Server{Name: "s", Command: "x"}); !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("Add() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMCPManager_DisableNotSupported(t *testing.T) {
+	def, err := Parse([]byte("name: nodisable\nconfig_dir: .nodisable\nmcp: {file: mcp.json, key: servers}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Disable fails before the config is read, so the definition need not
+	// be registered
+	mgr := NewMCPManager(def, NewGenericPaths(def, ScopeUser, ""))
+
+	if err := mgr.Disable("s"); !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("Disable() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMCPManager_InvalidServer(t *testing.T) {
+	def := builtinDef(t, "zed")
+	mgr := NewMCPManager(def, NewGenericPaths(def, ScopeProject, t.TempDir()))
+	if err := mgr.Add(&mcp.Server{}); !errors.Is(err, ErrInvalidMCPServer) {
+		t.Errorf("Add() error = %v, want ErrInvalidMCPServer", err)
+	}
+}
+
+func TestDefinition_ParseServers(t *testing.T) {
+	def := builtinDef(t, "zed")
+	servers, err := def.ParseServers([]byte(`{
+  // comment
+  "context_servers": {
+    "a": {"command": "run-a", "enabled": false},
+    "b": {"url": "https://b.example.com"},
+  },
+}`))
+	if err != nil {
+		t.Fatalf("ParseServers() error = %v", err)
+	}
+	if !servers["a"].Disabled || servers["a"].Command != "run-a" {
+		t.Errorf("a = %+v", servers["a"])
+	}
+	if servers["b"].Transport != mcp.TransportHTTP || servers["b"].Disabled {
+		t.Errorf("b = %+v", servers["b"])
+	}
+
+	if _, err := def.ParseServers([]byte(`{"context_servers": `)); err == nil {
+		t.Error("ParseServers() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,75 @@
+package generic
+
+import (
+	"path/filepath"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// Scope defines whether paths resolve to user-level or project-level configuration.
+type Scope int
+
+const (
+	// ScopeUser resolves paths relative to the global config directory.
+	ScopeUser Scope = iota
+	// ScopeProject resolves paths relative to the project config directory.
+	ScopeProject
+)
+
+// GenericPaths provides path resolution for a defined platform. The
+// definition must be registered, as the locations come from the paths
+// package.
+type GenericPaths struct {
+	name        string
+	scope       Scope
+	projectRoot string
+	mcpFile     string
+}
+
+// NewGenericPaths creates a new GenericPaths instance for def.
+// For ScopeProject, projectRoot must be non-empty.
+// For ScopeUser, projectRoot is ignored.
+func NewGenericPaths(def *Definition, scope Scope, projectRoot string) *GenericPaths {
+	return &GenericPaths{
+		name:        def.Name,
+		scope:       scope,
+		projectRoot: projectRoot,
+		mcpFile:     def.MCP.File,
+	}
+}
+
+// BaseDir returns the base configuration directory.
+// For ScopeUser: the global config directory.
+// For ScopeProject: the project config directory.
+// Returns empty string if the platform has no project configuration or
+// projectRoot is empty for ScopeProject.
+func (p *GenericPaths) BaseDir() string {
+	switch p.scope {
+	case ScopeUser:
+		return paths.GlobalConfigDir(p.name)
+	case ScopeProject:
+		return paths.ProjectConfigDir(p.name, p.projectRoot)
+	default:
+		return ""
+	}
+}
+
+// MCPConfigPath returns the path to the file holding the MCP servers.
+// Returns <base>/<mcp.file>
+func (p *GenericPaths) MCPConfigPath() string {
+	base := p.BaseDir()
+	if base == "" {
+		return ""
+	}
+	return filepath.Join(base, p.mcpFile)
+}
+
+// InstructionsPath returns the path to the instructions file.
+// For ScopeProject: <projectRoot>/<instructions>
+// For ScopeUser: empty.
+func (p *GenericPaths) InstructionsPath() string {
+	if p.scope != ScopeProject {
+		return ""
+	}
+	return paths.InstructionsPath(p.name, p.projectRoot)
+}
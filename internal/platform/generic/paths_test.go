@@ -0,0 +1,46 @@
+package generic
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGenericPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	zed := builtinDef(t, "zed")
+	windsurf := builtinDef(t, "windsurf")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"zed user MCP", NewGenericPaths(zed, ScopeUser, "").MCPConfigPath(), filepath.Join(home, ".config", "zed", "settings.json")},
+		{"zed project MCP", NewGenericPaths(zed, ScopeProject, "/proj").MCPConfigPath(), filepath.Join("/proj", ".zed", "settings.json")},
+		{"zed project instructions", NewGenericPaths(zed, ScopeProject, "/proj").InstructionsPath(), filepath.Join("/proj", ".rules")},
+		{"zed user instructions", NewGenericPaths(zed, ScopeUser, "").InstructionsPath(), ""},
+		{"windsurf user MCP", NewGenericPaths(windsurf, ScopeUser, "").MCPConfigPath(), filepath.Join(home, ".codeium", "windsurf", "mcp_config.json")},
+		{"windsurf project MCP", NewGenericPaths(windsurf, ScopeProject, "/proj").MCPConfigPath(), ""},
+		{"project without root", NewGenericPaths(zed, ScopeProject, "").MCPConfigPath(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenericPaths_ConfigDirOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("AIX_GOOSE_CONFIG_DIR", dir)
+
+	got := NewGenericPaths(builtinDef(t, "goose"), ScopeUser, "").MCPConfigPath()
+	if want := filepath.Join(dir, "config.yaml"); got != want {
+		t.Errorf("MCPConfigPath() = %q, want %q", got, want)
+	}
+}
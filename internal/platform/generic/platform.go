@@ -0,0 +1,174 @@
+package generic
+
+import (
+	"os"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// GenericPlatform provides the platform adapter for a defined platform.
+// Only MCP servers are managed; the platform has no skill, command or agent
+// directories.
+type GenericPlatform struct {
+	def   *Definition
+	paths *GenericPaths
+	mcp   *MCPManager
+}
+
+// Option configures a GenericPlatform instance.
+type Option func(*GenericPlatform)
+
+// WithScope sets the scope (user or project) for path resolution.
+func WithScope(scope Scope) Option {
+	return func(p *GenericPlatform) {
+		p.paths = NewGenericPaths(p.def, scope, p.paths.projectRoot)
+	}
+}
+
+// WithProjectRoot sets the project root directory for project-scoped paths.
+func WithProjectRoot(root string) Option {
+	return func(p *GenericPlatform) {
+		p.paths = NewGenericPaths(p.def, p.paths.scope, root)
+	}
+}
+
+// NewGenericPlatform creates a new GenericPlatform for a registered
+// definition with the given options. Default configuration uses ScopeUser
+// with no project root.
+func NewGenericPlatform(def *Definition, opts ...Option) *GenericPlatform {
+	p := &GenericPlatform{
+		def:   def,
+		paths: NewGenericPaths(def, ScopeUser, ""),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.mcp = NewMCPManager(def, p.paths)
+
+	return p
+}
+
+// Name returns the platform identifier.
+func (p *GenericPlatform) Name() string {
+	return p.def.Name
+}
+
+// DisplayName returns a human-readable platform name.
+func (p *GenericPlatform) DisplayName() string {
+	return p.def.DisplayName
+}
+
+// Definition returns the platform's definition.
+func (p *GenericPlatform) Definition() *Definition {
+	return p.def
+}
+
+// --- Path Methods ---
+
+// GlobalConfigDir returns the global configuration directory.
+func (p *GenericPlatform) GlobalConfigDir() string {
+	return paths.GlobalConfigDir(p.def.Name)
+}
+
+// ProjectConfigDir returns the project-scoped configuration directory, or
+// an empty string if the platform has none.
+func (p *GenericPlatform) ProjectConfigDir(projectRoot string) string {
+	return paths.ProjectConfigDir(p.def.Name, projectRoot)
+}
+
+// SkillDir returns an empty string; skills are not supported.
+func (p *GenericPlatform) SkillDir() string {
+	return ""
+}
+
+// CommandDir returns an empty string; commands are not supported.
+func (p *GenericPlatform) CommandDir() string {
+	return ""
+}
+
+// AgentDir returns an empty string; agents are not supported.
+func (p *GenericPlatform) AgentDir() string {
+	return ""
+}
+
+// MCPConfigPath returns the path to the file holding the MCP servers.
+func (p *GenericPlatform) MCPConfigPath() string {
+	return p.paths.MCPConfigPath()
+}
+
+// InstructionsPath returns the path to the instructions file in
+// projectRoot, or in the current project for an empty projectRoot.
+func (p *GenericPlatform) InstructionsPath(projectRoot string) string {
+	if projectRoot != "" {
+		return NewGenericPaths(p.def, ScopeProject, projectRoot).InstructionsPath()
+	}
+	return p.paths.InstructionsPath()
+}
+
+// --- MCP Operations ---
+
+// AddMCP adds or updates an MCP server.
+func (p *GenericPlatform) AddMCP(s *mcp.Server) error {
+	return p.mcp.Add(s)
+}
+
+// RemoveMCP removes an MCP server by name.
+func (p *GenericPlatform) RemoveMCP(name string) error {
+	return p.mcp.Remove(name)
+}
+
+// ListMCP returns all configured MCP servers.
+func (p *GenericPlatform) ListMCP() ([]*mcp.Server, error) {
+	return p.mcp.List()
+}
+
+// GetMCP retrieves an MCP server by name.
+func (p *GenericPlatform) GetMCP(name string) (*mcp.Server, error) {
+	return p.mcp.Get(name)
+}
+
+// EnableMCP enables an MCP server by name.
+func (p *GenericPlatform) EnableMCP(name string) error {
+	return p.mcp.Enable(name)
+}
+
+// DisableMCP disables an MCP server by name.
+func (p *GenericPlatform) DisableMCP(name string) error {
+	return p.mcp.Disable(name)
+}
+
+// --- Backup Methods ---
+
+// BackupPaths returns all config files/directories that should be backed up:
+// the file holding the MCP servers, if the current scope has one.
+func (p *GenericPlatform) BackupPaths() []string {
+	if path := p.paths.MCPConfigPath(); path != "" {
+		return []string{path}
+	}
+	return nil
+}
+
+// --- Status Methods ---
+
+// IsAvailable checks if the platform is available on this system.
+// Returns true if its global config directory exists.
+func (p *GenericPlatform) IsAvailable() bool {
+	globalDir := p.GlobalConfigDir()
+	if globalDir == "" {
+		return false
+	}
+	info, err := os.Stat(globalDir)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
+
+// Version returns the platform version.
+// Currently returns an empty string as version detection is not yet implemented.
+func (p *GenericPlatform) Version() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,62 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+)
+
+func TestGenericPlatform(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	p := NewGenericPlatform(builtinDef(t, "goose"))
+	if p.Name() != "goose" || p.DisplayName() != "Goose" {
+		t.Errorf("Name() = %q, DisplayName() = %q", p.Name(), p.DisplayName())
+	}
+	if p.SkillDir() != "" || p.CommandDir() != "" || p.AgentDir() != "" {
+		t.Error("resource directories should be empty")
+	}
+
+	if p.IsAvailable() {
+		t.Error("IsAvailable() = true before the config directory exists")
+	}
+	if err := os.MkdirAll(filepath.Join(home, ".config", "goose"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsAvailable() {
+		t.Error("IsAvailable() = false with the config directory present")
+	}
+
+	if got := p.BackupPaths(); len(got) != 1 || got[0] != p.MCPConfigPath() {
+		t.Errorf("BackupPaths() = %v, want the MCP config", got)
+	}
+
+	if err := p.AddMCP(&mcp.Server{Name: "s", Command: "run"}); err != nil {
+		t.Fatalf("AddMCP failed: %v", err)
+	}
+	servers, err := p.ListMCP()
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("ListMCP() = %v, %v", servers, err)
+	}
+}
+
+func TestGenericPlatform_ProjectScope(t *testing.T) {
+	root := t.TempDir()
+
+	zed := NewGenericPlatform(builtinDef(t, "zed"), WithScope(ScopeProject), WithProjectRoot(root))
+	if got, want := zed.MCPConfigPath(), filepath.Join(root, ".zed", "settings.json"); got != want {
+		t.Errorf("MCPConfigPath() = %q, want %q", got, want)
+	}
+	if got, want := zed.InstructionsPath(""), filepath.Join(root, ".rules"); got != want {
+		t.Errorf("InstructionsPath() = %q, want %q", got, want)
+	}
+
+	goose := NewGenericPlatform(builtinDef(t, "goose"), WithScope(ScopeProject), WithProjectRoot(root))
+	if got := goose.BackupPaths(); len(got) != 0 {
+		t.Errorf("BackupPaths() = %v, want none without project configuration", got)
+	}
+}
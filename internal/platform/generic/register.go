@@ -0,0 +1,125 @@
+package generic
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform"
+)
+
+// builtin holds the definitions shipped with aix, one per file.
+//
+//go:embed builtin/*.yaml
+var builtin embed.FS
+
+var (
+	mu          sync.RWMutex
+	definitions = make(map[string]*Definition)
+
+	// registry records the names of the defined platforms.
+	registry = platform.NewRegistry()
+)
+
+func init() {
+	entries, err := builtin.ReadDir("builtin")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := builtin.ReadFile("builtin/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		def, err := Parse(data)
+		if err == nil {
+			err = Register(def)
+		}
+		if err != nil {
+			panic("built-in platform definition " + entry.Name() + ": " + err.Error())
+		}
+	}
+}
+
+// Register adds a defined platform. Its locations are registered with the
+// paths package, so it is detected and accepted by --platform like the
+// built-in platforms, and its name with the package's platform.Registry.
+//
+// Register is meant to be called while the program initializes; see
+// paths.RegisterPlatform.
+func Register(def *Definition) error {
+	if err := paths.RegisterPlatform(def.spec()); err != nil {
+		return errors.Wrapf(err, "registering platform %q", def.Name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := registry.Register(def.Name); err != nil {
+		return errors.Wrapf(err, "registering platform %q", def.Name)
+	}
+	definitions[def.Name] = def
+	return nil
+}
+
+// Lookup returns the definition of a defined platform.
+func Lookup(name string) (*Definition, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	def, ok := definitions[name]
+	return def, ok
+}
+
+// Definitions returns the defined platforms in the order of
+// paths.Platforms().
+func Definitions() []*Definition {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := registry.All()
+	defs := make([]*Definition, len(names))
+	for i, name := range names {
+		defs[i] = definitions[name]
+	}
+	return defs
+}
+
+// LoadDir registers the definitions in the .yaml and .yml files of dir, in
+// file name order. A missing directory is not an error. Loading stops at
+// the first definition that is invalid or whose name is taken.
+func LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return errors.Wrap(err, "reading platform definitions")
+	}
+
+	// os.ReadDir sorts by file name
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrap(err, "reading platform definition")
+		}
+		def, err := Parse(data)
+		if err == nil {
+			err = Register(def)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "loading %s", file)
+		}
+	}
+	return nil
+}
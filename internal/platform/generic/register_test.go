@@ -0,0 +1,84 @@
+package generic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+func TestBuiltinDefinitions(t *testing.T) {
+	want := map[string]string{
+		"zed":      "Zed",
+		"goose":    "Goose",
+		"windsurf": "Windsurf",
+	}
+	for name, displayName := range want {
+		def, ok := Lookup(name)
+		if !ok {
+			t.Errorf("Lookup(%q) found no definition", name)
+			continue
+		}
+		if def.DisplayName != displayName {
+			t.Errorf("%s DisplayName = %q, want %q", name, def.DisplayName, displayName)
+		}
+		if !paths.ValidPlatform(name) {
+			t.Errorf("paths.ValidPlatform(%q) = false", name)
+		}
+		if !registry.Get(name) {
+			t.Errorf("%q is not in the registry", name)
+		}
+	}
+
+	defs := Definitions()
+	if len(defs) < len(want) {
+		t.Errorf("Definitions() returned %d definitions, want at least %d", len(defs), len(want))
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("loaddir-test.yaml", "name: loaddir-test\nconfig_dir: .loaddir-test\nmcp: {file: mcp.json, key: servers}\n")
+	write("README.md", "not a definition")
+
+	if err := LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if _, ok := Lookup("loaddir-test"); !ok {
+		t.Error("LoadDir() did not register loaddir-test")
+	}
+
+	// Loading it again collides with the registered name
+	err := LoadDir(dir)
+	if !errors.Is(err, paths.ErrPlatformExists) {
+		t.Errorf("LoadDir() error = %v, want ErrPlatformExists", err)
+	}
+}
+
+func TestLoadDir_Missing(t *testing.T) {
+	if err := LoadDir(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("LoadDir() error = %v, want nil for a missing directory", err)
+	}
+}
+
+func TestLoadDir_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.yml"), []byte("name: bad\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := LoadDir(dir)
+	if !errors.Is(err, ErrInvalidDefinition) {
+		t.Errorf("LoadDir() error = %v, want ErrInvalidDefinition", err)
+	}
+	if paths.ValidPlatform("bad") {
+		t.Error("an invalid definition was registered")
+	}
+}
@@ -12,7 +12,9 @@ const (
 	// TypeLocal indicates a local process server (maps to canonical "stdio").
 	TypeLocal = "local"
 
-	// TypeRemote indicates a remote HTTP/SSE server (maps to canonical "sse").
+	// TypeRemote indicates a remote server (maps to canonical "http").
+	// OpenCode connects with Streamable HTTP and falls back to SSE, so the
+	// config does not record which of the two a server speaks.
 	TypeRemote = "remote"
 )
 
@@ -21,10 +23,11 @@ const (
 // OpenCode uses different field names and structures:
 //   - "mcp" key instead of "mcpServers"
 //   - "command" is []string (combined cmd + args) instead of separate fields
-//   - "type" ("local"/"remote") instead of "transport" ("stdio"/"sse")
+//   - "type" ("local"/"remote") instead of "transport" ("stdio"/"http"/"sse")
 //   - "environment" instead of "env"
 //   - "enabled" (positive logic) instead of "disabled" (negative logic)
 //   - No "platforms" field (LOSSY: this field is not preserved)
+//   - No distinction between "http" and "sse" (LOSSY: remote servers read back as "http")
 type MCPTranslator struct{}
 
 // NewMCPTranslator creates a new OpenCode MCP translator.
@@ -45,7 +48,7 @@ func NewMCPTranslator() *MCPTranslator {
 // Field mappings:
 //   - Command ([]string) -> Command (string) + Args ([]string)
 //   - Type "local" -> Transport "stdio"
-//   - Type "remote" -> Transport "sse"
+//   - Type "remote" -> Transport "http"
 //   - Environment -> Env
 func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 	// First try to parse as MCPConfig (with mcp wrapper)
@@ -99,11 +102,11 @@ func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 		case TypeLocal:
 			server.Transport = mcp.TransportStdio
 		case TypeRemote:
-			server.Transport = mcp.TransportSSE
+			server.Transport = mcp.TransportHTTP
 		default:
 			// Infer transport from context
 			if openServer.URL != "" {
-				server.Transport = mcp.TransportSSE
+				server.Transport = mcp.TransportHTTP
 			} else if len(openServer.Command) > 0 {
 				server.Transport = mcp.TransportStdio
 			}
@@ -127,7 +130,7 @@ func (t *MCPTranslator) ToCanonical(platformData []byte) (*mcp.Config, error) {
 // Field mappings:
 //   - Command (string) + Args ([]string) -> Command ([]string)
 //   - Transport "stdio" -> Type "local"
-//   - Transport "sse" -> Type "remote"
+//   - Transport "http" or "sse" -> Type "remote"
 //   - Env -> Environment
 //
 // NOTE: The Platforms field from canonical format is NOT preserved.
@@ -170,7 +173,7 @@ func (t *MCPTranslator) FromCanonical(cfg *mcp.Config) ([]byte, error) {
 		switch server.Transport {
 		case mcp.TransportStdio:
 			openServer.Type = TypeLocal
-		case mcp.TransportSSE:
+		case mcp.TransportHTTP, mcp.TransportSSE:
 			openServer.Type = TypeRemote
 		default:
 			// Infer type from context
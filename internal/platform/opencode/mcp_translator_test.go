@@ -85,7 +85,7 @@ func TestMCPTranslator_ToCanonical(t *testing.T) {
 			},
 		},
 		{
-			name: "type remote maps to transport sse",
+			name: "type remote maps to transport http",
 			input: `{
 				"mcp": {
 					"remote-server": {
@@ -106,8 +106,8 @@ func TestMCPTranslator_ToCanonical(t *testing.T) {
 				if server.URL != "https://api.example.com/mcp" {
 					t.Errorf("URL = %q, want %q", server.URL, "https://api.example.com/mcp")
 				}
-				if server.Transport != mcp.TransportSSE {
-					t.Errorf("Transport = %q, want %q", server.Transport, mcp.TransportSSE)
+				if server.Transport != mcp.TransportHTTP {
+					t.Errorf("Transport = %q, want %q", server.Transport, mcp.TransportHTTP)
 				}
 				if server.Headers["Authorization"] != "Bearer token" {
 					t.Errorf("Headers[Authorization] = %q, want %q", server.Headers["Authorization"], "Bearer token")
@@ -157,8 +157,8 @@ func TestMCPTranslator_ToCanonical(t *testing.T) {
 				if server == nil {
 					t.Fatal("inferred-remote not found")
 				}
-				if server.Transport != mcp.TransportSSE {
-					t.Errorf("Transport = %q, want %q (inferred from URL)", server.Transport, mcp.TransportSSE)
+				if server.Transport != mcp.TransportHTTP {
+					t.Errorf("Transport = %q, want %q (inferred from URL)", server.Transport, mcp.TransportHTTP)
 				}
 			},
 		},
@@ -558,7 +558,7 @@ func TestMCPTranslator_RoundTrip_CanonicalToOpenCode(t *testing.T) {
 			"remote-server": {
 				Name:      "remote-server",
 				URL:       "https://api.example.com/mcp",
-				Transport: mcp.TransportSSE,
+				Transport: mcp.TransportHTTP,
 				Headers:   map[string]string{"Authorization": "Bearer token"},
 				Disabled:  true,
 			},
@@ -675,8 +675,8 @@ func TestMCPTranslator_RoundTrip_OpenCodeToCanonical(t *testing.T) {
 	if apiServer.URL != "https://api.example.com/mcp" {
 		t.Errorf("api-server.URL = %q, want %q", apiServer.URL, "https://api.example.com/mcp")
 	}
-	if apiServer.Transport != mcp.TransportSSE {
-		t.Errorf("api-server.Transport = %q, want %q", apiServer.Transport, mcp.TransportSSE)
+	if apiServer.Transport != mcp.TransportHTTP {
+		t.Errorf("api-server.Transport = %q, want %q", apiServer.Transport, mcp.TransportHTTP)
 	}
 	if !apiServer.Disabled {
 		t.Error("api-server.Disabled = false, want true")
@@ -233,7 +233,18 @@ func (p *OpenCodePlatform) ValidateVariables(content string) error {
 // For OpenCode, this includes:
 //   - ~/.config/opencode/opencode.json (MCP config)
 //   - ~/.config/opencode/ directory (skills, commands, agents)
+//
+// In project scope the base directory is the project root itself, so only
+// the OpenCode-owned files and directories are returned.
 func (p *OpenCodePlatform) BackupPaths() []string {
+	if p.paths.scope == ScopeProject {
+		return []string{
+			p.paths.MCPConfigPath(),
+			p.paths.SkillDir(),
+			p.paths.CommandDir(),
+			p.paths.AgentDir(),
+		}
+	}
 	return []string{
 		p.paths.MCPConfigPath(),
 		p.paths.BaseDir(),
@@ -83,6 +83,20 @@ func TestNewOpenCodePlatform_CombinedOptions(t *testing.T) {
 	}
 }
 
+func TestOpenCodePlatform_BackupPaths_ProjectScope(t *testing.T) {
+	root := "/test/project"
+	p := NewOpenCodePlatform(WithScope(ScopeProject), WithProjectRoot(root))
+
+	for _, path := range p.BackupPaths() {
+		if path == root {
+			t.Errorf("BackupPaths() includes the project root itself")
+		}
+	}
+	if got := len(p.BackupPaths()); got != 4 {
+		t.Errorf("BackupPaths() returned %d paths, want 4", got)
+	}
+}
+
 func TestOpenCodePlatform_Identity(t *testing.T) {
 	p := NewOpenCodePlatform()
 
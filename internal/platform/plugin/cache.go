@@ -0,0 +1,115 @@
+package plugin
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// cacheFileName is the file in the aix cache directory that keeps plugin
+// descriptions between runs.
+const cacheFileName = "plugins.json"
+
+// cachedDescription is the description of a plugin executable as it was
+// when the plugin described itself.
+type cachedDescription struct {
+	// Target is the executable with symlinks resolved.
+	Target      string      `json:"target"`
+	ModTime     time.Time   `json:"mod_time"`
+	Size        int64       `json:"size"`
+	Description Description `json:"description"`
+}
+
+// descriptionCache keeps plugin descriptions so that a plugin is only run
+// to describe itself when its executable changes. Executables are keyed by
+// their path, and compared by the file the path resolves to, its
+// modification time and its size.
+//
+// The cache only saves time: a cache that cannot be read is empty, and one
+// that cannot be written is not kept.
+type descriptionCache struct {
+	path string
+	old  map[string]cachedDescription
+
+	// entries holds the descriptions of the executables looked up or stored
+	// since the cache was read; save writes only these.
+	entries map[string]cachedDescription
+}
+
+// cachePath returns the location of the description cache.
+func cachePath() string {
+	return filepath.Join(paths.CacheHome(), "aix", cacheFileName)
+}
+
+// loadCache reads the description cache at path.
+func loadCache(path string) *descriptionCache {
+	c := &descriptionCache{path: path, entries: make(map[string]cachedDescription)}
+	if data, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(data, &c.old)
+	}
+	return c
+}
+
+// stat returns the cache entry of the executable at path, without a
+// description.
+func stat(path string) (cachedDescription, error) {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return cachedDescription{}, err
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return cachedDescription{}, err
+	}
+	return cachedDescription{Target: target, ModTime: info.ModTime(), Size: info.Size()}, nil
+}
+
+// sameFile reports whether a and b were made for the same executable.
+func sameFile(a, b cachedDescription) bool {
+	return a.Target == b.Target && a.ModTime.Equal(b.ModTime) && a.Size == b.Size
+}
+
+// lookup returns the cached description of the executable at path, if it
+// has not changed since it was stored.
+func (c *descriptionCache) lookup(path string) (*Description, bool) {
+	now, err := stat(path)
+	if err != nil {
+		return nil, false
+	}
+	e, ok := c.old[path]
+	if !ok || !sameFile(e, now) {
+		return nil, false
+	}
+	c.entries[path] = e
+	desc := e.Description
+	return &desc, true
+}
+
+// store records desc as the description of the executable at path.
+func (c *descriptionCache) store(path string, desc *Description) {
+	e, err := stat(path)
+	if err != nil {
+		return
+	}
+	e.Description = *desc
+	c.entries[path] = e
+}
+
+// save writes the cache if it changed. Executables that were not looked up
+// or stored, such as plugins that were removed, are dropped.
+func (c *descriptionCache) save() {
+	if maps.EqualFunc(c.old, c.entries, func(a, b cachedDescription) bool {
+		return sameFile(a, b) && a.Description == b.Description
+	}) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return
+	}
+	_ = fileutil.AtomicWriteJSON(c.path, c.entries)
+}
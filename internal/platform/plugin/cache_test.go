@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDescriptionCache(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, ExecutablePrefix+"acme")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, ExecutablePrefix+"other")
+	if err := os.Symlink(exe, link); err != nil {
+		t.Fatal(err)
+	}
+	cacheFile := filepath.Join(dir, "cache", cacheFileName)
+
+	c := loadCache(cacheFile)
+	if _, ok := c.lookup(exe); ok {
+		t.Fatal("lookup() in an empty cache found a description")
+	}
+	c.store(exe, &Description{ProtocolVersion: ProtocolVersion, ConfigDir: ".acme"})
+	c.save()
+
+	c = loadCache(cacheFile)
+	desc, ok := c.lookup(exe)
+	if !ok || desc.ConfigDir != ".acme" {
+		t.Fatalf("lookup() = %+v, %v; want the stored description", desc, ok)
+	}
+	// Another name for the same file is a different plugin
+	if _, ok := c.lookup(link); ok {
+		t.Error("lookup() of a symlink found the description of its target")
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(exe, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadCache(cacheFile).lookup(exe); ok {
+		t.Error("lookup() after the executable changed found the old description")
+	}
+}
+
+func TestDescriptionCache_DropsUnused(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, ExecutablePrefix+"acme")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cacheFile := filepath.Join(dir, cacheFileName)
+
+	c := loadCache(cacheFile)
+	c.store(exe, &Description{ProtocolVersion: ProtocolVersion, ConfigDir: ".acme"})
+	c.save()
+
+	// A load that does not see the plugin forgets it
+	loadCache(cacheFile).save()
+	if _, ok := loadCache(cacheFile).lookup(exe); ok {
+		t.Error("save() kept a description that was not looked up")
+	}
+}
@@ -0,0 +1,165 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+// callTimeout bounds a single plugin invocation.
+const callTimeout = 30 * time.Second
+
+// describeTimeout bounds the describe request, which plugins answer without
+// doing any work. It is short because plugins are described as soon as a
+// command needs to know every platform.
+const describeTimeout = 2 * time.Second
+
+// stderrTail is how much of a plugin's stderr is kept for error messages.
+const stderrTail = 2048
+
+// Sentinel errors for plugin operations.
+var (
+	// ErrNotFound indicates the plugin reported that a resource does not
+	// exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrPluginFailed indicates a plugin exited without a valid response.
+	ErrPluginFailed = errors.New("plugin failed")
+
+	// ErrInvalidPlugin indicates a plugin's description is unusable.
+	ErrInvalidPlugin = errors.New("invalid plugin")
+)
+
+// Scope defines whether requests target user-level or project-level
+// configuration.
+type Scope int
+
+const (
+	// ScopeUser targets the user's configuration.
+	ScopeUser Scope = iota
+	// ScopeProject targets a project's configuration.
+	ScopeProject
+)
+
+// String returns the scope as sent in requests.
+func (s Scope) String() string {
+	if s == ScopeProject {
+		return "project"
+	}
+	return "user"
+}
+
+// client runs a plugin executable, one process per request.
+type client struct {
+	name        string
+	path        string
+	scope       Scope
+	projectRoot string
+}
+
+// call sends method with params to the plugin and decodes the result into
+// result, which may be nil for methods without one. Errors reported by the
+// plugin are mapped to ErrNotFound and errors.ErrNotSupported where their
+// code says so.
+func (c *client) call(method string, params, result any) error {
+	req := Request{
+		Version: ProtocolVersion,
+		Method:  method,
+		Scope:   c.scope.String(),
+	}
+	if c.scope == ScopeProject {
+		req.ProjectRoot = c.projectRoot
+	}
+	if method != MethodDescribe {
+		req.ConfigDir = paths.GlobalConfigDir(c.name)
+	}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return errors.Wrapf(err, "encoding %s request", method)
+		}
+		req.Params = data
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrapf(err, "encoding %s request", method)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout(method))
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.path)
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	// A plugin may report an error and exit non-zero; the response says
+	// more than the exit status
+	var resp Response
+	if err := json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &resp); err != nil {
+		return c.failure(method, runErr, &stderr, ctx.Err())
+	}
+	if resp.Error != nil {
+		return resp.Error.err(method)
+	}
+	if runErr != nil {
+		return c.failure(method, runErr, &stderr, ctx.Err())
+	}
+
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return errors.Wrapf(json.Unmarshal(resp.Result, result), "decoding %s result", method)
+}
+
+// timeout returns how long a request for method may take.
+func timeout(method string) time.Duration {
+	if method == MethodDescribe {
+		return describeTimeout
+	}
+	return callTimeout
+}
+
+// failure describes a plugin that produced no usable response, including
+// the end of its stderr.
+func (c *client) failure(method string, runErr error, stderr *bytes.Buffer, ctxErr error) error {
+	status := "no response"
+	switch {
+	case ctxErr != nil:
+		status = "timed out after " + timeout(method).String()
+	case runErr != nil:
+		status = runErr.Error()
+	}
+	tail := strings.TrimSpace(stderr.String())
+	if len(tail) > stderrTail {
+		tail = tail[len(tail)-stderrTail:]
+	}
+	if tail != "" {
+		return errors.Newf("%w: %s: %s (%s): %s", ErrPluginFailed, c.path, method, status, tail)
+	}
+	return errors.Newf("%w: %s: %s (%s)", ErrPluginFailed, c.path, method, status)
+}
+
+// err converts a reported error to a Go error.
+func (e *Error) err(method string) error {
+	msg := e.Message
+	if msg == "" {
+		msg = method + " failed"
+	}
+	switch e.Code {
+	case CodeNotFound:
+		return errors.Newf("%w: %s", ErrNotFound, msg)
+	case CodeNotSupported:
+		return errors.Newf("%w: %s", errors.ErrNotSupported, msg)
+	default:
+		return errors.New(msg)
+	}
+}
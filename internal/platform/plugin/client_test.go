@@ -0,0 +1,70 @@
+package plugin
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aixerrors "github.com/thoreinstein/aix/internal/errors"
+)
+
+func TestClient_Call(t *testing.T) {
+	dir := installFakePlugin(t, "client", "ok")
+	c := &client{path: filepath.Join(dir, ExecutablePrefix+"client")}
+
+	var desc Description
+	if err := c.call(MethodDescribe, nil, &desc); err != nil {
+		t.Fatalf("call(describe) error = %v", err)
+	}
+	if desc.ProtocolVersion != ProtocolVersion || desc.ConfigDir != ".client" {
+		t.Errorf("describe = %+v", desc)
+	}
+
+	// Methods without a result accept a nil result
+	if err := c.call(MethodSkillUninstall, NameParams{Name: "x"}, nil); err != nil {
+		t.Errorf("call(skill.uninstall) error = %v", err)
+	}
+}
+
+func TestClient_CallErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		method  string
+		wantErr error
+		wantMsg string
+	}{
+		{"not found", "ok", MethodMCPGet, ErrNotFound, `server "" does not exist`},
+		{"not supported", "ok", MethodAgentList, aixerrors.ErrNotSupported, "agent.list is not supported"},
+		{"crash", "crash", MethodMCPList, ErrPluginFailed, "fatal: config is corrupt"},
+		{"not a response", "garbage", MethodMCPList, ErrPluginFailed, "mcp.list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := installFakePlugin(t, "errors", tt.mode)
+			c := &client{path: filepath.Join(dir, ExecutablePrefix+"errors")}
+
+			err := c.call(tt.method, nil, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("call() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("call() error = %v, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestClient_MissingExecutable(t *testing.T) {
+	c := &client{path: filepath.Join(t.TempDir(), ExecutablePrefix+"missing")}
+	if err := c.call(MethodDescribe, nil, nil); !errors.Is(err, ErrPluginFailed) {
+		t.Errorf("call() error = %v, want ErrPluginFailed", err)
+	}
+}
+
+func TestScope_String(t *testing.T) {
+	if ScopeUser.String() != "user" || ScopeProject.String() != "project" {
+		t.Errorf("String() = %q, %q", ScopeUser.String(), ScopeProject.String())
+	}
+}
@@ -0,0 +1,301 @@
+package plugin
+
+import (
+	"os"
+	"sync"
+
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+)
+
+// PluginPlatform provides the platform adapter for a plugin. Every
+// operation runs the plugin executable; its paths are asked for once and
+// cached.
+type PluginPlatform struct {
+	plugin *Plugin
+	client *client
+
+	pathsOnce sync.Once
+	paths     Paths
+	pathsErr  error
+}
+
+// Option configures a PluginPlatform instance.
+type Option func(*PluginPlatform)
+
+// WithScope sets the scope (user or project) sent with every request.
+func WithScope(scope Scope) Option {
+	return func(p *PluginPlatform) {
+		p.client.scope = scope
+	}
+}
+
+// WithProjectRoot sets the project root sent with project-scoped requests.
+func WithProjectRoot(root string) Option {
+	return func(p *PluginPlatform) {
+		p.client.projectRoot = root
+	}
+}
+
+// NewPluginPlatform creates a new PluginPlatform for a loaded plugin with
+// the given options. Default configuration uses ScopeUser with no project
+// root.
+func NewPluginPlatform(plugin *Plugin, opts ...Option) *PluginPlatform {
+	p := &PluginPlatform{
+		plugin: plugin,
+		client: &client{name: plugin.Name, path: plugin.Path, scope: ScopeUser},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Name returns the platform identifier.
+func (p *PluginPlatform) Name() string {
+	return p.plugin.Name
+}
+
+// DisplayName returns a human-readable platform name.
+func (p *PluginPlatform) DisplayName() string {
+	return p.plugin.DisplayName
+}
+
+// Plugin returns the plugin this platform runs.
+func (p *PluginPlatform) Plugin() *Plugin {
+	return p.plugin
+}
+
+// IsAvailable reports whether the platform's global config directory
+// exists.
+func (p *PluginPlatform) IsAvailable() bool {
+	dir := paths.GlobalConfigDir(p.plugin.Name)
+	if dir == "" {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// Paths returns the platform's directories for the current scope, asking
+// the plugin the first time.
+func (p *PluginPlatform) Paths() (Paths, error) {
+	p.pathsOnce.Do(func() {
+		p.pathsErr = p.client.call(MethodPaths, nil, &p.paths)
+	})
+	return p.paths, p.pathsErr
+}
+
+// SkillDir returns the skills directory, or "" if the plugin has none or
+// could not be asked.
+func (p *PluginPlatform) SkillDir() string {
+	dirs, _ := p.Paths()
+	return dirs.SkillDir
+}
+
+// CommandDir returns the commands directory, or "" if the plugin has none
+// or could not be asked.
+func (p *PluginPlatform) CommandDir() string {
+	dirs, _ := p.Paths()
+	return dirs.CommandDir
+}
+
+// AgentDir returns the agents directory, or "" if the plugin has none or
+// could not be asked.
+func (p *PluginPlatform) AgentDir() string {
+	dirs, _ := p.Paths()
+	return dirs.AgentDir
+}
+
+// MCPConfigPath returns the MCP config file, or "" if the plugin has none
+// or could not be asked.
+func (p *PluginPlatform) MCPConfigPath() string {
+	dirs, _ := p.Paths()
+	return dirs.MCPConfig
+}
+
+// BackupPaths returns the paths the plugin reports an operation may change.
+// A plugin that cannot be asked has nothing backed up; the operation that
+// follows reports the failure.
+func (p *PluginPlatform) BackupPaths() []string {
+	var backup []string
+	if err := p.client.call(MethodBackupPaths, nil, &backup); err != nil {
+		return nil
+	}
+	return backup
+}
+
+// --- Skill Operations ---
+
+// ListSkills returns the installed skills.
+func (p *PluginPlatform) ListSkills() ([]ResourceInfo, error) {
+	var infos []ResourceInfo
+	err := p.client.call(MethodSkillList, nil, &infos)
+	return infos, err
+}
+
+// GetSkill returns the named skill.
+func (p *PluginPlatform) GetSkill(name string) (*claude.Skill, error) {
+	var s Skill
+	if err := p.client.call(MethodSkillGet, NameParams{Name: name}, &s); err != nil {
+		return nil, err
+	}
+	return &claude.Skill{
+		Name:          s.Name,
+		Description:   s.Description,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		Metadata:      s.Metadata,
+		AllowedTools:  claude.ToolList(s.AllowedTools),
+		Instructions:  s.Instructions,
+	}, nil
+}
+
+// InstallSkill installs a skill, replacing any skill of the same name.
+func (p *PluginPlatform) InstallSkill(s *claude.Skill) error {
+	return p.client.call(MethodSkillInstall, &Skill{
+		Name:          s.Name,
+		Description:   s.Description,
+		License:       s.License,
+		Compatibility: s.Compatibility,
+		Metadata:      s.Metadata,
+		AllowedTools:  s.AllowedTools,
+		Instructions:  s.Instructions,
+		SourceDir:     s.SourceDir,
+	}, nil)
+}
+
+// UninstallSkill removes the named skill.
+func (p *PluginPlatform) UninstallSkill(name string) error {
+	return p.client.call(MethodSkillUninstall, NameParams{Name: name}, nil)
+}
+
+// --- Command Operations ---
+
+// ListCommands returns the installed slash commands.
+func (p *PluginPlatform) ListCommands() ([]ResourceInfo, error) {
+	var infos []ResourceInfo
+	err := p.client.call(MethodCommandList, nil, &infos)
+	return infos, err
+}
+
+// GetCommand returns the named slash command.
+func (p *PluginPlatform) GetCommand(name string) (*claude.Command, error) {
+	var c Command
+	if err := p.client.call(MethodCommandGet, NameParams{Name: name}, &c); err != nil {
+		return nil, err
+	}
+	return &claude.Command{
+		Name:                   c.Name,
+		Description:            c.Description,
+		ArgumentHint:           c.ArgumentHint,
+		DisableModelInvocation: c.DisableModelInvocation,
+		UserInvocable:          c.UserInvocable,
+		AllowedTools:           claude.ToolList(c.AllowedTools),
+		Model:                  c.Model,
+		Context:                c.Context,
+		Agent:                  c.Agent,
+		Hooks:                  c.Hooks,
+		Instructions:           c.Instructions,
+	}, nil
+}
+
+// InstallCommand installs a slash command, replacing any command of the
+// same name.
+func (p *PluginPlatform) InstallCommand(c *claude.Command) error {
+	return p.client.call(MethodCommandInstall, &Command{
+		Name:                   c.Name,
+		Description:            c.Description,
+		ArgumentHint:           c.ArgumentHint,
+		DisableModelInvocation: c.DisableModelInvocation,
+		UserInvocable:          c.UserInvocable,
+		AllowedTools:           c.AllowedTools,
+		Model:                  c.Model,
+		Context:                c.Context,
+		Agent:                  c.Agent,
+		Hooks:                  c.Hooks,
+		Instructions:           c.Instructions,
+	}, nil)
+}
+
+// UninstallCommand removes the named slash command.
+func (p *PluginPlatform) UninstallCommand(name string) error {
+	return p.client.call(MethodCommandUninstall, NameParams{Name: name}, nil)
+}
+
+// --- Agent Operations ---
+
+// ListAgents returns the installed agents.
+func (p *PluginPlatform) ListAgents() ([]ResourceInfo, error) {
+	var infos []ResourceInfo
+	err := p.client.call(MethodAgentList, nil, &infos)
+	return infos, err
+}
+
+// GetAgent returns the named agent.
+func (p *PluginPlatform) GetAgent(name string) (*claude.Agent, error) {
+	var a Agent
+	if err := p.client.call(MethodAgentGet, NameParams{Name: name}, &a); err != nil {
+		return nil, err
+	}
+	return &claude.Agent{
+		Name:         a.Name,
+		Description:  a.Description,
+		Instructions: a.Instructions,
+	}, nil
+}
+
+// InstallAgent installs an agent, replacing any agent of the same name.
+func (p *PluginPlatform) InstallAgent(a *claude.Agent) error {
+	return p.client.call(MethodAgentInstall, &Agent{
+		Name:         a.Name,
+		Description:  a.Description,
+		Instructions: a.Instructions,
+	}, nil)
+}
+
+// UninstallAgent removes the named agent.
+func (p *PluginPlatform) UninstallAgent(name string) error {
+	return p.client.call(MethodAgentUninstall, NameParams{Name: name}, nil)
+}
+
+// --- MCP Operations ---
+
+// ListMCP returns the configured MCP servers.
+func (p *PluginPlatform) ListMCP() ([]*mcp.Server, error) {
+	var servers []*mcp.Server
+	err := p.client.call(MethodMCPList, nil, &servers)
+	return servers, err
+}
+
+// GetMCP returns the named MCP server.
+func (p *PluginPlatform) GetMCP(name string) (*mcp.Server, error) {
+	var s mcp.Server
+	if err := p.client.call(MethodMCPGet, NameParams{Name: name}, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// AddMCP adds an MCP server, replacing any server of the same name.
+func (p *PluginPlatform) AddMCP(server *mcp.Server) error {
+	return p.client.call(MethodMCPAdd, server, nil)
+}
+
+// RemoveMCP removes the named MCP server.
+func (p *PluginPlatform) RemoveMCP(name string) error {
+	return p.client.call(MethodMCPRemove, NameParams{Name: name}, nil)
+}
+
+// EnableMCP enables the named MCP server.
+func (p *PluginPlatform) EnableMCP(name string) error {
+	return p.client.call(MethodMCPEnable, NameParams{Name: name}, nil)
+}
+
+// DisableMCP disables the named MCP server.
+func (p *PluginPlatform) DisableMCP(name string) error {
+	return p.client.call(MethodMCPDisable, NameParams{Name: name}, nil)
+}
@@ -0,0 +1,369 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	aixerrors "github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/mcp"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+)
+
+// fakePluginEnv makes the test binary act as a plugin instead of running
+// the tests. Its value selects the behavior; see runFakePlugin.
+const fakePluginEnv = "AIX_TEST_FAKE_PLUGIN"
+
+// fakeStateEnv names the directory the fake plugin keeps its state in.
+const fakeStateEnv = "AIX_TEST_FAKE_PLUGIN_STATE"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakePluginEnv); mode != "" {
+		runFakePlugin(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeState is what the fake plugin has installed.
+type fakeState struct {
+	Skills   map[string]*Skill      `json:"skills"`
+	Commands map[string]*Command    `json:"commands"`
+	MCP      map[string]*mcp.Server `json:"mcp"`
+
+	// Last is the most recent request, other than describe.
+	Last *Request `json:"last"`
+}
+
+// runFakePlugin answers a single request. Modes:
+//
+//	ok        a platform with skills, commands and MCP servers but no agents
+//	crash     exits with status 3 after writing to stderr
+//	garbage   writes something that is not a response
+//	oldproto  describes itself with an unknown protocol version
+func runFakePlugin(mode string) {
+	switch mode {
+	case "crash":
+		fmt.Fprintln(os.Stderr, "fatal: config is corrupt")
+		os.Exit(3)
+	case "garbage":
+		fmt.Println("hello")
+		return
+	}
+
+	var req Request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	name := strings.TrimPrefix(filepath.Base(os.Args[0]), ExecutablePrefix)
+	stateFile := filepath.Join(os.Getenv(fakeStateEnv), name+".json")
+	state := fakeState{
+		Skills:   map[string]*Skill{},
+		Commands: map[string]*Command{},
+		MCP:      map[string]*mcp.Server{},
+	}
+	if data, err := os.ReadFile(stateFile); err == nil {
+		_ = json.Unmarshal(data, &state)
+	}
+
+	var resp Response
+	reply := func(v any) {
+		resp.Result, _ = json.Marshal(v)
+	}
+	fail := func(code, format string, args ...any) {
+		resp.Error = &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+	}
+	var params NameParams
+	_ = json.Unmarshal(req.Params, &params)
+
+	switch req.Method {
+	case MethodDescribe:
+		version := ProtocolVersion
+		if mode == "oldproto" {
+			version = 0
+		}
+		reply(Description{
+			ProtocolVersion:  version,
+			Name:             name,
+			DisplayName:      "Fake " + name,
+			ConfigDir:        "." + name,
+			ProjectConfigDir: "." + name,
+			InstructionsFile: "FAKE.md",
+		})
+	case MethodPaths:
+		reply(Paths{
+			SkillDir:  filepath.Join(req.Scope, "skills"),
+			MCPConfig: filepath.Join(req.Scope, "mcp.json"),
+		})
+	case MethodBackupPaths:
+		reply([]string{stateFile})
+	case MethodSkillList:
+		infos := []ResourceInfo{}
+		for _, s := range state.Skills {
+			infos = append(infos, ResourceInfo{Name: s.Name, Description: s.Description})
+		}
+		reply(infos)
+	case MethodSkillGet:
+		if s, ok := state.Skills[params.Name]; ok {
+			reply(s)
+		} else {
+			fail(CodeNotFound, "skill %q does not exist", params.Name)
+		}
+	case MethodSkillInstall:
+		var s Skill
+		_ = json.Unmarshal(req.Params, &s)
+		state.Skills[s.Name] = &s
+	case MethodSkillUninstall:
+		delete(state.Skills, params.Name)
+	case MethodCommandGet:
+		if c, ok := state.Commands[params.Name]; ok {
+			reply(c)
+		} else {
+			fail(CodeNotFound, "command %q does not exist", params.Name)
+		}
+	case MethodCommandInstall:
+		var c Command
+		_ = json.Unmarshal(req.Params, &c)
+		state.Commands[c.Name] = &c
+	case MethodMCPList:
+		servers := []*mcp.Server{}
+		for _, s := range state.MCP {
+			servers = append(servers, s)
+		}
+		reply(servers)
+	case MethodMCPGet:
+		if s, ok := state.MCP[params.Name]; ok {
+			reply(s)
+		} else {
+			fail(CodeNotFound, "server %q does not exist", params.Name)
+		}
+	case MethodMCPAdd:
+		var s mcp.Server
+		_ = json.Unmarshal(req.Params, &s)
+		state.MCP[s.Name] = &s
+	case MethodMCPDisable, MethodMCPEnable:
+		s, ok := state.MCP[params.Name]
+		if !ok {
+			fail(CodeNotFound, "server %q does not exist", params.Name)
+			break
+		}
+		s.Disabled = req.Method == MethodMCPDisable
+	case MethodMCPRemove:
+		delete(state.MCP, params.Name)
+	default:
+		fail(CodeNotSupported, "%s is not supported", req.Method)
+	}
+
+	if req.Method != MethodDescribe {
+		state.Last = &req
+		data, _ := json.Marshal(state)
+		_ = os.WriteFile(stateFile, data, 0o600)
+	}
+	_ = json.NewEncoder(os.Stdout).Encode(resp)
+	// Plugins may exit non-zero when they report an error
+	if resp.Error != nil {
+		os.Exit(1)
+	}
+}
+
+// installFakePlugin makes the test binary available as the plugin
+// aix-platform-<name> in a new directory, which it returns, and selects the
+// fake plugin's mode.
+func installFakePlugin(t *testing.T, name, mode string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests link the test binary under a plugin name")
+	}
+	dir := t.TempDir()
+	if err := os.Symlink(os.Args[0], filepath.Join(dir, ExecutablePrefix+name)); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(fakePluginEnv, mode)
+	t.Setenv(fakeStateEnv, t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	return dir
+}
+
+// lastRequest returns the last request the fake plugin name answered.
+func lastRequest(t *testing.T, name string) *Request {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(os.Getenv(fakeStateEnv), name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state fakeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state.Last
+}
+
+// loadFakePlugin installs and loads the fake plugin name in ok mode.
+func loadFakePlugin(t *testing.T, name string) *Plugin {
+	t.Helper()
+	dir := installFakePlugin(t, name, "ok")
+	if err := Load([]string{dir}); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	p, ok := Lookup(name)
+	if !ok {
+		t.Fatalf("Lookup(%q) found no plugin", name)
+	}
+	return p
+}
+
+func TestPluginPlatform_Skills(t *testing.T) {
+	p := NewPluginPlatform(loadFakePlugin(t, "fake-skills"))
+
+	if p.Name() != "fake-skills" || p.DisplayName() != "Fake fake-skills" {
+		t.Errorf("Name() = %q, DisplayName() = %q", p.Name(), p.DisplayName())
+	}
+
+	skill := &claude.Skill{
+		Name:         "review",
+		Description:  "Reviews code",
+		AllowedTools: claude.ToolList{"Read", "Grep"},
+		Instructions: "Review the diff.",
+		SourceDir:    "/src/review",
+	}
+	if err := p.InstallSkill(skill); err != nil {
+		t.Fatalf("InstallSkill() error = %v", err)
+	}
+
+	got, err := p.GetSkill("review")
+	if err != nil {
+		t.Fatalf("GetSkill() error = %v", err)
+	}
+	if got.Description != "Reviews code" || got.Instructions != "Review the diff." || len(got.AllowedTools) != 2 {
+		t.Errorf("GetSkill() = %+v", got)
+	}
+
+	infos, err := p.ListSkills()
+	if err != nil || len(infos) != 1 || infos[0].Name != "review" {
+		t.Errorf("ListSkills() = %+v, %v", infos, err)
+	}
+
+	if err := p.UninstallSkill("review"); err != nil {
+		t.Fatalf("UninstallSkill() error = %v", err)
+	}
+	if _, err := p.GetSkill("review"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSkill() after uninstall error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestPluginPlatform_Commands(t *testing.T) {
+	p := NewPluginPlatform(loadFakePlugin(t, "fake-commands"))
+
+	cmd := &claude.Command{Name: "deploy", ArgumentHint: "[env]", Instructions: "Deploy $ARGUMENTS."}
+	if err := p.InstallCommand(cmd); err != nil {
+		t.Fatalf("InstallCommand() error = %v", err)
+	}
+	got, err := p.GetCommand("deploy")
+	if err != nil {
+		t.Fatalf("GetCommand() error = %v", err)
+	}
+	if got.ArgumentHint != "[env]" || got.Instructions != "Deploy $ARGUMENTS." {
+		t.Errorf("GetCommand() = %+v", got)
+	}
+}
+
+func TestPluginPlatform_AgentsNotSupported(t *testing.T) {
+	p := NewPluginPlatform(loadFakePlugin(t, "fake-agents"))
+
+	err := p.InstallAgent(&claude.Agent{Name: "a"})
+	if !errors.Is(err, aixerrors.ErrNotSupported) {
+		t.Errorf("InstallAgent() error = %v, want ErrNotSupported", err)
+	}
+	if !strings.Contains(err.Error(), "agent.install is not supported") {
+		t.Errorf("InstallAgent() error = %v, want the plugin's message", err)
+	}
+}
+
+func TestPluginPlatform_MCP(t *testing.T) {
+	p := NewPluginPlatform(loadFakePlugin(t, "fake-mcp"))
+
+	server := &mcp.Server{
+		Name:    "github",
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-github"},
+		Env:     map[string]string{"GITHUB_TOKEN": "${env:GITHUB_TOKEN}"},
+	}
+	if err := p.AddMCP(server); err != nil {
+		t.Fatalf("AddMCP() error = %v", err)
+	}
+	if err := p.DisableMCP("github"); err != nil {
+		t.Fatalf("DisableMCP() error = %v", err)
+	}
+
+	got, err := p.GetMCP("github")
+	if err != nil {
+		t.Fatalf("GetMCP() error = %v", err)
+	}
+	if !got.Disabled || got.Env["GITHUB_TOKEN"] != "${env:GITHUB_TOKEN}" || len(got.Args) != 2 {
+		t.Errorf("GetMCP() = %+v", got)
+	}
+
+	servers, err := p.ListMCP()
+	if err != nil || len(servers) != 1 {
+		t.Errorf("ListMCP() = %+v, %v", servers, err)
+	}
+
+	if err := p.EnableMCP("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("EnableMCP(missing) error = %v, want ErrNotFound", err)
+	}
+	if err := p.RemoveMCP("github"); err != nil {
+		t.Fatalf("RemoveMCP() error = %v", err)
+	}
+}
+
+func TestPluginPlatform_Scope(t *testing.T) {
+	plugin := loadFakePlugin(t, "fake-scope")
+	root := t.TempDir()
+	p := NewPluginPlatform(plugin, WithScope(ScopeProject), WithProjectRoot(root))
+
+	if got := p.SkillDir(); got != filepath.Join("project", "skills") {
+		t.Errorf("SkillDir() = %q", got)
+	}
+	if got := p.MCPConfigPath(); got != filepath.Join("project", "mcp.json") {
+		t.Errorf("MCPConfigPath() = %q", got)
+	}
+	if p.CommandDir() != "" || p.AgentDir() != "" {
+		t.Errorf("CommandDir() = %q, AgentDir() = %q; want empty", p.CommandDir(), p.AgentDir())
+	}
+
+	backup := p.BackupPaths()
+	if len(backup) != 1 || !strings.HasSuffix(backup[0], "fake-scope.json") {
+		t.Errorf("BackupPaths() = %v", backup)
+	}
+	req := lastRequest(t, "fake-scope")
+	if req.Method != MethodBackupPaths || req.Scope != "project" || req.ProjectRoot != root || req.Version != ProtocolVersion {
+		t.Errorf("last request = %+v", req)
+	}
+	if !filepath.IsAbs(req.ConfigDir) || filepath.Base(req.ConfigDir) != ".fake-scope" {
+		t.Errorf("request config_dir = %q, want the absolute global config directory", req.ConfigDir)
+	}
+}
+
+func TestPluginPlatform_IsAvailable(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	p := NewPluginPlatform(loadFakePlugin(t, "fake-available"))
+
+	if p.IsAvailable() {
+		t.Error("IsAvailable() = true before the config directory exists")
+	}
+	if err := os.Mkdir(filepath.Join(home, ".fake-available"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsAvailable() {
+		t.Error("IsAvailable() = false with the config directory present")
+	}
+}
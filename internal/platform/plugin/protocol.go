@@ -0,0 +1,204 @@
+// Package plugin provides platform adapters implemented by external
+// executables.
+//
+// A plugin is an executable named aix-platform-<name>, found in the aix
+// plugins directory or on PATH. aix runs it once per operation, writes a
+// single JSON [Request] to its stdin and reads a single JSON [Response] from
+// its stdout. The methods mirror the platform adapter: list, get, install
+// and uninstall for skills, commands and agents, the MCP server operations,
+// and the paths to back up before a change.
+//
+// Resources cross the protocol in their canonical form: skills, commands
+// and agents with the fields of their Claude Code frontmatter plus their
+// markdown instructions, and MCP servers as mcp.Server.
+package plugin
+
+import "encoding/json"
+
+// ProtocolVersion is the version of the plugin protocol spoken by aix. It is
+// sent with every request, and plugins report the version they speak from
+// the describe method.
+const ProtocolVersion = 1
+
+// ExecutablePrefix is the file name prefix of plugin executables. The rest
+// of the name, without any extension, is the platform name.
+const ExecutablePrefix = "aix-platform-"
+
+// Methods of the plugin protocol.
+const (
+	// MethodDescribe returns a Description. It takes no parameters and is
+	// called once, when the plugin is discovered.
+	MethodDescribe = "describe"
+
+	// MethodPaths returns the Paths of the request's scope.
+	MethodPaths = "paths"
+
+	// MethodBackupPaths returns the files and directories, as a list of
+	// strings, that an operation in the request's scope may change.
+	MethodBackupPaths = "backup_paths"
+
+	// Skill methods. List returns []ResourceInfo, get takes NameParams and
+	// returns a Skill, install takes a Skill and uninstall takes NameParams.
+	MethodSkillList      = "skill.list"
+	MethodSkillGet       = "skill.get"
+	MethodSkillInstall   = "skill.install"
+	MethodSkillUninstall = "skill.uninstall"
+
+	// Command methods, as for skills with Command.
+	MethodCommandList      = "command.list"
+	MethodCommandGet       = "command.get"
+	MethodCommandInstall   = "command.install"
+	MethodCommandUninstall = "command.uninstall"
+
+	// Agent methods, as for skills with Agent.
+	MethodAgentList      = "agent.list"
+	MethodAgentGet       = "agent.get"
+	MethodAgentInstall   = "agent.install"
+	MethodAgentUninstall = "agent.uninstall"
+
+	// MCP methods. List returns []mcp.Server, get takes NameParams and
+	// returns an mcp.Server, add takes an mcp.Server and replaces any server
+	// of the same name, and remove, enable and disable take NameParams.
+	MethodMCPList    = "mcp.list"
+	MethodMCPGet     = "mcp.get"
+	MethodMCPAdd     = "mcp.add"
+	MethodMCPRemove  = "mcp.remove"
+	MethodMCPEnable  = "mcp.enable"
+	MethodMCPDisable = "mcp.disable"
+)
+
+// Error codes a plugin may return. Other codes are reported with the
+// plugin's message.
+const (
+	// CodeNotFound means the named resource does not exist.
+	CodeNotFound = "not_found"
+
+	// CodeNotSupported means the platform has no such resources or cannot
+	// perform the operation.
+	CodeNotSupported = "not_supported"
+)
+
+// Request is written to a plugin's stdin.
+type Request struct {
+	// Version is ProtocolVersion.
+	Version int `json:"version"`
+
+	// Method is one of the Method constants.
+	Method string `json:"method"`
+
+	// Scope is "user" or "project".
+	Scope string `json:"scope"`
+
+	// ProjectRoot is the project root for the project scope.
+	ProjectRoot string `json:"project_root,omitempty"`
+
+	// ConfigDir is the absolute global config directory, with any override
+	// from the aix config file or environment applied. Plugins should use it
+	// rather than the directory they described. Not set for describe.
+	ConfigDir string `json:"config_dir,omitempty"`
+
+	// Params holds the method's parameters, if it takes any.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is read from a plugin's stdout. Exactly one of Result and Error
+// is set; methods without a result may leave both unset.
+type Response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is a failure reported by a plugin.
+type Error struct {
+	// Code is CodeNotFound, CodeNotSupported or a plugin-specific code.
+	Code string `json:"code,omitempty"`
+
+	// Message describes the failure.
+	Message string `json:"message"`
+}
+
+// Description is the result of the describe method.
+type Description struct {
+	// ProtocolVersion is the protocol version the plugin speaks.
+	ProtocolVersion int `json:"protocol_version"`
+
+	// Name is the platform name. It must match the executable name if set.
+	Name string `json:"name,omitempty"`
+
+	// DisplayName is the human-readable platform name. Defaults to Name.
+	DisplayName string `json:"display_name,omitempty"`
+
+	// ConfigDir is the global config directory, relative to the home
+	// directory. Its existence is how the platform is detected.
+	ConfigDir string `json:"config_dir"`
+
+	// ConfigDirEnv is the environment variable the assistant reads to
+	// relocate ConfigDir, if any.
+	ConfigDirEnv string `json:"config_dir_env,omitempty"`
+
+	// ProjectConfigDir is the project config directory, relative to the
+	// project root ("." for the root itself). Empty if the platform has no
+	// project configuration.
+	ProjectConfigDir string `json:"project_config_dir,omitempty"`
+
+	// InstructionsFile is the instructions file, relative to the project
+	// root.
+	InstructionsFile string `json:"instructions_file,omitempty"`
+}
+
+// Paths is the result of the paths method. Directories the platform does
+// not have are left empty.
+type Paths struct {
+	SkillDir   string `json:"skill_dir,omitempty"`
+	CommandDir string `json:"command_dir,omitempty"`
+	AgentDir   string `json:"agent_dir,omitempty"`
+	MCPConfig  string `json:"mcp_config,omitempty"`
+}
+
+// NameParams are the parameters of methods that act on a resource by name.
+type NameParams struct {
+	Name string `json:"name"`
+}
+
+// ResourceInfo is an entry of the skill, command and agent lists.
+type ResourceInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Skill is a skill in its canonical form.
+type Skill struct {
+	Name          string            `json:"name"`
+	Description   string            `json:"description"`
+	License       string            `json:"license,omitempty"`
+	Compatibility []string          `json:"compatibility,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	AllowedTools  []string          `json:"allowed_tools,omitempty"`
+	Instructions  string            `json:"instructions"`
+
+	// SourceDir is the directory the skill is installed from, holding any
+	// supporting files next to SKILL.md. Only set on install.
+	SourceDir string `json:"source_dir,omitempty"`
+}
+
+// Command is a slash command in its canonical form.
+type Command struct {
+	Name                   string   `json:"name"`
+	Description            string   `json:"description,omitempty"`
+	ArgumentHint           string   `json:"argument_hint,omitempty"`
+	DisableModelInvocation bool     `json:"disable_model_invocation,omitempty"`
+	UserInvocable          bool     `json:"user_invocable,omitempty"`
+	AllowedTools           []string `json:"allowed_tools,omitempty"`
+	Model                  string   `json:"model,omitempty"`
+	Context                string   `json:"context,omitempty"`
+	Agent                  string   `json:"agent,omitempty"`
+	Hooks                  []string `json:"hooks,omitempty"`
+	Instructions           string   `json:"instructions"`
+}
+
+// Agent is an agent in its canonical form.
+type Agent struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Instructions string `json:"instructions"`
+}
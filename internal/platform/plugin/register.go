@@ -0,0 +1,245 @@
+package plugin
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/platform"
+)
+
+// Plugin is a loaded plugin executable.
+type Plugin struct {
+	// Name is the platform name, taken from the executable name.
+	Name string
+
+	// DisplayName is the human-readable platform name.
+	DisplayName string
+
+	// Path is the absolute path of the executable.
+	Path string
+}
+
+var (
+	mu      sync.RWMutex
+	plugins = make(map[string]*Plugin)
+
+	// registry records the names of the loaded plugins.
+	registry = platform.NewRegistry()
+)
+
+// SearchPath returns the directories searched for plugins: dir, usually the
+// aix plugins directory, followed by the directories of PATH.
+func SearchPath(dir string) []string {
+	return append([]string{dir}, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// Discover returns the plugin executables in dirs, keyed by platform name.
+// As with PATH lookups, the first directory holding a name wins. Missing
+// and unreadable directories are skipped.
+func Discover(dirs []string) map[string]string {
+	found := make(map[string]string)
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			if _, ok := found[name]; ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			found[name] = path
+		}
+	}
+	return found
+}
+
+// pluginName returns the platform name of a plugin executable file name.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, ExecutablePrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, ExecutablePrefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+// isExecutable reports whether path is a regular file that can be run.
+// Directories are skipped, and on Unix the file must have an execute bit.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0o111 != 0
+}
+
+// Load discovers the plugins in dirs, asks each to describe itself and
+// registers it. Its locations are registered with the paths package, so it
+// is detected and accepted by --platform like the built-in platforms.
+//
+// Descriptions are cached by executable, so a plugin is only run when it
+// changed since it last described itself. Plugins that have to be run are
+// described in parallel, each with a short timeout.
+//
+// A plugin that fails to load does not stop the others: the returned error
+// joins the failures, and the plugins that loaded stay registered. A plugin
+// whose name is already taken, for example by a built-in platform, is a
+// failure too.
+//
+// Load is meant to be called while the program initializes; see
+// paths.RegisterPlatform and LoadOnDemand.
+func Load(dirs []string) error {
+	found := Discover(dirs)
+	cache := loadCache(cachePath())
+	defer cache.save()
+
+	// Load in a stable order so that errors read the same on every run
+	names := slices.Sorted(maps.Keys(found))
+	descs := make([]*Description, len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		if paths.ValidPlatform(name) {
+			errs[i] = errors.Wrapf(paths.ErrPlatformExists, "%q", name)
+			continue
+		}
+		if desc, ok := cache.lookup(found[name]); ok {
+			descs[i] = desc
+			continue
+		}
+		wg.Go(func() {
+			descs[i], errs[i] = describe(name, found[name])
+		})
+	}
+	wg.Wait()
+
+	var failures []error
+	for i, name := range names {
+		err := errs[i]
+		if err == nil {
+			cache.store(found[name], descs[i])
+			err = register(name, found[name], descs[i])
+		}
+		if err != nil {
+			failures = append(failures, errors.Wrapf(err, "loading plugin %s", name))
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// lazyLoad loads the plugins set up by LoadOnDemand.
+var lazyLoad func()
+
+// LoadOnDemand arranges for Load(dirs) to run the first time a plugin may be
+// needed: when Lookup or Plugins is called, or when the paths package lists
+// every platform or meets a platform name it does not know; see
+// paths.SetLoader. Commands that only use built-in platforms run no plugin.
+// Load failures are passed to report.
+func LoadOnDemand(dirs []string, report func(error)) {
+	lazyLoad = sync.OnceFunc(func() {
+		// Load asks the paths package about names it does not know, which
+		// must not start another load
+		paths.SetLoader(nil)
+		if err := Load(dirs); err != nil && report != nil {
+			report(err)
+		}
+	})
+	paths.SetLoader(lazyLoad)
+}
+
+// ensureLoaded runs the load set up by LoadOnDemand, if any.
+func ensureLoaded() {
+	if lazyLoad != nil {
+		lazyLoad()
+	}
+}
+
+// describe asks the plugin at path for its description and checks it.
+func describe(name, path string) (*Description, error) {
+	c := &client{name: name, path: path, scope: ScopeUser}
+	var desc Description
+	if err := c.call(MethodDescribe, nil, &desc); err != nil {
+		return nil, err
+	}
+	if desc.ProtocolVersion != ProtocolVersion {
+		return nil, errors.Wrapf(ErrInvalidPlugin, "protocol version %d, want %d", desc.ProtocolVersion, ProtocolVersion)
+	}
+	if desc.Name != "" && desc.Name != name {
+		return nil, errors.Wrapf(ErrInvalidPlugin, "describes itself as %q", desc.Name)
+	}
+	if desc.DisplayName == "" {
+		desc.DisplayName = name
+	}
+	return &desc, nil
+}
+
+// register registers the plugin at path, described by desc.
+func register(name, path string, desc *Description) error {
+	err := paths.RegisterPlatform(paths.PlatformSpec{
+		Name:             name,
+		GlobalConfigDir:  desc.ConfigDir,
+		ProjectConfigDir: desc.ProjectConfigDir,
+		InstructionsFile: desc.InstructionsFile,
+		ConfigDirEnv:     desc.ConfigDirEnv,
+	})
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err := registry.Register(name); err != nil {
+		return err
+	}
+	plugins[name] = &Plugin{Name: name, DisplayName: desc.DisplayName, Path: path}
+	return nil
+}
+
+// Lookup returns a loaded plugin.
+func Lookup(name string) (*Plugin, bool) {
+	ensureLoaded()
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := plugins[name]
+	return p, ok
+}
+
+// Plugins returns the loaded plugins in the order of paths.Platforms().
+func Plugins() []*Plugin {
+	ensureLoaded()
+	mu.RLock()
+	defer mu.RUnlock()
+	names := registry.All()
+	loaded := make([]*Plugin, len(names))
+	for i, name := range names {
+		loaded[i] = plugins[name]
+	}
+	return loaded
+}
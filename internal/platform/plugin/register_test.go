@@ -0,0 +1,139 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/paths"
+)
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bits are not used on Windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	write := func(dir, name string, mode os.FileMode) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(first, ExecutablePrefix+"alpha", 0o755)
+	write(second, ExecutablePrefix+"alpha", 0o755)
+	write(second, ExecutablePrefix+"beta", 0o755)
+	write(second, ExecutablePrefix+"noexec", 0o644)
+	write(second, "aix-other", 0o755)
+	if err := os.Mkdir(filepath.Join(second, ExecutablePrefix+"dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	found := Discover([]string{"", filepath.Join(first, "missing"), first, second})
+	if len(found) != 2 {
+		t.Fatalf("Discover() = %v, want alpha and beta", found)
+	}
+	if found["alpha"] != filepath.Join(first, ExecutablePrefix+"alpha") {
+		t.Errorf("alpha = %q, want the first directory's", found["alpha"])
+	}
+	if found["beta"] != filepath.Join(second, ExecutablePrefix+"beta") {
+		t.Errorf("beta = %q", found["beta"])
+	}
+}
+
+func TestSearchPath(t *testing.T) {
+	t.Setenv("PATH", strings.Join([]string{"/a", "/b"}, string(os.PathListSeparator)))
+	got := SearchPath("/plugins")
+	if len(got) != 3 || got[0] != "/plugins" || got[1] != "/a" || got[2] != "/b" {
+		t.Errorf("SearchPath() = %v", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	p := loadFakePlugin(t, "fake-load")
+
+	if !paths.ValidPlatform("fake-load") || !paths.Registered("fake-load") {
+		t.Error("plugin not registered with the paths package")
+	}
+	if got := paths.InstructionsPath("fake-load", "/project"); got != filepath.Join("/project", "FAKE.md") {
+		t.Errorf("InstructionsPath() = %q", got)
+	}
+	if !registry.Get("fake-load") {
+		t.Error("plugin not in the registry")
+	}
+	if len(Plugins()) == 0 {
+		t.Error("Plugins() is empty")
+	}
+	if p.Path == "" || !filepath.IsAbs(p.Path) {
+		t.Errorf("Path = %q, want an absolute path", p.Path)
+	}
+}
+
+func TestLoad_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		plugin  string
+		mode    string
+		wantErr error
+	}{
+		{"built-in name", "claude", "ok", paths.ErrPlatformExists},
+		{"protocol version", "fake-oldproto", "oldproto", ErrInvalidPlugin},
+		{"crash", "fake-crash", "crash", ErrPluginFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := installFakePlugin(t, tt.plugin, tt.mode)
+			err := Load([]string{dir})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, ok := Lookup(tt.plugin); ok && tt.plugin != "claude" {
+				t.Errorf("Lookup(%q) found a plugin that failed to load", tt.plugin)
+			}
+		})
+	}
+}
+
+func TestLoad_KeepsGoodPlugins(t *testing.T) {
+	dir := installFakePlugin(t, "fake-good", "ok")
+	if err := os.Symlink(os.Args[0], filepath.Join(dir, ExecutablePrefix+"Bad")); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Load([]string{dir})
+	if !errors.Is(err, paths.ErrInvalidPlatformSpec) {
+		t.Errorf("Load() error = %v, want ErrInvalidPlatformSpec for the malformed name", err)
+	}
+	if _, ok := Lookup("fake-good"); !ok {
+		t.Error("Load() dropped a plugin that loaded")
+	}
+}
+
+func TestLoadOnDemand(t *testing.T) {
+	dir := installFakePlugin(t, "fake-lazy", "ok")
+	t.Cleanup(func() {
+		lazyLoad = nil
+		paths.SetLoader(nil)
+	})
+
+	var reported []error
+	LoadOnDemand([]string{dir}, func(err error) { reported = append(reported, err) })
+	mu.RLock()
+	_, loaded := plugins["fake-lazy"]
+	mu.RUnlock()
+	if loaded {
+		t.Fatal("LoadOnDemand() loaded the plugin before it was needed")
+	}
+
+	if !paths.ValidPlatform("fake-lazy") {
+		t.Fatal("ValidPlatform() did not load the plugin")
+	}
+	if _, ok := Lookup("fake-lazy"); !ok {
+		t.Error("Lookup() found no plugin after the load")
+	}
+	if len(reported) != 0 {
+		t.Errorf("reported %v, want no failures", reported)
+	}
+}
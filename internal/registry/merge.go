@@ -0,0 +1,248 @@
+package registry
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/backup"
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/params"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/internal/textdiff"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// ErrMergeConflict is returned when local edits to an installed resource
+// and upstream changes to its source touch the same lines.
+var ErrMergeConflict = errors.New("local edits conflict with upstream changes")
+
+// ErrNoBase is returned when a resource's registry entry predates the
+// recording of installed content, so there is nothing to merge against.
+var ErrNoBase = errors.New("no record of the installed content")
+
+// Merge merges the edits made from base to local into upstream, the
+// description and the instructions separately. Conflicting edits are kept
+// between conflict markers; the number of conflicts is returned.
+func Merge(base, local, upstream *Snapshot) (*Snapshot, int) {
+	desc, n := textdiff.Merge(base.Description+"\n", local.Description+"\n", upstream.Description+"\n", "local", "upstream")
+	body, m := textdiff.Merge(base.Instructions+"\n", local.Instructions+"\n", upstream.Instructions+"\n", "local", "upstream")
+	return &Snapshot{Description: trimNewline(desc), Instructions: trimNewline(body)}, n + m
+}
+
+// Reinstall installs the current source of st's resource on every platform
+// that has it, rendered with the recorded parameter values, calling install
+// with the path to install from once per platform, and records the result.
+// The platforms change together: if one fails, every platform is rolled
+// back and the registry is left as it was.
+//
+// If merge is set, edits made to a platform's copy since it was installed
+// are merged into the source rather than overwritten. Conflicts are
+// detected before anything is installed: ErrMergeConflict is returned and
+// nothing changes. The recorded content stays that of the source, so
+// merged edits are still reported as local modifications.
+func Reinstall(st *Status, merge bool, install func(path string, p cli.Platform) error) error {
+	e := st.Entry
+	if st.Source == nil {
+		return errors.Newf("%s %q was removed from repository %s", e.Type, e.Name, e.Repo)
+	}
+
+	values, err := sourceValues(e, st.Source)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]*Snapshot)
+	var upstream *Snapshot
+	if merge && len(st.Modified) > 0 {
+		if e.Base == nil {
+			return errors.Wrap(ErrNoBase, "cannot merge")
+		}
+		upstream, err = upstreamSnapshot(e.Type, st.Source, values)
+		if err != nil {
+			return err
+		}
+		conflicts := 0
+		for _, p := range st.Modified {
+			v, err := cli.CanonicalResource(p, e.Type, e.Name)
+			if err != nil {
+				return err
+			}
+			local, err := SnapshotOf(v)
+			if err != nil {
+				return err
+			}
+			m, n := Merge(e.Base, local, upstream)
+			conflicts += n
+			merged[p.Name()] = m
+		}
+		if conflicts > 0 {
+			return errors.Wrapf(ErrMergeConflict, "%d conflicting change(s)", conflicts)
+		}
+	}
+
+	// Every platform joins one transaction up front, since install runs its
+	// own transaction per platform; if any platform fails, all are rolled
+	// back and nothing is recorded.
+	tx := backup.NewTransaction()
+	for _, p := range st.Installed {
+		if err := cli.BeginChange(tx, p, e.Type, e.Name); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "backing up %s before reinstall", p.DisplayName()))
+		}
+	}
+	for _, p := range st.Installed {
+		if err := reinstallOn(st.Source, values, merged[p.Name()], p, install); err != nil {
+			return cli.AbortTransaction(tx, errors.Wrapf(err, "installing %s %q on %s", e.Type, e.Name, p.Name()))
+		}
+	}
+
+	entry, err := NewEntry(*st.Source, st.Installed, values)
+	if err != nil {
+		return cli.AbortTransaction(tx, err)
+	}
+	for _, p := range st.Installed {
+		if merged[p.Name()] == nil {
+			continue
+		}
+		if entry.Platforms[p.Name()], err = upstream.installedDigest(p, e.Type, e.Name); err != nil {
+			return cli.AbortTransaction(tx, err)
+		}
+	}
+	err = Update(DefaultPath(), func(r *Registry) error {
+		r.Set(*entry)
+		return nil
+	})
+	if err != nil {
+		// Keep the platforms and the registry in agreement
+		return cli.AbortTransaction(tx, err)
+	}
+	return nil
+}
+
+// reinstallOn installs src rendered with values on p, with its content
+// replaced by merged if that is not nil.
+func reinstallOn(src *resource.Resource, values map[string]string, merged *Snapshot, p cli.Platform, install func(string, cli.Platform) error) error {
+	path, cleanup, err := params.Render(src.Type, src.SourcePath(), values)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if merged != nil {
+		// Never edit the repository cache.
+		if path == src.SourcePath() {
+			copied, remove, err := params.Copy(path)
+			if err != nil {
+				return err
+			}
+			defer remove()
+			path = copied
+		}
+		if err := rewrite(resource.MainFile(src.Type, path), merged); err != nil {
+			return err
+		}
+	}
+	return install(path, p)
+}
+
+// sourceValues resolves the parameters that the current source of e
+// declares from the values e was rendered with. Parameters added since
+// take their defaults.
+func sourceValues(e Entry, src *resource.Resource) (map[string]string, error) {
+	decl, err := params.Declared(e.Type, src.SourcePath())
+	if err != nil {
+		return nil, err
+	}
+	if len(decl) == 0 {
+		return nil, nil
+	}
+	values, err := params.Resolve(decl, e.Params, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering %s %q", e.Type, e.Name)
+	}
+	return values, nil
+}
+
+// upstreamSnapshot reads the snapshot of src rendered with values.
+func upstreamSnapshot(t resource.ResourceType, src *resource.Resource, values map[string]string) (*Snapshot, error) {
+	s, err := ReadSnapshot(t, src.SourcePath())
+	if err != nil || len(values) == 0 {
+		return s, err
+	}
+	return s.render(values)
+}
+
+// rewrite replaces the description and body of the markdown file at path
+// with those of s, leaving the rest of its frontmatter alone.
+func rewrite(path string, s *Snapshot) error {
+	data, err := fileutil.ReadFileWithLimit(path)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+	var doc yaml.Node
+	if _, err := frontmatter.Parse(bytes.NewReader(data), &doc); err != nil {
+		return errors.Wrapf(err, "parsing %s", path)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	setKey(doc.Content[0], "description", s.Description)
+
+	out, err := frontmatter.Format(&doc, s.Instructions)
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(os.WriteFile(path, out, 0o644), "writing %s", path)
+}
+
+// setKey sets key to value in mapping, appending it if absent.
+func setKey(mapping *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1].SetString(value)
+			return
+		}
+	}
+	k, v := &yaml.Node{}, &yaml.Node{}
+	k.SetString(key)
+	v.SetString(value)
+	mapping.Content = append(mapping.Content, k, v)
+}
+
+// trimNewline removes the newline that Merge's inputs were given.
+func trimNewline(s string) string {
+	if len(s) > 0 && s[len(s)-1] == '\n' {
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// ErrNotRecorded is returned when a resource was not installed from a
+// repository, so there is no source to reinstall it from.
+var ErrNotRecorded = errors.New("not installed from a repository")
+
+// ReinstallRecorded reinstalls the resource of type t named name from the
+// repository it was installed from, merging local edits, on those of
+// platforms that have it; see Reinstall.
+func ReinstallRecorded(t resource.ResourceType, name string, platforms []cli.Platform, install func(path string, p cli.Platform) error) error {
+	reg, err := Load(DefaultPath())
+	if err != nil {
+		return err
+	}
+	e := reg.Find(t, name)
+	if e == nil {
+		return errors.Wrapf(ErrNotRecorded, "%s %q", t, name)
+	}
+	st, err := Check(*e, platforms)
+	if err != nil {
+		return err
+	}
+	if len(st.Installed) == 0 {
+		return errors.Newf("%s %q is not installed on %s", t, name, strings.Join(platformNames(platforms), ", "))
+	}
+	return Reinstall(st, true, install)
+}
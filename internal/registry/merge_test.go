@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestMerge_Snapshots(t *testing.T) {
+	base := &Snapshot{Description: "Reviews code", Instructions: "Read the diff.\n\nComment on style.\n\nApprove."}
+
+	t.Run("disjoint edits", func(t *testing.T) {
+		local := &Snapshot{Description: "Reviews code", Instructions: "Read the whole diff.\n\nComment on style.\n\nApprove."}
+		upstream := &Snapshot{Description: "Reviews pull requests", Instructions: "Read the diff.\n\nComment on style.\n\nApprove or request changes."}
+
+		got, conflicts := Merge(base, local, upstream)
+		if conflicts != 0 {
+			t.Fatalf("Merge() conflicts = %d, want 0", conflicts)
+		}
+		want := &Snapshot{Description: "Reviews pull requests", Instructions: "Read the whole diff.\n\nComment on style.\n\nApprove or request changes."}
+		if *got != *want {
+			t.Errorf("Merge() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("overlapping edits", func(t *testing.T) {
+		local := &Snapshot{Description: "Reviews code", Instructions: "Read the diff.\n\nComment on naming.\n\nApprove."}
+		upstream := &Snapshot{Description: "Reviews code", Instructions: "Read the diff.\n\nComment on tests.\n\nApprove."}
+
+		got, conflicts := Merge(base, local, upstream)
+		if conflicts != 1 {
+			t.Fatalf("Merge() conflicts = %d, want 1", conflicts)
+		}
+		if !strings.Contains(got.Instructions, "<<<<<<< local\nComment on naming.\n=======\nComment on tests.\n>>>>>>> upstream") {
+			t.Errorf("Merge() instructions missing conflict markers:\n%s", got.Instructions)
+		}
+	})
+}
+
+func TestRewrite_KeepsOtherFrontmatter(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "review")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "SKILL.md")
+	content := "---\nname: review\ndescription: Reviews code\nallowed-tools: Read Grep\n---\n\nOld body.\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Snapshot{Description: "Reviews: code and tests", Instructions: "New body."}
+	if err := rewrite(path, want); err != nil {
+		t.Fatalf("rewrite() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "allowed-tools: Read Grep") {
+		t.Errorf("rewrite() dropped other frontmatter:\n%s", data)
+	}
+	got, err := ReadSnapshot(resource.TypeSkill, dir)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if *got != *want {
+		t.Errorf("ReadSnapshot() after rewrite = %+v, want %+v", got, want)
+	}
+}
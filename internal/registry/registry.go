@@ -0,0 +1,268 @@
+// Package registry records where installed resources came from.
+//
+// Every resource installed from a configured repository is recorded with the
+// repository, the path within it, the commit, and the content hash of its
+// source, together with the content digest of the installed copy on each
+// platform. Comparing the source hash with the repository cache shows which
+// resources have updates, and comparing the installed digests with what is
+// installed now shows which were modified locally.
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/config"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+)
+
+// FileName is the registry file kept next to the aix configuration.
+const FileName = "installed.json"
+
+// currentVersion is the registry format written by this build.
+const currentVersion = 1
+
+// SourceLocal is reported as the source of resources that were not
+// installed from a repository.
+const SourceLocal = "local"
+
+// Entry records a resource installed from a repository.
+type Entry struct {
+	Type resource.ResourceType `json:"type"`
+	Name string                `json:"name"`
+
+	// Repo is the name of the repository the resource was installed from,
+	// and URL its remote.
+	Repo string `json:"repo"`
+	URL  string `json:"url,omitempty"`
+
+	// Path is the resource's path relative to the repository root.
+	Path string `json:"path"`
+
+	// Commit is the repository commit that was checked out at install time.
+	Commit string `json:"commit,omitempty"`
+
+	// SHA256 is the content hash of the source files; see manifest.HashPath.
+	SHA256 string `json:"sha256"`
+
+	// Platforms maps each platform the resource was installed to onto the
+	// content digest of the installed copy; see manifest.Digest.
+	Platforms map[string]string `json:"platforms,omitempty"`
+
+	// Params holds the values the resource's parameters were rendered with;
+	// see package params. Upgrades render the new version with them.
+	Params map[string]string `json:"params,omitempty"`
+
+	// Base is the content that was installed, kept so that local edits can
+	// be merged into later versions. MCP servers have none.
+	Base *Snapshot `json:"base,omitempty"`
+
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// SourcePath returns the current location of the entry's source in the
+// repository cache.
+func (e *Entry) SourcePath() string {
+	return filepath.Join(paths.ReposCacheDir(), e.Repo, e.Path)
+}
+
+// Registry is the set of recorded installs.
+type Registry struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// DefaultPath returns the registry location in the aix configuration
+// directory.
+func DefaultPath() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), FileName)
+}
+
+// Load reads the registry at path. A missing file is an empty registry.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Registry{Version: currentVersion}, nil
+		}
+		return nil, errors.Wrap(err, "reading install registry")
+	}
+
+	var r Registry
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", path)
+	}
+	if r.Version != currentVersion {
+		return nil, errors.Newf("%s: unsupported registry version %d (expected %d)", path, r.Version, currentVersion)
+	}
+	return &r, nil
+}
+
+// Update applies fn to the registry at path under its file lock and saves
+// the result.
+func Update(path string, fn func(r *Registry) error) error {
+	load := func() (*Registry, error) { return Load(path) }
+	save := func(r *Registry) error {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return errors.Wrap(err, "creating config directory")
+		}
+		r.Version = currentVersion
+		slices.SortFunc(r.Entries, func(a, b Entry) int {
+			if c := strings.Compare(string(a.Type), string(b.Type)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		return fileutil.AtomicWriteJSON(path, r)
+	}
+	return fileutil.Update(path, load, fn, save)
+}
+
+// Find returns the entry for a resource, or nil if it is not recorded.
+func (r *Registry) Find(t resource.ResourceType, name string) *Entry {
+	for i := range r.Entries {
+		if r.Entries[i].Type == t && r.Entries[i].Name == name {
+			return &r.Entries[i]
+		}
+	}
+	return nil
+}
+
+// Set adds e, replacing any entry for the same resource.
+func (r *Registry) Set(e Entry) {
+	if existing := r.Find(e.Type, e.Name); existing != nil {
+		*existing = e
+		return
+	}
+	r.Entries = append(r.Entries, e)
+}
+
+// Remove deletes the entry for a resource, if there is one.
+func (r *Registry) Remove(t resource.ResourceType, name string) {
+	r.Entries = slices.DeleteFunc(r.Entries, func(e Entry) bool {
+		return e.Type == t && e.Name == name
+	})
+}
+
+// Source returns the repository a resource was installed from, or
+// SourceLocal if it is not recorded.
+func (r *Registry) Source(t resource.ResourceType, name string) string {
+	if e := r.Find(t, name); e != nil {
+		return e.Repo
+	}
+	return SourceLocal
+}
+
+// Record adds res to the registry at DefaultPath as installed on those of
+// platforms that now have it, rendered with values. It is called after a
+// resource is installed from a repository.
+func Record(res resource.Resource, platforms []cli.Platform, values map[string]string) error {
+	e, err := NewEntry(res, platforms, values)
+	if err != nil {
+		return err
+	}
+	return Update(DefaultPath(), func(r *Registry) error {
+		r.Set(*e)
+		return nil
+	})
+}
+
+// RecordFrom is Record for a copy of res read from dir rather than from the
+// repository cache, such as a checkout pinned by a lockfile. The entry takes
+// its commit and content hash from pin, so that it agrees with the lock.
+func RecordFrom(res resource.Resource, dir string, pin manifest.LockedResource, platforms []cli.Platform, values map[string]string) error {
+	e, err := newEntry(res, dir, pin.Commit, pin.SHA256, platforms, values)
+	if err != nil {
+		return err
+	}
+	return Update(DefaultPath(), func(r *Registry) error {
+		r.Set(*e)
+		return nil
+	})
+}
+
+// Forget removes a resource from the registry at DefaultPath. It is called
+// after a resource is installed from a local path or git URL, replacing the
+// copy that came from a repository.
+func Forget(t resource.ResourceType, name string) error {
+	reg, err := Load(DefaultPath())
+	if err != nil {
+		return err
+	}
+	if reg.Find(t, name) == nil {
+		return nil
+	}
+	return Update(DefaultPath(), func(r *Registry) error {
+		r.Remove(t, name)
+		return nil
+	})
+}
+
+// NewEntry builds the registry entry for res, rendered with values, hashing
+// its source and the copies installed on platforms.
+func NewEntry(res resource.Resource, platforms []cli.Platform, values map[string]string) (*Entry, error) {
+	// A repository that is not a git checkout has no commit to record.
+	commit, _ := git.Head(filepath.Join(paths.ReposCacheDir(), res.RepoName))
+	sum, err := manifest.HashPath(res.SourcePath())
+	if err != nil {
+		return nil, errors.Wrapf(err, "hashing %s %q", res.Type, res.Name)
+	}
+	return newEntry(res, res.SourcePath(), commit, sum, platforms, values)
+}
+
+// newEntry builds the registry entry for res as read from dir at commit,
+// where sum is the content hash of dir.
+func newEntry(res resource.Resource, dir, commit, sum string, platforms []cli.Platform, values map[string]string) (*Entry, error) {
+	var err error
+	e := &Entry{
+		Type:        res.Type,
+		Name:        res.Name,
+		Repo:        res.RepoName,
+		URL:         res.RepoURL,
+		Path:        res.Path,
+		Commit:      commit,
+		SHA256:      sum,
+		Platforms:   make(map[string]string),
+		Params:      values,
+		InstalledAt: time.Now().UTC(),
+	}
+	if res.Type != resource.TypeMCP {
+		if e.Base, err = ReadSnapshot(res.Type, dir); err != nil {
+			return nil, err
+		}
+		if len(values) > 0 {
+			if e.Base, err = e.Base.render(values); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, p := range platforms {
+		digest, err := InstalledDigest(p, res.Type, res.Name)
+		if err != nil {
+			continue
+		}
+		e.Platforms[p.Name()] = digest
+	}
+	return e, nil
+}
+
+// InstalledDigest returns the content digest of the resource of type t
+// named name as installed on p.
+func InstalledDigest(p cli.Platform, t resource.ResourceType, name string) (string, error) {
+	v, err := cli.CanonicalResource(p, t, name)
+	if err != nil {
+		return "", err
+	}
+	return manifest.Digest(v)
+}
@@ -0,0 +1,73 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+func TestRegistry_SetFindRemove(t *testing.T) {
+	var r Registry
+	r.Set(Entry{Type: resource.TypeSkill, Name: "review", Repo: "official"})
+	r.Set(Entry{Type: resource.TypeCommand, Name: "review", Repo: "community"})
+	r.Set(Entry{Type: resource.TypeSkill, Name: "review", Repo: "community"})
+
+	if len(r.Entries) != 2 {
+		t.Fatalf("Set() kept %d entries, want 2", len(r.Entries))
+	}
+	if got := r.Source(resource.TypeSkill, "review"); got != "community" {
+		t.Errorf("Source(skill) = %q, want community", got)
+	}
+	if got := r.Source(resource.TypeAgent, "review"); got != SourceLocal {
+		t.Errorf("Source(agent) = %q, want %q", got, SourceLocal)
+	}
+
+	r.Remove(resource.TypeSkill, "review")
+	if r.Find(resource.TypeSkill, "review") != nil {
+		t.Error("Remove() left the skill entry")
+	}
+	if r.Find(resource.TypeCommand, "review") == nil {
+		t.Error("Remove() dropped the command entry")
+	}
+}
+
+func TestUpdate_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", FileName)
+
+	reg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() of missing file error = %v", err)
+	}
+	if len(reg.Entries) != 0 {
+		t.Fatalf("Load() of missing file = %v, want empty", reg.Entries)
+	}
+
+	err = Update(path, func(r *Registry) error {
+		r.Set(Entry{Type: resource.TypeSkill, Name: "zeta", Repo: "official", SHA256: "1"})
+		r.Set(Entry{Type: resource.TypeAgent, Name: "alpha", Repo: "official", SHA256: "2"})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	reg, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(reg.Entries) != 2 || reg.Entries[0].Name != "alpha" || reg.Entries[1].Name != "zeta" {
+		t.Errorf("Load() entries = %+v, want alpha then zeta", reg.Entries)
+	}
+}
+
+func TestLoad_UnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte(`{"version": 99, "entries": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() should reject an unknown version")
+	}
+}
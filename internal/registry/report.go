@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/internal/textdiff"
+)
+
+// Untracked is the status reported for resources that were not installed
+// from a repository, whose modifications cannot be detected.
+const Untracked = "untracked"
+
+// Report describes an installed resource for status listings.
+type Report struct {
+	Name      string   `json:"name"`
+	Source    string   `json:"source"`
+	Status    string   `json:"status"`
+	Platforms []string `json:"platforms"`
+	Modified  []string `json:"modified,omitempty"`
+	Outdated  bool     `json:"outdated,omitempty"`
+	Removed   bool     `json:"removed,omitempty"`
+}
+
+// Reports describes each resource of type t in installed, which maps the
+// names of installed resources to the platforms that have them. Reports
+// are sorted by name.
+func (r *Registry) Reports(t resource.ResourceType, installed map[string][]cli.Platform) ([]Report, error) {
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reports := make([]Report, 0, len(names))
+	for _, name := range names {
+		rep := Report{Name: name, Source: SourceLocal, Status: Untracked, Platforms: platformNames(installed[name])}
+		if e := r.Find(t, name); e != nil {
+			st, err := Check(*e, installed[name])
+			if err != nil {
+				return nil, err
+			}
+			rep.Source = e.Repo
+			rep.Status = st.Summary()
+			rep.Modified = platformNames(st.Modified)
+			rep.Outdated = st.Outdated
+			rep.Removed = st.Source == nil
+		}
+		reports = append(reports, rep)
+	}
+	return reports, nil
+}
+
+// Summary describes st in a few words: "unmodified", or whether it was
+// modified on some platforms and whether its source changed.
+func (st *Status) Summary() string {
+	var parts []string
+	if len(st.Modified) > 0 {
+		parts = append(parts, "modified on "+strings.Join(platformNames(st.Modified), ", "))
+	}
+	switch {
+	case st.Source == nil:
+		parts = append(parts, "removed from repository")
+	case st.Outdated:
+		parts = append(parts, "update available")
+	}
+	if len(parts) == 0 {
+		return "unmodified"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// PlatformDiff is the difference between a resource's source and the copy
+// installed on one platform.
+type PlatformDiff struct {
+	Platform cli.Platform
+
+	// Diff is a unified diff from the source to the installed copy, empty
+	// if they match.
+	Diff string
+
+	// Modified reports whether the installed copy was changed since it was
+	// installed, in any field, including those the diff does not show.
+	Modified bool
+}
+
+// Diff compares the resource of type t named name, as installed on each of
+// platforms that has it, with its source in the repository it was installed
+// from. Only descriptions and instructions are diffed.
+func Diff(t resource.ResourceType, name string, platforms []cli.Platform) ([]PlatformDiff, error) {
+	reg, err := Load(DefaultPath())
+	if err != nil {
+		return nil, err
+	}
+	e := reg.Find(t, name)
+	if e == nil {
+		return nil, errors.Wrapf(ErrNotRecorded, "%s %q", t, name)
+	}
+	st, err := Check(*e, platforms)
+	if err != nil {
+		return nil, err
+	}
+	if st.Source == nil {
+		return nil, errors.Newf("%s %q was removed from repository %s", t, name, e.Repo)
+	}
+	if len(st.Installed) == 0 {
+		return nil, errors.Newf("%s %q is not installed on %s", t, name, strings.Join(platformNames(platforms), ", "))
+	}
+
+	values, err := sourceValues(*e, st.Source)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := upstreamSnapshot(t, st.Source, values)
+	if err != nil {
+		return nil, err
+	}
+	oldName := e.Repo + "/" + st.Source.Path
+	diffs := make([]PlatformDiff, 0, len(st.Installed))
+	for _, p := range st.Installed {
+		v, err := cli.CanonicalResource(p, t, name)
+		if err != nil {
+			return nil, err
+		}
+		local, err := SnapshotOf(v)
+		if err != nil {
+			return nil, err
+		}
+		diff := textdiff.Unified(oldName, p.Name()+"/"+name, upstream.Text(), local.Text())
+		diffs = append(diffs, PlatformDiff{Platform: p, Diff: diff, Modified: slices.Contains(st.Modified, p)})
+	}
+	return diffs, nil
+}
+
+// platformNames returns the names of platforms.
+func platformNames(platforms []cli.Platform) []string {
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.Name()
+	}
+	return names
+}
@@ -0,0 +1,109 @@
+package registry
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/params"
+	"github.com/thoreinstein/aix/internal/platform/claude"
+	"github.com/thoreinstein/aix/internal/resource"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// Snapshot is the content of a skill, command, or agent that aix compares
+// and merges: its description and instructions. Every platform preserves
+// both, so snapshots of the same resource taken from different platforms
+// and from its source are directly comparable.
+type Snapshot struct {
+	Description  string `json:"description,omitempty"`
+	Instructions string `json:"instructions"`
+}
+
+// SnapshotOf returns the snapshot of a canonical skill, command, or agent,
+// as returned by cli.CanonicalResource.
+func SnapshotOf(v any) (*Snapshot, error) {
+	switch r := v.(type) {
+	case *claude.Skill:
+		return &Snapshot{Description: r.Description, Instructions: r.Instructions}, nil
+	case *claude.Command:
+		return &Snapshot{Description: r.Description, Instructions: r.Instructions}, nil
+	case *claude.Agent:
+		return &Snapshot{Description: r.Description, Instructions: r.Instructions}, nil
+	default:
+		return nil, errors.Newf("cannot compare content of %T", v)
+	}
+}
+
+// ReadSnapshot reads the snapshot of the resource of type t at path, a
+// skill directory or a command or agent file or directory.
+func ReadSnapshot(t resource.ResourceType, path string) (*Snapshot, error) {
+	file := resource.MainFile(t, path)
+	if file == "" {
+		return nil, errors.Newf("cannot compare content of %s resources", t)
+	}
+	data, err := fileutil.ReadFileWithLimit(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", file)
+	}
+
+	var meta struct {
+		Description string `yaml:"description"`
+	}
+	body, err := frontmatter.Parse(bytes.NewReader(data), &meta)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", file)
+	}
+	return &Snapshot{Description: meta.Description, Instructions: strings.TrimSpace(string(body))}, nil
+}
+
+// Text renders s as markdown with frontmatter, for diffs and merges.
+func (s *Snapshot) Text() string {
+	if s.Description == "" {
+		return s.Instructions + "\n"
+	}
+	data, err := frontmatter.Format(map[string]string{"description": s.Description}, s.Instructions)
+	if err != nil {
+		// A single string always encodes.
+		return s.Instructions + "\n"
+	}
+	return string(data)
+}
+
+// render returns s with its templates rendered with values; see package
+// params.
+func (s *Snapshot) render(values map[string]string) (*Snapshot, error) {
+	desc, err := params.RenderString(s.Description, values)
+	if err != nil {
+		return nil, err
+	}
+	body, err := params.RenderString(s.Instructions, values)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{Description: desc, Instructions: strings.TrimSpace(body)}, nil
+}
+
+// installedDigest returns the content digest that the copy of the resource
+// of type t named name on p would have with this snapshot's content, its
+// other fields as they are installed; see InstalledDigest.
+func (s *Snapshot) installedDigest(p cli.Platform, t resource.ResourceType, name string) (string, error) {
+	v, err := cli.CanonicalResource(p, t, name)
+	if err != nil {
+		return "", err
+	}
+	switch r := v.(type) {
+	case *claude.Skill:
+		r.Description, r.Instructions = s.Description, s.Instructions
+	case *claude.Command:
+		r.Description, r.Instructions = s.Description, s.Instructions
+	case *claude.Agent:
+		r.Description, r.Instructions = s.Description, s.Instructions
+	default:
+		return "", errors.Newf("cannot compare content of %T", v)
+	}
+	return manifest.Digest(v)
+}
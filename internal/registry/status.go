@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/thoreinstein/aix/internal/cli"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/manifest"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/internal/repo"
+	"github.com/thoreinstein/aix/internal/resource"
+)
+
+// Status compares a recorded install with the repository cache and with
+// what is installed now.
+type Status struct {
+	Entry Entry
+
+	// Source is the resource in the repository cache, or nil if it is no
+	// longer in its repository.
+	Source *resource.Resource
+
+	// Commit is the commit the repository cache is at now.
+	Commit string
+
+	// Outdated reports whether the source changed since it was installed.
+	Outdated bool
+
+	// Installed lists the platforms that have the resource now, and
+	// Modified those whose copy was changed since it was installed.
+	Installed []cli.Platform
+	Modified  []cli.Platform
+}
+
+// Check returns the status of e on platforms.
+func Check(e Entry, platforms []cli.Platform) (*Status, error) {
+	st := &Status{Entry: e}
+
+	for _, p := range platforms {
+		digest, err := InstalledDigest(p, e.Type, e.Name)
+		if err != nil {
+			continue
+		}
+		st.Installed = append(st.Installed, p)
+		if recorded, ok := e.Platforms[p.Name()]; ok && recorded != digest {
+			st.Modified = append(st.Modified, p)
+		}
+	}
+
+	src, err := findSource(e)
+	if err != nil {
+		return nil, err
+	}
+	if src == nil {
+		return st, nil
+	}
+	st.Source = src
+	st.Commit, _ = git.Head(filepath.Join(paths.ReposCacheDir(), e.Repo))
+
+	sum, err := manifest.HashPath(src.SourcePath())
+	if err != nil {
+		return nil, errors.Wrapf(err, "hashing %s %q", e.Type, e.Name)
+	}
+	st.Outdated = sum != e.SHA256
+	return st, nil
+}
+
+// findSource locates e's source in the repository cache, following it if
+// it moved within its repository. It returns nil if the resource or its
+// repository is gone.
+func findSource(e Entry) (*resource.Resource, error) {
+	if _, err := os.Stat(e.SourcePath()); err == nil {
+		return &resource.Resource{Name: e.Name, Type: e.Type, RepoName: e.Repo, RepoURL: e.URL, Path: e.Path}, nil
+	}
+	res, err := resource.FindByNameInRepo(e.Name, e.Type, e.Repo)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up %s %q", e.Type, e.Name)
+	}
+	return res, nil
+}
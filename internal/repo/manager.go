@@ -23,6 +23,7 @@ var (
 	ErrNameCollision      = errors.New("repository with this name already exists")
 	ErrInvalidName        = errors.New("invalid repository name")
 	ErrCacheCleanupFailed = errors.New("cache cleanup failed")
+	ErrRefNotFound        = errors.New("git ref not found")
 )
 
 // namePattern validates repository names.
@@ -35,6 +36,7 @@ type Option func(*addOptions)
 // addOptions holds optional parameters for Add.
 type addOptions struct {
 	name string
+	ref  string
 }
 
 // WithName overrides the repository name derived from the URL.
@@ -44,6 +46,14 @@ func WithName(name string) Option {
 	}
 }
 
+// WithRef pins the repository to a branch, tag, or commit SHA instead of
+// tracking the remote's default branch.
+func WithRef(ref string) Option {
+	return func(o *addOptions) {
+		o.ref = ref
+	}
+}
+
 // Manager manages skill repositories.
 type Manager struct {
 	configPath string // Path to config file for persistence
@@ -128,14 +138,27 @@ func (m *Manager) Add(url string, opts ...Option) (*config.RepoConfig, error) {
 		}
 	}
 
-	// Clone repository - clean up partial clone on failure
-	if err := git.Clone(url, destPath, 1); err != nil {
+	// Clone repository - clean up partial clone on failure.
+	// Pinned repositories need full history so that any ref can be checked out.
+	depth := 1
+	if options.ref != "" {
+		depth = 0
+	}
+	if err := git.Clone(url, destPath, depth); err != nil {
 		// Remove any partially-created directory
 		if cleanupErr := os.RemoveAll(destPath); cleanupErr != nil {
 			return nil, errors.Wrapf(err, "cloning repository (cleanup also failed: %v)", cleanupErr)
 		}
 		return nil, errors.Wrap(err, "cloning repository")
 	}
+	if options.ref != "" {
+		if err := checkoutRef(destPath, options.ref); err != nil {
+			if cleanupErr := os.RemoveAll(destPath); cleanupErr != nil {
+				return nil, errors.Wrapf(err, "cleanup also failed: %v", cleanupErr)
+			}
+			return nil, err
+		}
+	}
 
 	// Create repo config entry
 	repo := config.RepoConfig{
@@ -143,20 +166,26 @@ func (m *Manager) Add(url string, opts ...Option) (*config.RepoConfig, error) {
 		Name:    name,
 		Path:    destPath,
 		AddedAt: time.Now(),
+		Ref:     options.ref,
 	}
 
-	// Initialize repos map if nil
-	if cfg.Repos == nil {
-		cfg.Repos = make(map[string]config.RepoConfig)
-	}
-
-	// Add repo to config
-	cfg.Repos[name] = repo
-
-	// Save config
-	if err := m.saveConfig(cfg); err != nil {
-		// Clean up cloned repo on save failure
-		os.RemoveAll(destPath)
+	// Add repo to config, checking again for a collision in case another
+	// process registered the name while we were cloning.
+	err = m.updateConfig(func(cfg *config.Config) error {
+		if existing, exists := cfg.Repos[name]; exists {
+			return errors.WithDetailf(ErrNameCollision,
+				"name %q is already used by %s; use --name to specify an alternate name",
+				name, existing.URL)
+		}
+		cfg.Repos[name] = repo
+		return nil
+	})
+	if err != nil {
+		// Clean up cloned repo on save failure, unless it now belongs to
+		// the process that won the race
+		if !errors.Is(err, ErrNameCollision) {
+			os.RemoveAll(destPath)
+		}
 		return nil, errors.Wrap(err, "saving config")
 	}
 
@@ -187,25 +216,21 @@ func (m *Manager) List() ([]config.RepoConfig, error) {
 // The config is persisted before deleting cached data to ensure
 // consistent state if the operation fails partway through.
 func (m *Manager) Remove(name string) error {
-	cfg, err := m.loadConfig()
-	if err != nil {
-		return errors.Wrap(err, "loading config")
-	}
-
-	if cfg.Repos == nil {
-		return errors.WithDetailf(ErrNotFound, "repository %q not found", name)
-	}
-
-	repo, exists := cfg.Repos[name]
-	if !exists {
-		return errors.WithDetailf(ErrNotFound, "repository %q not found", name)
-	}
-
-	// Remove from config first
-	delete(cfg.Repos, name)
-
 	// Persist config before deleting data - if this fails, cached data remains intact
-	if err := m.saveConfig(cfg); err != nil {
+	var repo config.RepoConfig
+	err := m.updateConfig(func(cfg *config.Config) error {
+		var exists bool
+		repo, exists = cfg.Repos[name]
+		if !exists {
+			return errors.WithDetailf(ErrNotFound, "repository %q not found", name)
+		}
+		delete(cfg.Repos, name)
+		return nil
+	})
+	if errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if err != nil {
 		return errors.Wrap(err, "saving config")
 	}
 
@@ -219,7 +244,8 @@ func (m *Manager) Remove(name string) error {
 	return nil
 }
 
-// Update pulls the latest changes for repositories.
+// Update pulls the latest changes for repositories, respecting pins
+// (see UpdateRepo).
 // If name is provided, only that repository is updated.
 // If name is empty, all repositories are updated.
 func (m *Manager) Update(name string) error {
@@ -241,12 +267,12 @@ func (m *Manager) Update(name string) error {
 		if !exists {
 			return errors.WithDetailf(ErrNotFound, "repository %q not found", name)
 		}
-		return errors.Wrapf(git.Pull(repo.Path), "pulling changes for %s", name)
+		return errors.Wrapf(m.UpdateRepo(&repo), "pulling changes for %s", name)
 	}
 
 	// Update all repos - return first error encountered
 	for _, repo := range cfg.Repos {
-		if err := git.Pull(repo.Path); err != nil {
+		if err := m.UpdateRepo(&repo); err != nil {
 			return errors.Wrapf(err, "updating repository %q", repo.Name)
 		}
 	}
@@ -255,12 +281,90 @@ func (m *Manager) Update(name string) error {
 }
 
 // UpdateByPath pulls the latest changes for a repository at the given path.
-// This is more efficient when you already have the repo config and don't need
-// to reload configuration.
+// It ignores any pin; prefer UpdateRepo when the repo config is available.
 func (m *Manager) UpdateByPath(path string) error {
 	return errors.Wrapf(git.Pull(path), "pulling changes at %s", path)
 }
 
+// UpdateRepo updates a single repository without reloading configuration.
+// Unpinned repositories fast-forward their default branch. Pinned
+// repositories fetch and check out their ref again, so a pinned branch
+// follows its upstream while a tag or commit stays where it is.
+func (m *Manager) UpdateRepo(repo *config.RepoConfig) error {
+	if repo.Ref == "" {
+		return errors.Wrapf(git.Pull(repo.Path), "pulling changes at %s", repo.Path)
+	}
+	if err := git.Fetch(repo.Path); err != nil {
+		return errors.Wrapf(err, "fetching %s", repo.Name)
+	}
+	return checkoutRef(repo.Path, repo.Ref)
+}
+
+// Pin checks out ref in a repository and records it in the config so that
+// later updates stay on it.
+func (m *Manager) Pin(name, ref string) (*config.RepoConfig, error) {
+	cfg, err := m.loadConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading config")
+	}
+
+	repo, exists := cfg.Repos[name]
+	if !exists {
+		return nil, errors.WithDetailf(ErrNotFound, "repository %q not found", name)
+	}
+
+	if err := git.Fetch(repo.Path); err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", name)
+	}
+	if err := checkoutRef(repo.Path, ref); err != nil {
+		return nil, err
+	}
+
+	repo.Ref = ref
+	if err := m.setRef(name, repo.Ref); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// Unpin removes a repository's pin and returns it to tracking the remote's
+// default branch.
+func (m *Manager) Unpin(name string) (*config.RepoConfig, error) {
+	cfg, err := m.loadConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading config")
+	}
+
+	repo, exists := cfg.Repos[name]
+	if !exists {
+		return nil, errors.WithDetailf(ErrNotFound, "repository %q not found", name)
+	}
+
+	if repo.Ref != "" {
+		if err := git.Fetch(repo.Path); err != nil {
+			return nil, errors.Wrapf(err, "fetching %s", name)
+		}
+		if err := git.CheckoutDefaultBranch(repo.Path); err != nil {
+			return nil, errors.Wrapf(err, "unpinning %s", name)
+		}
+	}
+
+	repo.Ref = ""
+	if err := m.setRef(name, repo.Ref); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// checkoutRef detaches the clone at path on the commit ref names.
+func checkoutRef(path, ref string) error {
+	sha, err := git.ResolveRef(path, ref)
+	if err != nil {
+		return errors.WithDetailf(ErrRefNotFound, "%q is not a branch, tag, or commit in this repository", ref)
+	}
+	return errors.Wrapf(git.Checkout(path, sha), "checking out %s", ref)
+}
+
 // Get retrieves a repository by name.
 func (m *Manager) Get(name string) (*config.RepoConfig, error) {
 	cfg, err := m.loadConfig()
@@ -331,7 +435,30 @@ func (m *Manager) loadConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
+// setRef records ref as the pin of the named repository.
+func (m *Manager) setRef(name, ref string) error {
+	err := m.updateConfig(func(cfg *config.Config) error {
+		repo, exists := cfg.Repos[name]
+		if !exists {
+			return errors.WithDetailf(ErrNotFound, "repository %q not found", name)
+		}
+		repo.Ref = ref
+		cfg.Repos[name] = repo
+		return nil
+	})
+	return errors.Wrap(err, "saving config")
+}
+
+// updateConfig applies fn to the configuration and saves the result while
+// holding the config file's lock, so that concurrent aix processes cannot
+// overwrite each other's changes. The configuration is read after the lock
+// is taken; slow work such as cloning should happen before calling it.
+func (m *Manager) updateConfig(fn func(cfg *config.Config) error) error {
+	return fileutil.Update(m.configPath, m.loadConfig, fn, m.saveConfig)
+}
+
 // saveConfig saves the configuration to the manager's config path.
+// Callers should use updateConfig.
 func (m *Manager) saveConfig(cfg *config.Config) error {
 	// Ensure parent directory exists
 	dir := filepath.Dir(m.configPath)
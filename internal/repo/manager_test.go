@@ -322,6 +322,80 @@ func TestManager_Update_Integration(t *testing.T) {
 	}
 }
 
+func TestManager_Pin_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	cacheDir := filepath.Join(tmpDir, "cache")
+	m := NewManager(configPath, WithCacheDir(cacheDir))
+
+	repoDir := filepath.Join(tmpDir, "source-repo")
+	createLocalGitRepo(t, repoDir)
+	runGitIn(t, repoDir, "tag", "v1.0.0")
+	v1 := headOf(t, repoDir)
+	if err := os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitIn(t, repoDir, "add", "CHANGELOG.md")
+	runGitIn(t, repoDir, "commit", "-m", "second commit")
+	v2 := headOf(t, repoDir)
+
+	repo, err := m.Add("file://"+repoDir, WithName("pinned"), WithRef("v1.0.0"))
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if repo.Ref != "v1.0.0" {
+		t.Errorf("Add() Ref = %q, want %q", repo.Ref, "v1.0.0")
+	}
+	if got := headOf(t, repo.Path); got != v1 {
+		t.Errorf("pinned clone at %s, want %s", got, v1)
+	}
+
+	// Update must not move a tag pin.
+	if err := m.Update("pinned"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := headOf(t, repo.Path); got != v1 {
+		t.Errorf("Update() moved pinned clone to %s, want %s", got, v1)
+	}
+
+	if _, err := m.Pin("pinned", "no-such-tag"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("Pin() unknown ref error = %v, want ErrRefNotFound", err)
+	}
+
+	repo, err = m.Unpin("pinned")
+	if err != nil {
+		t.Fatalf("Unpin() error = %v", err)
+	}
+	if repo.Ref != "" {
+		t.Errorf("Unpin() Ref = %q, want empty", repo.Ref)
+	}
+	if got := headOf(t, repo.Path); got != v2 {
+		t.Errorf("unpinned clone at %s, want %s", got, v2)
+	}
+	if err := m.Update("pinned"); err != nil {
+		t.Errorf("Update() after Unpin error = %v", err)
+	}
+
+	repo, err = m.Pin("pinned", v1[:12])
+	if err != nil {
+		t.Fatalf("Pin() error = %v", err)
+	}
+	if got := headOf(t, repo.Path); got != v1 {
+		t.Errorf("Pin() checked out %s, want %s", got, v1)
+	}
+	saved, err := m.Get("pinned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Ref != v1[:12] {
+		t.Errorf("saved Ref = %q, want %q", saved.Ref, v1[:12])
+	}
+}
+
 func createLocalGitRepo(t *testing.T, dir string) {
 	t.Helper()
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -352,3 +426,21 @@ func createLocalGitRepo(t *testing.T, dir string) {
 func isNameCollisionError(err error) bool {
 	return strings.Contains(err.Error(), "already used by") || errors.Is(err, ErrNameCollision)
 }
+
+func runGitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\nOutput: %s", strings.Join(args, " "), err, out)
+	}
+}
+
+func headOf(t *testing.T, dir string) string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("git rev-parse HEAD in %s: %v", dir, err)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,331 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/config"
+	"github.com/thoreinstein/aix/internal/errors"
+	"github.com/thoreinstein/aix/internal/git"
+	"github.com/thoreinstein/aix/internal/paths"
+	"github.com/thoreinstein/aix/pkg/fileutil"
+	"github.com/thoreinstein/aix/pkg/frontmatter"
+)
+
+// indexVersion is stored in the index file. Indexes written with a
+// different version are rebuilt rather than read.
+const indexVersion = 2
+
+// maxIndexedBody bounds how much of a resource's body text is indexed.
+const maxIndexedBody = 32 << 10
+
+// Searchable fields of a document.
+const (
+	fieldName = iota
+	fieldDescription
+	fieldMeta
+	fieldBody
+	numFields
+)
+
+// Index is a full-text index over the resources in cached repositories.
+// It is persisted so that repositories are only rescanned when they change.
+// Open one with OpenIndex and query it with its Search method.
+type Index struct {
+	path  string
+	repos map[string]indexedRepo
+	dirty bool
+
+	docs     []*document
+	postings map[string][]posting
+	vocab    []string
+	avgLen   [numFields]float64
+}
+
+// indexFile is the on-disk form of an Index.
+type indexFile struct {
+	Version int                    `json:"version"`
+	Repos   map[string]indexedRepo `json:"repos"`
+}
+
+// indexedRepo holds the documents scanned from one repository.
+type indexedRepo struct {
+	// Key identifies the repository state the documents were built from.
+	Key  string      `json:"key"`
+	Docs []*document `json:"docs"`
+}
+
+// document is a resource together with the text that is searched.
+type document struct {
+	Resource Resource `json:"resource"`
+	Tools    []string `json:"tools,omitempty"`
+	Meta     string   `json:"meta,omitempty"`
+	Body     string   `json:"body,omitempty"`
+
+	freqs   [numFields]map[string]int
+	lengths [numFields]int
+}
+
+// posting records that a term occurs in a document.
+type posting struct {
+	doc  int
+	freq [numFields]int
+}
+
+// DefaultIndexPath returns the location of the search index in the cache
+// directory.
+func DefaultIndexPath() string {
+	return filepath.Join(paths.CacheHome(), "aix", "search-index.json")
+}
+
+// InvalidateIndex removes the search index at path so that the next search
+// rescans every repository. It is not an error if the index does not exist.
+func InvalidateIndex(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing search index")
+	}
+	return nil
+}
+
+// OpenIndex loads the index at path and brings it up to date with repos.
+// Repositories that are new or whose configuration changed are scanned
+// with s; entries for repositories no longer configured are dropped. If
+// anything changed, the updated index is written back to path.
+//
+// A missing or unreadable index is rebuilt from scratch.
+func OpenIndex(path string, repos []config.RepoConfig, s *Scanner) (*Index, error) {
+	idx := &Index{path: path, repos: make(map[string]indexedRepo)}
+
+	if data, err := os.ReadFile(path); err == nil {
+		var f indexFile
+		if err := json.Unmarshal(data, &f); err == nil && f.Version == indexVersion && f.Repos != nil {
+			idx.repos = f.Repos
+		}
+	}
+
+	configured := make(map[string]bool, len(repos))
+	for _, r := range repos {
+		configured[r.Name] = true
+		key := repoKey(r)
+		if existing, ok := idx.repos[r.Name]; ok && existing.Key == key {
+			continue
+		}
+		docs, err := s.indexRepo(r)
+		if err != nil {
+			return nil, err
+		}
+		idx.repos[r.Name] = indexedRepo{Key: key, Docs: docs}
+		idx.dirty = true
+	}
+	for name := range idx.repos {
+		if !configured[name] {
+			delete(idx.repos, name)
+			idx.dirty = true
+		}
+	}
+
+	if idx.dirty {
+		if err := idx.save(); err != nil {
+			// A stale cache only costs speed; searching still works.
+			s.logger.Warn("failed to save search index", "path", path, "error", err)
+		}
+	}
+	idx.build()
+	return idx, nil
+}
+
+// Resources returns every indexed resource, ordered by repository and then
+// as scanned.
+func (idx *Index) Resources() []Resource {
+	out := make([]Resource, len(idx.docs))
+	for i, d := range idx.docs {
+		out[i] = d.Resource
+	}
+	return out
+}
+
+// save writes the index to its path.
+func (idx *Index) save() error {
+	if err := os.MkdirAll(filepath.Dir(idx.path), 0o755); err != nil {
+		return errors.Wrap(err, "creating cache directory")
+	}
+	f := indexFile{Version: indexVersion, Repos: idx.repos}
+	return fileutil.AtomicWriteJSON(idx.path, &f)
+}
+
+// build computes term statistics for the loaded documents.
+func (idx *Index) build() {
+	names := make([]string, 0, len(idx.repos))
+	for name := range idx.repos {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	idx.docs = idx.docs[:0]
+	for _, name := range names {
+		idx.docs = append(idx.docs, idx.repos[name].Docs...)
+	}
+
+	idx.postings = make(map[string][]posting)
+	var total [numFields]int
+	for i, d := range idx.docs {
+		texts := [numFields]string{
+			d.Resource.Name,
+			d.Resource.Description,
+			d.Meta + " " + strings.Join(d.Tools, " "),
+			d.Body,
+		}
+		terms := make(map[string][numFields]int)
+		for f, text := range texts {
+			tokens := tokenize(text)
+			d.lengths[f] = len(tokens)
+			total[f] += len(tokens)
+			d.freqs[f] = make(map[string]int)
+			for _, t := range tokens {
+				d.freqs[f][t]++
+				freq := terms[t]
+				freq[f]++
+				terms[t] = freq
+			}
+		}
+		for t, freq := range terms {
+			idx.postings[t] = append(idx.postings[t], posting{doc: i, freq: freq})
+		}
+	}
+
+	if n := len(idx.docs); n > 0 {
+		for f := range total {
+			idx.avgLen[f] = float64(total[f]) / float64(n)
+		}
+	}
+	idx.vocab = make([]string, 0, len(idx.postings))
+	for t := range idx.postings {
+		idx.vocab = append(idx.vocab, t)
+	}
+	sort.Strings(idx.vocab)
+}
+
+// repoKey identifies the configuration and commit a repository was indexed
+// at, so that a repository updated or checked out by git outside aix is
+// rescanned. A directory that is not a git repository has no commit; its
+// content changes are handled by InvalidateIndex.
+func repoKey(r config.RepoConfig) string {
+	head, _ := git.Head(r.Path)
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%s", r.Path, r.URL, r.Ref, r.AddedAt.UnixNano(), head)
+}
+
+// indexMeta holds the frontmatter fields that are searched as metadata.
+type indexMeta struct {
+	AllowedTools toolList       `yaml:"allowed-tools"`
+	Tools        toolList       `yaml:"tools"`
+	Metadata     map[string]any `yaml:"metadata"`
+}
+
+// toolList accepts tools written as a list or as a comma- or
+// space-separated string.
+type toolList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *toolList) UnmarshalYAML(value *yaml.Node) error {
+	var list []string
+	if err := value.Decode(&list); err == nil {
+		*t = list
+		return nil
+	}
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	*t = strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	return nil
+}
+
+// indexRepo scans a repository and reads the searchable text of each
+// resource.
+func (s *Scanner) indexRepo(r config.RepoConfig) ([]*document, error) {
+	resources, err := s.ScanRepo(r.Path, r.Name, r.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "scanning repository %s", r.Name)
+	}
+
+	docs := make([]*document, 0, len(resources))
+	for _, res := range resources {
+		d := &document{Resource: res}
+		for k, v := range res.Metadata {
+			d.Meta += k + " " + v + " "
+		}
+		if file := MainFile(res.Type, filepath.Join(r.Path, res.Path)); file != "" {
+			s.readDocument(file, d)
+		}
+		docs = append(docs, d)
+	}
+	return docs, nil
+}
+
+// readDocument fills in d's tools, metadata, and body from a markdown file.
+// Unreadable files leave d with only its name and description.
+func (s *Scanner) readDocument(file string, d *document) {
+	data, err := fileutil.ReadFileWithLimit(file)
+	if err != nil {
+		s.logger.Warn("failed to read resource for indexing", "path", file, "error", err)
+		return
+	}
+
+	var meta indexMeta
+	body, err := frontmatter.Parse(bytes.NewReader(data), &meta)
+	if err != nil {
+		body = data
+	}
+	d.Tools = append(meta.AllowedTools, meta.Tools...)
+	keys := make([]string, 0, len(meta.Metadata))
+	for k := range meta.Metadata {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		d.Meta += fmt.Sprintf("%s %v ", k, meta.Metadata[k])
+	}
+	if len(body) > maxIndexedBody {
+		body = body[:maxIndexedBody]
+	}
+	d.Body = string(body)
+}
+
+// tokenize splits text into lowercase runs of letters and digits.
+func tokenize(text string) []string {
+	spans := tokenSpans(text)
+	tokens := make([]string, len(spans))
+	for i, sp := range spans {
+		tokens[i] = strings.ToLower(text[sp.Start:sp.End])
+	}
+	return tokens
+}
+
+// tokenSpans returns the byte offsets of the tokens in text.
+func tokenSpans(text string) []Span {
+	var spans []Span
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			spans = append(spans, Span{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, Span{start, len(text)})
+	}
+	return spans
+}
@@ -0,0 +1,155 @@
+package resource
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/config"
+)
+
+// writeRepoFile writes content to name under dir, creating parents.
+func writeRepoFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupIndex creates two repositories and opens an index over them.
+func setupIndex(t *testing.T) (*Index, string, []config.RepoConfig) {
+	t.Helper()
+	dir := t.TempDir()
+
+	official := filepath.Join(dir, "official")
+	writeRepoFile(t, official, "skills/deploy/SKILL.md", `---
+name: deploy
+description: Deploy applications to production
+allowed-tools: Bash Read
+---
+Run the release pipeline and watch the rollout.
+`)
+	writeRepoFile(t, official, "skills/k8s-debug/SKILL.md", `---
+name: k8s-debug
+description: Diagnose failing pods
+allowed-tools:
+  - Read
+---
+Inspect Kubernetes events and container logs to find why a pod crashes.
+`)
+	writeRepoFile(t, official, "commands/review.md", `---
+description: Review the current change
+---
+Check the diff for bugs before you deploy.
+`)
+
+	community := filepath.Join(dir, "community")
+	writeRepoFile(t, community, "agents/planner.md", `---
+description: Plans large refactors
+tools: Read, Grep
+---
+Break work into steps.
+`)
+
+	repos := []config.RepoConfig{
+		{Name: "official", URL: "https://example.com/official.git", Path: official},
+		{Name: "community", URL: "https://example.com/community.git", Path: community},
+	}
+	indexPath := filepath.Join(dir, "cache", "search-index.json")
+	idx, err := OpenIndex(indexPath, repos, quietScanner())
+	if err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+	return idx, indexPath, repos
+}
+
+func quietScanner() *Scanner {
+	return NewScannerWithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func hitNames(hits []Hit) []string {
+	names := make([]string, len(hits))
+	for i, h := range hits {
+		names[i] = h.Resource.Name
+	}
+	return names
+}
+
+func TestOpenIndex_RescansNewCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeRepoFile(t, dir, "agents/planner.md", "Plans large refactors.\n")
+	gitRun("init")
+	gitRun("-c", "user.email=test@example.com", "-c", "user.name=Test", "add", ".")
+	gitRun("-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "-m", "initial")
+
+	repos := []config.RepoConfig{{Name: "official", URL: "https://example.com/official.git", Path: dir}}
+	indexPath := filepath.Join(t.TempDir(), "search-index.json")
+	if _, err := OpenIndex(indexPath, repos, quietScanner()); err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+
+	// A commit made outside aix moves HEAD, which is part of the key
+	writeRepoFile(t, dir, "agents/reviewer.md", "Reviews pull requests.\n")
+	gitRun("-c", "user.email=test@example.com", "-c", "user.name=Test", "add", ".")
+	gitRun("-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "-m", "reviewer")
+	idx, err := OpenIndex(indexPath, repos, quietScanner())
+	if err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+	if hits, _ := idx.Search("pull", SearchOptions{}); len(hits) != 1 {
+		t.Errorf("Search() after a new commit = %v, want reviewer", hitNames(hits))
+	}
+}
+
+func TestOpenIndex_Persists(t *testing.T) {
+	_, indexPath, repos := setupIndex(t)
+
+	// Content changes are not seen until the index is invalidated.
+	writeRepoFile(t, repos[1].Path, "agents/reviewer.md", "Reviews pull requests.\n")
+	idx, err := OpenIndex(indexPath, repos, quietScanner())
+	if err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+	if hits, _ := idx.Search("pull", SearchOptions{}); len(hits) != 0 {
+		t.Errorf("Search() before invalidation = %v, want cached results", hitNames(hits))
+	}
+
+	if err := InvalidateIndex(indexPath); err != nil {
+		t.Fatalf("InvalidateIndex() error = %v", err)
+	}
+	idx, err = OpenIndex(indexPath, repos, quietScanner())
+	if err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+	if hits, _ := idx.Search("pull", SearchOptions{}); len(hits) != 1 {
+		t.Errorf("Search() after invalidation = %v, want reviewer", hitNames(hits))
+	}
+
+	// Removed repositories are dropped.
+	idx, err = OpenIndex(indexPath, repos[:1], quietScanner())
+	if err != nil {
+		t.Fatalf("OpenIndex() error = %v", err)
+	}
+	for _, r := range idx.Resources() {
+		if r.RepoName == "community" {
+			t.Errorf("resource %s from removed repository still indexed", r.Name)
+		}
+	}
+}
@@ -12,6 +12,17 @@ var ErrNoReposConfigured = errors.New("no repositories configured")
 // FindByName scans all configured repositories and returns resources matching
 // the given name and type exactly. Returns an empty slice if no matches found.
 func FindByName(name string, resourceType ResourceType) ([]Resource, error) {
+	resources, err := ScanConfigured()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterByNameAndType(resources, name, resourceType), nil
+}
+
+// ScanConfigured scans all configured repositories and returns every
+// resource in them.
+func ScanConfigured() ([]Resource, error) {
 	configPath := config.DefaultConfigPath()
 	mgr := repo.NewManager(configPath)
 
@@ -29,8 +40,7 @@ func FindByName(name string, resourceType ResourceType) ([]Resource, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "scanning repositories")
 	}
-
-	return filterByNameAndType(resources, name, resourceType), nil
+	return resources, nil
 }
 
 // FindByNameInRepo scans a specific repository and returns the resource matching
@@ -0,0 +1,367 @@
+package resource
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// fieldWeights scale term frequencies by field. A match in a resource's
+// name counts for more than one in its description, which counts for more
+// than one in its metadata or body.
+var fieldWeights = [numFields]float64{
+	fieldName:        4,
+	fieldDescription: 2,
+	fieldMeta:        1.5,
+	fieldBody:        1,
+}
+
+// Weights applied to terms that only approximately match a query term.
+const (
+	prefixWeight = 0.7
+	fuzzyWeight  = 0.5
+)
+
+// maxExpansions bounds how many indexed terms a single query term may
+// match by prefix or edit distance.
+const maxExpansions = 50
+
+// exactNameBonus is added when the whole query is a resource's name.
+const exactNameBonus = 5
+
+// snippetWidth is the approximate length of a snippet taken from a body.
+const snippetWidth = 100
+
+// ErrInvalidQuery indicates that a search query could not be parsed.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// Span is a byte range within a string.
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Hit is a resource that matched a search.
+type Hit struct {
+	Resource Resource
+
+	// Score ranks the hit against others from the same search. It is 0
+	// when the query has no search terms.
+	Score float64
+
+	// Snippet is the text that best shows why the resource matched, taken
+	// from its description or body.
+	Snippet string
+
+	// Highlights are the ranges of Snippet that matched the query.
+	Highlights []Span
+}
+
+// Query is a parsed search query.
+type Query struct {
+	// Terms are the lowercase words to search for.
+	Terms []string
+
+	// Filters restrict which resources can match.
+	Filters SearchOptions
+
+	text string
+}
+
+// ParseQuery parses a search query. Words of the form key:value are
+// filters: type:<skill|command|agent|mcp>, repo:<name>, and tool:<name>.
+// Filters in the query are combined with opts. Everything else is
+// searched as text.
+func ParseQuery(text string, opts SearchOptions) (Query, error) {
+	q := Query{Filters: opts}
+	var words []string
+	for _, word := range strings.Fields(text) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			words = append(words, word)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "type":
+			t := ResourceType(strings.ToLower(value))
+			if !slices.Contains([]ResourceType{TypeSkill, TypeCommand, TypeAgent, TypeMCP}, t) {
+				return Query{}, errors.Wrapf(ErrInvalidQuery, "unknown type %q (want skill, command, agent, or mcp)", value)
+			}
+			if q.Filters.Type != "" && q.Filters.Type != t {
+				return Query{}, errors.Wrapf(ErrInvalidQuery, "conflicting types %q and %q", q.Filters.Type, t)
+			}
+			q.Filters.Type = t
+		case "repo":
+			q.Filters.RepoName = value
+		case "tool":
+			q.Filters.Tool = value
+		default:
+			words = append(words, word)
+		}
+	}
+	q.text = strings.ToLower(strings.Join(words, " "))
+	q.Terms = tokenize(q.text)
+	return q, nil
+}
+
+// Search returns the indexed resources matching query, best match first.
+// query may contain filters (see ParseQuery), which are combined with
+// opts. Search terms match whole words, word prefixes, and, when neither
+// matches, words within a small edit distance, so that typos still find
+// results. A query with no search terms returns every resource that passes
+// the filters.
+func (idx *Index) Search(query string, opts SearchOptions) ([]Hit, error) {
+	q, err := ParseQuery(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(q.Terms) == 0 {
+		var hits []Hit
+		for _, d := range idx.docs {
+			if d.matches(q.Filters) {
+				hits = append(hits, Hit{Resource: d.Resource, Snippet: d.Resource.Description})
+			}
+		}
+		return hits, nil
+	}
+
+	scores := make(map[int]float64)
+	matched := make(map[int]map[string]bool)
+	n := float64(len(idx.docs))
+	for _, qt := range q.Terms {
+		best := make(map[int]float64)
+		for term, weight := range idx.expand(qt) {
+			list := idx.postings[term]
+			df := float64(len(list))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for _, p := range list {
+				d := idx.docs[p.doc]
+				if !d.matches(q.Filters) {
+					continue
+				}
+				s := weight * idf * idx.saturate(d, p.freq)
+				if s > best[p.doc] {
+					best[p.doc] = s
+				}
+				if matched[p.doc] == nil {
+					matched[p.doc] = make(map[string]bool)
+				}
+				matched[p.doc][term] = true
+			}
+		}
+		for doc, s := range best {
+			scores[doc] += s
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for doc, score := range scores {
+		d := idx.docs[doc]
+		if strings.ToLower(d.Resource.Name) == q.text {
+			score += exactNameBonus
+		}
+		h := Hit{Resource: d.Resource, Score: score}
+		h.Snippet, h.Highlights = d.snippet(matched[doc])
+		hits = append(hits, h)
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(a.Resource.Name, b.Resource.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Resource.RepoName, b.Resource.RepoName)
+	})
+	return hits, nil
+}
+
+// saturate combines a term's per-field frequencies in d into a BM25F term
+// weight.
+func (idx *Index) saturate(d *document, freq [numFields]int) float64 {
+	var tf float64
+	for f := range freq {
+		if freq[f] == 0 || idx.avgLen[f] == 0 {
+			continue
+		}
+		norm := 1 - bm25B + bm25B*float64(d.lengths[f])/idx.avgLen[f]
+		tf += fieldWeights[f] * float64(freq[f]) / norm
+	}
+	return tf * (bm25K1 + 1) / (tf + bm25K1)
+}
+
+// expand returns the indexed terms that match qt, with their weights. An
+// exact match has weight 1 and terms that qt is a prefix of are also
+// matched. Only when neither exists are terms within a small edit distance
+// tried.
+func (idx *Index) expand(qt string) map[string]float64 {
+	out := make(map[string]float64)
+	if _, ok := idx.postings[qt]; ok {
+		out[qt] = 1
+	}
+	if len(qt) >= 2 {
+		i := sort.SearchStrings(idx.vocab, qt)
+		for ; i < len(idx.vocab) && len(out) < maxExpansions; i++ {
+			t := idx.vocab[i]
+			if !strings.HasPrefix(t, qt) {
+				break
+			}
+			if t != qt {
+				out[t] = prefixWeight
+			}
+		}
+	}
+	if len(out) > 0 || len([]rune(qt)) < 4 {
+		return out
+	}
+
+	maxEdits := 1
+	if len([]rune(qt)) >= 8 {
+		maxEdits = 2
+	}
+	for _, t := range idx.vocab {
+		if len(out) >= maxExpansions {
+			break
+		}
+		if editDistance(qt, t, maxEdits) <= maxEdits {
+			out[t] = fuzzyWeight
+		}
+	}
+	return out
+}
+
+// editDistance returns the Damerau-Levenshtein distance between a and b,
+// or maxDist+1 once it is known to exceed maxDist.
+func editDistance(a, b string, maxDist int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > maxDist || -d > maxDist {
+		return maxDist + 1
+	}
+
+	// Three rows are enough for adjacent transpositions.
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > maxDist {
+			return maxDist + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
+
+// matches reports whether d passes the filters.
+func (d *document) matches(opts SearchOptions) bool {
+	if !matchesFilters(d.Resource, opts) {
+		return false
+	}
+	if opts.Tool == "" {
+		return true
+	}
+	return slices.ContainsFunc(d.Tools, func(t string) bool {
+		// Tools may carry arguments, as in Bash(git:*).
+		name, _, _ := strings.Cut(t, "(")
+		return strings.EqualFold(name, opts.Tool) || strings.EqualFold(t, opts.Tool)
+	})
+}
+
+// snippet returns the part of d's description or body that contains the
+// terms, with the ranges of those terms. Resources that only matched by
+// name or metadata get their description without highlights.
+func (d *document) snippet(terms map[string]bool) (string, []Span) {
+	if spans := highlight(d.Resource.Description, terms); len(spans) > 0 {
+		return d.Resource.Description, spans
+	}
+	for line := range strings.Lines(d.Body) {
+		line = strings.TrimSpace(line)
+		spans := highlight(line, terms)
+		if len(spans) == 0 {
+			continue
+		}
+		start, end := 0, len(line)
+		if end > snippetWidth {
+			start = max(spans[0].Start-snippetWidth/3, 0)
+			end = min(start+snippetWidth, len(line))
+			start, end = wordBoundary(line, start, end)
+		}
+		text := line[start:end]
+		prefix := ""
+		if start > 0 {
+			prefix = "…"
+		}
+		var out []Span
+		for _, sp := range spans {
+			if sp.Start >= start && sp.End <= end {
+				out = append(out, Span{sp.Start - start + len(prefix), sp.End - start + len(prefix)})
+			}
+		}
+		if end < len(line) {
+			text += "…"
+		}
+		return prefix + text, out
+	}
+	return d.Resource.Description, nil
+}
+
+// wordBoundary widens start and shrinks end so that the range does not cut
+// a word or a UTF-8 sequence in half.
+func wordBoundary(s string, start, end int) (int, int) {
+	spans := tokenSpans(s)
+	for _, sp := range spans {
+		if sp.Start < start && sp.End > start {
+			start = sp.Start
+		}
+		if sp.Start < end && sp.End > end {
+			end = sp.Start
+		}
+	}
+	for start > 0 && !utf8.RuneStart(s[start]) {
+		start--
+	}
+	for end < len(s) && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return start, max(end, start)
+}
+
+// highlight returns the ranges of the tokens in text that are in terms.
+func highlight(text string, terms map[string]bool) []Span {
+	var out []Span
+	for _, sp := range tokenSpans(text) {
+		if terms[strings.ToLower(text[sp.Start:sp.End])] {
+			out = append(out, sp)
+		}
+	}
+	return out
+}
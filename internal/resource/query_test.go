@@ -0,0 +1,101 @@
+package resource
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIndex_Search(t *testing.T) {
+	idx, _, _ := setupIndex(t)
+
+	tests := []struct {
+		name  string
+		query string
+		opts  SearchOptions
+		want  []string
+	}{
+		{name: "name beats body", query: "deploy", want: []string{"deploy", "review"}},
+		{name: "body text", query: "kubernetes", want: []string{"k8s-debug"}},
+		{name: "prefix", query: "kube", want: []string{"k8s-debug"}},
+		{name: "typo", query: "kubernets", want: []string{"k8s-debug"}},
+		{name: "transposed letters", query: "reveiw", want: []string{"review"}},
+		{name: "type filter", query: "deploy type:command", want: []string{"review"}},
+		{name: "repo filter", query: "repo:community", want: []string{"planner"}},
+		{name: "tool filter", query: "tool:bash", want: []string{"deploy"}},
+		{name: "comma-separated tools", query: "tool:Grep", want: []string{"planner"}},
+		{name: "options combine with query", query: "tool:Read", opts: SearchOptions{Type: TypeSkill}, want: []string{"deploy", "k8s-debug"}},
+		{name: "no match", query: "zzzzzz", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := idx.Search(tt.query, tt.opts)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			got := hitNames(hits)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Snippet(t *testing.T) {
+	idx, _, _ := setupIndex(t)
+
+	hits, err := idx.Search("logs", SearchOptions{})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("Search() = %v, %v; want one hit", hits, err)
+	}
+	h := hits[0]
+	if !strings.Contains(h.Snippet, "container logs") {
+		t.Errorf("Snippet = %q, want the body line", h.Snippet)
+	}
+	if len(h.Highlights) != 1 {
+		t.Fatalf("Highlights = %v, want one", h.Highlights)
+	}
+	if got := h.Snippet[h.Highlights[0].Start:h.Highlights[0].End]; got != "logs" {
+		t.Errorf("highlighted %q, want %q", got, "logs")
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("Deploy type:skill repo:official tool:Bash http://x", SearchOptions{})
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	if got := strings.Join(q.Terms, ","); got != "deploy,http,x" {
+		t.Errorf("Terms = %v", q.Terms)
+	}
+	want := SearchOptions{Type: TypeSkill, RepoName: "official", Tool: "Bash"}
+	if q.Filters != want {
+		t.Errorf("Filters = %+v, want %+v", q.Filters, want)
+	}
+
+	if _, err := ParseQuery("type:widget", SearchOptions{}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("ParseQuery(type:widget) error = %v, want ErrInvalidQuery", err)
+	}
+	if _, err := ParseQuery("type:agent", SearchOptions{Type: TypeSkill}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("ParseQuery(conflicting types) error = %v, want ErrInvalidQuery", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"deploy", "deploy", 0},
+		{"deploy", "deplyo", 1},
+		{"deploy", "deply", 1},
+		{"kubernetes", "kubernets", 1},
+		{"kubernetes", "kuberentes", 1},
+		{"abc", "xyz", 2}, // exceeds the bound
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, 1); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Sentinel errors for dependency resolution.
+var (
+	// ErrDependencyCycle indicates that resources require each other.
+	ErrDependencyCycle = errors.New("dependency cycle")
+
+	// ErrDependencyNotFound indicates that a required resource is not in any
+	// configured repository.
+	ErrDependencyNotFound = errors.New("required resource not found")
+
+	// ErrAmbiguousDependency indicates that a required resource exists in
+	// several repositories and the requirement does not say which.
+	ErrAmbiguousDependency = errors.New("required resource is ambiguous")
+)
+
+// Requirement declares that a resource needs another resource installed.
+// It is written in frontmatter as a mapping:
+//
+//	requires:
+//	  - type: agent
+//	    name: reviewer
+//	    repo: official   # optional
+//
+// or as the shorthand "agent:reviewer" or "agent:official/reviewer".
+type Requirement struct {
+	// Type is the kind of resource required.
+	Type ResourceType `yaml:"type" json:"type"`
+
+	// Name is the required resource's name.
+	Name string `yaml:"name" json:"name"`
+
+	// Repo restricts the requirement to one repository. When empty, the
+	// requiring resource's own repository is preferred.
+	Repo string `yaml:"repo,omitempty" json:"repo,omitempty"`
+}
+
+// String returns the requirement in its shorthand form.
+func (r Requirement) String() string {
+	if r.Repo != "" {
+		return fmt.Sprintf("%s:%s/%s", r.Type, r.Repo, r.Name)
+	}
+	return fmt.Sprintf("%s:%s", r.Type, r.Name)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting the mapping and
+// shorthand forms.
+func (r *Requirement) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		typ, rest, ok := strings.Cut(value.Value, ":")
+		if !ok {
+			return errors.Newf("requirement %q must be written as type:name or type:repo/name", value.Value)
+		}
+		r.Type = ResourceType(typ)
+		if repo, name, ok := strings.Cut(rest, "/"); ok {
+			r.Repo, r.Name = repo, name
+		} else {
+			r.Name = rest
+		}
+	} else {
+		type plain Requirement
+		if err := value.Decode((*plain)(r)); err != nil {
+			return err
+		}
+	}
+
+	if !slices.Contains([]ResourceType{TypeSkill, TypeCommand, TypeAgent, TypeMCP}, r.Type) {
+		return errors.Newf("requirement has unknown type %q (want skill, command, agent, or mcp)", r.Type)
+	}
+	if r.Name == "" {
+		return errors.Newf("requirement of type %s has no name", r.Type)
+	}
+	return nil
+}
+
+// ResolveRequirements returns root and every resource it requires,
+// directly or indirectly, ordered so that each resource comes after the
+// resources it requires. Root is always last. Requirements are looked up
+// in all.
+//
+// Returns ErrDependencyCycle, ErrDependencyNotFound, or
+// ErrAmbiguousDependency if the requirements cannot be satisfied.
+func ResolveRequirements(root Resource, all []Resource) ([]Resource, error) {
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int)
+	var order []Resource
+	var path []string
+
+	var visit func(r Resource) error
+	visit = func(r Resource) error {
+		key := resourceKey(r)
+		switch state[key] {
+		case done:
+			return nil
+		case visiting:
+			start := slices.Index(path, key)
+			cycle := append(slices.Clone(path[start:]), key)
+			return errors.Wrapf(ErrDependencyCycle, "%s", strings.Join(cycle, " -> "))
+		}
+
+		state[key] = visiting
+		path = append(path, key)
+		for _, req := range r.Requires {
+			dep, err := findRequirement(req, r, all)
+			if err != nil {
+				return err
+			}
+			if err := visit(*dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = done
+		order = append(order, r)
+		return nil
+	}
+
+	if err := visit(root); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Dependents returns the resources in all that directly require the
+// resource of type t named name, from any repository.
+func Dependents(all []Resource, t ResourceType, name string) []Resource {
+	var out []Resource
+	for _, r := range all {
+		if slices.ContainsFunc(r.Requires, func(req Requirement) bool {
+			return req.Type == t && req.Name == name
+		}) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// findRequirement returns the resource in all that satisfies req for from.
+func findRequirement(req Requirement, from Resource, all []Resource) (*Resource, error) {
+	var matches []Resource
+	for _, r := range filterByNameAndType(all, req.Name, req.Type) {
+		if req.Repo == "" || r.RepoName == req.Repo {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, errors.Wrapf(ErrDependencyNotFound, "%s requires %s", resourceKey(from), req)
+	case 1:
+		return &matches[0], nil
+	}
+
+	for i, r := range matches {
+		if r.RepoName == from.RepoName {
+			return &matches[i], nil
+		}
+	}
+	repos := make([]string, len(matches))
+	for i, r := range matches {
+		repos[i] = r.RepoName
+	}
+	return nil, errors.Wrapf(ErrAmbiguousDependency,
+		"%s requires %s, which is in repositories %s; name one with %s:<repo>/%s",
+		resourceKey(from), req, strings.Join(repos, ", "), req.Type, req.Name)
+}
+
+// resourceKey identifies a resource in messages and cycle detection.
+func resourceKey(r Resource) string {
+	return fmt.Sprintf("%s:%s/%s", r.Type, r.RepoName, r.Name)
+}
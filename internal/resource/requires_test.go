@@ -0,0 +1,143 @@
+package resource
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRequirement_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Requirement
+		wantErr bool
+	}{
+		{name: "mapping", yaml: "type: agent\nname: reviewer\nrepo: official", want: Requirement{Type: TypeAgent, Name: "reviewer", Repo: "official"}},
+		{name: "shorthand", yaml: "mcp:github", want: Requirement{Type: TypeMCP, Name: "github"}},
+		{name: "shorthand with repo", yaml: "skill:official/deploy", want: Requirement{Type: TypeSkill, Name: "deploy", Repo: "official"}},
+		{name: "unknown type", yaml: "widget:x", wantErr: true},
+		{name: "missing name", yaml: "type: skill", wantErr: true},
+		{name: "no type", yaml: "deploy", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Requirement
+			err := yaml.Unmarshal([]byte(tt.yaml), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Unmarshal() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanRepo_Requires(t *testing.T) {
+	repoPath := createTestRepo(t, nil, map[string]string{
+		"deploy": "---\ndescription: Deploy\nrequires:\n  - agent:releaser\n  - type: mcp\n    name: github\n---\nDeploy it.\n",
+	}, nil, nil)
+
+	resources, err := NewScanner().ScanRepo(repoPath, "test-repo", "")
+	if err != nil || len(resources) != 1 {
+		t.Fatalf("ScanRepo() = %v, %v; want one resource", resources, err)
+	}
+	want := []Requirement{{Type: TypeAgent, Name: "releaser"}, {Type: TypeMCP, Name: "github"}}
+	got := resources[0].Requires
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Requires = %+v, want %+v", got, want)
+	}
+}
+
+func req(t ResourceType, name string) Requirement {
+	return Requirement{Type: t, Name: name}
+}
+
+func planKeys(plan []Resource) string {
+	keys := make([]string, len(plan))
+	for i, r := range plan {
+		keys[i] = resourceKey(r)
+	}
+	return strings.Join(keys, " ")
+}
+
+func TestResolveRequirements(t *testing.T) {
+	deploy := Resource{Name: "deploy", Type: TypeCommand, RepoName: "official",
+		Requires: []Requirement{req(TypeAgent, "releaser"), req(TypeMCP, "github")}}
+	all := []Resource{
+		deploy,
+		{Name: "releaser", Type: TypeAgent, RepoName: "official", Requires: []Requirement{req(TypeSkill, "changelog"), req(TypeMCP, "github")}},
+		{Name: "changelog", Type: TypeSkill, RepoName: "official"},
+		{Name: "github", Type: TypeMCP, RepoName: "community"},
+		{Name: "github", Type: TypeMCP, RepoName: "official"},
+	}
+
+	plan, err := ResolveRequirements(deploy, all)
+	if err != nil {
+		t.Fatalf("ResolveRequirements() error = %v", err)
+	}
+	want := "skill:official/changelog mcp:official/github agent:official/releaser command:official/deploy"
+	if got := planKeys(plan); got != want {
+		t.Errorf("plan = %s\nwant %s", got, want)
+	}
+}
+
+func TestResolveRequirements_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    Resource
+		all     []Resource
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "cycle",
+			root:    Resource{Name: "a", Type: TypeSkill, RepoName: "r", Requires: []Requirement{req(TypeSkill, "b")}},
+			all:     []Resource{{Name: "b", Type: TypeSkill, RepoName: "r", Requires: []Requirement{req(TypeSkill, "a")}}, {Name: "a", Type: TypeSkill, RepoName: "r", Requires: []Requirement{req(TypeSkill, "b")}}},
+			wantErr: ErrDependencyCycle,
+			wantMsg: "skill:r/a -> skill:r/b -> skill:r/a",
+		},
+		{
+			name:    "missing",
+			root:    Resource{Name: "a", Type: TypeSkill, RepoName: "r", Requires: []Requirement{req(TypeAgent, "gone")}},
+			wantErr: ErrDependencyNotFound,
+			wantMsg: "requires agent:gone",
+		},
+		{
+			name: "ambiguous",
+			root: Resource{Name: "a", Type: TypeSkill, RepoName: "r", Requires: []Requirement{req(TypeAgent, "x")}},
+			all: []Resource{
+				{Name: "x", Type: TypeAgent, RepoName: "one"},
+				{Name: "x", Type: TypeAgent, RepoName: "two"},
+			},
+			wantErr: ErrAmbiguousDependency,
+			wantMsg: "one, two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveRequirements(tt.root, tt.all)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveRequirements() error = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error %q does not mention %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	all := []Resource{
+		{Name: "deploy", Type: TypeCommand, Requires: []Requirement{req(TypeAgent, "releaser")}},
+		{Name: "ship", Type: TypeCommand, Requires: []Requirement{{Type: TypeAgent, Name: "releaser", Repo: "official"}}},
+		{Name: "other", Type: TypeCommand, Requires: []Requirement{req(TypeSkill, "releaser")}},
+	}
+	got := Dependents(all, TypeAgent, "releaser")
+	if len(got) != 2 || got[0].Name != "deploy" || got[1].Name != "ship" {
+		t.Errorf("Dependents() = %v, want deploy and ship", got)
+	}
+}
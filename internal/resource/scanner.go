@@ -149,8 +149,9 @@ func (s *Scanner) ScanAll(repos []config.RepoConfig) ([]Resource, error) {
 
 // skillMeta holds the frontmatter fields we extract from skills.
 type skillMeta struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Requires    []Requirement `yaml:"requires"`
 }
 
 // scanSkills scans the skills/ directory for SKILL.md files.
@@ -215,6 +216,7 @@ func (s *Scanner) scanSkills(repoPath, repoName, repoURL string) ([]Resource, er
 		resources = append(resources, Resource{
 			Name:        name,
 			Description: meta.Description,
+			Requires:    meta.Requires,
 			Type:        TypeSkill,
 			RepoName:    repoName,
 			RepoURL:     repoURL,
@@ -227,8 +229,9 @@ func (s *Scanner) scanSkills(repoPath, repoName, repoURL string) ([]Resource, er
 
 // commandMeta holds the frontmatter fields we extract from commands.
 type commandMeta struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Requires    []Requirement `yaml:"requires"`
 }
 
 // scanCommands scans the commands/ directory for command.md or *.md files.
@@ -308,6 +311,7 @@ func (s *Scanner) scanCommandDir(commandsDir, dirName, repoName, repoURL string)
 	return &Resource{
 		Name:        name,
 		Description: meta.Description,
+		Requires:    meta.Requires,
 		Type:        TypeCommand,
 		RepoName:    repoName,
 		RepoURL:     repoURL,
@@ -338,6 +342,7 @@ func (s *Scanner) scanCommandFile(commandsDir, fileName, repoName, repoURL strin
 	return &Resource{
 		Name:        name,
 		Description: meta.Description,
+		Requires:    meta.Requires,
 		Type:        TypeCommand,
 		RepoName:    repoName,
 		RepoURL:     repoURL,
@@ -347,8 +352,9 @@ func (s *Scanner) scanCommandFile(commandsDir, fileName, repoName, repoURL strin
 
 // agentMeta holds the frontmatter fields we extract from agents.
 type agentMeta struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description"`
+	Requires    []Requirement `yaml:"requires"`
 }
 
 // scanAgents scans the agents/ directory for AGENT.md or *.md files.
@@ -428,6 +434,7 @@ func (s *Scanner) scanAgentDir(agentsDir, dirName, repoName, repoURL string) (*R
 	return &Resource{
 		Name:        name,
 		Description: meta.Description,
+		Requires:    meta.Requires,
 		Type:        TypeAgent,
 		RepoName:    repoName,
 		RepoURL:     repoURL,
@@ -458,6 +465,7 @@ func (s *Scanner) scanAgentFile(agentsDir, fileName, repoName, repoURL string) (
 	return &Resource{
 		Name:        name,
 		Description: meta.Description,
+		Requires:    meta.Requires,
 		Type:        TypeAgent,
 		RepoName:    repoName,
 		RepoURL:     repoURL,
@@ -11,6 +11,9 @@ type SearchOptions struct {
 	Type ResourceType
 	// RepoName filters by repository name. Empty string matches all repos.
 	RepoName string
+	// Tool filters by a tool the resource declares in allowed-tools or
+	// tools. It is only supported by Index.Search.
+	Tool string
 }
 
 // Search finds resources matching the query and filter options.
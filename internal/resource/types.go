@@ -4,6 +4,7 @@ package resource
 
 import (
 	"path/filepath"
+	"strings"
 
 	"github.com/thoreinstein/aix/internal/paths"
 )
@@ -42,9 +43,35 @@ type Resource struct {
 
 	// Metadata contains additional key-value pairs for extensibility.
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Requires lists the resources that must be installed alongside this
+	// one, from its requires frontmatter field.
+	Requires []Requirement `json:"requires,omitempty"`
 }
 
 // SourcePath returns the absolute path to the resource in the persistent repository cache.
 func (r *Resource) SourcePath() string {
 	return filepath.Join(paths.ReposCacheDir(), r.RepoName, r.Path)
 }
+
+// MainFile returns the markdown file that defines a resource of type t
+// stored at path, or "" for resources without one. path is a skill
+// directory, or a command or agent file or directory.
+func MainFile(t ResourceType, path string) string {
+	switch t {
+	case TypeSkill:
+		return filepath.Join(path, "SKILL.md")
+	case TypeCommand:
+		if strings.HasSuffix(path, ".md") {
+			return path
+		}
+		return filepath.Join(path, "command.md")
+	case TypeAgent:
+		if strings.HasSuffix(path, ".md") {
+			return path
+		}
+		return filepath.Join(path, "AGENT.md")
+	default:
+		return ""
+	}
+}
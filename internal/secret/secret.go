@@ -0,0 +1,270 @@
+// Package secret handles references to secrets in MCP server env and header
+// values, so that tokens need not be stored in plaintext.
+//
+// A reference has the form ${kind:value}:
+//
+//	${env:GITHUB_TOKEN}    the GITHUB_TOKEN environment variable
+//	${file:~/.secrets/gh}  the contents of a file, without trailing newlines
+//	${cmd:pass show gh}    the output of a shell command, without trailing newlines
+//
+// References may appear anywhere in a value, for example
+// "Bearer ${env:API_TOKEN}", and cannot themselves contain "}".
+//
+// Canonical configuration stores references as written. When a server is
+// written to a platform, each reference is rewritten in the platform's own
+// interpolation syntax if it has one for that kind, and otherwise resolved
+// to its value.
+//
+// Command references are only run without asking when they were typed on
+// aix's command line. Values read from a file, whether a repository, a
+// project's manifest, or a platform's configuration, may have been written
+// by anyone who can edit it, so callers refuse the command references in
+// them with CheckUntrusted unless the user has opted in.
+package secret
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Reference kinds.
+const (
+	// KindEnv references an environment variable by name.
+	KindEnv = "env"
+
+	// KindFile references the contents of a file. A leading "~/" is
+	// expanded to the user's home directory.
+	KindFile = "file"
+
+	// KindCmd references the standard output of a shell command.
+	KindCmd = "cmd"
+)
+
+// Sentinel errors for resolving references.
+var (
+	// ErrEnvNotSet indicates a referenced environment variable is not set.
+	ErrEnvNotSet = errors.New("environment variable not set")
+
+	// ErrUntrustedCommand indicates a command reference in a value read
+	// from a file the user has not opted in to running commands from.
+	ErrUntrustedCommand = errors.New("command references read from files are not run")
+)
+
+// refPattern matches a canonical reference.
+var refPattern = regexp.MustCompile(`\$\{(env|file|cmd):([^}]+)\}`)
+
+// Ref is a single reference to a secret.
+type Ref struct {
+	// Kind is one of KindEnv, KindFile, or KindCmd.
+	Kind string
+
+	// Value is the variable name, file path, or command.
+	Value string
+}
+
+// String returns the reference in canonical ${kind:value} form.
+func (r Ref) String() string {
+	return "${" + r.Kind + ":" + r.Value + "}"
+}
+
+// Refs returns the references in s, in order of appearance.
+func Refs(s string) []Ref {
+	var refs []Ref
+	for _, m := range refPattern.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, Ref{Kind: m[1], Value: m[2]})
+	}
+	return refs
+}
+
+// HasRefs reports whether s contains at least one reference.
+func HasRefs(s string) bool {
+	return refPattern.MatchString(s)
+}
+
+// Syntax describes a platform's native interpolation. Each field is a
+// format string with a single %s verb for the variable name or file path.
+// An empty field means the platform cannot interpolate that kind, so
+// references of that kind are resolved when written. No platform runs
+// commands, so command references are always resolved.
+type Syntax struct {
+	Env  string
+	File string
+}
+
+// format returns the native format for kind, or "" if there is none.
+func (syn Syntax) format(kind string) string {
+	switch kind {
+	case KindEnv:
+		return syn.Env
+	case KindFile:
+		return syn.File
+	default:
+		return ""
+	}
+}
+
+// Render rewrites the references in s for a platform with the given
+// syntax, resolving those the platform cannot interpolate itself.
+func Render(s string, syn Syntax) (string, error) {
+	var firstErr error
+	out := refPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := refPattern.FindStringSubmatch(match)
+		ref := Ref{Kind: m[1], Value: m[2]}
+		if f := syn.format(ref.Kind); f != "" {
+			return fmt.Sprintf(f, ref.Value)
+		}
+		v, err := Resolve(ref)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return v
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+// RenderMap applies Render to every value of m and returns a new map.
+// It returns nil for a nil map.
+func RenderMap(m map[string]string, syn Syntax) (map[string]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		r, err := Render(v, syn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving %s", k)
+		}
+		out[k] = r
+	}
+	return out, nil
+}
+
+// CheckUntrusted returns an error naming the key of a value of m that
+// references a command. It is called on values read from a file before
+// they are resolved or written elsewhere.
+func CheckUntrusted(m map[string]string) error {
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		for _, ref := range Refs(m[k]) {
+			if ref.Kind == KindCmd {
+				return errors.Wrapf(ErrUntrustedCommand, "%s references %s", k, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// Canonicalize rewrites a platform's native interpolation in s as
+// references. It reverses Render for the kinds the platform supports;
+// resolved values cannot be recovered.
+func Canonicalize(s string, syn Syntax) string {
+	type native struct {
+		kind    string
+		pattern *regexp.Regexp
+	}
+	var natives []native
+	alts := []string{refPattern.String()}
+	for _, kind := range []string{KindEnv, KindFile} {
+		f := syn.format(kind)
+		if f == "" {
+			continue
+		}
+		capture := `([^}]+)`
+		if kind == KindEnv {
+			capture = `([A-Za-z_][A-Za-z0-9_]*)`
+		}
+		prefix, suffix, _ := strings.Cut(f, "%s")
+		expr := regexp.QuoteMeta(prefix) + capture + regexp.QuoteMeta(suffix)
+		natives = append(natives, native{kind: kind, pattern: regexp.MustCompile("^" + expr + "$")})
+		alts = append(alts, expr)
+	}
+	if len(natives) == 0 {
+		return s
+	}
+
+	// Canonical references come first in the alternation so that text
+	// already in canonical form is never matched as native syntax.
+	combined := regexp.MustCompile(strings.Join(alts, "|"))
+	return combined.ReplaceAllStringFunc(s, func(match string) string {
+		for _, n := range natives {
+			if m := n.pattern.FindStringSubmatch(match); m != nil {
+				return Ref{Kind: n.kind, Value: m[1]}.String()
+			}
+		}
+		return match
+	})
+}
+
+// CanonicalizeMap applies Canonicalize to every value of m and returns a
+// new map. It returns nil for a nil map.
+func CanonicalizeMap(m map[string]string, syn Syntax) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = Canonicalize(v, syn)
+	}
+	return out
+}
+
+// Resolve returns the value a reference points to. Trailing newlines are
+// removed from file contents and command output.
+func Resolve(r Ref) (string, error) {
+	switch r.Kind {
+	case KindEnv:
+		v, ok := os.LookupEnv(r.Value)
+		if !ok {
+			return "", errors.Wrap(ErrEnvNotSet, r.Value)
+		}
+		return v, nil
+	case KindFile:
+		path := r.Value
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", errors.Wrap(err, "expanding ~ in secret file path")
+			}
+			path = filepath.Join(home, rest)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", errors.Wrap(err, "reading secret file")
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case KindCmd:
+		cmd := shellCommand(r.Value)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", errors.Wrapf(err, "running %q: %s", r.Value, msg)
+			}
+			return "", errors.Wrapf(err, "running %q", r.Value)
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	default:
+		return "", errors.Newf("unknown secret reference kind %q", r.Kind)
+	}
+}
+
+// shellCommand returns a command that runs line with the system shell.
+func shellCommand(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", line)
+	}
+	return exec.Command("sh", "-c", line)
+}
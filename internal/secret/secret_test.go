@@ -0,0 +1,120 @@
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+func TestRefs(t *testing.T) {
+	got := Refs("Bearer ${env:TOKEN} ${file:~/a b} ${cmd:pass show gh} ${other:x} $TOKEN")
+	want := []Ref{
+		{Kind: KindEnv, Value: "TOKEN"},
+		{Kind: KindFile, Value: "~/a b"},
+		{Kind: KindCmd, Value: "pass show gh"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Refs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Refs()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if HasRefs("plain value") {
+		t.Error("HasRefs(plain value) = true, want false")
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Setenv("AIX_SECRET_TEST", "s3cret")
+	dir := t.TempDir()
+	file := filepath.Join(dir, "token")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	claude := Syntax{Env: "${%s}"}
+	openCode := Syntax{Env: "{env:%s}", File: "{file:%s}"}
+
+	tests := []struct {
+		name  string
+		value string
+		syn   Syntax
+		want  string
+	}{
+		{"no refs", "plain", claude, "plain"},
+		{"native env", "Bearer ${env:AIX_SECRET_TEST}", claude, "Bearer ${AIX_SECRET_TEST}"},
+		{"native file", "${file:" + file + "}", openCode, "{file:" + file + "}"},
+		{"resolved env", "${env:AIX_SECRET_TEST}", Syntax{}, "s3cret"},
+		{"resolved file", "${file:" + file + "}", claude, "from-file"},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, struct {
+			name  string
+			value string
+			syn   Syntax
+			want  string
+		}{"resolved cmd", "x-${cmd:echo hi}", openCode, "x-hi"})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.value, tt.syn)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_UnsetEnv(t *testing.T) {
+	_, err := Render("${env:AIX_SECRET_TEST_UNSET}", Syntax{})
+	if !errors.Is(err, ErrEnvNotSet) {
+		t.Errorf("Render() error = %v, want ErrEnvNotSet", err)
+	}
+}
+
+func TestCheckUntrusted(t *testing.T) {
+	if err := CheckUntrusted(map[string]string{"TOKEN": "${env:TOKEN}", "KEY": "${file:~/key}"}); err != nil {
+		t.Errorf("CheckUntrusted() without commands = %v, want nil", err)
+	}
+	err := CheckUntrusted(map[string]string{"A": "plain", "Authorization": "Bearer ${cmd:pass show gh}"})
+	if !errors.Is(err, ErrUntrustedCommand) {
+		t.Fatalf("CheckUntrusted() = %v, want ErrUntrustedCommand", err)
+	}
+	if want := "Authorization references ${cmd:pass show gh}"; !strings.Contains(err.Error(), want) {
+		t.Errorf("CheckUntrusted() = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		syn   Syntax
+		want  string
+	}{
+		{"dollar brace", "Bearer ${TOKEN}", Syntax{Env: "${%s}"}, "Bearer ${env:TOKEN}"},
+		{"already canonical", "${env:TOKEN}", Syntax{Env: "${%s}"}, "${env:TOKEN}"},
+		{"default not rewritten", "${TOKEN:-x}", Syntax{Env: "${%s}"}, "${TOKEN:-x}"},
+		{"opencode env", "{env:TOKEN}", Syntax{Env: "{env:%s}", File: "{file:%s}"}, "${env:TOKEN}"},
+		{"opencode file", "{file:~/.secrets/gh}", Syntax{Env: "{env:%s}", File: "{file:%s}"}, "${file:~/.secrets/gh}"},
+		{"opencode canonical", "${env:TOKEN}", Syntax{Env: "{env:%s}", File: "{file:%s}"}, "${env:TOKEN}"},
+		{"no syntax", "${TOKEN}", Syntax{}, "${TOKEN}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Canonicalize(tt.value, tt.syn); got != tt.want {
+				t.Errorf("Canonicalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
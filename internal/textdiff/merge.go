@@ -0,0 +1,144 @@
+package textdiff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Conflict markers written by Merge.
+const (
+	markerOurs   = "<<<<<<< "
+	markerSep    = "=======\n"
+	markerTheirs = ">>>>>>> "
+)
+
+// change replaces base[start:end] with lines.
+type change struct {
+	start, end int
+	lines      []string
+}
+
+// Merge performs a three-way merge: it applies the changes made from base
+// to ours and from base to theirs to base. Where both sides changed the same
+// or adjacent lines differently, both versions are kept between conflict
+// markers labeled oursName and theirsName. It returns the merged text and
+// the number of conflicts.
+func Merge(base, ours, theirs, oursName, theirsName string) (string, int) {
+	baseLines := Lines(base)
+	a := changes(Diff(baseLines, Lines(ours)))
+	b := changes(Diff(baseLines, Lines(theirs)))
+
+	var sb strings.Builder
+	conflicts := 0
+	pos := 0
+	for len(a) > 0 || len(b) > 0 {
+		// Start a region at the earliest change and grow it while changes
+		// from either side overlap or touch it.
+		var fromA, fromB []change
+		start, end := -1, -1
+		take := func(list *[]change, into *[]change) bool {
+			if len(*list) == 0 {
+				return false
+			}
+			c := (*list)[0]
+			if start >= 0 && c.start > end {
+				return false
+			}
+			if start < 0 {
+				start, end = c.start, c.end
+			}
+			end = max(end, c.end)
+			*into = append(*into, c)
+			*list = (*list)[1:]
+			return true
+		}
+		if len(b) == 0 || (len(a) > 0 && a[0].start <= b[0].start) {
+			take(&a, &fromA)
+		} else {
+			take(&b, &fromB)
+		}
+		for take(&a, &fromA) || take(&b, &fromB) {
+		}
+
+		writeLines(&sb, baseLines[pos:start])
+		oursText := apply(baseLines, start, end, fromA)
+		theirsText := apply(baseLines, start, end, fromB)
+		switch {
+		case len(fromB) == 0:
+			writeLines(&sb, oursText)
+		case len(fromA) == 0, slices.Equal(oursText, theirsText):
+			writeLines(&sb, theirsText)
+		default:
+			conflicts++
+			sb.WriteString(markerOurs + oursName + "\n")
+			writeLines(&sb, terminate(oursText))
+			sb.WriteString(markerSep)
+			writeLines(&sb, terminate(theirsText))
+			sb.WriteString(markerTheirs + theirsName + "\n")
+		}
+		pos = end
+	}
+	writeLines(&sb, baseLines[pos:])
+	return sb.String(), conflicts
+}
+
+// changes groups an edit script into the base ranges it replaces.
+func changes(ops []Op) []change {
+	var out []change
+	var cur *change
+	pos := 0
+	for _, op := range ops {
+		switch op.Kind {
+		case Equal:
+			if cur != nil {
+				out = append(out, *cur)
+				cur = nil
+			}
+			pos++
+		case Delete:
+			if cur == nil {
+				cur = &change{start: pos, end: pos}
+			}
+			pos++
+			cur.end = pos
+		case Insert:
+			if cur == nil {
+				cur = &change{start: pos, end: pos}
+			}
+			cur.lines = append(cur.lines, op.Line)
+		}
+	}
+	if cur != nil {
+		out = append(out, *cur)
+	}
+	return out
+}
+
+// apply returns base[start:end] with changes, which lie within that range,
+// applied.
+func apply(base []string, start, end int, changes []change) []string {
+	var out []string
+	pos := start
+	for _, c := range changes {
+		out = append(out, base[pos:c.start]...)
+		out = append(out, c.lines...)
+		pos = c.end
+	}
+	return append(out, base[pos:end]...)
+}
+
+// terminate ensures the last line ends with a newline so that a conflict
+// marker after it starts on its own line.
+func terminate(lines []string) []string {
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		lines = append(lines[:n-1:n-1], lines[n-1]+"\n")
+	}
+	return lines
+}
+
+// writeLines appends lines to sb.
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+}
@@ -0,0 +1,245 @@
+// Package textdiff computes line-based differences between texts and
+// formats them as unified diffs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+// maxCells bounds the size of the comparison table. Inputs whose differing
+// regions exceed it are reported as a single replacement.
+const maxCells = 4 << 20
+
+// OpKind identifies what an Op does to a line.
+type OpKind int
+
+// Op kinds.
+const (
+	// Equal marks a line present in both texts.
+	Equal OpKind = iota
+
+	// Delete marks a line present only in the old text.
+	Delete
+
+	// Insert marks a line present only in the new text.
+	Insert
+)
+
+// Op is one line of an edit script.
+type Op struct {
+	Kind OpKind
+	Line string
+}
+
+// Lines splits text into lines, keeping line endings so that a missing
+// final newline is preserved.
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Diff returns an edit script that turns a into b. The script is minimal
+// except for very large inputs, where the differing region is replaced
+// wholesale.
+func Diff(a, b []string) []Op {
+	// Common prefix and suffix need no comparison table.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]Op, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, Op{Equal, line})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, Op{Equal, line})
+	}
+	return ops
+}
+
+// diffMiddle diffs a and b using a longest-common-subsequence table.
+func diffMiddle(a, b []string) []Op {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || (n+1)*(m+1) > maxCells {
+		ops := make([]Op, 0, n+m)
+		for _, line := range a {
+			ops = append(ops, Op{Delete, line})
+		}
+		for _, line := range b {
+			ops = append(ops, Op{Insert, line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]Op, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, Op{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, Op{Delete, a[i]})
+			i++
+		default:
+			ops = append(ops, Op{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, Op{Delete, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, Op{Insert, b[j]})
+	}
+	return ops
+}
+
+// Unified returns a unified diff from oldText to newText with
+// DefaultContext lines of context, or "" if the texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	ops := Diff(Lines(oldText), Lines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops, DefaultContext) {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(h.oldStart, h.oldLines), hunkRange(h.newStart, h.newLines))
+		for _, op := range ops[h.first:h.last] {
+			switch op.Kind {
+			case Equal:
+				sb.WriteByte(' ')
+			case Delete:
+				sb.WriteByte('-')
+			case Insert:
+				sb.WriteByte('+')
+			}
+			sb.WriteString(op.Line)
+			if !strings.HasSuffix(op.Line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// hunk is a run of ops shown together, with its line ranges.
+type hunk struct {
+	first, last        int // ops[first:last]
+	oldStart, oldLines int
+	newStart, newLines int
+}
+
+// hunks groups changes in ops that are within 2*context lines of each other.
+func hunks(ops []Op, context int) []hunk {
+	var out []hunk
+	oldLine, newLine := 1, 1
+	var cur *hunk
+	lastChange := -1
+
+	for i, op := range ops {
+		if op.Kind != Equal {
+			if cur == nil || i-lastChange > 2*context {
+				if cur != nil {
+					out = append(out, closeHunk(ops, *cur, lastChange, context))
+				}
+				start := max(i-context, 0)
+				cur = &hunk{
+					first:    start,
+					oldStart: oldLine - countOld(ops[start:i]),
+					newStart: newLine - countNew(ops[start:i]),
+				}
+			}
+			lastChange = i
+		}
+		if op.Kind != Insert {
+			oldLine++
+		}
+		if op.Kind != Delete {
+			newLine++
+		}
+	}
+	if cur != nil {
+		out = append(out, closeHunk(ops, *cur, lastChange, context))
+	}
+	return out
+}
+
+// closeHunk ends h context lines after its last change and counts its lines.
+func closeHunk(ops []Op, h hunk, lastChange, context int) hunk {
+	h.last = min(lastChange+1+context, len(ops))
+	h.oldLines = countOld(ops[h.first:h.last])
+	h.newLines = countNew(ops[h.first:h.last])
+	return h
+}
+
+// countOld counts the ops that consume a line of the old text.
+func countOld(ops []Op) int {
+	n := 0
+	for _, op := range ops {
+		if op.Kind != Insert {
+			n++
+		}
+	}
+	return n
+}
+
+// countNew counts the ops that produce a line of the new text.
+func countNew(ops []Op) int {
+	n := 0
+	for _, op := range ops {
+		if op.Kind != Delete {
+			n++
+		}
+	}
+	return n
+}
+
+// hunkRange formats a hunk's start and length. An empty range starts at
+// the line before it, as diff(1) does.
+func hunkRange(start, lines int) string {
+	switch lines {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, lines)
+	}
+}
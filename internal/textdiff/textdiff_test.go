@@ -0,0 +1,115 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "modified line",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n",
+			new:  "1\n2\n3\n4\nfive\n6\n7\n8\n",
+			want: "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "separate hunks",
+			old:  "a\n1\n2\n3\n4\n5\n6\n7\nb\n",
+			new:  "A\n1\n2\n3\n4\n5\n6\n7\nB\n",
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "x\ny\n",
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "missing final newline",
+			old:  "x\n",
+			new:  "x",
+			want: "--- old\n+++ new\n@@ -1 +1 @@\n-x\n+x\n\\ No newline at end of file\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("old", "new", tt.old, tt.new); got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_Minimal(t *testing.T) {
+	a := Lines("a\nb\nc\nd\n")
+	b := Lines("b\nc\nx\nd\n")
+	var sb strings.Builder
+	for _, op := range Diff(a, b) {
+		sb.WriteString([]string{" ", "-", "+"}[op.Kind] + op.Line)
+	}
+	want := "-a\n b\n c\n+x\n d\n"
+	if sb.String() != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", sb.String(), want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+	tests := []struct {
+		name          string
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "separate changes",
+			ours:   "A\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\nE\n",
+			want:   "A\nb\nc\nd\nE\n",
+		},
+		{
+			name:   "only theirs",
+			ours:   base,
+			theirs: "a\nb\nx\ny\nd\ne\n",
+			want:   "a\nb\nx\ny\nd\ne\n",
+		},
+		{
+			name:   "same change on both sides",
+			ours:   "a\nB\nc\nd\ne\n",
+			theirs: "a\nB\nc\nd\ne\n",
+			want:   "a\nB\nc\nd\ne\n",
+		},
+		{
+			name:          "conflict",
+			ours:          "a\nb\nmine\nd\ne\n",
+			theirs:        "a\nb\ntheirs\nd\ne\n",
+			want:          "a\nb\n<<<<<<< local\nmine\n=======\ntheirs\n>>>>>>> upstream\nd\ne\n",
+			wantConflicts: 1,
+		},
+		{
+			name:   "insert and delete",
+			ours:   "a\nnew\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\ne\n",
+			want:   "a\nnew\nb\nc\ne\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge(base, tt.ours, tt.theirs, "local", "upstream")
+			if got != tt.want || conflicts != tt.wantConflicts {
+				t.Errorf("Merge() = %q, %d conflicts\nwant %q, %d conflicts", got, conflicts, tt.want, tt.wantConflicts)
+			}
+		})
+	}
+}
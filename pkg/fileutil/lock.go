@@ -0,0 +1,106 @@
+package fileutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// DefaultLockTimeout is how long callers wait for another process to
+// release a lock before giving up.
+const DefaultLockTimeout = 10 * time.Second
+
+// lockPollInterval is how often a held lock is retried.
+const lockPollInterval = 50 * time.Millisecond
+
+// ErrLocked indicates that another process held a lock for longer than
+// the caller was willing to wait.
+var ErrLocked = errors.New("another aix process is running")
+
+// lockDir is where lock files are created. It is a variable so tests can
+// point it at a temporary directory.
+var lockDir = defaultLockDir
+
+// FileLock is an advisory lock held by this process. Release it with
+// Unlock.
+type FileLock struct {
+	f *os.File
+}
+
+// LockFile takes an exclusive advisory lock on path, waiting up to timeout
+// for another process to release it. It returns ErrLocked if the lock is
+// still held when the timeout expires.
+//
+// The lock is held on a separate file in the user's cache directory rather
+// than on path itself, because atomic writes replace path with a new file
+// and lock files next to configuration would end up in backups. Locks are
+// advisory: they only exclude other callers of LockFile.
+func LockFile(path string, timeout time.Duration) (*FileLock, error) {
+	lockPath, err := lockPathFor(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o700); err != nil {
+		return nil, errors.Wrap(err, "creating lock directory")
+	}
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening lock file")
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			f.Close()
+			return nil, errors.Wrapf(err, "locking %s", path)
+		}
+		if ok {
+			return &FileLock{f: f}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, errors.Wrapf(ErrLocked, "waited %s for %s", timeout, path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// Unlock releases the lock. It is safe to call on a nil lock.
+func (l *FileLock) Unlock() error {
+	if l == nil || l.f == nil {
+		return nil
+	}
+	err := unlock(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	l.f = nil
+	if err != nil {
+		return errors.Wrap(err, "releasing lock")
+	}
+	return nil
+}
+
+// lockPathFor returns the lock file for path. Paths are made absolute so
+// that every process agrees on the lock for a given file.
+func lockPathFor(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", path)
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(abs)))
+	return filepath.Join(lockDir(), hex.EncodeToString(sum[:16])+".lock"), nil
+}
+
+// defaultLockDir returns the per-user directory for lock files.
+func defaultLockDir() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "aix", "locks")
+	}
+	return filepath.Join(os.TempDir(), "aix-locks")
+}
@@ -0,0 +1,55 @@
+package fileutil
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+	dir := t.TempDir()
+	orig := lockDir
+	lockDir = func() string { return filepath.Join(dir, "locks") }
+	t.Cleanup(func() { lockDir = orig })
+
+	path := filepath.Join(dir, "config.json")
+	lock, err := LockFile(path, time.Second)
+	if err != nil {
+		t.Fatalf("LockFile() error = %v", err)
+	}
+
+	t.Run("held lock times out", func(t *testing.T) {
+		start := time.Now()
+		_, err := LockFile(path, 100*time.Millisecond)
+		if !errors.Is(err, ErrLocked) {
+			t.Fatalf("LockFile() error = %v, want ErrLocked", err)
+		}
+		if time.Since(start) < 100*time.Millisecond {
+			t.Error("LockFile() returned before the timeout")
+		}
+	})
+
+	t.Run("other files are independent", func(t *testing.T) {
+		other, err := LockFile(filepath.Join(dir, "other.json"), 0)
+		if err != nil {
+			t.Fatalf("LockFile() error = %v", err)
+		}
+		if err := other.Unlock(); err != nil {
+			t.Errorf("Unlock() error = %v", err)
+		}
+	})
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Errorf("second Unlock() error = %v", err)
+	}
+
+	again, err := LockFile(path, 0)
+	if err != nil {
+		t.Fatalf("LockFile() after Unlock error = %v", err)
+	}
+	again.Unlock()
+}
@@ -0,0 +1,73 @@
+package fileutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"os"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// maxUpdateAttempts bounds how many times Update re-applies a change to a
+// file that keeps changing underneath it.
+const maxUpdateAttempts = 3
+
+// ErrConflict indicates that a file was changed by another program while it
+// was being updated, and the update was abandoned rather than overwrite
+// those changes.
+var ErrConflict = errors.New("file was changed by another program")
+
+// Update performs a read-modify-write of the file at path while holding its
+// lock (see LockFile). load reads the current contents, modify applies the
+// change, and save writes the result.
+//
+// Programs other than aix, such as the assistants themselves, rewrite their
+// configuration without taking the lock. If path changes between load and
+// save, Update discards its result and applies modify again to the new
+// contents, so neither side's changes are lost. modify must therefore be
+// safe to call more than once. Returns ErrConflict if the file is still
+// changing after several attempts.
+func Update[T any](path string, load func() (T, error), modify func(T) error, save func(T) error) error {
+	lock, err := LockFile(path, DefaultLockTimeout)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	for range maxUpdateAttempts {
+		before, err := fingerprint(path)
+		if err != nil {
+			return err
+		}
+		v, err := load()
+		if err != nil {
+			return err
+		}
+		if err := modify(v); err != nil {
+			return err
+		}
+		after, err := fingerprint(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(before, after) {
+			continue
+		}
+		return save(v)
+	}
+	return errors.Wrapf(ErrConflict, "%s changed %d times while aix was updating it", path, maxUpdateAttempts)
+}
+
+// fingerprint returns a hash of the file's contents, or nil if it does not
+// exist.
+func fingerprint(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", path)
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
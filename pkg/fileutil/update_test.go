@@ -0,0 +1,82 @@
+package fileutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	dir := t.TempDir()
+	orig := lockDir
+	lockDir = func() string { return filepath.Join(dir, "locks") }
+	t.Cleanup(func() { lockDir = orig })
+
+	path := filepath.Join(dir, "list.txt")
+	load := func() (*[]string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		lines := strings.Fields(string(data))
+		return &lines, nil
+	}
+	save := func(lines *[]string) error {
+		return os.WriteFile(path, []byte(strings.Join(*lines, "\n")), 0o644)
+	}
+	appendLine := func(line string) func(*[]string) error {
+		return func(lines *[]string) error {
+			*lines = append(*lines, line)
+			return nil
+		}
+	}
+
+	t.Run("applies change", func(t *testing.T) {
+		if err := Update(path, load, appendLine("a"), save); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "a" {
+			t.Errorf("file = %q, want %q", data, "a")
+		}
+	})
+
+	t.Run("reapplies after external change", func(t *testing.T) {
+		calls := 0
+		modify := func(lines *[]string) error {
+			calls++
+			if calls == 1 {
+				// Another program rewrites the file mid-update.
+				if err := os.WriteFile(path, []byte("a\nexternal"), 0o644); err != nil {
+					return err
+				}
+			}
+			return appendLine("b")(lines)
+		}
+		if err := Update(path, load, modify, save); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("modify called %d times, want 2", calls)
+		}
+		if data, _ := os.ReadFile(path); string(data) != "a\nexternal\nb" {
+			t.Errorf("file = %q, want external change kept", data)
+		}
+	})
+
+	t.Run("gives up when file keeps changing", func(t *testing.T) {
+		calls := 0
+		modify := func(lines *[]string) error {
+			calls++
+			return os.WriteFile(path, []byte(strings.Repeat("x\n", calls)), 0o644)
+		}
+		err := Update(path, load, modify, save)
+		if !errors.Is(err, ErrConflict) {
+			t.Fatalf("Update() error = %v, want ErrConflict", err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != strings.Repeat("x\n", calls) {
+			t.Errorf("file = %q, want the other program's content", data)
+		}
+	})
+}
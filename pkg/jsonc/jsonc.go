@@ -0,0 +1,284 @@
+// Package jsonc edits JSON files that may contain comments and trailing
+// commas, as editors such as VS Code and Zed allow in their settings.
+package jsonc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/thoreinstein/aix/internal/errors"
+)
+
+// Document is a JSON file that allows comments and trailing commas, which
+// encoding/json rejects and would drop. Rather than decoding the whole
+// file, Document edits the text of single values in place, so the rest of
+// the file, comments included, is written back unchanged.
+type Document struct {
+	data []byte
+}
+
+// Parse checks that data is a JSON object, allowing comments and trailing
+// commas. Empty data is an empty document.
+func Parse(data []byte) (*Document, error) {
+	doc := &Document{data: data}
+	if doc.empty() {
+		return doc, nil
+	}
+	stripped := Strip(data)
+	if !json.Valid(stripped) {
+		var v any
+		err := json.Unmarshal(stripped, &v)
+		return nil, errors.Wrap(err, "parsing JSON")
+	}
+	if first := bytes.TrimSpace(stripped); first[0] != '{' {
+		return nil, errors.New("parsing JSON: top level is not an object")
+	}
+	return doc, nil
+}
+
+// empty reports whether the file has no content other than whitespace.
+func (d *Document) empty() bool {
+	return len(bytes.TrimSpace(d.data)) == 0
+}
+
+// Get decodes the value at path, a list of object keys, into v. It reports
+// whether the value exists.
+func (d *Document) Get(path []string, v any) (bool, error) {
+	if d.empty() {
+		return false, nil
+	}
+	stripped := Strip(d.data)
+	start, end, found, err := locate(stripped, path)
+	if err != nil || found < len(path) {
+		return false, err
+	}
+
+	if err := json.Unmarshal(stripped[start:end], v); err != nil {
+		return false, errors.Wrapf(err, "parsing %s", joinPath(path))
+	}
+	return true, nil
+}
+
+// Set replaces the value at path with v, indented like the line the value
+// starts on. The objects leading to path are created as needed.
+func (d *Document) Set(path []string, v any) error {
+	if d.empty() {
+		out, err := marshalIndent(nest(path, v), "")
+		if err != nil {
+			return err
+		}
+		d.data = append(out, '\n')
+		return nil
+	}
+
+	stripped := Strip(d.data)
+	start, end, found, err := locate(stripped, path)
+	if err != nil {
+		return err
+	}
+
+	var insert []byte
+	if found == len(path) {
+		// Replace the existing value, indented like the line it starts on
+		insert, err = marshalIndent(v, lineIndent(d.data, start))
+		if err != nil {
+			return err
+		}
+	} else {
+		// Add the missing keys as the last member of the deepest object
+		// that exists
+		indent := lineIndent(d.data, start)
+		child := indent + "  "
+		value, err := marshalIndent(nest(path[found+1:], v), child)
+		if err != nil {
+			return err
+		}
+		key, _ := json.Marshal(path[found])
+
+		last := bytes.LastIndexFunc(stripped[start:end-1], func(r rune) bool {
+			return !isJSONSpace(byte(r))
+		}) + start
+		var b bytes.Buffer
+		if stripped[last] != '{' {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n" + child)
+		b.Write(key)
+		b.WriteString(": ")
+		b.Write(value)
+		if stripped[last] == '{' {
+			b.WriteString("\n" + indent)
+		}
+		insert = b.Bytes()
+		start, end = last+1, last+1
+	}
+
+	out := make([]byte, 0, len(d.data)+len(insert))
+	out = append(out, d.data[:start]...)
+	out = append(out, insert...)
+	out = append(out, d.data[end:]...)
+	d.data = out
+	return nil
+}
+
+// Bytes returns the file contents.
+func (d *Document) Bytes() []byte {
+	return d.data
+}
+
+// locate finds the value at path in stripped, which must hold a JSON
+// object. found is the number of path components that exist. If the whole
+// path exists, start and end delimit its value; otherwise they delimit the
+// object the next component is missing from.
+func locate(stripped []byte, path []string) (start, end, found int, err error) {
+	start = bytes.IndexByte(stripped, '{')
+	end = bytes.LastIndexByte(stripped, '}') + 1
+	for found < len(path) {
+		if stripped[start] != '{' {
+			return 0, 0, 0, errors.Newf("%s is not an object", joinPath(path[:found]))
+		}
+		vs, ve, ok, err := member(stripped, start, end, path[found])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if !ok {
+			return start, end, found, nil
+		}
+		start, end = vs, ve
+		found++
+	}
+	return start, end, found, nil
+}
+
+// member finds the value of key in the object stripped[start:end]. As with
+// encoding/json, the last of several equal keys wins.
+func member(stripped []byte, start, end int, key string) (vs, ve int, ok bool, err error) {
+	dec := json.NewDecoder(bytes.NewReader(stripped[start:end]))
+	if _, err := dec.Token(); err != nil {
+		return 0, 0, false, errors.Wrap(err, "parsing JSON")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, false, errors.Wrap(err, "parsing JSON")
+		}
+		valueStart := start + int(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, 0, false, errors.Wrap(err, "parsing JSON")
+		}
+		if tok == key {
+			// Skip the colon and whitespace between the key and its value
+			for isJSONSpace(stripped[valueStart]) || stripped[valueStart] == ':' {
+				valueStart++
+			}
+			vs, ve, ok = valueStart, start+int(dec.InputOffset()), true
+		}
+	}
+	return vs, ve, ok, nil
+}
+
+// nest wraps v in an object for each component of path.
+func nest(path []string, v any) any {
+	for i := len(path) - 1; i >= 0; i-- {
+		v = map[string]any{path[i]: v}
+	}
+	return v
+}
+
+// marshalIndent formats v with 2-space indentation, prefixing every line
+// but the first with prefix. Unlike json.MarshalIndent it does not escape
+// HTML characters, which are common in URLs.
+func marshalIndent(v any, prefix string) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(prefix, "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, errors.Wrap(err, "marshaling JSON")
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// lineIndent returns the leading whitespace of the line containing offset.
+func lineIndent(data []byte, offset int) string {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	i := lineStart
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	return string(data[lineStart:i])
+}
+
+// Strip returns a copy of data with comments and trailing commas
+// replaced by spaces. Offsets into the result are offsets into data.
+func Strip(data []byte) []byte {
+	out := bytes.Clone(data)
+
+	// Comments first, so that a comma followed by a comment and a closing
+	// bracket is seen as trailing
+	for i := 0; i < len(out); i++ {
+		switch {
+		case out[i] == '"':
+			i = skipString(out, i)
+		case hasPrefixAt(out, i, "//"):
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case hasPrefixAt(out, i, "/*"):
+			end := len(out)
+			if n := bytes.Index(out[i+2:], []byte("*/")); n >= 0 {
+				end = i + 2 + n + 2
+			}
+			for ; i < end; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '"':
+			i = skipString(out, i)
+		case ',':
+			j := i + 1
+			for j < len(out) && isJSONSpace(out[j]) {
+				j++
+			}
+			if j < len(out) && (out[j] == '}' || out[j] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+	return out
+}
+
+// skipString returns the offset of the quote closing the string that opens
+// at data[i].
+func skipString(data []byte, i int) int {
+	for i++; i < len(data) && data[i] != '"'; i++ {
+		if data[i] == '\\' {
+			i++
+		}
+	}
+	return i
+}
+
+// hasPrefixAt reports whether data[i:] starts with prefix.
+func hasPrefixAt(data []byte, i int, prefix string) bool {
+	return bytes.HasPrefix(data[i:], []byte(prefix))
+}
+
+// isJSONSpace reports whether c is JSON whitespace.
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// joinPath formats a key path for error messages.
+func joinPath(path []string) string {
+	return strings.Join(path, ".")
+}
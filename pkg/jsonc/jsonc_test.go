@@ -0,0 +1,121 @@
+package jsonc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "line comment",
+			input: "{\"a\": 1 // one\n}",
+			want:  "{\"a\": 1       \n}",
+		},
+		{
+			name:  "block comment",
+			input: "{/* x\ny */\"a\": 1}",
+			want:  "{    \n    \"a\": 1}",
+		},
+		{
+			name:  "trailing commas",
+			input: `{"a": [1, 2,], "b": 3,}`,
+			want:  `{"a": [1, 2 ], "b": 3 }`,
+		},
+		{
+			name:  "trailing comma before comment",
+			input: "{\"a\": 1, // last\n}",
+			want:  "{\"a\": 1         \n}",
+		},
+		{
+			name:  "comment markers in strings",
+			input: `{"url": "https://example.com/*", "s": "a,}"}`,
+			want:  `{"url": "https://example.com/*", "s": "a,}"}`,
+		},
+		{
+			name:  "escaped quote",
+			input: `{"s": "say \"//hi\""}`,
+			want:  `{"s": "say \"//hi\""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(Strip([]byte(tt.input)))
+			if got != tt.want {
+				t.Errorf("Strip() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocument_GetAndSet(t *testing.T) {
+	input := `{
+  // Inputs are prompted for once
+  "inputs": [
+    {"type": "promptString", "id": "old"},
+  ],
+  "servers": {}
+}
+`
+	doc, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var inputs []map[string]any
+	if ok, err := doc.Get([]string{"inputs"}, &inputs); err != nil || !ok {
+		t.Fatalf("Get(inputs) = %v, %v", ok, err)
+	}
+	if len(inputs) != 1 || inputs[0]["id"] != "old" {
+		t.Fatalf("Get(inputs) decoded %v", inputs)
+	}
+	var missing any
+	if ok, err := doc.Get([]string{"servers", "s"}, &missing); err != nil || ok {
+		t.Errorf("Get(servers.s) = %v, %v; want false, nil", ok, err)
+	}
+
+	if err := doc.Set([]string{"inputs"}, []map[string]any{{"id": "new"}}); err != nil {
+		t.Fatalf("Set(inputs) error = %v", err)
+	}
+	if err := doc.Set([]string{"servers", "s"}, map[string]any{"command": "x"}); err != nil {
+		t.Fatalf("Set(servers.s) error = %v", err)
+	}
+	want := `{
+  // Inputs are prompted for once
+  "inputs": [
+    {
+      "id": "new"
+    }
+  ],
+  "servers": {
+    "s": {
+      "command": "x"
+    }
+  }
+}
+`
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Bytes() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDocument_Errors(t *testing.T) {
+	if _, err := Parse([]byte(`{"a": `)); err == nil {
+		t.Error("Parse() accepted invalid JSON")
+	}
+	if _, err := Parse([]byte(`[1, 2]`)); err == nil {
+		t.Error("Parse() accepted a top-level array")
+	}
+
+	doc, err := Parse([]byte(`{"a": "b"}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := doc.Set([]string{"a", "c"}, 1); err == nil || !strings.Contains(err.Error(), "a is not an object") {
+		t.Errorf("Set() descending into a string error = %v", err)
+	}
+}